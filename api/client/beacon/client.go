@@ -35,6 +35,7 @@ const (
 	getForkSchedulePath     = "/eth/v1/config/fork_schedule"
 	getStatePath            = "/eth/v2/debug/beacon/states"
 	getNodeVersionPath      = "/eth/v1/node/version"
+	getNodeIdentityPath     = "/eth/v1/node/identity"
 )
 
 // StateOrBlockId represents the block_id / state_id parameters that several of the Eth Beacon API methods accept.
@@ -293,6 +294,66 @@ func (c *Client) GetNodeVersion(ctx context.Context) (*NodeVersion, error) {
 	return parseNodeVersion(d.Data.Version)
 }
 
+// NodeIdentity holds the network identity of a beacon node, as reported by the node itself.
+type NodeIdentity struct {
+	PeerID             string
+	ENR                string
+	P2PAddresses       []string
+	DiscoveryAddresses []string
+	SeqNumber          uint64
+	Attnets            []byte
+	Syncnets           []byte
+}
+
+// GetNodeIdentity retrieves the network identity (peer id, ENR, addresses and metadata) of the beacon node.
+func (c *Client) GetNodeIdentity(ctx context.Context) (*NodeIdentity, error) {
+	b, err := c.get(ctx, getNodeIdentityPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "error requesting node identity")
+	}
+	d := struct {
+		Data struct {
+			PeerID             string   `json:"peer_id"`
+			ENR                string   `json:"enr"`
+			P2PAddresses       []string `json:"p2p_addresses"`
+			DiscoveryAddresses []string `json:"discovery_addresses"`
+			Metadata           struct {
+				SeqNumber string `json:"seq_number"`
+				Attnets   string `json:"attnets"`
+				Syncnets  string `json:"syncnets"`
+			} `json:"metadata"`
+		} `json:"data"`
+	}{}
+	if err := json.Unmarshal(b, &d); err != nil {
+		return nil, errors.Wrapf(err, "error unmarshaling response body: %s", string(b))
+	}
+	identity := &NodeIdentity{
+		PeerID:             d.Data.PeerID,
+		ENR:                d.Data.ENR,
+		P2PAddresses:       d.Data.P2PAddresses,
+		DiscoveryAddresses: d.Data.DiscoveryAddresses,
+	}
+	if d.Data.Metadata.SeqNumber != "" {
+		identity.SeqNumber, err = strconv.ParseUint(d.Data.Metadata.SeqNumber, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid metadata sequence number: %s", d.Data.Metadata.SeqNumber)
+		}
+	}
+	if d.Data.Metadata.Attnets != "" {
+		identity.Attnets, err = hexutil.Decode(d.Data.Metadata.Attnets)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid metadata attnets: %s", d.Data.Metadata.Attnets)
+		}
+	}
+	if d.Data.Metadata.Syncnets != "" {
+		identity.Syncnets, err = hexutil.Decode(d.Data.Metadata.Syncnets)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid metadata syncnets: %s", d.Data.Metadata.Syncnets)
+		}
+	}
+	return identity, nil
+}
+
 func renderGetStatePath(id StateOrBlockId) string {
 	return path.Join(getStatePath, string(id))
 }
@@ -1,6 +1,10 @@
 package beacon
 
 import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
 	"net/url"
 	"testing"
 
@@ -136,3 +140,31 @@ func TestValidHostname(t *testing.T) {
 		})
 	}
 }
+
+func TestGetNodeIdentity(t *testing.T) {
+	c := &Client{
+		hc:      &http.Client{},
+		baseURL: &url.URL{Host: "localhost:3500", Scheme: "http"},
+	}
+	c.hc.Transport = &testRT{rt: func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, getNodeIdentityPath, req.URL.Path)
+		body := `{"data":{"peer_id":"16Uiu2HAmQ5Q8hnV8QAtVDnxbrhMkuwGGU3sZuHTbsr4xYB3zTZbz","enr":"enr:-abc",` +
+			`"p2p_addresses":["/ip4/127.0.0.1/tcp/13000"],"discovery_addresses":["/ip4/127.0.0.1/udp/12000"],` +
+			`"metadata":{"seq_number":"7","attnets":"0x0100000000000080"}}}`
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBufferString(body)),
+			Request:    req,
+		}, nil
+	}}
+
+	identity, err := c.GetNodeIdentity(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "16Uiu2HAmQ5Q8hnV8QAtVDnxbrhMkuwGGU3sZuHTbsr4xYB3zTZbz", identity.PeerID)
+	require.Equal(t, "enr:-abc", identity.ENR)
+	require.DeepEqual(t, []string{"/ip4/127.0.0.1/tcp/13000"}, identity.P2PAddresses)
+	require.DeepEqual(t, []string{"/ip4/127.0.0.1/udp/12000"}, identity.DiscoveryAddresses)
+	require.Equal(t, uint64(7), identity.SeqNumber)
+	require.DeepEqual(t, []byte{0x01, 0, 0, 0, 0, 0, 0, 0x80}, identity.Attnets)
+	require.Equal(t, 0, len(identity.Syncnets))
+}
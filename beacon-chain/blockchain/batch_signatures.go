@@ -0,0 +1,139 @@
+package blockchain
+
+import (
+	"context"
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/consensus-types/wrapper"
+	"github.com/prysmaticlabs/prysm/crypto/bls"
+	"github.com/prysmaticlabs/prysm/crypto/hash"
+	"github.com/prysmaticlabs/prysm/network/forks"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	"go.opencensus.io/trace"
+)
+
+// verifiedSignaturesCacheSize is the number of pre-verified signature sets kept, which covers the
+// block, randao and attestation signatures of a few initial sync batches.
+const verifiedSignaturesCacheSize = 1 << 15
+
+// PreVerifyBlockBatchSignatures verifies the proposer, randao and attestation signatures of a batch
+// of blocks ahead of their processing, so that the state transition of the batch does not have to
+// verify them again. Signature sets are computed from the head state, which is only possible for
+// blocks up to the epoch following the head, in the fork of the head. Blocks past that are left for
+// onBlockBatch to verify.
+func (s *Service) PreVerifyBlockBatchSignatures(ctx context.Context, blks []interfaces.SignedBeaconBlock) error {
+	ctx, span := trace.StartSpan(ctx, "blockChain.PreVerifyBlockBatchSignatures")
+	defer span.End()
+
+	s.headLock.RLock()
+	if !s.hasHeadState() {
+		s.headLock.RUnlock()
+		return errors.New("head state does not exist")
+	}
+	// The head state is replaced rather than mutated on head updates, so it is only read below.
+	headState := s.head.state
+	s.headLock.RUnlock()
+
+	fork := headState.Fork()
+	gvr := headState.GenesisValidatorsRoot()
+	headEpoch := slots.ToEpoch(headState.Slot())
+	cfg := params.BeaconConfig()
+	set := bls.NewSet()
+	for _, b := range blks {
+		if err := wrapper.BeaconBlockIsNil(b); err != nil {
+			return err
+		}
+		blk := b.Block()
+		epoch := slots.ToEpoch(blk.Slot())
+		if epoch > headEpoch+1 {
+			break
+		}
+		blockFork, err := forks.Fork(epoch)
+		if err != nil {
+			return err
+		}
+		if string(blockFork.CurrentVersion) != string(fork.CurrentVersion) {
+			break
+		}
+		proposerPubKey := headState.PubkeyAtIndex(blk.ProposerIndex())
+		domain, err := signing.Domain(fork, epoch, cfg.DomainBeaconProposer, gvr)
+		if err != nil {
+			return err
+		}
+		blockSet, err := signing.BlockSignatureBatch(proposerPubKey[:], b.Signature(), domain, blk.HashTreeRoot)
+		if err != nil {
+			return errors.Wrapf(err, "could not get block signature set of slot %d", blk.Slot())
+		}
+		randaoDomain, err := signing.Domain(fork, epoch, cfg.DomainRandao, gvr)
+		if err != nil {
+			return err
+		}
+		buf := make([]byte, 32)
+		binary.LittleEndian.PutUint64(buf, uint64(epoch))
+		randaoRoot, err := (&ethpb.SigningData{ObjectRoot: buf, Domain: randaoDomain}).HashTreeRoot()
+		if err != nil {
+			return err
+		}
+		pub, err := bls.PublicKeyFromBytes(proposerPubKey[:])
+		if err != nil {
+			return err
+		}
+		attSet, err := blocks.AttestationSignatureBatch(ctx, headState, blk.Body().Attestations())
+		if err != nil {
+			return errors.Wrapf(err, "could not get attestation signature set of slot %d", blk.Slot())
+		}
+		set.Join(blockSet).Join(&bls.SignatureBatch{
+			Signatures: [][]byte{blk.Body().RandaoReveal()},
+			PublicKeys: []bls.PublicKey{pub},
+			Messages:   [][32]byte{randaoRoot},
+		}).Join(attSet)
+	}
+	if len(set.Signatures) == 0 {
+		return nil
+	}
+	verified, err := set.Verify()
+	if err != nil {
+		return err
+	}
+	if !verified {
+		return errors.New("batch block signature verification failed")
+	}
+	for i := range set.Signatures {
+		s.verifiedSignatures.Add(signatureSetKey(set.Signatures[i], set.PublicKeys[i], set.Messages[i]), true)
+	}
+	return nil
+}
+
+// removePreVerifiedSignatures removes signature sets verified by PreVerifyBlockBatchSignatures from
+// the batch.
+func (s *Service) removePreVerifiedSignatures(set *bls.SignatureBatch) *bls.SignatureBatch {
+	if s.verifiedSignatures.Len() == 0 {
+		return set
+	}
+	remaining := bls.NewSet()
+	for i := range set.Signatures {
+		if s.verifiedSignatures.Contains(signatureSetKey(set.Signatures[i], set.PublicKeys[i], set.Messages[i])) {
+			continue
+		}
+		remaining.Signatures = append(remaining.Signatures, set.Signatures[i])
+		remaining.PublicKeys = append(remaining.PublicKeys, set.PublicKeys[i])
+		remaining.Messages = append(remaining.Messages, set.Messages[i])
+	}
+	return remaining
+}
+
+// signatureSetKey identifies a single signature set by its signature, public key and message.
+func signatureSetKey(sig []byte, pub bls.PublicKey, msg [32]byte) [32]byte {
+	pubKey := pub.Marshal()
+	buf := make([]byte, 0, len(sig)+len(pubKey)+len(msg))
+	buf = append(buf, sig...)
+	buf = append(buf, pubKey...)
+	buf = append(buf, msg[:]...)
+	return hash.Hash(buf)
+}
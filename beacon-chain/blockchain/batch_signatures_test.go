@@ -0,0 +1,106 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/transition"
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/consensus-types/interfaces"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/consensus-types/wrapper"
+	"github.com/prysmaticlabs/prysm/crypto/bls"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+// batchSignaturesService returns a chain service at genesis, its genesis state and a chain of blocks
+// on top of it, ready to be processed as a batch.
+func batchSignaturesService(t *testing.T, numBlocks int) (*Service, state.BeaconState, []interfaces.SignedBeaconBlock, [][32]byte) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	opts := []Option{
+		WithDatabase(beaconDB),
+		WithStateGen(stategen.New(beaconDB)),
+		WithForkChoiceStore(protoarray.New()),
+	}
+	service, err := NewService(ctx, opts...)
+	require.NoError(t, err)
+
+	st, keys := util.DeterministicGenesisState(t, 64)
+	require.NoError(t, service.saveGenesisData(ctx, st))
+	bState := st.Copy()
+	var blks []interfaces.SignedBeaconBlock
+	var blkRoots [][32]byte
+	for i := 1; i <= numBlocks; i++ {
+		b, err := util.GenerateFullBlock(bState, keys, util.DefaultBlockGenConfig(), types.Slot(i))
+		require.NoError(t, err)
+		wsb, err := wrapper.WrappedSignedBeaconBlock(b)
+		require.NoError(t, err)
+		bState, err = transition.ExecuteStateTransition(ctx, bState, wsb)
+		require.NoError(t, err)
+		root, err := b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		require.NoError(t, service.saveInitSyncBlock(ctx, root, wsb))
+		blks = append(blks, wsb)
+		blkRoots = append(blkRoots, root)
+	}
+	return service, st, blks, blkRoots
+}
+
+func TestService_PreVerifyBlockBatchSignatures(t *testing.T) {
+	ctx := context.Background()
+	service, genesis, blks, blkRoots := batchSignaturesService(t, 8)
+
+	require.NoError(t, service.PreVerifyBlockBatchSignatures(ctx, blks))
+	// A proposer and a randao signature per block, along with attestation signatures.
+	assert.Equal(t, true, service.verifiedSignatures.Len() > 2*len(blks))
+
+	// Every signature set of the state transition of the batch is already verified.
+	set := bls.NewSet()
+	preState := genesis.Copy()
+	for _, b := range blks {
+		var blockSet *bls.SignatureBatch
+		var err error
+		blockSet, preState, err = transition.ExecuteStateTransitionNoVerifyAnySig(ctx, preState, b)
+		require.NoError(t, err)
+		set.Join(blockSet)
+	}
+	require.NotEqual(t, 0, len(set.Signatures))
+	assert.Equal(t, 0, len(service.removePreVerifiedSignatures(set).Signatures))
+
+	require.NoError(t, service.onBlockBatch(ctx, blks, blkRoots))
+}
+
+func TestService_PreVerifyBlockBatchSignatures_InvalidSignature(t *testing.T) {
+	ctx := context.Background()
+	service, _, blks, blkRoots := batchSignaturesService(t, 4)
+	sb, err := blks[2].PbPhase0Block()
+	require.NoError(t, err)
+	sb.Signature = blks[1].Signature()
+	blks[2], err = wrapper.WrappedSignedBeaconBlock(sb)
+	require.NoError(t, err)
+
+	require.ErrorContains(t, "batch block signature verification failed", service.PreVerifyBlockBatchSignatures(ctx, blks))
+	assert.Equal(t, 0, service.verifiedSignatures.Len())
+	require.ErrorContains(t, "batch block signature verification failed", service.onBlockBatch(ctx, blks, blkRoots))
+}
+
+func TestService_PreVerifyBlockBatchSignatures_BeyondNextEpoch(t *testing.T) {
+	ctx := context.Background()
+	service, _, blks, _ := batchSignaturesService(t, 1)
+	sb, err := blks[0].PbPhase0Block()
+	require.NoError(t, err)
+	sb.Block.Slot = 2 * params.BeaconConfig().SlotsPerEpoch
+	blk, err := wrapper.WrappedSignedBeaconBlock(sb)
+	require.NoError(t, err)
+
+	// Signatures of blocks too far ahead of the head are left for processing to verify.
+	require.NoError(t, service.PreVerifyBlockBatchSignatures(ctx, []interfaces.SignedBeaconBlock{blk}))
+	assert.Equal(t, 0, service.verifiedSignatures.Len())
+}
@@ -37,6 +37,12 @@ type HeadUpdater interface {
 	UpdateHead(context.Context) error
 }
 
+// PayloadInvalidator defines a common interface for methods in blockchain service
+// which allow to manually invalidate the execution payload of a block.
+type PayloadInvalidator interface {
+	InvalidateBlock(ctx context.Context, root [32]byte) (*ethpb.PayloadVerdict, error)
+}
+
 // TimeFetcher retrieves the Ethereum consensus data that's related to time.
 type TimeFetcher interface {
 	GenesisTime() time.Time
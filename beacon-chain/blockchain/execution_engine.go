@@ -18,6 +18,7 @@ import (
 	"github.com/prysmaticlabs/prysm/consensus-types/wrapper"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	enginev1 "github.com/prysmaticlabs/prysm/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/time/slots"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
@@ -73,8 +74,8 @@ func (s *Service) notifyForkchoiceUpdate(ctx context.Context, arg *notifyForkcho
 
 	payloadID, lastValidHash, err := s.cfg.ExecutionEngineCaller.ForkchoiceUpdated(ctx, fcs, attr)
 	if err != nil {
-		switch err {
-		case powchain.ErrAcceptedSyncingPayloadStatus:
+		switch {
+		case errors.Is(err, powchain.ErrAcceptedSyncingPayloadStatus):
 			forkchoiceUpdatedOptimisticNodeCount.Inc()
 			log.WithFields(logrus.Fields{
 				"headSlot":                  headBlk.Slot(),
@@ -86,7 +87,7 @@ func (s *Service) notifyForkchoiceUpdate(ctx context.Context, arg *notifyForkcho
 				log.WithError(err).Error("Optimistic block failed to be candidate")
 			}
 			return payloadID, nil
-		case powchain.ErrInvalidPayloadStatus:
+		case errors.Is(err, powchain.ErrInvalidPayloadStatus):
 			newPayloadInvalidNodeCount.Inc()
 			headRoot := arg.headRoot
 			invalidRoots, err := s.ForkChoicer().SetOptimisticToInvalid(ctx, headRoot, bytesutil.ToBytes32(headBlk.ParentRoot()), bytesutil.ToBytes32(lastValidHash))
@@ -98,6 +99,15 @@ func (s *Service) notifyForkchoiceUpdate(ctx context.Context, arg *notifyForkcho
 				log.WithError(err).Error("Could not remove invalid block and state")
 				return nil, nil
 			}
+			s.recordPayloadVerdict(ctx, &ethpb.PayloadVerdict{
+				BlockRoot:       bytesutil.SafeCopyBytes(headRoot[:]),
+				Slot:            headBlk.Slot(),
+				PayloadHash:     headPayload.BlockHash,
+				Status:          ethpb.PayloadVerdict_INVALID,
+				Source:          ethpb.PayloadVerdict_FORKCHOICE_UPDATED,
+				LatestValidHash: lastValidHash,
+				PrunedRoots:     rootsToBytes(invalidRoots),
+			})
 
 			r, err := s.cfg.ForkChoiceStore.Head(ctx, s.justifiedBalances.balances)
 			if err != nil {
@@ -141,10 +151,25 @@ func (s *Service) notifyForkchoiceUpdate(ctx context.Context, arg *notifyForkcho
 		}
 	}
 	forkchoiceUpdatedValidNodeCount.Inc()
+	// Only a verdict that changes the optimistic status of the head is recorded, as the head is
+	// usually validated already when importing its block.
+	wasOptimistic, err := s.cfg.ForkChoiceStore.IsOptimistic(arg.headRoot)
+	if err != nil {
+		log.WithError(err).Error("Could not get optimistic status of head root")
+	}
 	if err := s.cfg.ForkChoiceStore.SetOptimisticToValid(ctx, arg.headRoot); err != nil {
 		log.WithError(err).Error("Could not set head root to valid")
 		return nil, nil
 	}
+	if wasOptimistic {
+		s.recordPayloadVerdict(ctx, &ethpb.PayloadVerdict{
+			BlockRoot:   bytesutil.SafeCopyBytes(arg.headRoot[:]),
+			Slot:        headBlk.Slot(),
+			PayloadHash: headPayload.BlockHash,
+			Status:      ethpb.PayloadVerdict_VALID,
+			Source:      ethpb.PayloadVerdict_FORKCHOICE_UPDATED,
+		})
+	}
 	if hasAttr { // If the forkchoice update call has an attribute, update the proposer payload ID cache.
 		var pId [8]byte
 		copy(pId[:], payloadID[:])
@@ -198,23 +223,34 @@ func (s *Service) notifyNewPayload(ctx context.Context, postStateVersion int,
 		return false, errors.Wrap(invalidBlock{error: err}, "could not get execution payload")
 	}
 	lastValidHash, err := s.cfg.ExecutionEngineCaller.NewPayload(ctx, payload)
-	switch err {
-	case nil:
+	if err == nil {
+		// A valid payload is the common case and is not recorded, only verdicts that leave the block
+		// optimistic or invalid, or that later validate an optimistic block, are of interest.
 		newPayloadValidNodeCount.Inc()
 		return true, nil
-	case powchain.ErrAcceptedSyncingPayloadStatus:
+	}
+	root, rootErr := blk.Block().HashTreeRoot()
+	if rootErr != nil {
+		return false, rootErr
+	}
+	verdict := &ethpb.PayloadVerdict{
+		BlockRoot:   root[:],
+		Slot:        blk.Block().Slot(),
+		PayloadHash: payload.BlockHash,
+		Status:      payloadVerdictStatus(err),
+		Source:      ethpb.PayloadVerdict_NEW_PAYLOAD,
+	}
+	switch {
+	case errors.Is(err, powchain.ErrAcceptedSyncingPayloadStatus):
 		newPayloadOptimisticNodeCount.Inc()
 		log.WithFields(logrus.Fields{
 			"slot":             blk.Block().Slot(),
 			"payloadBlockHash": fmt.Sprintf("%#x", bytesutil.Trunc(payload.BlockHash)),
 		}).Info("Called new payload with optimistic block")
+		s.recordPayloadVerdict(ctx, verdict)
 		return false, s.optimisticCandidateBlock(ctx, blk.Block())
-	case powchain.ErrInvalidPayloadStatus:
+	case errors.Is(err, powchain.ErrInvalidPayloadStatus):
 		newPayloadInvalidNodeCount.Inc()
-		root, err := blk.Block().HashTreeRoot()
-		if err != nil {
-			return false, err
-		}
 		invalidRoots, err := s.ForkChoicer().SetOptimisticToInvalid(ctx, root, bytesutil.ToBytes32(blk.Block().ParentRoot()), bytesutil.ToBytes32(lastValidHash))
 		if err != nil {
 			return false, err
@@ -222,14 +258,18 @@ func (s *Service) notifyNewPayload(ctx context.Context, postStateVersion int,
 		if err := s.removeInvalidBlockAndState(ctx, invalidRoots); err != nil {
 			return false, err
 		}
+		verdict.LatestValidHash = lastValidHash
+		verdict.PrunedRoots = rootsToBytes(invalidRoots)
+		s.recordPayloadVerdict(ctx, verdict)
 		log.WithFields(logrus.Fields{
 			"slot":         blk.Block().Slot(),
 			"blockRoot":    fmt.Sprintf("%#x", root),
 			"invalidCount": len(invalidRoots),
 		}).Warn("Pruned invalid blocks")
 		return false, ErrInvalidPayload
-	case powchain.ErrInvalidBlockHashPayloadStatus:
+	case errors.Is(err, powchain.ErrInvalidBlockHashPayloadStatus):
 		newPayloadInvalidNodeCount.Inc()
+		s.recordPayloadVerdict(ctx, verdict)
 		return false, ErrInvalidBlockHashPayloadStatus
 	default:
 		return false, errors.WithMessage(ErrUndefinedExecutionEngineError, err.Error())
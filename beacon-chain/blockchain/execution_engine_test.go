@@ -7,6 +7,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
 	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
@@ -46,6 +47,7 @@ func Test_NotifyForkchoiceUpdate(t *testing.T) {
 		WithDatabase(beaconDB),
 		WithStateGen(stategen.New(beaconDB)),
 		WithForkChoiceStore(fcs),
+		WithStateNotifier(&mock.MockStateNotifier{}),
 		WithProposerIdsCache(cache.NewProposerPayloadIDsCache()),
 	}
 	service, err := NewService(ctx, opts...)
@@ -501,6 +503,7 @@ func Test_NotifyNewPayload(t *testing.T) {
 		WithDatabase(beaconDB),
 		WithStateGen(stategen.New(beaconDB)),
 		WithForkChoiceStore(fcs),
+		WithStateNotifier(&mock.MockStateNotifier{}),
 	}
 	phase0State, _ := util.DeterministicGenesisState(t, 1)
 	altairState, _ := util.DeterministicGenesisStateAltair(t, 1)
@@ -747,6 +750,7 @@ func Test_NotifyNewPayload_SetOptimisticToValid(t *testing.T) {
 		WithDatabase(beaconDB),
 		WithStateGen(stategen.New(beaconDB)),
 		WithForkChoiceStore(fcs),
+		WithStateNotifier(&mock.MockStateNotifier{}),
 	}
 	bellatrixState, _ := util.DeterministicGenesisStateBellatrix(t, 2)
 	blk := &ethpb.SignedBeaconBlockBellatrix{
@@ -1067,10 +1071,20 @@ func Test_UpdateLastValidatedCheckpoint(t *testing.T) {
 	require.NoError(t, fcs.InsertNode(ctx, state, blkRoot))
 	require.NoError(t, fcs.SetOptimisticToValid(ctx, validRoot))
 	assert.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, validRoot))
+	require.NoError(t, beaconDB.SavePayloadVerdict(ctx, &ethpb.PayloadVerdict{BlockRoot: opRoot[:], Slot: 320}))
+	require.NoError(t, beaconDB.SavePayloadVerdict(ctx, &ethpb.PayloadVerdict{BlockRoot: validRoot[:], Slot: 640}))
 	require.NoError(t, service.updateFinalized(ctx, validCheckpoint))
 	cp, err = service.cfg.BeaconDB.LastValidatedCheckpoint(ctx)
 	require.NoError(t, err)
 
+	// Verdicts on blocks before the finalized checkpoint are pruned.
+	verdicts, err := beaconDB.PayloadVerdicts(ctx, opRoot)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(verdicts))
+	verdicts, err = beaconDB.PayloadVerdicts(ctx, validRoot)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(verdicts))
+
 	optimistic, err := service.IsOptimisticForRoot(ctx, validRoot)
 	require.NoError(t, err)
 	require.Equal(t, false, optimistic)
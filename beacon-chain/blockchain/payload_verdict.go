@@ -0,0 +1,133 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/powchain"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// recordPayloadVerdict persists a verdict on the execution payload of a block and sends it to the
+// state feed subscribers. A verdict that could not be persisted is logged, and does not interrupt
+// the processing of the block.
+func (s *Service) recordPayloadVerdict(ctx context.Context, verdict *ethpb.PayloadVerdict) {
+	verdict.Timestamp = uint64(time.Now().Unix())
+	if err := s.cfg.BeaconDB.SavePayloadVerdict(ctx, verdict); err != nil {
+		log.WithError(err).WithField("blockRoot", fmt.Sprintf("%#x", verdict.BlockRoot)).Error("Could not save payload verdict")
+	}
+	s.cfg.StateNotifier.StateFeed().Send(&feed.Event{
+		Type: statefeed.PayloadVerdict,
+		Data: verdict,
+	})
+}
+
+// payloadVerdictStatus returns the verdict status corresponding to the error returned by the execution engine.
+func payloadVerdictStatus(err error) ethpb.PayloadVerdict_Status {
+	switch {
+	case err == nil:
+		return ethpb.PayloadVerdict_VALID
+	case errors.Is(err, powchain.ErrAcceptedPayloadStatus):
+		return ethpb.PayloadVerdict_ACCEPTED
+	case errors.Is(err, powchain.ErrAcceptedSyncingPayloadStatus):
+		return ethpb.PayloadVerdict_SYNCING
+	case errors.Is(err, powchain.ErrInvalidBlockHashPayloadStatus):
+		return ethpb.PayloadVerdict_INVALID_BLOCK_HASH
+	default:
+		return ethpb.PayloadVerdict_INVALID
+	}
+}
+
+// rootsToBytes converts a list of block roots to their byte slice representation.
+func rootsToBytes(roots [][32]byte) [][]byte {
+	b := make([][]byte, len(roots))
+	for i := range roots {
+		b[i] = bytesutil.SafeCopyBytes(roots[i][:])
+	}
+	return b
+}
+
+// InvalidateBlock marks the optimistic block with the given root and all of its descendants as
+// invalid, as if the execution engine had returned INVALID with the payload of its parent as the
+// latest valid one. The invalid blocks are pruned from fork choice and the database, and a new
+// head is computed. This is meant for incident response, when the execution engine is known to
+// have accepted a payload it should not have.
+func (s *Service) InvalidateBlock(ctx context.Context, root [32]byte) (*ethpb.PayloadVerdict, error) {
+	ctx, span := trace.StartSpan(ctx, "blockChain.InvalidateBlock")
+	defer span.End()
+
+	optimistic, err := s.ForkChoicer().IsOptimistic(root)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get optimistic status of block")
+	}
+	if !optimistic {
+		return nil, ErrNotOptimisticBlock
+	}
+	blk, err := s.getBlock(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	payloadHash, err := s.getPayloadHash(ctx, root[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get payload hash of block")
+	}
+	parentRoot := bytesutil.ToBytes32(blk.Block().ParentRoot())
+	lastValidHash, err := s.getPayloadHash(ctx, parentRoot[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get payload hash of parent block")
+	}
+	invalidRoots, err := s.ForkChoicer().SetOptimisticToInvalid(ctx, root, parentRoot, lastValidHash)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not set block to invalid")
+	}
+	if err := s.removeInvalidBlockAndState(ctx, invalidRoots); err != nil {
+		return nil, errors.Wrap(err, "could not remove invalid block and state")
+	}
+	verdict := &ethpb.PayloadVerdict{
+		BlockRoot:       root[:],
+		Slot:            blk.Block().Slot(),
+		PayloadHash:     payloadHash[:],
+		Status:          ethpb.PayloadVerdict_INVALID,
+		Source:          ethpb.PayloadVerdict_MANUAL,
+		LatestValidHash: lastValidHash[:],
+		PrunedRoots:     rootsToBytes(invalidRoots),
+	}
+	s.recordPayloadVerdict(ctx, verdict)
+
+	headRoot, err := s.cfg.ForkChoiceStore.Head(ctx, s.justifiedBalances.balances)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get head root")
+	}
+	headBlock, err := s.getBlock(ctx, headRoot)
+	if err != nil {
+		return nil, err
+	}
+	headState, err := s.cfg.StateGen.StateByRoot(ctx, headRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get head state")
+	}
+	if _, err := s.notifyForkchoiceUpdate(ctx, &notifyForkchoiceUpdateArg{
+		headState: headState,
+		headRoot:  headRoot,
+		headBlock: headBlock.Block(),
+	}); err != nil {
+		return nil, err
+	}
+	if err := s.saveHead(ctx, headRoot, headBlock, headState); err != nil {
+		return nil, errors.Wrap(err, "could not save head after invalidating block")
+	}
+	log.WithFields(logrus.Fields{
+		"slot":         blk.Block().Slot(),
+		"blockRoot":    fmt.Sprintf("%#x", bytesutil.Trunc(root[:])),
+		"invalidCount": len(invalidRoots),
+		"newHeadRoot":  fmt.Sprintf("%#x", bytesutil.Trunc(headRoot[:])),
+	}).Warn("Manually invalidated block")
+	return verdict, nil
+}
@@ -0,0 +1,64 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/powchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func Test_payloadVerdictStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want ethpb.PayloadVerdict_Status
+	}{
+		{name: "valid", err: nil, want: ethpb.PayloadVerdict_VALID},
+		{name: "accepted", err: powchain.ErrAcceptedPayloadStatus, want: ethpb.PayloadVerdict_ACCEPTED},
+		{name: "syncing", err: powchain.ErrSyncingPayloadStatus, want: ethpb.PayloadVerdict_SYNCING},
+		{name: "invalid", err: powchain.ErrInvalidPayloadStatus, want: ethpb.PayloadVerdict_INVALID},
+		{name: "invalid block hash", err: powchain.ErrInvalidBlockHashPayloadStatus, want: ethpb.PayloadVerdict_INVALID_BLOCK_HASH},
+		{name: "wrapped syncing", err: errors.Wrap(powchain.ErrSyncingPayloadStatus, "wrapped"), want: ethpb.PayloadVerdict_SYNCING},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, payloadVerdictStatus(tt.err))
+		})
+	}
+}
+
+func TestService_recordPayloadVerdict(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	notifier := &mock.MockStateNotifier{RecordEvents: true}
+	service, err := NewService(ctx, WithDatabase(beaconDB), WithStateGen(stategen.New(beaconDB)), WithStateNotifier(notifier))
+	require.NoError(t, err)
+
+	root := [32]byte{'a'}
+	verdict := &ethpb.PayloadVerdict{
+		BlockRoot: root[:],
+		Slot:      1,
+		Status:    ethpb.PayloadVerdict_SYNCING,
+		Source:    ethpb.PayloadVerdict_NEW_PAYLOAD,
+	}
+	service.recordPayloadVerdict(ctx, verdict)
+	require.NotEqual(t, uint64(0), verdict.Timestamp)
+
+	verdicts, err := beaconDB.PayloadVerdicts(ctx, root)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(verdicts))
+	assert.DeepEqual(t, verdict, verdicts[0])
+
+	events := notifier.ReceivedEvents()
+	require.Equal(t, 1, len(events))
+	assert.Equal(t, statefeed.PayloadVerdict, int(events[0].Type))
+	assert.DeepEqual(t, verdict, events[0].Data)
+}
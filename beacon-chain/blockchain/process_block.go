@@ -149,7 +149,7 @@ func (s *Service) onBlock(ctx context.Context, signed interfaces.SignedBeaconBlo
 
 	// If slasher is configured, forward the attestations in the block via
 	// an event feed for processing.
-	if features.Get().EnableSlasher {
+	if features.Get().EnableSlasher || features.Get().EnableRemoteSlasher {
 		// Feed the indexed attestation to slasher if enabled. This action
 		// is done in the background to avoid adding more load to this critical code path.
 		go func() {
@@ -362,12 +362,15 @@ func (s *Service) onBlockBatch(ctx context.Context, blks []interfaces.SignedBeac
 		}
 		sigSet.Join(set)
 	}
-	verify, err := sigSet.Verify()
-	if err != nil {
-		return invalidBlock{error: err}
-	}
-	if !verify {
-		return errors.New("batch block signature verification failed")
+	sigSet = s.removePreVerifiedSignatures(sigSet)
+	if len(sigSet.Signatures) > 0 {
+		verify, err := sigSet.Verify()
+		if err != nil {
+			return invalidBlock{error: err}
+		}
+		if !verify {
+			return errors.New("batch block signature verification failed")
+		}
 	}
 
 	// blocks have been verified, save them and call the engine
@@ -165,6 +165,15 @@ func (s *Service) updateFinalized(ctx context.Context, cp *ethpb.Checkpoint) err
 	if err := s.cfg.StateGen.MigrateToCold(ctx, fRoot); err != nil {
 		return errors.Wrap(err, "could not migrate to cold")
 	}
+	fSlot, err := slots.EpochStart(cp.Epoch)
+	if err != nil {
+		return err
+	}
+	// The payload verdicts are only kept for debugging, failing to prune them must not fail the
+	// finalization.
+	if err := s.cfg.BeaconDB.DeletePayloadVerdictsBeforeSlot(ctx, fSlot); err != nil {
+		log.WithError(err).Error("Could not prune payload verdicts")
+	}
 	return nil
 }
 
@@ -25,6 +25,12 @@ type BlockReceiver interface {
 	HasBlock(ctx context.Context, root [32]byte) bool
 }
 
+// BatchSignatureVerifier interface defines the methods of chain service for verifying block signatures
+// ahead of block processing.
+type BatchSignatureVerifier interface {
+	PreVerifyBlockBatchSignatures(ctx context.Context, blocks []interfaces.SignedBeaconBlock) error
+}
+
 // SlashingReceiver interface defines the methods of chain service for receiving validated slashing over the wire.
 type SlashingReceiver interface {
 	ReceiveAttesterSlashing(ctx context.Context, slashings *ethpb.AttesterSlashing)
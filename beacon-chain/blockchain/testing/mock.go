@@ -64,6 +64,7 @@ type ChainService struct {
 	ReceiveBlockMockErr         error
 	OptimisticCheckRootReceived [32]byte
 	FinalizedRoots              map[[32]byte]bool
+	InvalidateBlockErr          error
 }
 
 // ForkChoicer mocks the same method in the chain service
@@ -193,6 +194,11 @@ func (s *ChainService) ReceiveBlockInitialSync(ctx context.Context, block interf
 	return nil
 }
 
+// PreVerifyBlockBatchSignatures mocks the same method in the chain service.
+func (_ *ChainService) PreVerifyBlockBatchSignatures(_ context.Context, _ []interfaces.SignedBeaconBlock) error {
+	return nil
+}
+
 // ReceiveBlockBatch processes blocks in batches from initial-sync.
 func (s *ChainService) ReceiveBlockBatch(ctx context.Context, blks []interfaces.SignedBeaconBlock, _ [][32]byte) error {
 	if s.State == nil {
@@ -448,6 +454,18 @@ func (s *ChainService) IsOptimistic(_ context.Context) (bool, error) {
 	return s.Optimistic, nil
 }
 
+// InvalidateBlock mocks the same method in the chain service.
+func (s *ChainService) InvalidateBlock(_ context.Context, root [32]byte) (*ethpb.PayloadVerdict, error) {
+	if s.InvalidateBlockErr != nil {
+		return nil, s.InvalidateBlockErr
+	}
+	return &ethpb.PayloadVerdict{
+		BlockRoot: root[:],
+		Status:    ethpb.PayloadVerdict_INVALID,
+		Source:    ethpb.PayloadVerdict_MANUAL,
+	}, nil
+}
+
 // IsOptimisticForRoot mocks the same method in the chain service.
 func (s *ChainService) IsOptimisticForRoot(_ context.Context, root [32]byte) (bool, error) {
 	s.OptimisticCheckRootReceived = root
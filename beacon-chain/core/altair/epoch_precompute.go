@@ -9,6 +9,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/time"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/math"
 	"go.opencensus.io/trace"
 )
@@ -251,6 +252,27 @@ func ProcessRewardsAndPenaltiesPrecompute(
 	return beaconState, nil
 }
 
+// AttestationDelta is the breakdown by attestation component of the rewards and penalties of a validator,
+// applied during the epoch transition.
+type AttestationDelta struct {
+	SourceReward      uint64
+	SourcePenalty     uint64
+	TargetReward      uint64
+	TargetPenalty     uint64
+	HeadReward        uint64
+	InactivityPenalty uint64
+}
+
+// Reward returns the sum of the rewards of the attestation components.
+func (d AttestationDelta) Reward() uint64 {
+	return d.SourceReward + d.TargetReward + d.HeadReward
+}
+
+// Penalty returns the sum of the penalties of the attestation components.
+func (d AttestationDelta) Penalty() uint64 {
+	return d.SourcePenalty + d.TargetPenalty + d.InactivityPenalty
+}
+
 // AttestationsDelta computes and returns the rewards and penalties differences for individual validators based on the
 // voting records.
 func AttestationsDelta(beaconState state.BeaconState, bal *precompute.Balance, vals []*precompute.Validator) (rewards, penalties []uint64, err error) {
@@ -258,41 +280,78 @@ func AttestationsDelta(beaconState state.BeaconState, bal *precompute.Balance, v
 	rewards = make([]uint64, numOfVals)
 	penalties = make([]uint64, numOfVals)
 
+	baseRewardMultiplier, inactivityDenominator, leak, err := attestationsDeltaParams(beaconState, bal)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i, v := range vals {
+		delta, err := attestationDelta(bal, v, baseRewardMultiplier, inactivityDenominator, leak)
+		if err != nil {
+			return nil, nil, err
+		}
+		rewards[i], penalties[i] = delta.Reward(), delta.Penalty()
+	}
+
+	return rewards, penalties, nil
+}
+
+// AttestationsDeltaBreakdown computes the rewards and penalties of each attestation component for the validators
+// at the given indices, based on the voting records.
+func AttestationsDeltaBreakdown(
+	beaconState state.BeaconState,
+	bal *precompute.Balance,
+	vals []*precompute.Validator,
+	indices []types.ValidatorIndex,
+) ([]AttestationDelta, error) {
+	baseRewardMultiplier, inactivityDenominator, leak, err := attestationsDeltaParams(beaconState, bal)
+	if err != nil {
+		return nil, err
+	}
+	deltas := make([]AttestationDelta, len(indices))
+	for i, idx := range indices {
+		if uint64(idx) >= uint64(len(vals)) {
+			return nil, errors.Errorf("validator index %d out of range", idx)
+		}
+		deltas[i], err = attestationDelta(bal, vals[idx], baseRewardMultiplier, inactivityDenominator, leak)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return deltas, nil
+}
+
+// attestationsDeltaParams returns the parameters shared by the attestation deltas of all the validators.
+func attestationsDeltaParams(
+	beaconState state.BeaconState,
+	bal *precompute.Balance,
+) (baseRewardMultiplier, inactivityDenominator uint64, inactivityLeak bool, err error) {
 	cfg := params.BeaconConfig()
 	prevEpoch := time.PrevEpoch(beaconState)
 	finalizedEpoch := beaconState.FinalizedCheckpointEpoch()
 	increment := cfg.EffectiveBalanceIncrement
 	factor := cfg.BaseRewardFactor
-	baseRewardMultiplier := increment * factor / math.IntegerSquareRoot(bal.ActiveCurrentEpoch)
-	leak := helpers.IsInInactivityLeak(prevEpoch, finalizedEpoch)
+	baseRewardMultiplier = increment * factor / math.IntegerSquareRoot(bal.ActiveCurrentEpoch)
+	inactivityLeak = helpers.IsInInactivityLeak(prevEpoch, finalizedEpoch)
 
 	// Modified in Altair and Bellatrix.
 	bias := cfg.InactivityScoreBias
 	inactivityPenaltyQuotient, err := beaconState.InactivityPenaltyQuotient()
 	if err != nil {
-		return nil, nil, err
-	}
-	inactivityDenominator := bias * inactivityPenaltyQuotient
-
-	for i, v := range vals {
-		rewards[i], penalties[i], err = attestationDelta(bal, v, baseRewardMultiplier, inactivityDenominator, leak)
-		if err != nil {
-			return nil, nil, err
-		}
+		return 0, 0, false, err
 	}
-
-	return rewards, penalties, nil
+	inactivityDenominator = bias * inactivityPenaltyQuotient
+	return baseRewardMultiplier, inactivityDenominator, inactivityLeak, nil
 }
 
 func attestationDelta(
 	bal *precompute.Balance,
 	val *precompute.Validator,
 	baseRewardMultiplier, inactivityDenominator uint64,
-	inactivityLeak bool) (reward, penalty uint64, err error) {
+	inactivityLeak bool) (delta AttestationDelta, err error) {
 	eligible := val.IsActivePrevEpoch || (val.IsSlashed && !val.IsWithdrawableCurrentEpoch)
 	// Per spec `ActiveCurrentEpoch` can't be 0 to process attestation delta.
 	if !eligible || bal.ActiveCurrentEpoch == 0 {
-		return 0, 0, nil
+		return AttestationDelta{}, nil
 	}
 
 	cfg := params.BeaconConfig()
@@ -305,32 +364,31 @@ func attestationDelta(
 	srcWeight := cfg.TimelySourceWeight
 	tgtWeight := cfg.TimelyTargetWeight
 	headWeight := cfg.TimelyHeadWeight
-	reward, penalty = uint64(0), uint64(0)
 	// Process source reward / penalty
 	if val.IsPrevEpochSourceAttester && !val.IsSlashed {
 		if !inactivityLeak {
 			n := baseReward * srcWeight * (bal.PrevEpochAttested / increment)
-			reward += n / (activeIncrement * weightDenominator)
+			delta.SourceReward = n / (activeIncrement * weightDenominator)
 		}
 	} else {
-		penalty += baseReward * srcWeight / weightDenominator
+		delta.SourcePenalty = baseReward * srcWeight / weightDenominator
 	}
 
 	// Process target reward / penalty
 	if val.IsPrevEpochTargetAttester && !val.IsSlashed {
 		if !inactivityLeak {
 			n := baseReward * tgtWeight * (bal.PrevEpochTargetAttested / increment)
-			reward += n / (activeIncrement * weightDenominator)
+			delta.TargetReward = n / (activeIncrement * weightDenominator)
 		}
 	} else {
-		penalty += baseReward * tgtWeight / weightDenominator
+		delta.TargetPenalty = baseReward * tgtWeight / weightDenominator
 	}
 
 	// Process head reward / penalty
 	if val.IsPrevEpochHeadAttester && !val.IsSlashed {
 		if !inactivityLeak {
 			n := baseReward * headWeight * (bal.PrevEpochHeadAttested / increment)
-			reward += n / (activeIncrement * weightDenominator)
+			delta.HeadReward = n / (activeIncrement * weightDenominator)
 		}
 	}
 
@@ -339,10 +397,10 @@ func attestationDelta(
 	if !val.IsPrevEpochTargetAttester || val.IsSlashed {
 		n, err := math.Mul64(effectiveBalance, val.InactivityScore)
 		if err != nil {
-			return 0, 0, err
+			return AttestationDelta{}, err
 		}
-		penalty += n / inactivityDenominator
+		delta.InactivityPenalty = n / inactivityDenominator
 	}
 
-	return reward, penalty, nil
+	return delta, nil
 }
@@ -269,6 +269,40 @@ func TestAttestationsDeltaBellatrix(t *testing.T) {
 	require.DeepEqual(t, want, penalties)
 }
 
+func TestAttestationsDeltaBreakdown(t *testing.T) {
+	s, err := testState()
+	require.NoError(t, err)
+	validators, balance, err := InitializePrecomputeValidators(context.Background(), s)
+	require.NoError(t, err)
+	validators, balance, err = ProcessEpochParticipation(context.Background(), s, balance, validators)
+	require.NoError(t, err)
+	rewards, penalties, err := AttestationsDelta(s, balance, validators)
+	require.NoError(t, err)
+
+	indices := []types.ValidatorIndex{0, 1, 2, 3}
+	deltas, err := AttestationsDeltaBreakdown(s, balance, validators, indices)
+	require.NoError(t, err)
+	require.Equal(t, len(indices), len(deltas))
+	for i, d := range deltas {
+		require.Equal(t, rewards[i], d.Reward())
+		require.Equal(t, penalties[i], d.Penalty())
+	}
+
+	// Validator 0 did not attest, validator 1 only has a timely source and validator 3 has every flag.
+	require.Equal(t, uint64(0), deltas[0].Reward())
+	require.NotEqual(t, uint64(0), deltas[0].SourcePenalty)
+	require.NotEqual(t, uint64(0), deltas[0].TargetPenalty)
+	require.NotEqual(t, uint64(0), deltas[1].SourceReward)
+	require.Equal(t, uint64(0), deltas[1].SourcePenalty)
+	require.NotEqual(t, uint64(0), deltas[1].TargetPenalty)
+	require.Equal(t, uint64(0), deltas[2].HeadReward)
+	require.NotEqual(t, uint64(0), deltas[3].HeadReward)
+	require.Equal(t, uint64(0), deltas[3].Penalty())
+
+	_, err = AttestationsDeltaBreakdown(s, balance, validators, []types.ValidatorIndex{4})
+	require.ErrorContains(t, "validator index 4 out of range", err)
+}
+
 func TestProcessRewardsAndPenaltiesPrecompute_Ok(t *testing.T) {
 	s, err := testState()
 	require.NoError(t, err)
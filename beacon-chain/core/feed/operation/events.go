@@ -2,6 +2,7 @@
 package operation
 
 import (
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 )
 
@@ -19,6 +20,18 @@ const (
 
 	// SyncCommitteeContributionReceived is sent after a sync committee contribution object has been received.
 	SyncCommitteeContributionReceived
+
+	// AttesterSlashingReceived is sent after an attester slashing object has been received from the outside
+	// world (eg. in RPC or sync) or detected by the slasher.
+	AttesterSlashingReceived
+
+	// ProposerSlashingReceived is sent after a proposer slashing object has been received from the outside
+	// world (eg. in RPC or sync) or detected by the slasher.
+	ProposerSlashingReceived
+
+	// ProposerPreparationReceived is sent after the validator indices of a connected validator client have been
+	// received through a proposer preparation request.
+	ProposerPreparationReceived
 )
 
 // UnAggregatedAttReceivedData is the data sent with UnaggregatedAttReceived events.
@@ -44,3 +57,21 @@ type SyncCommitteeContributionReceivedData struct {
 	// Contribution is the sync committee contribution object.
 	Contribution *ethpb.SignedContributionAndProof
 }
+
+// AttesterSlashingReceivedData is the data sent with AttesterSlashingReceived events.
+type AttesterSlashingReceivedData struct {
+	// AttesterSlashing is the attester slashing object.
+	AttesterSlashing *ethpb.AttesterSlashing
+}
+
+// ProposerSlashingReceivedData is the data sent with ProposerSlashingReceived events.
+type ProposerSlashingReceivedData struct {
+	// ProposerSlashing is the proposer slashing object.
+	ProposerSlashing *ethpb.ProposerSlashing
+}
+
+// ProposerPreparationReceivedData is the data sent with ProposerPreparationReceived events.
+type ProposerPreparationReceivedData struct {
+	// ValidatorIndices are the indices of the validators attached to the validator client.
+	ValidatorIndices []types.ValidatorIndex
+}
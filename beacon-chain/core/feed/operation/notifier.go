@@ -1,8 +1,23 @@
 package operation
 
-import "github.com/prysmaticlabs/prysm/async/event"
+import (
+	"github.com/prysmaticlabs/prysm/async/event"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+)
 
 // Notifier interface defines the methods of the service that provides beacon block operation updates to consumers.
 type Notifier interface {
 	OperationFeed() *event.Feed
 }
+
+// Notify sends an operation event to the consumers of the notifier's feed. Nothing is sent
+// when no notifier is configured.
+func Notify(n Notifier, typ feed.EventType, data interface{}) {
+	if n == nil {
+		return
+	}
+	n.OperationFeed().Send(&feed.Event{
+		Type: typ,
+		Data: data,
+	})
+}
@@ -26,6 +26,8 @@ const (
 	FinalizedCheckpoint
 	// NewHead of the chain event.
 	NewHead
+	// PayloadVerdict is sent when a verdict on the execution payload of a block is recorded.
+	PayloadVerdict
 )
 
 // BlockProcessedData is the data sent with BlockProcessed events.
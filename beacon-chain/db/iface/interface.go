@@ -56,6 +56,9 @@ type ReadOnlyDatabase interface {
 	// origin checkpoint sync support
 	OriginCheckpointBlockRoot(ctx context.Context) ([32]byte, error)
 	BackfillBlockRoot(ctx context.Context) ([32]byte, error)
+	// Execution payload verdicts operations.
+	PayloadVerdicts(ctx context.Context, blockRoot [32]byte) ([]*ethpb.PayloadVerdict, error)
+	LatestPayloadVerdicts(ctx context.Context, limit int) ([]*ethpb.PayloadVerdict, error)
 }
 
 // NoHeadAccessDatabase defines a struct without access to chain head data.
@@ -87,6 +90,9 @@ type NoHeadAccessDatabase interface {
 	// Fee reicipients operations.
 	SaveFeeRecipientsByValidatorIDs(ctx context.Context, ids []types.ValidatorIndex, addrs []common.Address) error
 	SaveRegistrationsByValidatorIDs(ctx context.Context, ids []types.ValidatorIndex, regs []*ethpb.ValidatorRegistrationV1) error
+	// Execution payload verdicts operations.
+	SavePayloadVerdict(ctx context.Context, verdict *ethpb.PayloadVerdict) error
+	DeletePayloadVerdictsBeforeSlot(ctx context.Context, slot types.Slot) error
 
 	CleanUpDirtyStates(ctx context.Context, slotsPerArchivedPoint types.Slot) error
 }
@@ -71,6 +71,10 @@ var blockedBuckets = [][]byte{
 // Config for the bolt db kv store.
 type Config struct {
 	InitialMMapSize int
+	// ReadOnly opens an existing database with a shared file lock and without write access. A beacon
+	// node holds an exclusive lock on its database, so it must be stopped before the database can be
+	// opened this way. Any write to a read-only store fails.
+	ReadOnly bool
 }
 
 // Store defines an implementation of the Prysm Database interface
@@ -81,6 +85,7 @@ type Store struct {
 	blockCache          *ristretto.Cache
 	validatorEntryCache *ristretto.Cache
 	stateSummaryCache   *stateSummaryCache
+	readOnly            bool
 	ctx                 context.Context
 }
 
@@ -100,6 +105,9 @@ func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, er
 		return nil, err
 	}
 	if !hasDir {
+		if config.ReadOnly {
+			return nil, errors.Errorf("cannot open missing database directory %s in read-only mode", dirPath)
+		}
 		if err := file.MkdirAll(dirPath); err != nil {
 			return nil, err
 		}
@@ -112,6 +120,7 @@ func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, er
 		&bolt.Options{
 			Timeout:         1 * time.Second,
 			InitialMmapSize: config.InitialMMapSize,
+			ReadOnly:        config.ReadOnly,
 		},
 	)
 	if err != nil {
@@ -145,8 +154,13 @@ func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, er
 		blockCache:          blockCache,
 		validatorEntryCache: validatorCache,
 		stateSummaryCache:   newStateSummaryCache(),
+		readOnly:            config.ReadOnly,
 		ctx:                 ctx,
 	}
+	if config.ReadOnly {
+		err = prometheus.Register(createBoltCollector(kv.db))
+		return kv, err
+	}
 	if err := kv.db.Update(func(tx *bolt.Tx) error {
 		return createBuckets(
 			tx,
@@ -179,6 +193,7 @@ func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, er
 
 			feeRecipientBucket,
 			registrationBucket,
+			payloadVerdictsBucket,
 		)
 	}); err != nil {
 		return nil, err
@@ -203,6 +218,9 @@ func (s *Store) ClearDB() error {
 func (s *Store) Close() error {
 	prometheus.Unregister(createBoltCollector(s.db))
 
+	if s.readOnly {
+		return s.db.Close()
+	}
 	// Before DB closes, we should dump the cached state summary objects to DB.
 	if err := s.saveCachedStateSummariesDB(s.ctx); err != nil {
 		return err
@@ -2,8 +2,11 @@ package kv
 
 import (
 	"context"
+	"path/filepath"
 	"testing"
 
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/testing/require"
 )
 
@@ -16,3 +19,25 @@ func setupDB(t testing.TB) *Store {
 	})
 	return db
 }
+
+func TestStore_ReadOnly(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	db, err := NewKVStore(ctx, dir, &Config{})
+	require.NoError(t, err)
+	root := [32]byte{'a'}
+	require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: 1, Root: root[:]}))
+	require.NoError(t, db.Close())
+
+	db, err = NewKVStore(ctx, dir, &Config{ReadOnly: true})
+	require.NoError(t, err)
+	summary, err := db.StateSummary(ctx, root)
+	require.NoError(t, err)
+	require.Equal(t, types.Slot(1), summary.Slot)
+	err = db.SaveGenesisBlockRoot(ctx, root)
+	require.ErrorContains(t, "database is in read-only mode", err)
+	require.NoError(t, db.Close())
+
+	_, err = NewKVStore(ctx, filepath.Join(dir, "missing"), &Config{ReadOnly: true})
+	require.ErrorContains(t, "cannot open missing database directory", err)
+}
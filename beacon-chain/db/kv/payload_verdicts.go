@@ -0,0 +1,134 @@
+package kv
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// SavePayloadVerdict appends a verdict on the execution payload of a block to the
+// verdicts already recorded for the same block root.
+func (s *Store) SavePayloadVerdict(ctx context.Context, verdict *ethpb.PayloadVerdict) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SavePayloadVerdict")
+	defer span.End()
+
+	if verdict == nil || len(verdict.BlockRoot) != 32 {
+		return errors.New("cannot save payload verdict without a block root")
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(payloadVerdictsBucket)
+		verdicts := &ethpb.PayloadVerdicts{}
+		if enc := bkt.Get(verdict.BlockRoot); enc != nil {
+			if err := decode(ctx, enc, verdicts); err != nil {
+				return err
+			}
+		}
+		verdicts.Verdicts = append(verdicts.Verdicts, verdict)
+		enc, err := encode(ctx, verdicts)
+		if err != nil {
+			return err
+		}
+		return bkt.Put(verdict.BlockRoot, enc)
+	})
+}
+
+// PayloadVerdicts returns the verdicts recorded on the execution payload of the block
+// with the given root, in the order they were recorded.
+func (s *Store) PayloadVerdicts(ctx context.Context, blockRoot [32]byte) ([]*ethpb.PayloadVerdict, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.PayloadVerdicts")
+	defer span.End()
+
+	verdicts := &ethpb.PayloadVerdicts{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(payloadVerdictsBucket).Get(blockRoot[:])
+		if enc == nil {
+			return nil
+		}
+		return decode(ctx, enc, verdicts)
+	})
+	return verdicts.Verdicts, err
+}
+
+// LatestPayloadVerdicts returns at most limit of the most recently recorded verdicts on execution
+// payloads, sorted by the time they were recorded.
+func (s *Store) LatestPayloadVerdicts(ctx context.Context, limit int) ([]*ethpb.PayloadVerdict, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.LatestPayloadVerdicts")
+	defer span.End()
+
+	all := make([]*ethpb.PayloadVerdict, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(payloadVerdictsBucket).ForEach(func(_, enc []byte) error {
+			verdicts := &ethpb.PayloadVerdicts{}
+			if err := decode(ctx, enc, verdicts); err != nil {
+				return err
+			}
+			all = append(all, verdicts.Verdicts...)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].Timestamp < all[j].Timestamp
+	})
+	if limit >= 0 && len(all) > limit {
+		all = all[len(all)-limit:]
+	}
+	return all, nil
+}
+
+// DeletePayloadVerdictsBeforeSlot deletes the verdicts recorded on the execution payloads of
+// blocks older than the given slot. Verdicts on finalized blocks are no longer of interest,
+// except for the blocks found invalid or invalidated manually, whose verdicts are kept.
+func (s *Store) DeletePayloadVerdictsBeforeSlot(ctx context.Context, slot types.Slot) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.DeletePayloadVerdictsBeforeSlot")
+	defer span.End()
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(payloadVerdictsBucket)
+		var staleRoots [][]byte
+		if err := bkt.ForEach(func(root, enc []byte) error {
+			verdicts := &ethpb.PayloadVerdicts{}
+			if err := decode(ctx, enc, verdicts); err != nil {
+				return err
+			}
+			if len(verdicts.Verdicts) == 0 {
+				staleRoots = append(staleRoots, bytesutil.SafeCopyBytes(root))
+				return nil
+			}
+			if verdicts.Verdicts[0].Slot < slot && !retainPayloadVerdicts(verdicts.Verdicts) {
+				staleRoots = append(staleRoots, bytesutil.SafeCopyBytes(root))
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		for _, root := range staleRoots {
+			if err := bkt.Delete(root); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// retainPayloadVerdicts returns true if the verdicts of a block should be kept after finalization,
+// which is the case when its payload was found invalid or the block was invalidated manually.
+func retainPayloadVerdicts(verdicts []*ethpb.PayloadVerdict) bool {
+	for _, v := range verdicts {
+		switch {
+		case v.Source == ethpb.PayloadVerdict_MANUAL:
+			return true
+		case v.Status == ethpb.PayloadVerdict_INVALID, v.Status == ethpb.PayloadVerdict_INVALID_BLOCK_HASH:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestStore_PayloadVerdicts_CanSaveRetrieve(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	root1 := [32]byte{'A'}
+	root2 := [32]byte{'B'}
+
+	verdicts, err := db.PayloadVerdicts(ctx, root1)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(verdicts))
+
+	syncing := &ethpb.PayloadVerdict{
+		BlockRoot: root1[:],
+		Slot:      1,
+		Status:    ethpb.PayloadVerdict_SYNCING,
+		Source:    ethpb.PayloadVerdict_NEW_PAYLOAD,
+		Timestamp: 10,
+	}
+	valid := &ethpb.PayloadVerdict{
+		BlockRoot: root2[:],
+		Slot:      2,
+		Status:    ethpb.PayloadVerdict_VALID,
+		Source:    ethpb.PayloadVerdict_NEW_PAYLOAD,
+		Timestamp: 11,
+	}
+	invalid := &ethpb.PayloadVerdict{
+		BlockRoot:       root1[:],
+		Slot:            1,
+		Status:          ethpb.PayloadVerdict_INVALID,
+		Source:          ethpb.PayloadVerdict_FORKCHOICE_UPDATED,
+		LatestValidHash: []byte{'C'},
+		PrunedRoots:     [][]byte{root1[:]},
+		Timestamp:       12,
+	}
+	require.NoError(t, db.SavePayloadVerdict(ctx, syncing))
+	require.NoError(t, db.SavePayloadVerdict(ctx, valid))
+	require.NoError(t, db.SavePayloadVerdict(ctx, invalid))
+
+	verdicts, err = db.PayloadVerdicts(ctx, root1)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(verdicts))
+	assert.DeepEqual(t, syncing, verdicts[0])
+	assert.DeepEqual(t, invalid, verdicts[1])
+
+	verdicts, err = db.LatestPayloadVerdicts(ctx, 10)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(verdicts))
+	assert.DeepEqual(t, syncing, verdicts[0])
+	assert.DeepEqual(t, valid, verdicts[1])
+	assert.DeepEqual(t, invalid, verdicts[2])
+
+	verdicts, err = db.LatestPayloadVerdicts(ctx, 2)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(verdicts))
+	assert.DeepEqual(t, valid, verdicts[0])
+	assert.DeepEqual(t, invalid, verdicts[1])
+}
+
+func TestStore_DeletePayloadVerdictsBeforeSlot(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	root1 := [32]byte{'A'}
+	root2 := [32]byte{'B'}
+	root3 := [32]byte{'C'}
+	for i, root := range [][32]byte{root1, root2, root3} {
+		require.NoError(t, db.SavePayloadVerdict(ctx, &ethpb.PayloadVerdict{
+			BlockRoot: bytesutil.SafeCopyBytes(root[:]),
+			Slot:      types.Slot(i + 1),
+			Status:    ethpb.PayloadVerdict_SYNCING,
+			Source:    ethpb.PayloadVerdict_NEW_PAYLOAD,
+			Timestamp: uint64(i),
+		}))
+	}
+
+	// The verdicts of invalid and manually invalidated blocks are kept.
+	invalidRoot := [32]byte{'D'}
+	require.NoError(t, db.SavePayloadVerdict(ctx, &ethpb.PayloadVerdict{
+		BlockRoot: invalidRoot[:],
+		Slot:      1,
+		Status:    ethpb.PayloadVerdict_INVALID,
+		Source:    ethpb.PayloadVerdict_NEW_PAYLOAD,
+	}))
+	manualRoot := [32]byte{'E'}
+	require.NoError(t, db.SavePayloadVerdict(ctx, &ethpb.PayloadVerdict{
+		BlockRoot: manualRoot[:],
+		Slot:      1,
+		Status:    ethpb.PayloadVerdict_SYNCING,
+		Source:    ethpb.PayloadVerdict_NEW_PAYLOAD,
+	}))
+	require.NoError(t, db.SavePayloadVerdict(ctx, &ethpb.PayloadVerdict{
+		BlockRoot: manualRoot[:],
+		Slot:      1,
+		Status:    ethpb.PayloadVerdict_INVALID,
+		Source:    ethpb.PayloadVerdict_MANUAL,
+	}))
+
+	require.NoError(t, db.DeletePayloadVerdictsBeforeSlot(ctx, 2))
+	verdicts, err := db.PayloadVerdicts(ctx, root1)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(verdicts))
+	verdicts, err = db.PayloadVerdicts(ctx, invalidRoot)
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(verdicts))
+	verdicts, err = db.PayloadVerdicts(ctx, manualRoot)
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(verdicts))
+	verdicts, err = db.PayloadVerdicts(ctx, root2)
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(verdicts))
+	verdicts, err = db.PayloadVerdicts(ctx, root3)
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(verdicts))
+}
+
+func TestStore_SavePayloadVerdict_NoRoot(t *testing.T) {
+	db := setupDB(t)
+	err := db.SavePayloadVerdict(context.Background(), &ethpb.PayloadVerdict{Status: ethpb.PayloadVerdict_VALID})
+	require.ErrorContains(t, "cannot save payload verdict without a block root", err)
+}
@@ -20,6 +20,7 @@ var (
 	stateValidatorsBucket   = []byte("state-validators")
 	feeRecipientBucket      = []byte("fee-recipient")
 	registrationBucket      = []byte("registration")
+	payloadVerdictsBucket   = []byte("payload-verdicts")
 
 	// Deprecated: This bucket was migrated in PR 6461. Do not use, except for migrations.
 	slotsHasObjectBucket = []byte("slots-has-objects")
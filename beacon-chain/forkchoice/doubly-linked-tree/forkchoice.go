@@ -413,11 +413,45 @@ func (f *ForkChoice) FinalizedCheckpoint() *forkchoicetypes.Checkpoint {
 	return f.store.finalizedCheckpoint
 }
 
-func (f *ForkChoice) ForkChoiceNodes() []*ethpb.ForkChoiceNode {
+// ForkChoiceDump returns a full dump of the fork choice store, including every node in the tree.
+func (f *ForkChoice) ForkChoiceDump(ctx context.Context) (*ethpb.ForkChoiceResponse, error) {
 	f.store.nodesLock.RLock()
 	defer f.store.nodesLock.RUnlock()
-	ret := make([]*ethpb.ForkChoiceNode, len(f.store.nodeByRoot))
-	return f.store.treeRootNode.rpcNodes(ret)
+
+	nodes := make([]*ethpb.ForkChoiceNode, 0, len(f.store.nodeByRoot))
+	if f.store.treeRootNode != nil {
+		var err error
+		nodes, err = f.store.treeRootNode.nodeTreeDump(ctx, nodes)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var headRoot [32]byte
+	if f.store.headNode != nil {
+		headRoot = f.store.headNode.root
+	}
+
+	f.store.proposerBoostLock.RLock()
+	proposerBoostRoot := f.store.proposerBoostRoot
+	previousProposerBoostRoot := f.store.previousProposerBoostRoot
+	f.store.proposerBoostLock.RUnlock()
+
+	f.store.checkpointsLock.RLock()
+	defer f.store.checkpointsLock.RUnlock()
+	return &ethpb.ForkChoiceResponse{
+		JustifiedEpoch:                f.store.justifiedCheckpoint.Epoch,
+		FinalizedEpoch:                f.store.finalizedCheckpoint.Epoch,
+		ForkchoiceNodes:               nodes,
+		JustifiedCheckpoint:           f.store.justifiedCheckpoint.Proto(),
+		FinalizedCheckpoint:           f.store.finalizedCheckpoint.Proto(),
+		BestJustifiedCheckpoint:       f.store.bestJustifiedCheckpoint.Proto(),
+		PreviousJustifiedCheckpoint:   f.store.prevJustifiedCheckpoint.Proto(),
+		UnrealizedJustifiedCheckpoint: f.store.unrealizedJustifiedCheckpoint.Proto(),
+		UnrealizedFinalizedCheckpoint: f.store.unrealizedFinalizedCheckpoint.Proto(),
+		ProposerBoostRoot:             proposerBoostRoot[:],
+		PreviousProposerBoostRoot:     previousProposerBoostRoot[:],
+		HeadRoot:                      headRoot[:],
+	}, nil
 }
 
 // SetOptimisticToInvalid removes a block with an invalid execution payload from fork choice store
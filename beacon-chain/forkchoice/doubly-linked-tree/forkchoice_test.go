@@ -3,6 +3,8 @@ package doublylinkedtree
 import (
 	"context"
 	"encoding/binary"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -14,11 +16,13 @@ import (
 	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/consensus-types/wrapper"
 	"github.com/prysmaticlabs/prysm/crypto/hash"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	enginev1 "github.com/prysmaticlabs/prysm/proto/engine/v1"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/testing/assert"
 	"github.com/prysmaticlabs/prysm/testing/require"
 	"github.com/prysmaticlabs/prysm/testing/util"
+	"google.golang.org/protobuf/encoding/protojson"
 )
 
 // prepareForkchoiceState prepares a beacon State with the given data to mock
@@ -738,3 +742,55 @@ func TestForkChoice_UpdateCheckpoints(t *testing.T) {
 		})
 	}
 }
+
+func TestForkChoice_ForkChoiceDump(t *testing.T) {
+	ctx := context.Background()
+	f := setup(0, 0)
+	driftGenesisTime(f, 1, 0)
+	state, blkRoot, err := prepareForkchoiceState(ctx, 1, indexToHash(1), params.BeaconConfig().ZeroHash, [32]byte{'A'}, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, f.InsertNode(ctx, state, blkRoot))
+	driftGenesisTime(f, 2, params.BeaconConfig().SecondsPerSlot-1)
+	state, blkRoot, err = prepareForkchoiceState(ctx, 2, indexToHash(2), indexToHash(1), [32]byte{'B'}, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, f.InsertNode(ctx, state, blkRoot))
+	require.NoError(t, f.SetOptimisticToValid(ctx, indexToHash(1)))
+	head, err := f.Head(ctx, []uint64{10, 10})
+	require.NoError(t, err)
+	require.Equal(t, indexToHash(2), head)
+
+	dump, err := f.ForkChoiceDump(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(dump.ForkchoiceNodes))
+	assert.DeepEqual(t, head[:], dump.HeadRoot)
+	assert.DeepEqual(t, f.JustifiedCheckpoint().Root[:], dump.JustifiedCheckpoint.Root)
+	assert.Equal(t, f.FinalizedCheckpoint().Epoch, dump.FinalizedCheckpoint.Epoch)
+
+	n := dump.ForkchoiceNodes[1]
+	root := indexToHash(1)
+	assert.DeepEqual(t, root[:], n.Root)
+	assert.Equal(t, types.Slot(1), n.Slot)
+	assert.DeepEqual(t, params.BeaconConfig().ZeroHash[:], n.Parent)
+	assert.DeepEqual(t, head[:], n.BestDescendant)
+	assert.DeepEqual(t, [32]byte{'A'}, bytesutil.ToBytes32(n.ExecutionBlockHash))
+	assert.Equal(t, ethpb.ForkChoiceNode_VALID, n.Validity)
+	assert.Equal(t, true, n.Timely)
+
+	n = dump.ForkchoiceNodes[2]
+	assert.DeepEqual(t, head[:], n.Root)
+	assert.DeepEqual(t, [32]byte{'B'}, bytesutil.ToBytes32(n.ExecutionBlockHash))
+	assert.Equal(t, ethpb.ForkChoiceNode_OPTIMISTIC, n.Validity)
+	assert.Equal(t, false, n.Timely)
+
+	rec := httptest.NewRecorder()
+	forkchoice.DumpHandler(f)(rec, httptest.NewRequest("GET", "/debug/forkchoice", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	res := &ethpb.ForkChoiceResponse{}
+	require.NoError(t, protojson.Unmarshal(rec.Body.Bytes(), res))
+	assert.DeepEqual(t, dump, res)
+
+	rec = httptest.NewRecorder()
+	forkchoice.DumpHandler(f)(rec, httptest.NewRequest("GET", "/debug/forkchoice?format=dot", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, forkchoice.DumpToDot(dump), rec.Body.String())
+}
@@ -21,6 +21,12 @@ var (
 			Help: "The number of nodes in the DAG array based store structure.",
 		},
 	)
+	optimisticNodeCount = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "doublylinkedtree_optimistic_node_count",
+			Help: "The number of optimistic nodes in the DAG array based store structure.",
+		},
+	)
 	headChangesCount = promauto.NewCounter(
 		prometheus.CounterOpts{
 			Name: "doublylinkedtree_head_changed_count",
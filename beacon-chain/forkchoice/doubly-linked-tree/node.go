@@ -124,30 +124,47 @@ func (n *Node) setNodeAndParentValidated(ctx context.Context) error {
 	return n.parent.setNodeAndParentValidated(ctx)
 }
 
-// rpcNodes is used by the RPC Debug endpoint to return information
-// about all nodes in the fork choice store
-func (n *Node) rpcNodes(ret []*pbrpc.ForkChoiceNode) []*pbrpc.ForkChoiceNode {
-	for _, child := range n.children {
-		ret = child.rpcNodes(ret)
+// nodeTreeDump appends to the given slice the information of every node of the subtree rooted
+// at this node. It is used by the RPC Debug endpoint to dump the fork choice store.
+func (n *Node) nodeTreeDump(ctx context.Context, nodes []*pbrpc.ForkChoiceNode) ([]*pbrpc.ForkChoiceNode, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
 	}
-	r := n.root
-	p := [32]byte{}
+	var parentRoot [32]byte
 	if n.parent != nil {
-		copy(p[:], n.parent.root[:])
+		parentRoot = n.parent.root
 	}
-	b := [32]byte{}
+	var bestDescendantRoot [32]byte
 	if n.bestDescendant != nil {
-		copy(b[:], n.bestDescendant.root[:])
+		bestDescendantRoot = n.bestDescendant.root
 	}
-	node := &pbrpc.ForkChoiceNode{
-		Slot:           n.slot,
-		Root:           r[:],
-		Parent:         p[:],
-		JustifiedEpoch: n.justifiedEpoch,
-		FinalizedEpoch: n.finalizedEpoch,
-		Weight:         n.weight,
-		BestDescendant: b[:],
+	validity := pbrpc.ForkChoiceNode_VALID
+	if n.optimistic {
+		validity = pbrpc.ForkChoiceNode_OPTIMISTIC
 	}
-	ret = append(ret, node)
-	return ret
+	root := n.root
+	payloadHash := n.payloadHash
+	nodes = append(nodes, &pbrpc.ForkChoiceNode{
+		Slot:                     n.slot,
+		Root:                     root[:],
+		Parent:                   parentRoot[:],
+		JustifiedEpoch:           n.justifiedEpoch,
+		FinalizedEpoch:           n.finalizedEpoch,
+		UnrealizedJustifiedEpoch: n.unrealizedJustifiedEpoch,
+		UnrealizedFinalizedEpoch: n.unrealizedFinalizedEpoch,
+		Balance:                  n.balance,
+		Weight:                   n.weight,
+		BestDescendant:           bestDescendantRoot[:],
+		ExecutionBlockHash:       payloadHash[:],
+		Validity:                 validity,
+		Timely:                   n.timely,
+	})
+	var err error
+	for _, child := range n.children {
+		nodes, err = child.nodeTreeDump(ctx, nodes)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
 }
@@ -0,0 +1,111 @@
+package doublylinkedtree
+
+import (
+	"time"
+
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/time/slots"
+)
+
+const (
+	// reorgHeadWeightThreshold is the percentage of a committee weight below which a late head is considered weak.
+	reorgHeadWeightThreshold = 20
+	// reorgParentWeightThreshold is the percentage of a committee weight above which the parent of a late head
+	// is considered strong.
+	reorgParentWeightThreshold = 160
+	// reorgMaxEpochsSinceFinalization is the maximum number of epochs since the finalized checkpoint for which
+	// late blocks may be re-orged.
+	reorgMaxEpochsSinceFinalization = 2
+)
+
+// Weight returns the weight of the given root if found on the store
+func (f *ForkChoice) Weight(root [32]byte) (uint64, error) {
+	f.store.nodesLock.RLock()
+	defer f.store.nodesLock.RUnlock()
+
+	n, ok := f.store.nodeByRoot[root]
+	if !ok || n == nil {
+		return 0, ErrNilNode
+	}
+	return n.weight, nil
+}
+
+// IsTimely returns true if the block with the given root arrived before the attestation
+// deadline of its slot.
+func (f *ForkChoice) IsTimely(root [32]byte) (bool, error) {
+	f.store.nodesLock.RLock()
+	defer f.store.nodesLock.RUnlock()
+
+	n, ok := f.store.nodeByRoot[root]
+	if !ok || n == nil {
+		return false, ErrNilNode
+	}
+	return n.timely, nil
+}
+
+// ProposerHead returns the block root that a proposer of the given slot should build on. This is
+// the current head, unless the head arrived late and gathered little attestation weight while its
+// parent is strong, in which case the parent is returned so that the late block gets re-orged out.
+func (f *ForkChoice) ProposerHead(slot types.Slot) [32]byte {
+	f.store.nodesLock.RLock()
+	defer f.store.nodesLock.RUnlock()
+
+	head := f.store.headNode
+	if head == nil {
+		return [32]byte{}
+	}
+	if !f.shouldReorgLateHead(head, slot) {
+		return head.root
+	}
+	return head.parent.root
+}
+
+// shouldReorgLateHead returns true if a proposer of the given slot should build on the parent of
+// the given head node. This function assumes a lock on s.nodesLock.
+func (f *ForkChoice) shouldReorgLateHead(head *Node, slot types.Slot) bool {
+	parent := head.parent
+	if head.timely || parent == nil {
+		return false
+	}
+	// Only single slot re-orgs are attempted, and the proposer shuffling must be stable.
+	if parent.slot+1 != head.slot || head.slot+1 != slot {
+		return false
+	}
+	if slot%params.BeaconConfig().SlotsPerEpoch == 0 {
+		return false
+	}
+	// The re-org must not change the unrealized justification.
+	if head.unrealizedJustifiedEpoch != parent.unrealizedJustifiedEpoch {
+		return false
+	}
+	if slots.ToEpoch(slot) > f.FinalizedCheckpoint().Epoch+reorgMaxEpochsSinceFinalization {
+		return false
+	}
+	if !f.store.proposingOnTime(slot) {
+		return false
+	}
+	committeeWeight, err := computeCommitteeWeight(f.balances)
+	if err != nil {
+		return false
+	}
+	if head.weight*100 >= committeeWeight*reorgHeadWeightThreshold {
+		return false
+	}
+	return parent.weight*100 > committeeWeight*reorgParentWeightThreshold
+}
+
+// proposingOnTime returns true if the current time is early enough in the given slot for a
+// re-orging block to receive the proposer boost.
+func (s *Store) proposingOnTime(slot types.Slot) bool {
+	timeNow := uint64(time.Now().Unix())
+	if timeNow < s.genesisTime {
+		return false
+	}
+	if slots.CurrentSlot(s.genesisTime) != slot {
+		return false
+	}
+	secondsIntoSlot := (timeNow - s.genesisTime) % params.BeaconConfig().SecondsPerSlot
+	reorgCutoff := params.BeaconConfig().SecondsPerSlot / params.BeaconConfig().IntervalsPerSlot / 2
+	return secondsIntoSlot <= reorgCutoff
+}
@@ -0,0 +1,136 @@
+package doublylinkedtree
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+// setupLateHead returns a fork choice store whose head at slot+1 was received with the given delay
+// into its slot, on top of a parent at the given slot that received the votes of ten validators.
+func setupLateHead(t *testing.T, slot types.Slot, delay uint64, headVoters []uint64) (*ForkChoice, [32]byte, [32]byte) {
+	ctx := context.Background()
+	zeroHash := params.BeaconConfig().ZeroHash
+	balances := make([]uint64, 64)
+	for i := 0; i < len(balances); i++ {
+		balances[i] = 10
+	}
+	f := setup(0, 0)
+
+	parentRoot := indexToHash(1)
+	driftGenesisTime(f, slot, 0)
+	state, blkRoot, err := prepareForkchoiceState(ctx, slot, parentRoot, zeroHash, zeroHash, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, f.InsertNode(ctx, state, blkRoot))
+	f.ProcessAttestation(ctx, []uint64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, parentRoot, 0)
+
+	headRoot := indexToHash(2)
+	driftGenesisTime(f, slot+1, delay)
+	state, blkRoot, err = prepareForkchoiceState(ctx, slot+1, headRoot, parentRoot, zeroHash, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, f.InsertNode(ctx, state, blkRoot))
+	f.ProcessAttestation(ctx, headVoters, headRoot, 0)
+	require.NoError(t, f.ResetBoostedProposerRoot(ctx))
+
+	head, err := f.Head(ctx, balances)
+	require.NoError(t, err)
+	require.Equal(t, headRoot, head)
+	return f, parentRoot, headRoot
+}
+
+func TestForkChoice_IsTimely(t *testing.T) {
+	f, parentRoot, headRoot := setupLateHead(t, 1, 5, nil)
+	timely, err := f.IsTimely(parentRoot)
+	require.NoError(t, err)
+	assert.Equal(t, true, timely)
+	timely, err = f.IsTimely(headRoot)
+	require.NoError(t, err)
+	assert.Equal(t, false, timely)
+
+	_, err = f.IsTimely([32]byte{'a'})
+	require.ErrorIs(t, err, ErrNilNode)
+}
+
+func TestForkChoice_Weight(t *testing.T) {
+	f, parentRoot, headRoot := setupLateHead(t, 1, 5, []uint64{10})
+	w, err := f.Weight(parentRoot)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(110), w)
+	w, err = f.Weight(headRoot)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), w)
+
+	_, err = f.Weight([32]byte{'a'})
+	require.ErrorIs(t, err, ErrNilNode)
+}
+
+func TestForkChoice_ProposerHead(t *testing.T) {
+	epochSlots := params.BeaconConfig().SlotsPerEpoch
+	tests := []struct {
+		name         string
+		slot         types.Slot
+		delay        uint64
+		headVoters   []uint64
+		proposalSlot types.Slot
+		reorg        bool
+	}{
+		{
+			name:         "late and weak head is re-orged",
+			slot:         1,
+			delay:        5,
+			proposalSlot: 3,
+			reorg:        true,
+		},
+		{
+			name:         "timely head is not re-orged",
+			slot:         1,
+			proposalSlot: 3,
+		},
+		{
+			name:         "late head with enough weight is not re-orged",
+			slot:         1,
+			delay:        5,
+			headVoters:   []uint64{10},
+			proposalSlot: 3,
+		},
+		{
+			name:         "proposal that would skip a slot does not re-org",
+			slot:         1,
+			delay:        5,
+			proposalSlot: 4,
+		},
+		{
+			name:         "proposal at an epoch boundary does not re-org",
+			slot:         epochSlots - 2,
+			delay:        5,
+			proposalSlot: epochSlots,
+		},
+		{
+			name:         "proposal far from finality does not re-org",
+			slot:         3*epochSlots + 1,
+			delay:        5,
+			proposalSlot: 3*epochSlots + 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, parentRoot, headRoot := setupLateHead(t, tt.slot, tt.delay, tt.headVoters)
+			driftGenesisTime(f, tt.proposalSlot, 0)
+			if tt.reorg {
+				assert.Equal(t, parentRoot, f.ProposerHead(tt.proposalSlot))
+			} else {
+				assert.Equal(t, headRoot, f.ProposerHead(tt.proposalSlot))
+			}
+		})
+	}
+}
+
+func TestForkChoice_ProposerHead_LateProposal(t *testing.T) {
+	f, _, headRoot := setupLateHead(t, 1, 5, nil)
+	driftGenesisTime(f, 3, params.BeaconConfig().SecondsPerSlot/2)
+	assert.Equal(t, headRoot, f.ProposerHead(3))
+}
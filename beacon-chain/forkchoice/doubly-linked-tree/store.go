@@ -99,6 +99,7 @@ func (s *Store) head(ctx context.Context) ([32]byte, error) {
 		headSlotNumber.Set(float64(bestDescendant.slot))
 		s.headNode = bestDescendant
 	}
+	optimisticNodeCount.Set(float64(s.optimisticNodeCount()))
 
 	return bestDescendant.root, nil
 }
@@ -154,6 +155,7 @@ func (s *Store) insert(ctx context.Context,
 		currentSlot := slots.CurrentSlot(s.genesisTime)
 		boostTreshold := params.BeaconConfig().SecondsPerSlot / params.BeaconConfig().IntervalsPerSlot
 		if currentSlot == slot && secondsIntoSlot < boostTreshold {
+			n.timely = true
 			s.proposerBoostLock.Lock()
 			s.proposerBoostRoot = root
 			s.proposerBoostLock.Unlock()
@@ -241,3 +243,15 @@ func (s *Store) tips() ([][32]byte, []types.Slot) {
 	}
 	return roots, slots
 }
+
+// optimisticNodeCount returns the number of optimistic nodes in the store.
+// This function assumes a lock on s.nodesLock.
+func (s *Store) optimisticNodeCount() int {
+	count := 0
+	for _, n := range s.nodeByRoot {
+		if n.optimistic {
+			count++
+		}
+	}
+	return count
+}
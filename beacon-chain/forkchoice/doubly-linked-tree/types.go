@@ -56,6 +56,7 @@ type Node struct {
 	weight                   uint64                       // weight of this node: the total balance including children
 	bestDescendant           *Node                        // bestDescendant node of this node.
 	optimistic               bool                         // whether the block has been fully validated or not
+	timely                   bool                         // whether the block arrived before the attestation deadline of its slot
 }
 
 // Vote defines an individual validator's vote.
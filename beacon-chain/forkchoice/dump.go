@@ -0,0 +1,102 @@
+package forkchoice
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+
+	"github.com/emicklei/dot"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// DumpToDot renders a fork choice store dump as a directed graph in Graphviz DOT format. Every
+// node points to its parent, optimistic nodes are dashed, invalid nodes are red and the head and
+// the proposer boosted node are highlighted.
+func DumpToDot(dump *ethpb.ForkChoiceResponse) string {
+	graph := dot.NewGraph(dot.Directed)
+	graph.Attr("rankdir", "RL")
+	graph.Attr("labeljust", "l")
+	if dump == nil {
+		return graph.String()
+	}
+
+	dotNodes := make(map[string]dot.Node, len(dump.ForkchoiceNodes))
+	for _, n := range dump.ForkchoiceNodes {
+		id := hex.EncodeToString(n.Root)
+		label := fmt.Sprintf("slot: %d\nroot: %#x\nweight: %d\nbalance: %d\njustified: %d, finalized: %d\n"+
+			"unrealized justified: %d, unrealized finalized: %d\npayload: %#x\n",
+			n.Slot, shortRoot(n.Root), n.Weight, n.Balance, n.JustifiedEpoch, n.FinalizedEpoch,
+			n.UnrealizedJustifiedEpoch, n.UnrealizedFinalizedEpoch, shortRoot(n.ExecutionBlockHash))
+		if n.Timely {
+			label += "timely"
+		} else {
+			label += "late"
+		}
+		if bytes.Equal(n.Root, dump.ProposerBoostRoot) {
+			label += ", boosted"
+		}
+		dn := graph.Node(id).Box().Attr("label", label)
+		switch n.Validity {
+		case ethpb.ForkChoiceNode_OPTIMISTIC:
+			dn = dn.Attr("style", "dashed")
+		case ethpb.ForkChoiceNode_INVALID:
+			dn = dn.Attr("color", "red")
+		}
+		if bytes.Equal(n.Root, dump.HeadRoot) {
+			dn = dn.Attr("penwidth", "3")
+		}
+		dotNodes[id] = dn
+	}
+
+	// Construct an edge only if the node's parent is in the dump.
+	for _, n := range dump.ForkchoiceNodes {
+		parent, ok := dotNodes[hex.EncodeToString(n.Parent)]
+		if !ok {
+			continue
+		}
+		graph.Edge(dotNodes[hex.EncodeToString(n.Root)], parent)
+	}
+	return graph.String()
+}
+
+// DumpHandler serves the full fork choice store of the given fork choicer. The store is written
+// as JSON unless the request asks for DOT with the `format=dot` query parameter.
+func DumpHandler(f ForkChoicer) func(http.ResponseWriter, *http.Request) {
+	log := logrus.WithField("prefix", "forkchoice")
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		dump, err := f.ForkChoiceDump(r.Context())
+		if err != nil {
+			log.WithError(err).Error("Could not dump fork choice store")
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		var res []byte
+		if r.URL.Query().Get("format") == "dot" {
+			w.Header().Set("Content-Type", "text/vnd.graphviz")
+			res = []byte(DumpToDot(dump))
+		} else {
+			res, err = protojson.Marshal(dump)
+			if err != nil {
+				log.WithError(err).Error("Could not marshal fork choice store")
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+		}
+		if _, err := w.Write(res); err != nil {
+			log.WithError(err).Error("Could not write fork choice store")
+		}
+	}
+}
+
+// shortRoot returns the first bytes of a root for display purposes.
+func shortRoot(root []byte) []byte {
+	if len(root) > 4 {
+		return root[:4]
+	}
+	return root
+}
@@ -0,0 +1,41 @@
+package forkchoice
+
+import (
+	"strings"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+)
+
+func TestDumpToDot(t *testing.T) {
+	root := []byte{'a'}
+	valid := []byte{'b'}
+	optimistic := []byte{'c'}
+	invalid := []byte{'d'}
+	dump := &ethpb.ForkChoiceResponse{
+		ForkchoiceNodes: []*ethpb.ForkChoiceNode{
+			{Slot: 1, Root: root, Parent: []byte{'z'}, Timely: true},
+			{Slot: 2, Root: valid, Parent: root, Weight: 32, Timely: true},
+			{Slot: 3, Root: optimistic, Parent: valid, Validity: ethpb.ForkChoiceNode_OPTIMISTIC},
+			{Slot: 3, Root: invalid, Parent: valid, Validity: ethpb.ForkChoiceNode_INVALID},
+		},
+		ProposerBoostRoot: valid,
+		HeadRoot:          optimistic,
+	}
+	graph := DumpToDot(dump)
+
+	assert.Equal(t, true, strings.HasPrefix(graph, "digraph"))
+	assert.Equal(t, 3, strings.Count(graph, "->"), "Wanted an edge for every node with a known parent")
+	assert.Equal(t, 1, strings.Count(graph, "boosted"))
+	assert.Equal(t, 1, strings.Count(graph, "style=\"dashed\""))
+	assert.Equal(t, 1, strings.Count(graph, "color=\"red\""))
+	assert.Equal(t, 1, strings.Count(graph, "penwidth=\"3\""))
+	assert.Equal(t, 2, strings.Count(graph, "late"))
+	assert.Equal(t, true, strings.Contains(graph, "weight: 32"))
+}
+
+func TestDumpToDot_Nil(t *testing.T) {
+	graph := DumpToDot(nil)
+	assert.Equal(t, 0, strings.Count(graph, "->"))
+}
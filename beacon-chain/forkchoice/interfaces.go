@@ -26,6 +26,7 @@ type HeadRetriever interface {
 	CachedHeadRoot() [32]byte
 	Tips() ([][32]byte, []types.Slot)
 	IsOptimistic(root [32]byte) (bool, error)
+	ProposerHead(slot types.Slot) [32]byte
 }
 
 // BlockProcessor processes the block that's used for accounting fork choice.
@@ -59,8 +60,10 @@ type Getter interface {
 	PreviousJustifiedCheckpoint() *forkchoicetypes.Checkpoint
 	JustifiedPayloadBlockHash() [32]byte
 	BestJustifiedCheckpoint() *forkchoicetypes.Checkpoint
-	ForkChoiceNodes() []*ethpb.ForkChoiceNode
+	ForkChoiceDump(context.Context) (*ethpb.ForkChoiceResponse, error)
 	NodeCount() int
+	Weight(root [32]byte) (uint64, error)
+	IsTimely(root [32]byte) (bool, error)
 }
 
 // Setter allows to set forkchoice information
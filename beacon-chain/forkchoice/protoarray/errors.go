@@ -18,3 +18,4 @@ var errInvalidUnrealizedJustifiedEpoch = errors.New("invalid unrealized justifie
 var errInvalidUnrealizedFinalizedEpoch = errors.New("invalid unrealized finalized epoch")
 var errNilBlockHeader = errors.New("invalid nil block header")
 var errInvalidParentRoot = errors.New("invalid parent root")
+var errTimelinessNotTracked = errors.New("block timeliness is not tracked")
@@ -412,6 +412,7 @@ func (s *Store) head(ctx context.Context) ([32]byte, error) {
 		headSlotNumber.Set(float64(bestNode.slot))
 		s.lastHeadRoot = bestNode.root
 	}
+	optimisticNodeCount.Set(float64(s.optimisticNodeCount()))
 
 	// Update canonical mapping given the head root.
 	if err := s.updateCanonicalNodes(ctx, bestNode.root); err != nil {
@@ -872,7 +873,8 @@ func (f *ForkChoice) Tips() ([][32]byte, []types.Slot) {
 	return headsRoots, headsSlots
 }
 
-func (f *ForkChoice) ForkChoiceNodes() []*ethpb.ForkChoiceNode {
+// ForkChoiceDump returns a full dump of the fork choice store, including every node in the store.
+func (f *ForkChoice) ForkChoiceDump(_ context.Context) (*ethpb.ForkChoiceResponse, error) {
 	f.store.nodesLock.RLock()
 	defer f.store.nodesLock.RUnlock()
 	ret := make([]*ethpb.ForkChoiceNode, len(f.store.nodes))
@@ -894,18 +896,54 @@ func (f *ForkChoice) ForkChoiceNodes() []*ethpb.ForkChoiceNode {
 			bestDescendantNode := f.store.nodes[bestDescendantIdx]
 			bestDescendantRoot = bestDescendantNode.Root()
 		}
+		var validity ethpb.ForkChoiceNode_Validity
+		switch node.status {
+		case syncing:
+			validity = ethpb.ForkChoiceNode_OPTIMISTIC
+		case invalid:
+			validity = ethpb.ForkChoiceNode_INVALID
+		default:
+			validity = ethpb.ForkChoiceNode_VALID
+		}
+		payloadHash := node.payloadHash
 
 		ret[i] = &ethpb.ForkChoiceNode{
-			Slot:           node.Slot(),
-			Root:           root[:],
-			Parent:         parentRoot[:],
-			JustifiedEpoch: node.JustifiedEpoch(),
-			FinalizedEpoch: node.FinalizedEpoch(),
-			Weight:         node.Weight(),
-			BestDescendant: bestDescendantRoot[:],
+			Slot:                     node.Slot(),
+			Root:                     root[:],
+			Parent:                   parentRoot[:],
+			JustifiedEpoch:           node.JustifiedEpoch(),
+			FinalizedEpoch:           node.FinalizedEpoch(),
+			UnrealizedJustifiedEpoch: node.unrealizedJustifiedEpoch,
+			UnrealizedFinalizedEpoch: node.unrealizedFinalizedEpoch,
+			Weight:                   node.Weight(),
+			BestDescendant:           bestDescendantRoot[:],
+			ExecutionBlockHash:       payloadHash[:],
+			Validity:                 validity,
 		}
 	}
-	return ret
+	headRoot := f.store.lastHeadRoot
+
+	f.store.proposerBoostLock.RLock()
+	proposerBoostRoot := f.store.proposerBoostRoot
+	previousProposerBoostRoot := f.store.previousProposerBoostRoot
+	f.store.proposerBoostLock.RUnlock()
+
+	f.store.checkpointsLock.RLock()
+	defer f.store.checkpointsLock.RUnlock()
+	return &ethpb.ForkChoiceResponse{
+		JustifiedEpoch:                f.store.justifiedCheckpoint.Epoch,
+		FinalizedEpoch:                f.store.finalizedCheckpoint.Epoch,
+		ForkchoiceNodes:               ret,
+		JustifiedCheckpoint:           f.store.justifiedCheckpoint.Proto(),
+		FinalizedCheckpoint:           f.store.finalizedCheckpoint.Proto(),
+		BestJustifiedCheckpoint:       f.store.bestJustifiedCheckpoint.Proto(),
+		PreviousJustifiedCheckpoint:   f.store.prevJustifiedCheckpoint.Proto(),
+		UnrealizedJustifiedCheckpoint: f.store.unrealizedJustifiedCheckpoint.Proto(),
+		UnrealizedFinalizedCheckpoint: f.store.unrealizedFinalizedCheckpoint.Proto(),
+		ProposerBoostRoot:             proposerBoostRoot[:],
+		PreviousProposerBoostRoot:     previousProposerBoostRoot[:],
+		HeadRoot:                      headRoot[:],
+	}, nil
 }
 
 // InsertSlashedIndex adds the given slashed validator index to the
@@ -1025,6 +1063,28 @@ func (f *ForkChoice) CachedHeadRoot() [32]byte {
 	return f.store.lastHeadRoot
 }
 
+// ProposerHead returns the cached head root. Late block re-orgs are not supported by the proto array store.
+func (f *ForkChoice) ProposerHead(_ types.Slot) [32]byte {
+	return f.CachedHeadRoot()
+}
+
+// Weight returns the weight of the given root if found on the store
+func (f *ForkChoice) Weight(root [32]byte) (uint64, error) {
+	f.store.nodesLock.RLock()
+	defer f.store.nodesLock.RUnlock()
+
+	i, ok := f.store.nodesIndices[root]
+	if !ok || i >= uint64(len(f.store.nodes)) {
+		return 0, ErrUnknownNodeRoot
+	}
+	return f.store.nodes[i].weight, nil
+}
+
+// IsTimely is not supported by the proto array store, which does not track block arrival times.
+func (f *ForkChoice) IsTimely(_ [32]byte) (bool, error) {
+	return false, errTimelinessNotTracked
+}
+
 // FinalizedPayloadBlockHash returns the hash of the payload at the finalized checkpoint
 func (f *ForkChoice) FinalizedPayloadBlockHash() [32]byte {
 	f.store.nodesLock.RLock()
@@ -1052,3 +1112,15 @@ func (f *ForkChoice) JustifiedPayloadBlockHash() [32]byte {
 	node := f.store.nodes[idx]
 	return node.payloadHash
 }
+
+// optimisticNodeCount returns the number of optimistic nodes in the store.
+// This function assumes a lock on s.nodesLock.
+func (s *Store) optimisticNodeCount() int {
+	count := 0
+	for _, n := range s.nodes {
+		if n.status == syncing {
+			count++
+		}
+	}
+	return count
+}
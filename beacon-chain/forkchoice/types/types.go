@@ -23,6 +23,15 @@ type Checkpoint struct {
 	Root  [fieldparams.RootLength]byte
 }
 
+// Proto returns the slice version of the checkpoint, nil checkpoints are returned as nil.
+func (c *Checkpoint) Proto() *ethpb.Checkpoint {
+	if c == nil {
+		return nil
+	}
+	root := c.Root
+	return &ethpb.Checkpoint{Epoch: c.Epoch, Root: root[:]}
+}
+
 // BlockAndCheckpoints to call the InsertOptimisticChain function
 type BlockAndCheckpoints struct {
 	Block               interfaces.BeaconBlock
@@ -22,6 +22,30 @@ var (
 			"validator_index",
 		},
 	)
+	// inclusionDelayHistogram used to track the distribution of attestation inclusion delays
+	inclusionDelayHistogram = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "monitor",
+			Name:      "inclusion_delay_slots",
+			Help:      "Number of slots between an attestation and its inclusion in a block",
+			Buckets:   []float64{1, 2, 3, 4, 6, 8, 16, 32},
+		},
+		[]string{
+			"validator_index",
+		},
+	)
+	// latestTimelyFlagsGauge used to track the timely flags of the latest included attestation
+	latestTimelyFlagsGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "monitor",
+			Name:      "latest_timely_flag",
+			Help:      "Timely flag of the latest included attestation, 1 when set and 0 otherwise",
+		},
+		[]string{
+			"validator_index",
+			"flag",
+		},
+	)
 	// timelyHeadCounter used to track attestation timely head flags
 	timelyHeadCounter = promauto.NewCounterVec(
 		prometheus.CounterOpts{
@@ -90,4 +114,61 @@ var (
 			"validator_index",
 		},
 	)
+	// syncCommitteeMissedCounter used to track sync committee contributions missing from blocks
+	syncCommitteeMissedCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "monitor",
+			Name:      "sync_committee_missed_total",
+			Help:      "Number of Sync committee contributions missing from included sync aggregates",
+		},
+		[]string{
+			"validator_index",
+		},
+	)
+	// attestationRewardsGauge used to track the attestation rewards of the last epoch transition
+	attestationRewardsGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "monitor",
+			Name:      "attestation_rewards_gwei",
+			Help:      "Attestation rewards of the last epoch transition by component",
+		},
+		[]string{
+			"validator_index",
+			"component",
+		},
+	)
+	// attestationPenaltiesGauge used to track the attestation penalties of the last epoch transition
+	attestationPenaltiesGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "monitor",
+			Name:      "attestation_penalties_gwei",
+			Help:      "Attestation penalties of the last epoch transition by component",
+		},
+		[]string{
+			"validator_index",
+			"component",
+		},
+	)
+	// missedAttestationsCounter used to track epochs without an included attestation
+	missedAttestationsCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "monitor",
+			Name:      "missed_attestations_total",
+			Help:      "Number of epochs without an included attestation",
+		},
+		[]string{
+			"validator_index",
+		},
+	)
+	// missedProposalsCounter used to track skipped proposal slots
+	missedProposalsCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "monitor",
+			Name:      "missed_proposals_total",
+			Help:      "Number of proposal slots without a block",
+		},
+		[]string{
+			"validator_index",
+		},
+	)
 )
@@ -0,0 +1,97 @@
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+)
+
+// The maximum time allowed to deliver an alert to a webhook.
+const notifyTimeout = 10 * time.Second
+
+// AlertType defines the event which triggered an alert about a tracked validator.
+type AlertType string
+
+const (
+	// SlashedAlert is sent when a slashing of a tracked validator was included in a block.
+	SlashedAlert AlertType = "slashed"
+	// MissedAttestationsAlert is sent when a tracked validator missed the configured number
+	// of consecutive attestations.
+	MissedAttestationsAlert AlertType = "missed_attestations"
+	// MissedProposalAlert is sent when a tracked validator did not propose the block of its slot.
+	MissedProposalAlert AlertType = "missed_proposal"
+)
+
+// Alert about a tracked validator, sent to the configured notifier.
+type Alert struct {
+	Type           AlertType            `json:"type"`
+	ValidatorIndex types.ValidatorIndex `json:"validator_index,string"`
+	Slot           types.Slot           `json:"slot,string"`
+	Epoch          types.Epoch          `json:"epoch,string"`
+	Message        string               `json:"message"`
+}
+
+// Notifier sends the alerts of the monitor service, for an operator to react to.
+type Notifier interface {
+	Notify(ctx context.Context, alert *Alert) error
+}
+
+// WebhookNotifier posts alerts as JSON to an HTTP endpoint.
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookNotifier creates a notifier posting alerts to the given URL.
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:    url,
+		client: &http.Client{Timeout: notifyTimeout},
+	}
+}
+
+// Notify posts the alert to the webhook, failing on any non-2xx response.
+func (n *WebhookNotifier) Notify(ctx context.Context, alert *Alert) error {
+	enc, err := json.Marshal(alert)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal alert")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(enc))
+	if err != nil {
+		return errors.Wrap(err, "could not create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "could not post alert")
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.WithError(err).Error("Could not close response body")
+		}
+	}()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// notify sends an alert in the background, not to delay the processing of the monitor service.
+func (s *Service) notify(alert *Alert) {
+	if s.config.Notifier == nil {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(s.ctx, notifyTimeout)
+		defer cancel()
+		if err := s.config.Notifier.Notify(ctx, alert); err != nil {
+			log.WithError(err).WithField("AlertType", alert.Type).Error("Could not send alert")
+		}
+	}()
+}
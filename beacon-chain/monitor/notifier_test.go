@@ -0,0 +1,70 @@
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+// alertsRecorder records the alerts sent by the monitor service.
+type alertsRecorder struct {
+	alerts chan *Alert
+}
+
+func newAlertsRecorder() *alertsRecorder {
+	return &alertsRecorder{alerts: make(chan *Alert, 10)}
+}
+
+func (r *alertsRecorder) Notify(_ context.Context, alert *Alert) error {
+	r.alerts <- alert
+	return nil
+}
+
+func TestWebhookNotifier_Notify(t *testing.T) {
+	// Capture the request bodies like tools/http-request-sink does.
+	bodies := make(chan map[string]interface{}, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		content := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(body, &content))
+		bodies <- content
+	}))
+	defer srv.Close()
+
+	n := NewWebhookNotifier(srv.URL)
+	require.NoError(t, n.Notify(context.Background(), &Alert{
+		Type:           MissedProposalAlert,
+		ValidatorIndex: 7,
+		Slot:           65,
+		Epoch:          2,
+		Message:        "Validator 7 missed the proposal of slot 65",
+	}))
+	wanted := map[string]interface{}{
+		"type":            "missed_proposal",
+		"validator_index": "7",
+		"slot":            "65",
+		"epoch":           "2",
+		"message":         "Validator 7 missed the proposal of slot 65",
+	}
+	assert.DeepEqual(t, wanted, <-bodies)
+}
+
+func TestWebhookNotifier_Notify_ErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	n := NewWebhookNotifier(srv.URL)
+	err := n.Notify(context.Background(), &Alert{Type: SlashedAlert})
+	require.ErrorContains(t, "webhook returned status code 500", err)
+}
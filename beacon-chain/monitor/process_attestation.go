@@ -72,6 +72,7 @@ func (s *Service) processIncludedAttestation(ctx context.Context, state state.Be
 	s.Lock()
 	defer s.Unlock()
 	for _, idx := range attestingIndices {
+		s.recordAttestedEpoch(types.ValidatorIndex(idx), slots.ToEpoch(att.Data.Slot))
 		if s.canUpdateAttestedValidator(types.ValidatorIndex(idx), att.Data.Slot) {
 			logFields := logMessageTimelyFlagsForIndex(types.ValidatorIndex(idx), att.Data)
 			balance, err := state.BalanceAtIndex(types.ValidatorIndex(idx))
@@ -91,9 +92,11 @@ func (s *Service) processIncludedAttestation(ctx context.Context, state state.Be
 			latestPerf.attestedSlot = att.Data.Slot
 			latestPerf.inclusionSlot = state.Slot()
 			inclusionSlotGauge.WithLabelValues(fmt.Sprintf("%d", idx)).Set(float64(latestPerf.inclusionSlot))
+			inclusionDelayHistogram.WithLabelValues(fmt.Sprintf("%d", idx)).Observe(
+				float64(latestPerf.inclusionSlot - latestPerf.attestedSlot))
 			aggregatedPerf.totalDistance += uint64(latestPerf.inclusionSlot - latestPerf.attestedSlot)
 
-			if state.Version() == version.Altair {
+			if state.Version() >= version.Altair {
 				targetIdx := params.BeaconConfig().TimelyTargetFlagIndex
 				sourceIdx := params.BeaconConfig().TimelySourceFlagIndex
 				headIdx := params.BeaconConfig().TimelyHeadFlagIndex
@@ -132,6 +135,9 @@ func (s *Service) processIncludedAttestation(ctx context.Context, state state.Be
 					return
 				}
 				latestPerf.timelyTarget = hasFlag
+				setTimelyFlagGauge(types.ValidatorIndex(idx), "source", latestPerf.timelySource)
+				setTimelyFlagGauge(types.ValidatorIndex(idx), "target", latestPerf.timelyTarget)
+				setTimelyFlagGauge(types.ValidatorIndex(idx), "head", latestPerf.timelyHead)
 
 				if latestPerf.timelySource {
 					timelySourceCounter.WithLabelValues(fmt.Sprintf("%d", idx)).Inc()
@@ -160,6 +166,15 @@ func (s *Service) processIncludedAttestation(ctx context.Context, state state.Be
 	}
 }
 
+// setTimelyFlagGauge reports whether the given timely flag is set on the latest included attestation of a validator.
+func setTimelyFlagGauge(idx types.ValidatorIndex, flag string, set bool) {
+	value := float64(0)
+	if set {
+		value = 1
+	}
+	latestTimelyFlagsGauge.WithLabelValues(fmt.Sprintf("%d", idx), flag).Set(value)
+}
+
 // processUnaggregatedAttestation logs when the beacon node observes an unaggregated attestation from tracked validator.
 func (s *Service) processUnaggregatedAttestation(ctx context.Context, att *ethpb.Attestation) {
 	s.RLock()
@@ -22,6 +22,8 @@ const AggregateReportingPeriod = 5
 // - An attestation by one of our tracked validators was included
 // - An Exit by one of our validators was included
 // - A Slashing by one of our tracked validators was included
+// - A tracked validator missed a proposal or consecutive attestations
+// - The attestation rewards and penalties of an epoch transition were applied
 // - A Sync Committee Contribution by one of our tracked validators was included
 func (s *Service) processBlock(ctx context.Context, b interfaces.SignedBeaconBlock) {
 	if b == nil || b.Block() == nil {
@@ -56,7 +58,10 @@ func (s *Service) processBlock(ctx context.Context, b interfaces.SignedBeaconBlo
 
 	s.processSyncAggregate(st, blk)
 	s.processProposedBlock(st, root, blk)
+	s.processMissedProposals(ctx, st, blk)
 	s.processAttestations(ctx, st, blk)
+	s.processMissedAttestations(st, currEpoch)
+	s.processEpochRewards(ctx, blk)
 
 	if blk.Slot()%(AggregateReportingPeriod*params.BeaconConfig().SlotsPerEpoch) == 0 {
 		s.logAggregatedPerformance()
@@ -114,6 +119,13 @@ func (s *Service) processSlashings(blk interfaces.BeaconBlock) {
 				"BodyRoot1":     fmt.Sprintf("%#x", bytesutil.Trunc(slashing.Header_1.Header.BodyRoot)),
 				"BodyRoot2":     fmt.Sprintf("%#x", bytesutil.Trunc(slashing.Header_2.Header.BodyRoot)),
 			}).Info("Proposer slashing was included")
+			s.notify(&Alert{
+				Type:           SlashedAlert,
+				ValidatorIndex: idx,
+				Slot:           blk.Slot(),
+				Epoch:          slots.ToEpoch(blk.Slot()),
+				Message:        fmt.Sprintf("Proposer slashing of validator %d was included", idx),
+			})
 		}
 	}
 
@@ -132,6 +144,13 @@ func (s *Service) processSlashings(blk interfaces.BeaconBlock) {
 					"SourceEpoch2":       slashing.Attestation_2.Data.Source.Epoch,
 					"TargetEpoch2":       slashing.Attestation_2.Data.Target.Epoch,
 				}).Info("Attester slashing was included")
+				s.notify(&Alert{
+					Type:           SlashedAlert,
+					ValidatorIndex: types.ValidatorIndex(idx),
+					Slot:           blk.Slot(),
+					Epoch:          slots.ToEpoch(blk.Slot()),
+					Message:        fmt.Sprintf("Attester slashing of validator %d was included", idx),
+				})
 
 			}
 		}
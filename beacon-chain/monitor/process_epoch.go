@@ -0,0 +1,118 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/epoch/precompute"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/consensus-types/interfaces"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/runtime/version"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// processEpochRewards reports the attestation rewards and penalties of the tracked validators applied by
+// the epoch transition between the parent state and the block. When several epochs were skipped, only the
+// first epoch transition is reported.
+func (s *Service) processEpochRewards(ctx context.Context, blk interfaces.BeaconBlock) {
+	parentRoot := bytesutil.ToBytes32(blk.ParentRoot())
+	parentState := s.config.StateGen.StateByRootIfCachedNoCopy(parentRoot)
+	if parentState == nil {
+		log.WithField("BeaconBlockRoot", fmt.Sprintf("%#x", bytesutil.Trunc(parentRoot[:]))).Debug(
+			"Skipping epoch rewards due to parent state not found in cache")
+		return
+	}
+	epoch := slots.ToEpoch(parentState.Slot())
+	if epoch == slots.ToEpoch(blk.Slot()) || parentState.Version() < version.Altair {
+		return
+	}
+	// Rewards and penalties are not processed at the end of the genesis epoch.
+	if epoch == params.BeaconConfig().GenesisEpoch {
+		return
+	}
+
+	s.RLock()
+	tracked := make([]types.ValidatorIndex, 0, len(s.TrackedValidators))
+	for idx := range s.TrackedValidators {
+		if uint64(idx) < uint64(parentState.NumValidators()) {
+			tracked = append(tracked, idx)
+		}
+	}
+	s.RUnlock()
+	if len(tracked) == 0 {
+		return
+	}
+	sort.Slice(tracked, func(i, j int) bool { return tracked[i] < tracked[j] })
+
+	deltas, err := attestationsDeltaBreakdown(ctx, parentState.Copy(), tracked)
+	if err != nil {
+		log.WithError(err).Error("Could not compute attestation rewards and penalties")
+		return
+	}
+	for i, idx := range tracked {
+		d := deltas[i]
+		label := fmt.Sprintf("%d", idx)
+		attestationRewardsGauge.WithLabelValues(label, "source").Set(float64(d.SourceReward))
+		attestationRewardsGauge.WithLabelValues(label, "target").Set(float64(d.TargetReward))
+		attestationRewardsGauge.WithLabelValues(label, "head").Set(float64(d.HeadReward))
+		attestationPenaltiesGauge.WithLabelValues(label, "source").Set(float64(d.SourcePenalty))
+		attestationPenaltiesGauge.WithLabelValues(label, "target").Set(float64(d.TargetPenalty))
+		attestationPenaltiesGauge.WithLabelValues(label, "inactivity").Set(float64(d.InactivityPenalty))
+
+		log.WithFields(logrus.Fields{
+			"ValidatorIndex":    idx,
+			"Epoch":             epoch,
+			"SourceReward":      d.SourceReward,
+			"TargetReward":      d.TargetReward,
+			"HeadReward":        d.HeadReward,
+			"SourcePenalty":     d.SourcePenalty,
+			"TargetPenalty":     d.TargetPenalty,
+			"InactivityPenalty": d.InactivityPenalty,
+		}).Debug("Attestation rewards and penalties applied")
+	}
+}
+
+// attestationsDeltaBreakdown runs the steps of the epoch transition preceding the attestation rewards
+// on the given state, and returns the rewards and penalties of the validators at the given indices.
+func attestationsDeltaBreakdown(
+	ctx context.Context,
+	st state.BeaconState,
+	indices []types.ValidatorIndex,
+) ([]altair.AttestationDelta, error) {
+	// The epoch transition is processed at the last slot of the epoch.
+	lastSlot, err := slots.EpochEnd(slots.ToEpoch(st.Slot()))
+	if err != nil {
+		return nil, err
+	}
+	if st.Slot() < lastSlot {
+		st, err = transition.ProcessSlots(ctx, st, lastSlot)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not process slots")
+		}
+	}
+	vals, bal, err := altair.InitializePrecomputeValidators(ctx, st)
+	if err != nil {
+		return nil, err
+	}
+	vals, bal, err = altair.ProcessEpochParticipation(ctx, st, bal, vals)
+	if err != nil {
+		return nil, err
+	}
+	st, err = precompute.ProcessJustificationAndFinalizationPreCompute(st, bal)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not process justification")
+	}
+	st, vals, err = altair.ProcessInactivityScores(ctx, st, vals)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not process inactivity updates")
+	}
+	return altair.AttestationsDeltaBreakdown(st, bal, vals, indices)
+}
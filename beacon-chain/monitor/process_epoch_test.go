@@ -0,0 +1,105 @@
+package monitor
+
+import (
+	"context"
+	"testing"
+
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/consensus-types/wrapper"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+	"github.com/sirupsen/logrus"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+// altairStateWithParticipation returns an Altair state in epoch 2 with 64 active validators, where every
+// validator but the given one attested timely in the previous epoch.
+func altairStateWithParticipation(t *testing.T, absent types.ValidatorIndex) state.BeaconState {
+	cfg := params.BeaconConfig()
+	st, err := util.NewBeaconStateAltair(func(s *ethpb.BeaconStateAltair) error {
+		s.Slot = 2*cfg.SlotsPerEpoch + 5
+		for i := 0; i < 64; i++ {
+			s.Validators = append(s.Validators, &ethpb.Validator{
+				PublicKey:             make([]byte, 48),
+				WithdrawalCredentials: make([]byte, 32),
+				ExitEpoch:             cfg.FarFutureEpoch,
+				WithdrawableEpoch:     cfg.FarFutureEpoch,
+				EffectiveBalance:      cfg.MaxEffectiveBalance,
+			})
+			s.Balances = append(s.Balances, cfg.MaxEffectiveBalance)
+			s.InactivityScores = append(s.InactivityScores, 0)
+			s.CurrentEpochParticipation = append(s.CurrentEpochParticipation, 0)
+			flags := byte(0b111)
+			if types.ValidatorIndex(i) == absent {
+				flags = 0
+			}
+			s.PreviousEpochParticipation = append(s.PreviousEpochParticipation, flags)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	return st
+}
+
+func TestAttestationsDeltaBreakdown(t *testing.T) {
+	st := altairStateWithParticipation(t, 2)
+	deltas, err := attestationsDeltaBreakdown(context.Background(), st, []types.ValidatorIndex{1, 2})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(deltas))
+
+	// Validator 1 attested timely.
+	assert.NotEqual(t, uint64(0), deltas[0].SourceReward)
+	assert.NotEqual(t, uint64(0), deltas[0].TargetReward)
+	assert.NotEqual(t, uint64(0), deltas[0].HeadReward)
+	assert.Equal(t, uint64(0), deltas[0].Penalty())
+
+	// Validator 2 did not attest.
+	assert.Equal(t, uint64(0), deltas[1].Reward())
+	assert.NotEqual(t, uint64(0), deltas[1].SourcePenalty)
+	assert.NotEqual(t, uint64(0), deltas[1].TargetPenalty)
+}
+
+func TestProcessEpochRewards(t *testing.T) {
+	logrus.SetLevel(logrus.DebugLevel)
+	defer logrus.SetLevel(logrus.InfoLevel)
+	hook := logTest.NewGlobal()
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	s := &Service{
+		config: &ValidatorMonitorConfig{
+			StateGen: stategen.New(beaconDB),
+		},
+		TrackedValidators: map[types.ValidatorIndex]bool{1: true, 2: true},
+	}
+	parentRoot := [32]byte{'a'}
+	require.NoError(t, s.config.StateGen.SaveState(ctx, parentRoot, altairStateWithParticipation(t, 2)))
+
+	// A block of the same epoch as its parent does not report rewards.
+	blk := util.NewBeaconBlockAltair()
+	blk.Block.Slot = 2*params.BeaconConfig().SlotsPerEpoch + 6
+	blk.Block.ParentRoot = parentRoot[:]
+	wrapped, err := wrapper.WrappedBeaconBlock(blk.Block)
+	require.NoError(t, err)
+	s.processEpochRewards(ctx, wrapped)
+	require.LogsDoNotContain(t, hook, "Attestation rewards and penalties applied")
+
+	blk.Block.Slot = 3 * params.BeaconConfig().SlotsPerEpoch
+	wrapped, err = wrapper.WrappedBeaconBlock(blk.Block)
+	require.NoError(t, err)
+	s.processEpochRewards(ctx, wrapped)
+	require.LogsContain(t, hook, "Attestation rewards and penalties applied")
+	var logged []types.ValidatorIndex
+	for _, e := range hook.AllEntries() {
+		if e.Message == "Attestation rewards and penalties applied" {
+			logged = append(logged, e.Data["ValidatorIndex"].(types.ValidatorIndex))
+			assert.Equal(t, types.Epoch(2), e.Data["Epoch"])
+		}
+	}
+	assert.DeepEqual(t, []types.ValidatorIndex{1, 2}, logged)
+}
@@ -0,0 +1,145 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/consensus-types/interfaces"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/crypto/hash"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// processMissedAttestations counts the consecutive epochs in which the tracked validators
+// had no attestation included, and alerts when reaching the configured threshold. The
+// attestations of an epoch can be included until the end of the next epoch, so an epoch is
+// only checked once we process blocks two epochs later. The epochs up to the one in which a
+// validator started being tracked are not checked, as its attestations may have been included
+// before.
+func (s *Service) processMissedAttestations(state state.BeaconState, epoch types.Epoch) {
+	threshold := s.config.MissedAttestationsThreshold
+	if threshold == 0 || epoch < 2 {
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+	for e := s.lastMissedAttestationsEpoch + 1; e <= epoch-2; e++ {
+		for idx := range s.TrackedValidators {
+			val, err := state.ValidatorAtIndexReadOnly(idx)
+			if err != nil || !helpers.IsActiveValidatorUsingTrie(val, e) {
+				continue
+			}
+			if e <= s.aggregatedPerformance[idx].startEpoch {
+				continue
+			}
+			if s.attestedEpochs[idx][e] {
+				s.missedAttestations[idx] = 0
+				continue
+			}
+			s.missedAttestations[idx]++
+			missedAttestationsCounter.WithLabelValues(fmt.Sprintf("%d", idx)).Inc()
+			if s.missedAttestations[idx] != threshold {
+				continue
+			}
+			log.WithFields(logrus.Fields{
+				"ValidatorIndex":     idx,
+				"Epoch":              e,
+				"MissedAttestations": threshold,
+			}).Warn("Consecutive attestations were missed")
+			s.notify(&Alert{
+				Type:           MissedAttestationsAlert,
+				ValidatorIndex: idx,
+				Epoch:          e,
+				Message:        fmt.Sprintf("Validator %d missed %d consecutive attestations", idx, threshold),
+			})
+		}
+		for _, epochs := range s.attestedEpochs {
+			delete(epochs, e)
+		}
+		s.lastMissedAttestationsEpoch = e
+	}
+}
+
+// recordAttestedEpoch records that an attestation of a tracked validator for the given epoch
+// was included, for processMissedAttestations to check. Epochs already checked are not recorded.
+// It assumes the caller holds the service Lock.
+func (s *Service) recordAttestedEpoch(idx types.ValidatorIndex, epoch types.Epoch) {
+	if !s.trackedIndex(idx) || epoch <= s.lastMissedAttestationsEpoch {
+		return
+	}
+	if s.attestedEpochs == nil {
+		s.attestedEpochs = make(map[types.ValidatorIndex]map[types.Epoch]bool)
+	}
+	if s.attestedEpochs[idx] == nil {
+		s.attestedEpochs[idx] = make(map[types.Epoch]bool)
+	}
+	s.attestedEpochs[idx][epoch] = true
+}
+
+// processMissedProposals alerts when a tracked validator was the proposer of one of the slots
+// skipped between a block and its parent.
+func (s *Service) processMissedProposals(ctx context.Context, st state.BeaconState, blk interfaces.BeaconBlock) {
+	parentRoot := bytesutil.ToBytes32(blk.ParentRoot())
+	parentState := s.config.StateGen.StateByRootIfCachedNoCopy(parentRoot)
+	if parentState == nil {
+		log.WithField("BeaconBlockRoot", fmt.Sprintf("%#x", bytesutil.Trunc(parentRoot[:]))).Debug(
+			"Skipping missed proposals due to parent state not found in cache")
+		return
+	}
+	for slot := parentState.Slot() + 1; slot < blk.Slot(); slot++ {
+		// The proposer of a slot is computed from a state in the same epoch. Epochs
+		// entirely skipped between the parent and the block are not covered.
+		var proposerState state.ReadOnlyBeaconState
+		switch slots.ToEpoch(slot) {
+		case slots.ToEpoch(blk.Slot()):
+			proposerState = st
+		case slots.ToEpoch(parentState.Slot()):
+			proposerState = parentState
+		default:
+			continue
+		}
+		idx, err := proposerIndexAtSlot(ctx, proposerState, slot)
+		if err != nil {
+			log.WithError(err).WithField("Slot", slot).Error("Could not compute proposer index")
+			continue
+		}
+		s.RLock()
+		tracked := s.trackedIndex(idx)
+		s.RUnlock()
+		if !tracked {
+			continue
+		}
+		missedProposalsCounter.WithLabelValues(fmt.Sprintf("%d", idx)).Inc()
+		log.WithFields(logrus.Fields{
+			"ProposerIndex": idx,
+			"Slot":          slot,
+		}).Warn("Proposal was missed")
+		s.notify(&Alert{
+			Type:           MissedProposalAlert,
+			ValidatorIndex: idx,
+			Slot:           slot,
+			Epoch:          slots.ToEpoch(slot),
+			Message:        fmt.Sprintf("Validator %d missed the proposal of slot %d", idx, slot),
+		})
+	}
+}
+
+// proposerIndexAtSlot returns the proposer index of a slot in the epoch of the given state.
+func proposerIndexAtSlot(ctx context.Context, st state.ReadOnlyBeaconState, slot types.Slot) (types.ValidatorIndex, error) {
+	e := slots.ToEpoch(slot)
+	seed, err := helpers.Seed(st, e, params.BeaconConfig().DomainBeaconProposer)
+	if err != nil {
+		return 0, err
+	}
+	seedWithSlot := append(seed[:], bytesutil.Bytes8(uint64(slot))...)
+	indices, err := helpers.ActiveValidatorIndices(ctx, st, e)
+	if err != nil {
+		return 0, err
+	}
+	return helpers.ComputeProposerIndex(st, indices, hash.Hash(seedWithSlot))
+}
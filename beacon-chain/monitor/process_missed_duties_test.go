@@ -0,0 +1,193 @@
+package monitor
+
+import (
+	"context"
+	"testing"
+
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/consensus-types/wrapper"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+// stateWithValidators returns a state with 64 active validators at the given slot.
+func stateWithValidators(t *testing.T, slot types.Slot) state.BeaconState {
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	validators := make([]*ethpb.Validator, 64)
+	balances := make([]uint64, len(validators))
+	for i := range validators {
+		validators[i] = &ethpb.Validator{
+			PublicKey:        make([]byte, 48),
+			ExitEpoch:        params.BeaconConfig().FarFutureEpoch,
+			EffectiveBalance: params.BeaconConfig().MaxEffectiveBalance,
+		}
+		balances[i] = params.BeaconConfig().MaxEffectiveBalance
+	}
+	require.NoError(t, st.SetValidators(validators))
+	require.NoError(t, st.SetBalances(balances))
+	require.NoError(t, st.SetSlot(slot))
+	return st
+}
+
+func TestProcessMissedAttestations(t *testing.T) {
+	hook := logTest.NewGlobal()
+	recorder := newAlertsRecorder()
+	s := &Service{
+		ctx: context.Background(),
+		config: &ValidatorMonitorConfig{
+			Notifier:                    recorder,
+			MissedAttestationsThreshold: 2,
+		},
+		TrackedValidators: map[types.ValidatorIndex]bool{1: true, 2: true},
+		latestPerformance: map[types.ValidatorIndex]ValidatorLatestPerformance{
+			1: {attestedSlot: 3 * params.BeaconConfig().SlotsPerEpoch},
+			2: {},
+		},
+		attestedEpochs: map[types.ValidatorIndex]map[types.Epoch]bool{
+			1: {1: true, 2: true, 3: true},
+		},
+		missedAttestations: make(map[types.ValidatorIndex]uint64),
+	}
+	st := stateWithValidators(t, 5*params.BeaconConfig().SlotsPerEpoch)
+
+	// Epochs 1 to 3 are checked, validator 2 has no attestation included.
+	s.processMissedAttestations(st, 5)
+	alert := <-recorder.alerts
+	assert.DeepEqual(t, &Alert{
+		Type:           MissedAttestationsAlert,
+		ValidatorIndex: 2,
+		Epoch:          2,
+		Message:        "Validator 2 missed 2 consecutive attestations",
+	}, alert)
+	assert.Equal(t, uint64(0), s.missedAttestations[1])
+	assert.Equal(t, uint64(3), s.missedAttestations[2])
+	assert.Equal(t, types.Epoch(3), s.lastMissedAttestationsEpoch)
+	assert.Equal(t, 0, len(s.attestedEpochs[1]))
+	require.LogsContain(t, hook, "Consecutive attestations were missed")
+
+	// The epochs already checked are skipped, and the alert is not repeated.
+	s.processMissedAttestations(st, 5)
+	assert.Equal(t, uint64(3), s.missedAttestations[2])
+	assert.Equal(t, 0, len(recorder.alerts))
+}
+
+func TestProcessMissedAttestations_PromptRecovery(t *testing.T) {
+	recorder := newAlertsRecorder()
+	s := &Service{
+		ctx: context.Background(),
+		config: &ValidatorMonitorConfig{
+			Notifier:                    recorder,
+			MissedAttestationsThreshold: 2,
+		},
+		TrackedValidators: map[types.ValidatorIndex]bool{1: true},
+		latestPerformance: map[types.ValidatorIndex]ValidatorLatestPerformance{
+			1: {attestedSlot: 3 * params.BeaconConfig().SlotsPerEpoch},
+		},
+		missedAttestations: make(map[types.ValidatorIndex]uint64),
+	}
+	// The validator missed epochs 1 and 2, and its attestation of epoch 3 was included right away.
+	s.Lock()
+	s.recordAttestedEpoch(1, 3)
+	s.Unlock()
+	st := stateWithValidators(t, 5*params.BeaconConfig().SlotsPerEpoch)
+
+	// The inclusion in epoch 3 does not cover the epochs missed before it.
+	s.processMissedAttestations(st, 5)
+	alert := <-recorder.alerts
+	assert.DeepEqual(t, &Alert{
+		Type:           MissedAttestationsAlert,
+		ValidatorIndex: 1,
+		Epoch:          2,
+		Message:        "Validator 1 missed 2 consecutive attestations",
+	}, alert)
+	assert.Equal(t, uint64(0), s.missedAttestations[1])
+	assert.Equal(t, 0, len(recorder.alerts))
+
+	// Inclusions for the epochs already checked are not recorded.
+	s.Lock()
+	s.recordAttestedEpoch(1, 2)
+	s.Unlock()
+	assert.Equal(t, 0, len(s.attestedEpochs[1]))
+}
+
+func TestProcessMissedAttestations_TrackedLater(t *testing.T) {
+	recorder := newAlertsRecorder()
+	s := &Service{
+		ctx: context.Background(),
+		config: &ValidatorMonitorConfig{
+			Notifier:                    recorder,
+			MissedAttestationsThreshold: 1,
+		},
+		TrackedValidators: map[types.ValidatorIndex]bool{1: true},
+		latestPerformance: map[types.ValidatorIndex]ValidatorLatestPerformance{1: {}},
+		aggregatedPerformance: map[types.ValidatorIndex]ValidatorAggregatedPerformance{
+			1: {startEpoch: 3},
+		},
+		missedAttestations: make(map[types.ValidatorIndex]uint64),
+	}
+	st := stateWithValidators(t, 6*params.BeaconConfig().SlotsPerEpoch)
+
+	// The validator started being tracked in epoch 3, so only epoch 4 counts as missed.
+	s.processMissedAttestations(st, 6)
+	alert := <-recorder.alerts
+	assert.Equal(t, types.Epoch(4), alert.Epoch)
+	assert.Equal(t, uint64(1), s.missedAttestations[1])
+	assert.Equal(t, 0, len(recorder.alerts))
+}
+
+func TestProcessMissedProposals(t *testing.T) {
+	hook := logTest.NewGlobal()
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	recorder := newAlertsRecorder()
+
+	parentState := stateWithValidators(t, 1)
+	st := parentState.Copy()
+	require.NoError(t, st.SetSlot(5))
+
+	// Track the proposer of the skipped slot 3.
+	proposer, err := proposerIndexAtSlot(ctx, st, 3)
+	require.NoError(t, err)
+	s := &Service{
+		ctx: ctx,
+		config: &ValidatorMonitorConfig{
+			StateGen: stategen.New(beaconDB),
+			Notifier: recorder,
+		},
+		TrackedValidators: map[types.ValidatorIndex]bool{proposer: true},
+	}
+	parentRoot := [32]byte{'a'}
+	require.NoError(t, s.config.StateGen.SaveState(ctx, parentRoot, parentState))
+
+	blk := util.NewBeaconBlock()
+	blk.Block.Slot = 5
+	blk.Block.ParentRoot = parentRoot[:]
+	wrapped, err := wrapper.WrappedBeaconBlock(blk.Block)
+	require.NoError(t, err)
+	s.processMissedProposals(ctx, st, wrapped)
+
+	// The same validator may be the proposer of several skipped slots.
+	var wanted []types.Slot
+	for slot := types.Slot(2); slot < 5; slot++ {
+		idx, err := proposerIndexAtSlot(ctx, st, slot)
+		require.NoError(t, err)
+		if idx == proposer {
+			wanted = append(wanted, slot)
+		}
+	}
+	for _, slot := range wanted {
+		alert := <-recorder.alerts
+		assert.Equal(t, MissedProposalAlert, alert.Type)
+		assert.Equal(t, proposer, alert.ValidatorIndex)
+		assert.Equal(t, slot, alert.Slot)
+	}
+	require.LogsContain(t, hook, "Proposal was missed")
+}
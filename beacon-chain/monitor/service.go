@@ -63,6 +63,13 @@ type ValidatorMonitorConfig struct {
 	AttestationNotifier operation.Notifier
 	HeadFetcher         blockchain.HeadFetcher
 	StateGen            stategen.StateManager
+	// Notifier receives alerts when a tracked validator is slashed, misses a proposal, or
+	// misses MissedAttestationsThreshold consecutive attestations (0 disables this alert).
+	Notifier                    Notifier
+	MissedAttestationsThreshold uint64
+	// TrackAttachedValidators starts tracking the validators of the validator clients attached to the beacon
+	// node as their proposer preparations are received.
+	TrackAttachedValidators bool
 }
 
 // Service is the main structure that tracks validators and reports logs and
@@ -74,7 +81,8 @@ type Service struct {
 	isLogging bool
 
 	// Locks access to TrackedValidators, latestPerformance, aggregatedPerformance,
-	// trackedSyncedCommitteeIndices and lastSyncedEpoch
+	// trackedSyncedCommitteeIndices, lastSyncedEpoch, attestedEpochs, missedAttestations
+	// and lastMissedAttestationsEpoch
 	sync.RWMutex
 
 	TrackedValidators           map[types.ValidatorIndex]bool
@@ -82,6 +90,9 @@ type Service struct {
 	aggregatedPerformance       map[types.ValidatorIndex]ValidatorAggregatedPerformance
 	trackedSyncCommitteeIndices map[types.ValidatorIndex][]types.CommitteeIndex
 	lastSyncedEpoch             types.Epoch
+	attestedEpochs              map[types.ValidatorIndex]map[types.Epoch]bool
+	missedAttestations          map[types.ValidatorIndex]uint64
+	lastMissedAttestationsEpoch types.Epoch
 }
 
 // NewService sets up a new validator monitor service instance when given a list of validator indices to track.
@@ -95,6 +106,8 @@ func NewService(ctx context.Context, config *ValidatorMonitorConfig, tracked []t
 		latestPerformance:           make(map[types.ValidatorIndex]ValidatorLatestPerformance),
 		aggregatedPerformance:       make(map[types.ValidatorIndex]ValidatorAggregatedPerformance),
 		trackedSyncCommitteeIndices: make(map[types.ValidatorIndex][]types.CommitteeIndex),
+		attestedEpochs:              make(map[types.ValidatorIndex]map[types.Epoch]bool),
+		missedAttestations:          make(map[types.ValidatorIndex]uint64),
 		isLogging:                   false,
 	}
 	for _, idx := range tracked {
@@ -164,21 +177,66 @@ func (s *Service) run(stateChannel chan *feed.Event, stateSub event.Subscription
 // initializePerformanceStructures initializes the validatorLatestPerformance
 // and validatorAggregatedPerformance for each tracked validator.
 func (s *Service) initializePerformanceStructures(state state.BeaconState, epoch types.Epoch) {
+	// The attestations of the epochs before the start of the service were not observed.
+	s.lastMissedAttestationsEpoch = epoch
 	for idx := range s.TrackedValidators {
-		balance, err := state.BalanceAtIndex(idx)
-		if err != nil {
-			log.WithError(err).WithField("ValidatorIndex", idx).Error(
-				"Could not fetch starting balance, skipping aggregated logs.")
-			balance = 0
-		}
-		s.aggregatedPerformance[idx] = ValidatorAggregatedPerformance{
-			startEpoch:   epoch,
-			startBalance: balance,
-		}
-		s.latestPerformance[idx] = ValidatorLatestPerformance{
-			balance: balance,
+		s.initializeValidatorPerformance(state, epoch, idx)
+	}
+}
+
+// initializeValidatorPerformance initializes the validatorLatestPerformance and
+// validatorAggregatedPerformance of a tracked validator. It assumes the caller holds the service Lock.
+func (s *Service) initializeValidatorPerformance(state state.BeaconState, epoch types.Epoch, idx types.ValidatorIndex) {
+	balance, err := state.BalanceAtIndex(idx)
+	if err != nil {
+		log.WithError(err).WithField("ValidatorIndex", idx).Error(
+			"Could not fetch starting balance, skipping aggregated logs.")
+		balance = 0
+	}
+	s.aggregatedPerformance[idx] = ValidatorAggregatedPerformance{
+		startEpoch:   epoch,
+		startBalance: balance,
+	}
+	s.latestPerformance[idx] = ValidatorLatestPerformance{
+		balance: balance,
+	}
+}
+
+// trackValidators starts tracking the given validators, when they are not tracked yet. It gets called
+// with the validator indices of the validator clients attached to the beacon node.
+func (s *Service) trackValidators(ctx context.Context, indices []types.ValidatorIndex) {
+	s.RLock()
+	var untracked []types.ValidatorIndex
+	for _, idx := range indices {
+		if !s.trackedIndex(idx) {
+			untracked = append(untracked, idx)
 		}
 	}
+	s.RUnlock()
+	if len(untracked) == 0 {
+		return
+	}
+	st, err := s.config.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not get head state")
+		return
+	}
+	if st == nil || st.IsNil() {
+		log.Error("Head state is nil")
+		return
+	}
+	sort.Slice(untracked, func(i, j int) bool { return untracked[i] < untracked[j] })
+
+	s.Lock()
+	epoch := slots.ToEpoch(st.Slot())
+	for _, idx := range untracked {
+		s.TrackedValidators[idx] = true
+		s.initializeValidatorPerformance(st, epoch, idx)
+	}
+	s.Unlock()
+	s.updateSyncCommitteeTrackedVals(st)
+
+	log.WithField("ValidatorIndices", untracked).Info("Started tracking local validators")
 }
 
 // Status retrieves the status of the service.
@@ -221,7 +279,7 @@ func (s *Service) waitForSync(stateChannel chan *feed.Event, stateSub event.Subs
 // monitorRoutine is the main dispatcher, it registers event channels for the
 // state feed and the operation feed. It then calls the appropriate function
 // when we get messages after syncing a block or processing attestations/sync
-// committee contributions.
+// committee contributions, or when attached validator clients prepare proposals.
 func (s *Service) monitorRoutine(stateChannel chan *feed.Event, stateSub event.Subscription) {
 	defer stateSub.Unsubscribe()
 
@@ -271,6 +329,13 @@ func (s *Service) monitorRoutine(stateChannel chan *feed.Event, stateSub event.S
 				} else {
 					s.processSyncCommitteeContribution(data.Contribution)
 				}
+			case operation.ProposerPreparationReceived:
+				data, ok := e.Data.(*operation.ProposerPreparationReceivedData)
+				if !ok {
+					log.Error("Event feed data is not of type *operation.ProposerPreparationReceivedData")
+				} else if s.config.TrackAttachedValidators {
+					s.trackValidators(s.ctx, data.ValidatorIndices)
+				}
 			}
 		case <-s.ctx.Done():
 			log.Debug("Context closed, exiting goroutine")
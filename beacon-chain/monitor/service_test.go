@@ -10,9 +10,11 @@ import (
 	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/altair"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed/operation"
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/config/params"
 	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/consensus-types/wrapper"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
@@ -217,6 +219,34 @@ func TestInitializePerformanceStructures(t *testing.T) {
 	require.DeepEqual(t, s.aggregatedPerformance, aggregatedPerformance)
 }
 
+func TestTrackValidators(t *testing.T) {
+	hook := logTest.NewGlobal()
+	ctx := context.Background()
+	st := stateWithValidators(t, 3*params.BeaconConfig().SlotsPerEpoch)
+	s, err := NewService(ctx, &ValidatorMonitorConfig{
+		HeadFetcher: &mock.ChainService{State: st},
+	}, []types.ValidatorIndex{1})
+	require.NoError(t, err)
+
+	s.trackValidators(ctx, []types.ValidatorIndex{1, 4, 3})
+	require.LogsContain(t, hook, "Started tracking local validators")
+	require.DeepEqual(t, map[types.ValidatorIndex]bool{1: true, 3: true, 4: true}, s.TrackedValidators)
+	require.DeepEqual(t, ValidatorAggregatedPerformance{
+		startEpoch:   3,
+		startBalance: params.BeaconConfig().MaxEffectiveBalance,
+	}, s.aggregatedPerformance[4])
+	require.DeepEqual(t, ValidatorLatestPerformance{
+		balance: params.BeaconConfig().MaxEffectiveBalance,
+	}, s.latestPerformance[3])
+	_, ok := s.latestPerformance[1]
+	require.Equal(t, false, ok, "Already tracked validator should not be reinitialized")
+
+	// Validators already tracked are left unchanged.
+	hook.Reset()
+	s.trackValidators(ctx, []types.ValidatorIndex{3, 4})
+	require.LogsDoNotContain(t, hook, "Started tracking local validators")
+}
+
 func TestMonitorRoutine(t *testing.T) {
 	ctx := context.Background()
 	hook := logTest.NewGlobal()
@@ -263,6 +293,51 @@ func TestMonitorRoutine(t *testing.T) {
 
 }
 
+func TestMonitorRoutine_AttachedValidators(t *testing.T) {
+	for _, trackAttached := range []bool{false, true} {
+		t.Run(fmt.Sprintf("track attached validators %v", trackAttached), func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			chainService := &mock.ChainService{State: stateWithValidators(t, params.BeaconConfig().SlotsPerEpoch)}
+			s, err := NewService(ctx, &ValidatorMonitorConfig{
+				StateNotifier:           chainService.StateNotifier(),
+				AttestationNotifier:     chainService.OperationNotifier(),
+				HeadFetcher:             chainService,
+				TrackAttachedValidators: trackAttached,
+			}, nil)
+			require.NoError(t, err)
+			stateChannel := make(chan *feed.Event, 1)
+			stateSub := s.config.StateNotifier.StateFeed().Subscribe(stateChannel)
+			opFeed := s.config.AttestationNotifier.OperationFeed()
+
+			wg := &sync.WaitGroup{}
+			wg.Add(1)
+			go func() {
+				s.monitorRoutine(stateChannel, stateSub)
+				wg.Done()
+			}()
+
+			prepared := &feed.Event{
+				Type: operation.ProposerPreparationReceived,
+				Data: &operation.ProposerPreparationReceivedData{ValidatorIndices: []types.ValidatorIndex{3}},
+			}
+			// Wait for the routine to subscribe to the operation feed. With the channel buffering one event,
+			// the third event is only received once the first one has been handled.
+			for opFeed.Send(prepared) == 0 {
+				time.Sleep(10 * time.Millisecond)
+			}
+			opFeed.Send(prepared)
+			opFeed.Send(prepared)
+			cancel()
+			wg.Wait()
+
+			s.RLock()
+			defer s.RUnlock()
+			require.Equal(t, trackAttached, s.trackedIndex(3))
+		})
+	}
+}
+
 func TestWaitForSync(t *testing.T) {
 	s := setupService(t)
 	stateChannel := make(chan *feed.Event, 1)
@@ -42,6 +42,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/apimiddleware"
 	"github.com/prysmaticlabs/prysm/beacon-chain/slasher"
+	remoteslasher "github.com/prysmaticlabs/prysm/beacon-chain/slasher/remote"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
 	regularsync "github.com/prysmaticlabs/prysm/beacon-chain/sync"
@@ -249,6 +250,11 @@ func New(cliCtx *cli.Context, opts ...Option) (*BeaconNode, error) {
 		return nil, err
 	}
 
+	log.Debugln("Registering Remote Slasher Service")
+	if err := beacon.registerRemoteSlasherService(); err != nil {
+		return nil, err
+	}
+
 	log.Debugln("Registering builder service")
 	if err := beacon.registerBuilderService(); err != nil {
 		return nil, err
@@ -553,6 +559,8 @@ func (b *BeaconNode) registerP2P(cliCtx *cli.Context) error {
 		TCPPort:           cliCtx.Uint(cmd.P2PTCPPort.Name),
 		UDPPort:           cliCtx.Uint(cmd.P2PUDPPort.Name),
 		MaxPeers:          cliCtx.Uint(cmd.P2PMaxPeers.Name),
+		MaxPeersPerSubnet: cliCtx.Uint(cmd.P2PMaxPeersPerSubnet.Name),
+		MaxPeersPerClient: cliCtx.Uint(cmd.P2PMaxPeersPerClient.Name),
 		AllowListCIDR:     cliCtx.String(cmd.P2PAllowList.Name),
 		DenyListCIDR:      slice.SplitCommaSeparated(cliCtx.StringSlice(cmd.P2PDenyList.Name)),
 		EnableUPnP:        cliCtx.Bool(cmd.EnableUPnPFlag.Name),
@@ -737,6 +745,11 @@ func (b *BeaconNode) registerSlasherService() error {
 		SlashingPoolInserter:    b.slashingsPool,
 		SyncChecker:             syncService,
 		HeadStateFetcher:        chainService,
+		OperationNotifier:       b,
+		BeaconDatabase:          b.db,
+		Backfill:                b.cliCtx.Bool(flags.SlasherBackfillFlag.Name),
+		BackfillStartEpoch:      types.Epoch(b.cliCtx.Uint64(flags.SlasherBackfillStartEpochFlag.Name)),
+		BackfillEndEpoch:        types.Epoch(b.cliCtx.Uint64(flags.SlasherBackfillEndEpochFlag.Name)),
 	})
 	if err != nil {
 		return err
@@ -744,6 +757,30 @@ func (b *BeaconNode) registerSlasherService() error {
 	return b.services.RegisterService(slasherSrv)
 }
 
+func (b *BeaconNode) registerRemoteSlasherService() error {
+	if !features.Get().EnableRemoteSlasher {
+		return nil
+	}
+	var chainService *blockchain.Service
+	if err := b.services.FetchService(&chainService); err != nil {
+		return err
+	}
+
+	remoteSlasherSrv, err := remoteslasher.New(b.ctx, &remoteslasher.ServiceConfig{
+		Endpoint:                b.cliCtx.String(flags.RemoteSlasherEndpoint.Name),
+		CertFlag:                b.cliCtx.String(flags.RemoteSlasherCertFlag.Name),
+		IndexedAttestationsFeed: b.slasherAttestationsFeed,
+		BeaconBlockHeadersFeed:  b.slasherBlockHeadersFeed,
+		SlashingPoolInserter:    b.slashingsPool,
+		HeadStateFetcher:        chainService,
+		OperationNotifier:       b,
+	})
+	if err != nil {
+		return err
+	}
+	return b.services.RegisterService(remoteSlasherSrv)
+}
+
 func (b *BeaconNode) registerRPCService() error {
 	var chainService *blockchain.Service
 	if err := b.services.FetchService(&chainService); err != nil {
@@ -821,6 +858,7 @@ func (b *BeaconNode) registerRPCService() error {
 		GenesisTimeFetcher:      chainService,
 		GenesisFetcher:          chainService,
 		OptimisticModeFetcher:   chainService,
+		PayloadInvalidator:      chainService,
 		AttestationsPool:        b.attestationPool,
 		ExitPool:                b.exitPool,
 		SlashingsPool:           b.slashingsPool,
@@ -855,9 +893,15 @@ func (b *BeaconNode) registerPrometheusService(cliCtx *cli.Context) error {
 	}
 	additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/p2p", Handler: p.InfoHandler})
 
-	var c *blockchain.Service
-	if err := b.services.FetchService(&c); err != nil {
-		panic(err)
+	if cliCtx.Bool(flags.EnableDebugRPCEndpoints.Name) {
+		var c *blockchain.Service
+		if err := b.services.FetchService(&c); err != nil {
+			panic(err)
+		}
+		additionalHandlers = append(
+			additionalHandlers,
+			prometheus.Handler{Path: "/debug/forkchoice", Handler: forkchoice.DumpHandler(c.ForkChoicer())},
+		)
 	}
 
 	if cliCtx.IsSet(cmd.EnableBackupWebhookFlag.Name) {
@@ -957,27 +1001,31 @@ func (b *BeaconNode) registerDeterminsticGenesisService() error {
 }
 
 func (b *BeaconNode) registerValidatorMonitorService() error {
-	if cmd.ValidatorMonitorIndicesFlag.Value == nil {
-		return nil
+	var tracked []types.ValidatorIndex
+	if cmd.ValidatorMonitorIndicesFlag.Value != nil {
+		for _, idx := range cmd.ValidatorMonitorIndicesFlag.Value.Value() {
+			tracked = append(tracked, types.ValidatorIndex(idx))
+		}
 	}
-	cliSlice := cmd.ValidatorMonitorIndicesFlag.Value.Value()
-	if cliSlice == nil {
+	trackAttached := b.cliCtx.Bool(flags.MonitorAttachedValidatorsFlag.Name)
+	if len(tracked) == 0 && !trackAttached {
 		return nil
 	}
-	tracked := make([]types.ValidatorIndex, len(cliSlice))
-	for i := range tracked {
-		tracked[i] = types.ValidatorIndex(cliSlice[i])
-	}
 
 	var chainService *blockchain.Service
 	if err := b.services.FetchService(&chainService); err != nil {
 		return err
 	}
 	monitorConfig := &monitor.ValidatorMonitorConfig{
-		StateNotifier:       b,
-		AttestationNotifier: b,
-		StateGen:            b.stateGen,
-		HeadFetcher:         chainService,
+		StateNotifier:               b,
+		AttestationNotifier:         b,
+		StateGen:                    b.stateGen,
+		HeadFetcher:                 chainService,
+		MissedAttestationsThreshold: b.cliCtx.Uint64(flags.MonitorMissedAttestationsThresholdFlag.Name),
+		TrackAttachedValidators:     trackAttached,
+	}
+	if url := b.cliCtx.String(flags.MonitorWebhookURLFlag.Name); url != "" {
+		monitorConfig.Notifier = monitor.NewWebhookNotifier(url)
 	}
 	svc, err := monitor.NewService(b.ctx, monitorConfig, tracked)
 	if err != nil {
@@ -24,6 +24,8 @@ type Config struct {
 	TCPPort             uint
 	UDPPort             uint
 	MaxPeers            uint
+	MaxPeersPerSubnet   uint
+	MaxPeersPerClient   uint
 	AllowListCIDR       string
 	DenyListCIDR        []string
 	StateNotifier       statefeed.Notifier
@@ -36,6 +36,10 @@ func (s *Service) InterceptAddrDial(pid peer.ID, m multiaddr.Multiaddr) (allow b
 	if s.peers.IsBad(pid) {
 		return false
 	}
+	// Do not dial peers from subnets we already have enough peers from.
+	if !s.peers.IsActive(pid) && s.peers.IsAboveSubnetLimit(m) {
+		return false
+	}
 	return filterConnections(s.addrFilter, m)
 }
 
@@ -54,6 +58,11 @@ func (s *Service) InterceptAccept(n network.ConnMultiaddrs) (allow bool) {
 			"reason": "at peer limit"}).Trace("Not accepting inbound dial")
 		return false
 	}
+	if s.peers.IsAboveSubnetLimit(n.RemoteMultiaddr()) {
+		log.WithFields(logrus.Fields{"peer": n.RemoteMultiaddr(),
+			"reason": "at subnet peer limit"}).Trace("Not accepting inbound dial")
+		return false
+	}
 	return filterConnections(s.addrFilter, n.RemoteMultiaddr())
 }
 
@@ -7,6 +7,7 @@ import (
 
 	"github.com/kevinms/leakybucket-go"
 	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
@@ -132,6 +133,39 @@ func TestService_RejectInboundPeersBeyondLimit(t *testing.T) {
 	}
 }
 
+func TestService_RejectPeersBeyondSubnetLimit(t *testing.T) {
+	s := &Service{
+		ipLimiter: leakybucket.NewCollector(ipLimit, ipBurst, false),
+		peers: peers.NewStatus(context.Background(), &peers.StatusConfig{
+			PeerLimit:       20,
+			ScorerParams:    &scorers.Config{},
+			SubnetPeerLimit: 2,
+		}),
+		host: mockp2p.NewTestP2P(t).BHost,
+		cfg:  &Config{MaxPeers: 20},
+	}
+	var err error
+	s.addrFilter, err = configureFilter(&Config{})
+	require.NoError(t, err)
+	multiAddress, err := ma.NewMultiaddr("/ip4/212.67.10.122/tcp/3000")
+	require.NoError(t, err)
+	assert.Equal(t, true, s.InterceptAccept(&maEndpoints{raddr: multiAddress}))
+	assert.Equal(t, true, s.InterceptAddrDial("fake-peer", multiAddress))
+
+	for i := 1; i <= 2; i++ {
+		addr, err := ma.NewMultiaddr(fmt.Sprintf("/ip4/212.67.10.%d/tcp/3000", i))
+		require.NoError(t, err)
+		pid := addPeer(t, s.peers, peerdata.PeerConnectionState(ethpb.ConnectionState_CONNECTED))
+		s.peers.Add(nil, pid, addr, network.DirInbound)
+	}
+	assert.Equal(t, false, s.InterceptAccept(&maEndpoints{raddr: multiAddress}), "Expected inbound dial from full subnet to be rejected")
+	assert.Equal(t, false, s.InterceptAddrDial("fake-peer", multiAddress), "Expected dial to full subnet to be rejected")
+
+	otherSubnet, err := ma.NewMultiaddr("/ip4/212.67.11.122/tcp/3000")
+	require.NoError(t, err)
+	assert.Equal(t, true, s.InterceptAccept(&maEndpoints{raddr: otherSubnet}))
+}
+
 func TestPeer_BelowMaxLimit(t *testing.T) {
 	// create host and remote peer
 	ipAddr, pkey := createAddrAndPrivKey(t)
@@ -129,17 +129,9 @@ func (s *Service) createListener(
 	ipAddr net.IP,
 	privKey *ecdsa.PrivateKey,
 ) (*discover.UDPv5, error) {
-	// BindIP is used to specify the ip
-	// on which we will bind our listener on
-	// by default we will listen to all interfaces.
-	var bindIP net.IP
-	switch udpVersionFromIP(ipAddr) {
-	case "udp4":
-		bindIP = net.IPv4zero
-	case "udp6":
-		bindIP = net.IPv6zero
-	default:
-		return nil, errors.New("invalid ip provided")
+	bindIP, err := bindIPFromIP(ipAddr)
+	if err != nil {
+		return nil, err
 	}
 
 	// If local ip is specified then use that instead.
@@ -193,11 +185,52 @@ func (s *Service) createListener(
 			localNode.SetFallbackIP(firstIP)
 		}
 	}
+	return listenV5(conn, localNode, privKey, s.cfg.Discv5BootStrapAddr)
+}
+
+// CreateStandaloneListener starts a discovery v5 listener which is not backed by a p2p service
+// or a beacon chain. The local node advertises no eth2 specific fields, which makes the listener
+// suitable for tools that only need to walk the DHT, such as network crawlers.
+func CreateStandaloneListener(
+	privKey *ecdsa.PrivateKey,
+	ipAddr net.IP,
+	udpPort, tcpPort int,
+	bootstrapAddrs []string,
+) (*discover.UDPv5, error) {
+	bindIP, err := bindIPFromIP(ipAddr)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: bindIP, Port: udpPort})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not listen to UDP")
+	}
+	db, err := enode.OpenDB("")
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open node's peer database")
+	}
+	localNode := enode.NewLocalNode(db, privKey)
+	localNode.Set(enr.IP(ipAddr))
+	localNode.Set(enr.UDP(udpPort))
+	if tcpPort != 0 {
+		localNode.Set(enr.TCP(tcpPort))
+	}
+	localNode.SetFallbackIP(ipAddr)
+	localNode.SetFallbackUDP(udpPort)
+	return listenV5(conn, localNode, privKey, bootstrapAddrs)
+}
+
+func listenV5(
+	conn *net.UDPConn,
+	localNode *enode.LocalNode,
+	privKey *ecdsa.PrivateKey,
+	bootstrapAddrs []string,
+) (*discover.UDPv5, error) {
 	dv5Cfg := discover.Config{
 		PrivateKey: privKey,
 	}
 	dv5Cfg.Bootnodes = []*enode.Node{}
-	for _, addr := range s.cfg.Discv5BootStrapAddr {
+	for _, addr := range bootstrapAddrs {
 		bootNode, err := enode.Parse(enode.ValidSchemes, addr)
 		if err != nil {
 			return nil, errors.Wrap(err, "could not bootstrap addr")
@@ -212,6 +245,19 @@ func (s *Service) createListener(
 	return listener, nil
 }
 
+// bindIPFromIP returns the address the listener binds to. By default
+// we listen to all interfaces of the ip's protocol version.
+func bindIPFromIP(ipAddr net.IP) (net.IP, error) {
+	switch udpVersionFromIP(ipAddr) {
+	case "udp4":
+		return net.IPv4zero, nil
+	case "udp6":
+		return net.IPv6zero, nil
+	default:
+		return nil, errors.New("invalid ip provided")
+	}
+}
+
 func (s *Service) createLocalNode(
 	privKey *ecdsa.PrivateKey,
 	ipAddr net.IP,
@@ -83,6 +83,27 @@ func TestCreateListener(t *testing.T) {
 	}
 }
 
+func TestCreateStandaloneListener(t *testing.T) {
+	ipAddr, pkey := createAddrAndPrivKey(t)
+	bootListener, err := CreateStandaloneListener(pkey, ipAddr, 1025, 0, nil)
+	require.NoError(t, err)
+	defer bootListener.Close()
+
+	assert.Equal(t, true, bootListener.Self().IP().Equal(ipAddr), "IP address is not the expected type")
+	assert.Equal(t, 1025, bootListener.Self().UDP(), "Incorrect port number")
+	assert.Equal(t, 0, bootListener.Self().TCP(), "Unexpected tcp port")
+	forkEntry := make([]byte, 16)
+	err = bootListener.Self().Record().Load(enr.WithEntry(params.BeaconNetworkConfig().ETH2Key, &forkEntry))
+	assert.Equal(t, true, enr.IsNotFound(err), "Standalone listener should not advertise eth2 field")
+
+	ipAddr, pkey = createAddrAndPrivKey(t)
+	listener, err := CreateStandaloneListener(pkey, ipAddr, 1026, 13000, []string{bootListener.Self().String()})
+	require.NoError(t, err)
+	defer listener.Close()
+	assert.Equal(t, 13000, listener.Self().TCP(), "Incorrect tcp port")
+	require.NoError(t, listener.Ping(bootListener.Self()))
+}
+
 func TestStartDiscV5_DiscoverAllPeers(t *testing.T) {
 	port := 2000
 	ipAddr, pkey := createAddrAndPrivKey(t)
@@ -170,6 +191,7 @@ func TestMultiAddrConversion_OK(t *testing.T) {
 
 func TestStaticPeering_PeersAreAdded(t *testing.T) {
 	cfg := &Config{
+		DataDir:  t.TempDir(),
 		MaxPeers: 30,
 	}
 	port := 6000
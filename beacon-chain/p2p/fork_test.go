@@ -46,6 +46,7 @@ func TestStartDiscv5_DifferentForkDigests(t *testing.T) {
 
 	bootNode := bootListener.Self()
 	cfg := &Config{
+		DataDir:             t.TempDir(),
 		Discv5BootStrapAddr: []string{bootNode.String()},
 		UDPPort:             uint(port),
 		StateNotifier:       &mock.MockStateNotifier{},
@@ -133,6 +134,7 @@ func TestStartDiscv5_SameForkDigests_DifferentNextForkData(t *testing.T) {
 
 	bootNode := bootListener.Self()
 	cfg := &Config{
+		DataDir:             t.TempDir(),
 		Discv5BootStrapAddr: []string{bootNode.String()},
 		UDPPort:             uint(port),
 	}
@@ -90,6 +90,21 @@ func (s *Service) AddConnectionHandler(reqFunc, goodByeFunc func(ctx context.Con
 					disconnectFromPeer()
 					return
 				}
+				// Identify has completed by the time the status handshake is done, so the
+				// peer's client is known and its limit can be enforced.
+				admitClient := func() bool {
+					if rawAgent, err := s.host.Peerstore().Get(remotePeer, "AgentVersion"); err == nil {
+						if agent, ok := rawAgent.(string); ok {
+							s.peers.SetAgent(remotePeer, agent)
+						}
+					}
+					if s.peers.IsAboveClientLimit(remotePeer) {
+						log.WithField("reason", "at client peer limit").Trace("Disconnecting peer")
+						disconnectFromPeer()
+						return false
+					}
+					return true
+				}
 				validPeerConnection := func() {
 					s.peers.SetConnectionState(conn.RemotePeer(), peers.PeerConnected)
 					// Go through the handshake process.
@@ -133,6 +148,9 @@ func (s *Service) AddConnectionHandler(reqFunc, goodByeFunc func(ctx context.Con
 							return
 						}
 					}
+					if !admitClient() {
+						return
+					}
 					validPeerConnection()
 					return
 				}
@@ -143,6 +161,9 @@ func (s *Service) AddConnectionHandler(reqFunc, goodByeFunc func(ctx context.Con
 					disconnectFromPeer()
 					return
 				}
+				if !admitClient() {
+					return
+				}
 				validPeerConnection()
 			}()
 		},
@@ -0,0 +1,186 @@
+package peers
+
+import (
+	"net"
+	"strings"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers/peerdata"
+)
+
+const (
+	// ipv4SubnetBits is the prefix length of the IPv4 subnets colocation limits apply to.
+	ipv4SubnetBits = 24
+	// ipv6SubnetBits is the prefix length of the IPv6 subnets colocation limits apply to.
+	ipv6SubnetBits = 48
+	// unknownClient is the client name of peers which haven't told us their agent string (yet).
+	unknownClient = "unknown"
+)
+
+// SetAgent sets the agent string the peer identified itself with.
+func (p *Status) SetAgent(pid peer.ID, agent string) {
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	peerData := p.store.PeerDataGetOrCreate(pid)
+	peerData.Agent = agent
+}
+
+// Agent returns the agent string the peer identified itself with.
+// This will error if the peer does not exist.
+func (p *Status) Agent(pid peer.ID) (string, error) {
+	p.store.RLock()
+	defer p.store.RUnlock()
+
+	if peerData, ok := p.store.PeerData(pid); ok {
+		return peerData.Agent, nil
+	}
+	return "", peerdata.ErrPeerUnknown
+}
+
+// IsAboveSubnetLimit checks whether accepting a connection from the given address would
+// exceed the number of active peers allowed from a single /24 (IPv4) or /48 (IPv6) subnet.
+func (p *Status) IsAboveSubnetLimit(addr ma.Multiaddr) bool {
+	if p.subnetPeerLimit <= 0 {
+		return false
+	}
+	subnet := subnetFromAddr(addr)
+	if subnet == "" {
+		return false
+	}
+	p.store.RLock()
+	defer p.store.RUnlock()
+	count := 0
+	for _, peerData := range p.store.Peers() {
+		if isActive(peerData) && subnetFromAddr(peerData.Address) == subnet {
+			count++
+		}
+	}
+	return count >= p.subnetPeerLimit
+}
+
+// IsAboveClientLimit checks whether the given peer's client implementation is already
+// run by as many other active peers as allowed.
+func (p *Status) IsAboveClientLimit(pid peer.ID) bool {
+	if p.clientPeerLimit <= 0 {
+		return false
+	}
+	p.store.RLock()
+	defer p.store.RUnlock()
+	peerData, ok := p.store.PeerData(pid)
+	if !ok {
+		return false
+	}
+	client := clientFromAgent(peerData.Agent)
+	if client == unknownClient {
+		return false
+	}
+	count := 0
+	for id, data := range p.store.Peers() {
+		if id != pid && isActive(data) && clientFromAgent(data.Agent) == client {
+			count++
+		}
+	}
+	return count >= p.clientPeerLimit
+}
+
+// selectOverrepresented picks amount peers out of the candidates, which are ordered by pruning
+// preference. Peers belonging to the subnet or client most in excess of its limit are
+// picked first, the candidates' order breaks ties. When no limit is exceeded, this is the
+// same as picking the first candidates. This method assumes the store lock is acquired.
+func (p *Status) selectOverrepresented(candidates []peer.ID, amount uint64) []peer.ID {
+	if amount >= uint64(len(candidates)) {
+		return candidates
+	}
+	if p.subnetPeerLimit <= 0 && p.clientPeerLimit <= 0 {
+		return candidates[:amount]
+	}
+	subnetCounts := make(map[string]int)
+	clientCounts := make(map[string]int)
+	for _, peerData := range p.store.Peers() {
+		if !isActive(peerData) {
+			continue
+		}
+		if subnet := subnetFromAddr(peerData.Address); subnet != "" {
+			subnetCounts[subnet]++
+		}
+		clientCounts[clientFromAgent(peerData.Agent)]++
+	}
+	subnets := make([]string, len(candidates))
+	clients := make([]string, len(candidates))
+	for i, pid := range candidates {
+		if peerData, ok := p.store.PeerData(pid); ok {
+			subnets[i] = subnetFromAddr(peerData.Address)
+			clients[i] = clientFromAgent(peerData.Agent)
+		}
+	}
+	// excess is how far above its limit the most overrepresented group of the candidate is.
+	excess := func(i int) int {
+		result := 0
+		if p.subnetPeerLimit > 0 && subnets[i] != "" {
+			if e := subnetCounts[subnets[i]] - p.subnetPeerLimit; e > result {
+				result = e
+			}
+		}
+		if p.clientPeerLimit > 0 && clients[i] != "" && clients[i] != unknownClient {
+			if e := clientCounts[clients[i]] - p.clientPeerLimit; e > result {
+				result = e
+			}
+		}
+		return result
+	}
+
+	picked := make([]bool, len(candidates))
+	selected := make([]peer.ID, 0, amount)
+	for uint64(len(selected)) < amount {
+		best, bestExcess := -1, 0
+		for i := range candidates {
+			if picked[i] {
+				continue
+			}
+			if e := excess(i); best == -1 || e > bestExcess {
+				best, bestExcess = i, e
+			}
+		}
+		picked[best] = true
+		selected = append(selected, candidates[best])
+		if subnets[best] != "" {
+			subnetCounts[subnets[best]]--
+		}
+		clientCounts[clients[best]]--
+	}
+	return selected
+}
+
+func isActive(peerData *peerdata.PeerData) bool {
+	return peerData.ConnState == PeerConnected || peerData.ConnState == PeerConnecting
+}
+
+// subnetFromAddr returns the /24 (IPv4) or /48 (IPv6) subnet of the address, or an
+// empty string for invalid and loopback addresses, which are exempt from colocation limits.
+func subnetFromAddr(addr ma.Multiaddr) string {
+	if addr == nil {
+		return ""
+	}
+	ip, err := manet.ToIP(addr)
+	if err != nil || ip.IsLoopback() {
+		return ""
+	}
+	mask := net.CIDRMask(ipv6SubnetBits, 8*net.IPv6len)
+	if ip4 := ip.To4(); ip4 != nil {
+		ip, mask = ip4, net.CIDRMask(ipv4SubnetBits, 8*net.IPv4len)
+	}
+	return (&net.IPNet{IP: ip.Mask(mask), Mask: mask}).String()
+}
+
+// clientFromAgent extracts the client implementation from an agent string, ex:
+// "Prysm/v2.1.3/8f4f5a4" and "prysm" both map to "prysm".
+func clientFromAgent(agent string) string {
+	client := strings.ToLower(strings.TrimSpace(strings.SplitN(agent, "/", 2)[0]))
+	if client == "" {
+		return unknownClient
+	}
+	return client
+}
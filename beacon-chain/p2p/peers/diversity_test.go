@@ -0,0 +1,153 @@
+package peers_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers/peerdata"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers/scorers"
+	"github.com/prysmaticlabs/prysm/config/features"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func newDiversityStatus(peerLimit, subnetLimit, clientLimit int) *peers.Status {
+	return peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit: peerLimit,
+		ScorerParams: &scorers.Config{
+			BadResponsesScorerConfig: &scorers.BadResponsesScorerConfig{
+				Threshold: 1,
+			},
+		},
+		SubnetPeerLimit: subnetLimit,
+		ClientPeerLimit: clientLimit,
+	})
+}
+
+func tcpAddr(t *testing.T, ip string) ma.Multiaddr {
+	proto := "ip4"
+	if strings.Contains(ip, ":") {
+		proto = "ip6"
+	}
+	addr, err := ma.NewMultiaddr(fmt.Sprintf("/%s/%s/tcp/13000", proto, ip))
+	require.NoError(t, err)
+	return addr
+}
+
+func TestStatus_IsAboveSubnetLimit(t *testing.T) {
+	connected := peerdata.PeerConnectionState(ethpb.ConnectionState_CONNECTED)
+	p := newDiversityStatus(30, 2, 0)
+	assert.Equal(t, false, p.IsAboveSubnetLimit(tcpAddr(t, "212.67.10.5")))
+
+	createPeer(t, p, tcpAddr(t, "212.67.10.1"), network.DirInbound, connected)
+	createPeer(t, p, tcpAddr(t, "212.67.10.2"), network.DirOutbound, connected)
+	// Disconnected peers don't count towards the limit.
+	createPeer(t, p, tcpAddr(t, "212.67.10.3"), network.DirInbound, peers.PeerDisconnected)
+	assert.Equal(t, true, p.IsAboveSubnetLimit(tcpAddr(t, "212.67.10.5")))
+	assert.Equal(t, false, p.IsAboveSubnetLimit(tcpAddr(t, "212.67.11.5")))
+	// Loopback addresses are exempt.
+	createPeer(t, p, tcpAddr(t, "127.0.0.1"), network.DirInbound, connected)
+	createPeer(t, p, tcpAddr(t, "127.0.0.1"), network.DirInbound, connected)
+	assert.Equal(t, false, p.IsAboveSubnetLimit(tcpAddr(t, "127.0.0.1")))
+
+	// IPv6 peers are grouped by /48.
+	createPeer(t, p, tcpAddr(t, "2001:db8:1:1::1"), network.DirInbound, connected)
+	createPeer(t, p, tcpAddr(t, "2001:db8:1:2::1"), network.DirInbound, connected)
+	assert.Equal(t, true, p.IsAboveSubnetLimit(tcpAddr(t, "2001:db8:1:ffff::1")))
+	assert.Equal(t, false, p.IsAboveSubnetLimit(tcpAddr(t, "2001:db8:2::1")))
+
+	// No limit configured.
+	p = newDiversityStatus(30, 0, 0)
+	createPeer(t, p, tcpAddr(t, "212.67.10.1"), network.DirInbound, connected)
+	assert.Equal(t, false, p.IsAboveSubnetLimit(tcpAddr(t, "212.67.10.5")))
+}
+
+func TestStatus_IsAboveClientLimit(t *testing.T) {
+	connected := peerdata.PeerConnectionState(ethpb.ConnectionState_CONNECTED)
+	p := newDiversityStatus(30, 0, 2)
+	for i := 0; i < 2; i++ {
+		pid := createPeer(t, p, nil, network.DirInbound, connected)
+		p.SetAgent(pid, fmt.Sprintf("Prysm/v2.1.%d/abcdef", i))
+	}
+	lighthouse := createPeer(t, p, nil, network.DirInbound, connected)
+	p.SetAgent(lighthouse, "Lighthouse/v2.3.1-564d7da/x86_64-linux")
+	agent, err := p.Agent(lighthouse)
+	require.NoError(t, err)
+	assert.Equal(t, "Lighthouse/v2.3.1-564d7da/x86_64-linux", agent)
+
+	prysm := createPeer(t, p, nil, network.DirInbound, connected)
+	p.SetAgent(prysm, "prysm")
+	assert.Equal(t, true, p.IsAboveClientLimit(prysm))
+	assert.Equal(t, false, p.IsAboveClientLimit(lighthouse))
+	// Peers with no known agent are never limited.
+	unknown := createPeer(t, p, nil, network.DirInbound, connected)
+	assert.Equal(t, false, p.IsAboveClientLimit(unknown))
+
+	_, err = p.Agent("unknown")
+	assert.ErrorContains(t, peerdata.ErrPeerUnknown.Error(), err)
+}
+
+func TestStatus_PeersToPrune_Diversity(t *testing.T) {
+	resetCfg := features.InitWithReset(&features.Flags{
+		EnablePeerScorer: true,
+	})
+	defer resetCfg()
+	connected := peerdata.PeerConnectionState(ethpb.ConnectionState_CONNECTED)
+	// Allow 20 connected peers, at most 5 from a subnet and 12 running the same client.
+	p := newDiversityStatus(20, 5, 12)
+
+	var sameSubnet, sameClient []peer.ID
+	for i := 0; i < 7; i++ {
+		pid := createPeer(t, p, tcpAddr(t, fmt.Sprintf("212.67.10.%d", i+1)), network.DirInbound, connected)
+		p.SetAgent(pid, "Lighthouse/v2.3.1")
+		sameSubnet = append(sameSubnet, pid)
+	}
+	for i := 0; i < 14; i++ {
+		// Outbound peers are never pruned, but count towards the client's representation.
+		dir := network.DirInbound
+		if i%3 == 0 {
+			dir = network.DirOutbound
+		}
+		pid := createPeer(t, p, tcpAddr(t, fmt.Sprintf("10.%d.0.1", i)), dir, connected)
+		p.SetAgent(pid, "Prysm/v2.1.3")
+		sameClient = append(sameClient, pid)
+	}
+	// Well scored peers of overrepresented groups are still pruned before badly scored diverse ones.
+	for i := 0; i < 4; i++ {
+		pid := createPeer(t, p, tcpAddr(t, fmt.Sprintf("34.%d.0.1", i)), network.DirInbound, connected)
+		p.SetAgent(pid, "Teku/v22.6.0")
+		p.Scorers().BadResponsesScorer().Increment(pid)
+	}
+
+	// 25 peers (21 inbound) for a limit of 20: 2 peers in excess of the subnet limit, 2 of the client limit.
+	peersToPrune := p.PeersToPrune()
+	require.Equal(t, 5, len(peersToPrune))
+	fromSubnet, fromClient := 0, 0
+	for _, pid := range peersToPrune {
+		for _, other := range sameSubnet {
+			if pid == other {
+				fromSubnet++
+			}
+		}
+		for _, other := range sameClient {
+			if pid == other {
+				fromClient++
+			}
+		}
+	}
+	assert.Equal(t, 2, fromSubnet)
+	assert.Equal(t, 2, fromClient)
+
+	// The last pick falls back to the lowest scored peer.
+	dropped := peersToPrune[len(peersToPrune)-1]
+	agent, err := p.Agent(dropped)
+	require.NoError(t, err)
+	assert.Equal(t, "Teku/v22.6.0", agent)
+}
@@ -51,15 +51,17 @@ type PeerData struct {
 	ConnState     PeerConnectionState
 	Enr           *enr.Record
 	NextValidTime time.Time
+	Agent         string
 	// Chain related data.
 	MetaData                  metadata.Metadata
 	ChainState                *ethpb.Status
 	ChainStateLastUpdated     time.Time
 	ChainStateValidationError error
 	// Scorers internal data.
-	BadResponses         int
-	ProcessedBlocks      uint64
-	BlockProviderUpdated time.Time
+	BadResponses            int
+	ProcessedBlocks         uint64
+	BlockProviderUpdated    time.Time
+	BlockProviderThroughput float64
 	// Gossip Scoring data.
 	TopicScores      map[string]*ethpb.TopicScoreSnapshot
 	GossipScore      float64
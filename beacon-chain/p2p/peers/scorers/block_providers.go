@@ -32,6 +32,9 @@ const (
 	// opportunity to provide blocks (their score gets boosted, up until they are selected for
 	// fetching).
 	DefaultBlockProviderStalePeerRefreshInterval = 5 * time.Minute
+	// DefaultBlockProviderThroughputSmoothing defines how much weight the most recent measurement
+	// carries in peer's throughput moving average.
+	DefaultBlockProviderThroughputSmoothing = float64(0.3)
 )
 
 // BlockProviderScorer represents block provider scoring service.
@@ -141,6 +144,37 @@ func (s *BlockProviderScorer) IncrementProcessedBlocks(pid peer.ID, cnt uint64)
 	}
 }
 
+// RecordThroughput updates the measured throughput of a peer, given a number of blocks
+// the peer has served in the provided time. Throughput is kept as an exponential moving
+// average of blocks per second, so that single slow or fast responses do not dominate it.
+func (s *BlockProviderScorer) RecordThroughput(pid peer.ID, blocks uint64, elapsed time.Duration) {
+	if elapsed <= 0 {
+		return
+	}
+	s.store.Lock()
+	defer s.store.Unlock()
+
+	measured := float64(blocks) / elapsed.Seconds()
+	peerData := s.store.PeerDataGetOrCreate(pid)
+	if peerData.BlockProviderThroughput == 0 {
+		peerData.BlockProviderThroughput = measured
+		return
+	}
+	peerData.BlockProviderThroughput = DefaultBlockProviderThroughputSmoothing*measured +
+		(1-DefaultBlockProviderThroughputSmoothing)*peerData.BlockProviderThroughput
+}
+
+// Throughput returns measured throughput (in blocks per second) of a given peer.
+// Zero is returned for peers that haven't served any blocks yet.
+func (s *BlockProviderScorer) Throughput(pid peer.ID) float64 {
+	s.store.RLock()
+	defer s.store.RUnlock()
+	if peerData, ok := s.store.PeerData(pid); ok {
+		return peerData.BlockProviderThroughput
+	}
+	return 0
+}
+
 // Touch updates last access time for a given peer. This allows to detect peers that are
 // stale and boost their scores to increase chances in block fetching participation.
 func (s *BlockProviderScorer) Touch(pid peer.ID, t ...time.Time) {
@@ -6,6 +6,7 @@ import (
 	"sort"
 	"strconv"
 	"testing"
+	gotime "time"
 
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
@@ -149,6 +150,29 @@ func TestScorers_BlockProvider_GettersSetters(t *testing.T) {
 	assert.Equal(t, uint64(64), scorer.ProcessedBlocks("peer1"))
 }
 
+func TestScorers_BlockProvider_Throughput(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	peerStatuses := peers.NewStatus(ctx, &peers.StatusConfig{
+		ScorerParams: &scorers.Config{},
+	})
+	scorer := peerStatuses.Scorers().BlockProviderScorer()
+
+	assert.Equal(t, float64(0), scorer.Throughput("peer1"), "Unexpected throughput for unregistered peer")
+	scorer.RecordThroughput("peer1", 64, 0)
+	assert.Equal(t, float64(0), scorer.Throughput("peer1"), "Zero duration must be ignored")
+
+	// First measurement is taken as is.
+	scorer.RecordThroughput("peer1", 64, 2*gotime.Second)
+	assert.Equal(t, float64(32), scorer.Throughput("peer1"))
+
+	// Subsequent measurements are smoothed.
+	scorer.RecordThroughput("peer1", 64, gotime.Second)
+	want := scorers.DefaultBlockProviderThroughputSmoothing*64 + (1-scorers.DefaultBlockProviderThroughputSmoothing)*32
+	assert.Equal(t, want, scorer.Throughput("peer1"))
+}
+
 func TestScorers_BlockProvider_WeightSorted(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
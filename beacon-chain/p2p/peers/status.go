@@ -78,11 +78,13 @@ const (
 
 // Status is the structure holding the peer status information.
 type Status struct {
-	ctx       context.Context
-	scorers   *scorers.Service
-	store     *peerdata.Store
-	ipTracker map[string]uint64
-	rand      *rand.Rand
+	ctx             context.Context
+	scorers         *scorers.Service
+	store           *peerdata.Store
+	ipTracker       map[string]uint64
+	rand            *rand.Rand
+	subnetPeerLimit int
+	clientPeerLimit int
 }
 
 // StatusConfig represents peer status service params.
@@ -91,6 +93,12 @@ type StatusConfig struct {
 	PeerLimit int
 	// ScorerParams holds peer scorer configuration params.
 	ScorerParams *scorers.Config
+	// SubnetPeerLimit specifies maximum amount of active peers from a single /24 (IPv4) or /48 (IPv6) subnet.
+	// Zero means no limit.
+	SubnetPeerLimit int
+	// ClientPeerLimit specifies maximum amount of active peers running the same client implementation.
+	// Zero means no limit.
+	ClientPeerLimit int
 }
 
 // NewStatus creates a new status entity.
@@ -105,7 +113,9 @@ func NewStatus(ctx context.Context, config *StatusConfig) *Status {
 		ipTracker: map[string]uint64{},
 		// Random generator used to calculate dial backoff period.
 		// It is ok to use deterministic generator, no need for true entropy.
-		rand: rand.NewDeterministicGenerator(),
+		rand:            rand.NewDeterministicGenerator(),
+		subnetPeerLimit: config.SubnetPeerLimit,
+		clientPeerLimit: config.ClientPeerLimit,
 	}
 }
 
@@ -744,7 +754,9 @@ func (p *Status) BestNonFinalized(minPeers int, ourHeadEpoch types.Epoch) (types
 // to disconnect the host peer from. As of this moment
 // the pruning relies on simple heuristics such as
 // bad response count. In the future scoring will be used
-// to determine the most suitable peers to take out.
+// to determine the most suitable peers to take out. Peers
+// from subnets or clients above their configured limits are
+// taken out first.
 func (p *Status) PeersToPrune() []peer.ID {
 	if !features.Get().EnablePeerScorer {
 		return p.deprecatedPeersToPrune()
@@ -802,14 +814,12 @@ func (p *Status) PeersToPrune() []peer.ID {
 	if excessInbound > amountToPrune {
 		amountToPrune = excessInbound
 	}
-	if amountToPrune < uint64(len(peersToPrune)) {
-		peersToPrune = peersToPrune[:amountToPrune]
-	}
 	ids := make([]peer.ID, 0, len(peersToPrune))
 	for _, pr := range peersToPrune {
 		ids = append(ids, pr.pid)
 	}
-	return ids
+	// Favour pruning peers from overrepresented subnets and clients.
+	return p.selectOverrepresented(ids, amountToPrune)
 }
 
 // Deprecated: Is used to represent the older method
@@ -867,14 +877,12 @@ func (p *Status) deprecatedPeersToPrune() []peer.ID {
 	if excessInbound > amountToPrune {
 		amountToPrune = excessInbound
 	}
-	if amountToPrune < uint64(len(peersToPrune)) {
-		peersToPrune = peersToPrune[:amountToPrune]
-	}
 	ids := make([]peer.ID, 0, len(peersToPrune))
 	for _, pr := range peersToPrune {
 		ids = append(ids, pr.pid)
 	}
-	return ids
+	// Favour pruning peers from overrepresented subnets and clients.
+	return p.selectOverrepresented(ids, amountToPrune)
 }
 
 // HighestEpoch returns the highest epoch reported epoch amongst peers.
@@ -339,6 +339,7 @@ func TestService_MonitorsStateForkUpdates(t *testing.T) {
 	defer cancel()
 	notifier := &mock.MockStateNotifier{}
 	s, err := NewService(ctx, &Config{
+		DataDir:       t.TempDir(),
 		StateNotifier: notifier,
 	})
 	require.NoError(t, err)
@@ -17,6 +17,7 @@ import (
 
 func TestService_PublishToTopicConcurrentMapWrite(t *testing.T) {
 	s, err := NewService(context.Background(), &Config{
+		DataDir:       t.TempDir(),
 		StateNotifier: &mock.MockStateNotifier{},
 	})
 	require.NoError(t, err)
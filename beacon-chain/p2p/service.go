@@ -172,6 +172,8 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 				DecayInterval: time.Hour,
 			},
 		},
+		SubnetPeerLimit: int(s.cfg.MaxPeersPerSubnet),
+		ClientPeerLimit: int(s.cfg.MaxPeersPerClient),
 	})
 
 	// Initialize Data maps.
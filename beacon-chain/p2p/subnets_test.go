@@ -89,6 +89,7 @@ func TestStartDiscV5_DiscoverPeersWithSubnets(t *testing.T) {
 	// Make one service on port 4001.
 	port = 4001
 	cfg := &Config{
+		DataDir:             t.TempDir(),
 		BootstrapNodeAddr:   []string{bootNode.String()},
 		Discv5BootStrapAddr: []string{bootNode.String()},
 		MaxPeers:            30,
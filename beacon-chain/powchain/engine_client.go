@@ -92,8 +92,10 @@ func (s *Service) NewPayload(ctx context.Context, payload *pb.ExecutionPayload)
 	switch result.Status {
 	case pb.PayloadStatus_INVALID_BLOCK_HASH:
 		return nil, ErrInvalidBlockHashPayloadStatus
-	case pb.PayloadStatus_ACCEPTED, pb.PayloadStatus_SYNCING:
-		return nil, ErrAcceptedSyncingPayloadStatus
+	case pb.PayloadStatus_ACCEPTED:
+		return nil, ErrAcceptedPayloadStatus
+	case pb.PayloadStatus_SYNCING:
+		return nil, ErrSyncingPayloadStatus
 	case pb.PayloadStatus_INVALID:
 		return result.LatestValidHash, ErrInvalidPayloadStatus
 	case pb.PayloadStatus_VALID:
@@ -129,7 +131,7 @@ func (s *Service) ForkchoiceUpdated(
 	resp := result.Status
 	switch resp.Status {
 	case pb.PayloadStatus_SYNCING:
-		return nil, nil, ErrAcceptedSyncingPayloadStatus
+		return nil, nil, ErrSyncingPayloadStatus
 	case pb.PayloadStatus_INVALID:
 		return nil, resp.LatestValidHash, ErrInvalidPayloadStatus
 	case pb.PayloadStatus_VALID:
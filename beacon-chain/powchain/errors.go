@@ -28,6 +28,10 @@ var (
 	ErrConfigMismatch = errors.New("execution client configuration mismatch")
 	// ErrAcceptedSyncingPayloadStatus when the status of the payload is syncing or accepted.
 	ErrAcceptedSyncingPayloadStatus = errors.New("payload status is SYNCING or ACCEPTED")
+	// ErrSyncingPayloadStatus when the status of the payload is syncing. It wraps ErrAcceptedSyncingPayloadStatus.
+	ErrSyncingPayloadStatus = errors.Wrap(ErrAcceptedSyncingPayloadStatus, "payload status is SYNCING")
+	// ErrAcceptedPayloadStatus when the status of the payload is accepted. It wraps ErrAcceptedSyncingPayloadStatus.
+	ErrAcceptedPayloadStatus = errors.Wrap(ErrAcceptedSyncingPayloadStatus, "payload status is ACCEPTED")
 	// ErrInvalidPayloadStatus when the status of the payload is invalid.
 	ErrInvalidPayloadStatus = errors.New("payload status is INVALID")
 	// ErrInvalidBlockHashPayloadStatus when the status of the payload fails to validate block hash.
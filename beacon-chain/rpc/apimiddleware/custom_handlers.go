@@ -389,6 +389,10 @@ func receiveEvents(eventChan <-chan *sse.Event, w http.ResponseWriter, req *http
 				data = &eventChainReorgJson{}
 			case events.SyncCommitteeContributionTopic:
 				data = &signedContributionAndProofJson{}
+			case events.AttesterSlashingTopic:
+				data = &attesterSlashingJson{}
+			case events.ProposerSlashingTopic:
+				data = &proposerSlashingJson{}
 			case "error":
 				data = &eventErrorJson{}
 			default:
@@ -451,3 +455,14 @@ func flushEvent(w http.ResponseWriter) apimiddleware.ErrorJson {
 	flusher.Flush()
 	return nil
 }
+
+// handleSelections answers the aggregated selection proof endpoints. Combining partial selection proofs
+// is the job of distributed validator middlewares sitting in front of the beacon node, which intercept
+// these requests. A beacon node has no partial proofs to combine them with, so it does not implement them.
+func handleSelections(_ *apimiddleware.ApiProxyMiddleware, _ apimiddleware.Endpoint, w http.ResponseWriter, _ *http.Request) (handled bool) {
+	apimiddleware.WriteError(w, &apimiddleware.DefaultErrorJson{
+		Message: "Aggregating selection proofs is only supported by distributed validator middlewares",
+		Code:    http.StatusNotImplemented,
+	}, nil)
+	return true
+}
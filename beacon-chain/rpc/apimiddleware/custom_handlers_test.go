@@ -346,3 +346,15 @@ func TestWriteEvent(t *testing.T) {
 	written := w.Body.String()
 	assert.Equal(t, "event: test_event\ndata: {\"block\":\"0x666f6f\",\"state\":\"0x666f6f\",\"epoch\":\"1\",\"execution_optimistic\":false}\n\n", written)
 }
+
+func TestHandleSelections(t *testing.T) {
+	req := httptest.NewRequest("POST", "http://foo.example/eth/v1/validator/beacon_committee_selections", nil)
+	w := httptest.NewRecorder()
+
+	handled := handleSelections(nil, apimiddleware.Endpoint{}, w, req)
+	assert.Equal(t, true, handled)
+	assert.Equal(t, http.StatusNotImplemented, w.Code)
+	errJson := &apimiddleware.DefaultErrorJson{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), errJson))
+	assert.Equal(t, http.StatusNotImplemented, errJson.Code)
+}
@@ -68,6 +68,8 @@ func (_ *BeaconEndpointFactory) Paths() []string {
 		"/eth/v1/validator/sync_committee_contribution",
 		"/eth/v1/validator/contribution_and_proofs",
 		"/eth/v1/validator/prepare_beacon_proposer",
+		"/eth/v1/validator/beacon_committee_selections",
+		"/eth/v1/validator/sync_committee_selections",
 	}
 }
 
@@ -268,6 +270,8 @@ func (_ *BeaconEndpointFactory) Create(path string) (*apimiddleware.Endpoint, er
 		endpoint.Hooks = apimiddleware.HookCollection{
 			OnPreDeserializeRequestBodyIntoContainer: wrapFeeRecipientsArray,
 		}
+	case "/eth/v1/validator/beacon_committee_selections", "/eth/v1/validator/sync_committee_selections":
+		endpoint.CustomHandlers = []apimiddleware.CustomHandler{handleSelections}
 	default:
 		return nil, errors.New("invalid path")
 	}
@@ -431,9 +431,10 @@ func TestSubmitAttesterSlashing_Ok(t *testing.T) {
 
 	broadcaster := &p2pMock.MockBroadcaster{}
 	s := &Server{
-		ChainInfoFetcher: &blockchainmock.ChainService{State: bs},
-		SlashingsPool:    &slashingsmock.PoolMock{},
-		Broadcaster:      broadcaster,
+		ChainInfoFetcher:  &blockchainmock.ChainService{State: bs},
+		SlashingsPool:     &slashingsmock.PoolMock{},
+		Broadcaster:       broadcaster,
+		OperationNotifier: &blockchainmock.MockOperationNotifier{},
 	}
 
 	_, err = s.SubmitAttesterSlashing(ctx, slashing)
@@ -474,9 +475,10 @@ func TestSubmitAttesterSlashing_InvalidSlashing(t *testing.T) {
 
 	broadcaster := &p2pMock.MockBroadcaster{}
 	s := &Server{
-		ChainInfoFetcher: &blockchainmock.ChainService{State: bs},
-		SlashingsPool:    &slashingsmock.PoolMock{},
-		Broadcaster:      broadcaster,
+		ChainInfoFetcher:  &blockchainmock.ChainService{State: bs},
+		SlashingsPool:     &slashingsmock.PoolMock{},
+		Broadcaster:       broadcaster,
+		OperationNotifier: &blockchainmock.MockOperationNotifier{},
 	}
 
 	_, err = s.SubmitAttesterSlashing(ctx, slashing)
@@ -538,9 +540,10 @@ func TestSubmitProposerSlashing_Ok(t *testing.T) {
 
 	broadcaster := &p2pMock.MockBroadcaster{}
 	s := &Server{
-		ChainInfoFetcher: &blockchainmock.ChainService{State: bs},
-		SlashingsPool:    &slashingsmock.PoolMock{},
-		Broadcaster:      broadcaster,
+		ChainInfoFetcher:  &blockchainmock.ChainService{State: bs},
+		SlashingsPool:     &slashingsmock.PoolMock{},
+		Broadcaster:       broadcaster,
+		OperationNotifier: &blockchainmock.MockOperationNotifier{},
 	}
 
 	_, err = s.SubmitProposerSlashing(ctx, slashing)
@@ -574,9 +577,10 @@ func TestSubmitProposerSlashing_InvalidSlashing(t *testing.T) {
 
 	broadcaster := &p2pMock.MockBroadcaster{}
 	s := &Server{
-		ChainInfoFetcher: &blockchainmock.ChainService{State: bs},
-		SlashingsPool:    &slashingsmock.PoolMock{},
-		Broadcaster:      broadcaster,
+		ChainInfoFetcher:  &blockchainmock.ChainService{State: bs},
+		SlashingsPool:     &slashingsmock.PoolMock{},
+		Broadcaster:       broadcaster,
+		OperationNotifier: &blockchainmock.MockOperationNotifier{},
 	}
 
 	_, err = s.SubmitProposerSlashing(ctx, slashing)
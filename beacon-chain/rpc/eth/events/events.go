@@ -33,6 +33,10 @@ const (
 	ChainReorgTopic = "chain_reorg"
 	// SyncCommitteeContributionTopic represents a new sync committee contribution event topic.
 	SyncCommitteeContributionTopic = "contribution_and_proof"
+	// AttesterSlashingTopic represents a new received or detected attester slashing event topic.
+	AttesterSlashingTopic = "attester_slashing"
+	// ProposerSlashingTopic represents a new received or detected proposer slashing event topic.
+	ProposerSlashingTopic = "proposer_slashing"
 )
 
 var casesHandled = map[string]bool{
@@ -43,11 +47,13 @@ var casesHandled = map[string]bool{
 	FinalizedCheckpointTopic:       true,
 	ChainReorgTopic:                true,
 	SyncCommitteeContributionTopic: true,
+	AttesterSlashingTopic:          true,
+	ProposerSlashingTopic:          true,
 }
 
 // StreamEvents allows requesting all events from a set of topics defined in the Ethereum consensus API standard.
 // The topics supported include block events, attestations, chain reorgs, voluntary exits,
-// chain finality, and more.
+// slashings, chain finality, and more.
 func (s *Server) StreamEvents(
 	req *ethpb.StreamEventsRequest, stream ethpbservice.Events_StreamEventsServer,
 ) error {
@@ -178,6 +184,26 @@ func handleBlockOperationEvents(
 		}
 		v2Data := migration.V1Alpha1SignedContributionAndProofToV2(contributionData.Contribution)
 		return streamData(stream, SyncCommitteeContributionTopic, v2Data)
+	case operation.AttesterSlashingReceived:
+		if _, ok := requestedTopics[AttesterSlashingTopic]; !ok {
+			return nil
+		}
+		slashingData, ok := event.Data.(*operation.AttesterSlashingReceivedData)
+		if !ok {
+			return nil
+		}
+		v1Data := migration.V1Alpha1AttSlashingToV1(slashingData.AttesterSlashing)
+		return streamData(stream, AttesterSlashingTopic, v1Data)
+	case operation.ProposerSlashingReceived:
+		if _, ok := requestedTopics[ProposerSlashingTopic]; !ok {
+			return nil
+		}
+		slashingData, ok := event.Data.(*operation.ProposerSlashingReceivedData)
+		if !ok {
+			return nil
+		}
+		v1Data := migration.V1Alpha1ProposerSlashingToV1(slashingData.ProposerSlashing)
+		return streamData(stream, ProposerSlashingTopic, v1Data)
 	default:
 		return nil
 	}
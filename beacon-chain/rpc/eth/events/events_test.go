@@ -232,6 +232,80 @@ func TestStreamEvents_OperationsEvents(t *testing.T) {
 			feed: srv.OperationNotifier.OperationFeed(),
 		})
 	})
+	t.Run(AttesterSlashingTopic, func(t *testing.T) {
+		ctx := context.Background()
+		srv, ctrl, mockStream := setupServer(ctx, t)
+		defer ctrl.Finish()
+
+		wantedSlashingV1alpha1 := &eth.AttesterSlashing{
+			Attestation_1: util.NewAttestationUtil().HydrateIndexedAttestation(&eth.IndexedAttestation{
+				AttestingIndices: []uint64{1, 2},
+			}),
+			Attestation_2: util.NewAttestationUtil().HydrateIndexedAttestation(&eth.IndexedAttestation{
+				AttestingIndices: []uint64{2, 3},
+			}),
+		}
+		wantedSlashing := migration.V1Alpha1AttSlashingToV1(wantedSlashingV1alpha1)
+		genericResponse, err := anypb.New(wantedSlashing)
+		require.NoError(t, err)
+
+		wantedMessage := &gateway.EventSource{
+			Event: AttesterSlashingTopic,
+			Data:  genericResponse,
+		}
+
+		assertFeedSendAndReceive(ctx, &assertFeedArgs{
+			t:             t,
+			srv:           srv,
+			topics:        []string{AttesterSlashingTopic},
+			stream:        mockStream,
+			shouldReceive: wantedMessage,
+			itemToSend: &feed.Event{
+				Type: operation.AttesterSlashingReceived,
+				Data: &operation.AttesterSlashingReceivedData{
+					AttesterSlashing: wantedSlashingV1alpha1,
+				},
+			},
+			feed: srv.OperationNotifier.OperationFeed(),
+		})
+	})
+	t.Run(ProposerSlashingTopic, func(t *testing.T) {
+		ctx := context.Background()
+		srv, ctrl, mockStream := setupServer(ctx, t)
+		defer ctrl.Finish()
+
+		wantedSlashingV1alpha1 := &eth.ProposerSlashing{
+			Header_1: util.HydrateSignedBeaconHeader(&eth.SignedBeaconBlockHeader{
+				Header: &eth.BeaconBlockHeader{Slot: 1, ProposerIndex: 1},
+			}),
+			Header_2: util.HydrateSignedBeaconHeader(&eth.SignedBeaconBlockHeader{
+				Header: &eth.BeaconBlockHeader{Slot: 1, ProposerIndex: 1, BodyRoot: []byte("body")},
+			}),
+		}
+		wantedSlashing := migration.V1Alpha1ProposerSlashingToV1(wantedSlashingV1alpha1)
+		genericResponse, err := anypb.New(wantedSlashing)
+		require.NoError(t, err)
+
+		wantedMessage := &gateway.EventSource{
+			Event: ProposerSlashingTopic,
+			Data:  genericResponse,
+		}
+
+		assertFeedSendAndReceive(ctx, &assertFeedArgs{
+			t:             t,
+			srv:           srv,
+			topics:        []string{ProposerSlashingTopic},
+			stream:        mockStream,
+			shouldReceive: wantedMessage,
+			itemToSend: &feed.Event{
+				Type: operation.ProposerSlashingReceived,
+				Data: &operation.ProposerSlashingReceivedData{
+					ProposerSlashing: wantedSlashingV1alpha1,
+				},
+			},
+			feed: srv.OperationNotifier.OperationFeed(),
+		})
+	})
 }
 
 func TestStreamEvents_StateEvents(t *testing.T) {
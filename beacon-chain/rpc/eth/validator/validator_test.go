@@ -12,6 +12,8 @@ import (
 	mockChain "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	opfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/operation"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/signing"
 	coreTime "github.com/prysmaticlabs/prysm/beacon-chain/core/time"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/transition"
@@ -3699,8 +3701,13 @@ func TestPrepareBeaconProposer(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			db := dbutil.SetupDB(t)
 			ctx := context.Background()
+			notifier := &mockChain.MockOperationNotifier{}
+			opChannel := make(chan *feed.Event, 1)
+			opSub := notifier.OperationFeed().Subscribe(opChannel)
+			defer opSub.Unsubscribe()
 			v1Server := &v1alpha1validator.Server{
-				BeaconDB: db,
+				BeaconDB:          db,
+				OperationNotifier: notifier,
 			}
 			server := &Server{
 				V1Alpha1Server: v1Server,
@@ -3714,6 +3721,11 @@ func TestPrepareBeaconProposer(t *testing.T) {
 			address, err := server.V1Alpha1Server.BeaconDB.FeeRecipientByValidatorID(ctx, 1)
 			require.NoError(t, err)
 			require.Equal(t, common.BytesToAddress(tt.args.request.Recipients[0].FeeRecipient), address)
+			e := <-opChannel
+			require.Equal(t, opfeed.ProposerPreparationReceived, int(e.Type))
+			data, ok := e.Data.(*opfeed.ProposerPreparationReceivedData)
+			require.Equal(t, true, ok)
+			require.DeepEqual(t, []types.ValidatorIndex{1}, data.ValidatorIndices)
 		})
 	}
 }
@@ -5,15 +5,15 @@ import (
 
 	"github.com/golang/protobuf/ptypes/empty"
 	pbrpc "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-// GetForkChoice returns a fork choice store.
-func (ds *Server) GetForkChoice(_ context.Context, _ *empty.Empty) (*pbrpc.ForkChoiceResponse, error) {
-	store := ds.ForkFetcher.ForkChoicer()
-
-	return &pbrpc.ForkChoiceResponse{
-		JustifiedEpoch:  store.JustifiedCheckpoint().Epoch,
-		FinalizedEpoch:  store.FinalizedCheckpoint().Epoch,
-		ForkchoiceNodes: store.ForkChoiceNodes(),
-	}, nil
+// GetForkChoice returns a dump of the fork choice store.
+func (ds *Server) GetForkChoice(ctx context.Context, _ *empty.Empty) (*pbrpc.ForkChoiceResponse, error) {
+	dump, err := ds.ForkFetcher.ForkChoicer().ForkChoiceDump(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not dump fork choice store: %v", err)
+	}
+	return dump, nil
 }
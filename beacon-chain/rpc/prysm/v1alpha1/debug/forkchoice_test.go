@@ -6,6 +6,7 @@ import (
 
 	"github.com/golang/protobuf/ptypes/empty"
 	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	doublylinkedtree "github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/doubly-linked-tree"
 	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
 	"github.com/prysmaticlabs/prysm/testing/assert"
 	"github.com/prysmaticlabs/prysm/testing/require"
@@ -19,3 +20,15 @@ func TestServer_GetForkChoice_ProtoArray(t *testing.T) {
 	assert.Equal(t, store.JustifiedCheckpoint().Epoch, res.JustifiedEpoch, "Did not get wanted justified epoch")
 	assert.Equal(t, store.FinalizedCheckpoint().Epoch, res.FinalizedEpoch, "Did not get wanted finalized epoch")
 }
+
+func TestServer_GetForkChoice_DoublyLinkedTree(t *testing.T) {
+	store := doublylinkedtree.New()
+	bs := &Server{ForkFetcher: &mock.ChainService{ForkChoiceStore: store}}
+	res, err := bs.GetForkChoice(context.Background(), &empty.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, store.JustifiedCheckpoint().Epoch, res.JustifiedEpoch, "Did not get wanted justified epoch")
+	assert.Equal(t, store.FinalizedCheckpoint().Epoch, res.FinalizedEpoch, "Did not get wanted finalized epoch")
+	require.NotNil(t, res.JustifiedCheckpoint)
+	require.NotNil(t, res.FinalizedCheckpoint)
+	assert.Equal(t, 0, len(res.ForkchoiceNodes))
+}
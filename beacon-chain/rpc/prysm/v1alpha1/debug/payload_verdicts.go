@@ -0,0 +1,79 @@
+package debug
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/cmd"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetPayloadVerdicts returns the recorded verdicts on the execution payload of the requested block,
+// or the most recent verdicts, up to the maximum RPC page size, if no block root is given.
+func (ds *Server) GetPayloadVerdicts(ctx context.Context, req *pbrpc.PayloadVerdictsRequest) (*pbrpc.PayloadVerdicts, error) {
+	if len(req.BlockRoot) == 0 {
+		verdicts, err := ds.BeaconDB.LatestPayloadVerdicts(ctx, cmd.Get().MaxRPCPageSize)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not retrieve payload verdicts: %v", err)
+		}
+		return &pbrpc.PayloadVerdicts{Verdicts: verdicts}, nil
+	}
+	if len(req.BlockRoot) != fieldparams.RootLength {
+		return nil, status.Errorf(codes.InvalidArgument, "Block root must be %d bytes", fieldparams.RootLength)
+	}
+	verdicts, err := ds.BeaconDB.PayloadVerdicts(ctx, bytesutil.ToBytes32(req.BlockRoot))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not retrieve payload verdicts: %v", err)
+	}
+	return &pbrpc.PayloadVerdicts{Verdicts: verdicts}, nil
+}
+
+// StreamPayloadVerdicts streams the verdicts on execution payloads as they are recorded.
+func (ds *Server) StreamPayloadVerdicts(_ *empty.Empty, stream pbrpc.Debug_StreamPayloadVerdictsServer) error {
+	stateChannel := make(chan *feed.Event, 1)
+	stateSub := ds.StateNotifier.StateFeed().Subscribe(stateChannel)
+	defer stateSub.Unsubscribe()
+	for {
+		select {
+		case stateEvent := <-stateChannel:
+			if stateEvent.Type != statefeed.PayloadVerdict {
+				continue
+			}
+			verdict, ok := stateEvent.Data.(*pbrpc.PayloadVerdict)
+			if !ok {
+				continue
+			}
+			if err := stream.Send(verdict); err != nil {
+				return status.Errorf(codes.Unavailable, "Could not send over stream: %v", err)
+			}
+		case <-stateSub.Err():
+			return status.Error(codes.Aborted, "Subscriber closed, exiting goroutine")
+		case <-stream.Context().Done():
+			return status.Error(codes.Canceled, "Context canceled")
+		}
+	}
+}
+
+// InvalidateBlock marks an optimistic block and its descendants as invalid, as if the execution
+// engine had rejected its payload.
+func (ds *Server) InvalidateBlock(ctx context.Context, req *pbrpc.InvalidateBlockRequest) (*pbrpc.PayloadVerdict, error) {
+	if len(req.BlockRoot) != fieldparams.RootLength {
+		return nil, status.Errorf(codes.InvalidArgument, "Block root must be %d bytes", fieldparams.RootLength)
+	}
+	verdict, err := ds.PayloadInvalidator.InvalidateBlock(ctx, bytesutil.ToBytes32(req.BlockRoot))
+	if errors.Is(err, blockchain.ErrNotOptimisticBlock) {
+		return nil, status.Errorf(codes.FailedPrecondition, "Could not invalidate block: %v", err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not invalidate block: %v", err)
+	}
+	return verdict, nil
+}
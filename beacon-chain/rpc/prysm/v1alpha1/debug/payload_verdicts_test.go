@@ -0,0 +1,76 @@
+package debug
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	dbTest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/cmd"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestServer_GetPayloadVerdicts(t *testing.T) {
+	db := dbTest.SetupDB(t)
+	ctx := context.Background()
+	root1 := [32]byte{'a'}
+	root2 := [32]byte{'b'}
+	syncing := &pbrpc.PayloadVerdict{
+		BlockRoot: root1[:],
+		Status:    pbrpc.PayloadVerdict_SYNCING,
+		Source:    pbrpc.PayloadVerdict_NEW_PAYLOAD,
+		Timestamp: 1,
+	}
+	invalid := &pbrpc.PayloadVerdict{
+		BlockRoot: root2[:],
+		Status:    pbrpc.PayloadVerdict_INVALID,
+		Source:    pbrpc.PayloadVerdict_FORKCHOICE_UPDATED,
+		Timestamp: 2,
+	}
+	require.NoError(t, db.SavePayloadVerdict(ctx, syncing))
+	require.NoError(t, db.SavePayloadVerdict(ctx, invalid))
+	bs := &Server{BeaconDB: db}
+
+	res, err := bs.GetPayloadVerdicts(ctx, &pbrpc.PayloadVerdictsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(res.Verdicts))
+	assert.DeepEqual(t, syncing, res.Verdicts[0])
+	assert.DeepEqual(t, invalid, res.Verdicts[1])
+
+	resetCfg := cmd.InitWithReset(&cmd.Flags{MaxRPCPageSize: 1})
+	res, err = bs.GetPayloadVerdicts(ctx, &pbrpc.PayloadVerdictsRequest{})
+	resetCfg()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(res.Verdicts))
+	assert.DeepEqual(t, invalid, res.Verdicts[0])
+
+	res, err = bs.GetPayloadVerdicts(ctx, &pbrpc.PayloadVerdictsRequest{BlockRoot: root2[:]})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(res.Verdicts))
+	assert.DeepEqual(t, invalid, res.Verdicts[0])
+
+	_, err = bs.GetPayloadVerdicts(ctx, &pbrpc.PayloadVerdictsRequest{BlockRoot: []byte{'a'}})
+	require.ErrorContains(t, "Block root must be 32 bytes", err)
+}
+
+func TestServer_InvalidateBlock(t *testing.T) {
+	ctx := context.Background()
+	root := [32]byte{'a'}
+	bs := &Server{PayloadInvalidator: &mock.ChainService{}}
+
+	res, err := bs.InvalidateBlock(ctx, &pbrpc.InvalidateBlockRequest{BlockRoot: root[:]})
+	require.NoError(t, err)
+	assert.DeepEqual(t, root[:], res.BlockRoot)
+	assert.Equal(t, pbrpc.PayloadVerdict_INVALID, res.Status)
+	assert.Equal(t, pbrpc.PayloadVerdict_MANUAL, res.Source)
+
+	_, err = bs.InvalidateBlock(ctx, &pbrpc.InvalidateBlockRequest{BlockRoot: []byte{'a'}})
+	require.ErrorContains(t, "Block root must be 32 bytes", err)
+
+	bs.PayloadInvalidator = &mock.ChainService{InvalidateBlockErr: blockchain.ErrNotOptimisticBlock}
+	_, err = bs.InvalidateBlock(ctx, &pbrpc.InvalidateBlockRequest{BlockRoot: root[:]})
+	require.ErrorContains(t, "block is not optimistic", err)
+}
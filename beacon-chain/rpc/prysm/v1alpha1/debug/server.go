@@ -11,6 +11,7 @@ import (
 	"github.com/golang/protobuf/ptypes/empty"
 	golog "github.com/ipfs/go-log/v2"
 	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
@@ -32,6 +33,8 @@ type Server struct {
 	PeerManager        p2p.PeerManager
 	PeersFetcher       p2p.PeersProvider
 	ReplayerBuilder    stategen.ReplayerBuilder
+	StateNotifier      statefeed.Notifier
+	PayloadInvalidator blockchain.PayloadInvalidator
 }
 
 // SetLoggingLevel of a beacon node according to a request type,
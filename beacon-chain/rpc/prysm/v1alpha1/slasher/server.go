@@ -3,10 +3,15 @@
 package slasher
 
 import (
+	"github.com/prysmaticlabs/prysm/async/event"
 	slasherservice "github.com/prysmaticlabs/prysm/beacon-chain/slasher"
 )
 
 // Server defines a server implementation of the gRPC slasher service.
 type Server struct {
 	SlashingChecker slasherservice.SlashingChecker
+	// Feeds of a standalone slasher, which receives its data from remote beacon nodes.
+	IndexedAttestationsFeed *event.Feed
+	BeaconBlockHeadersFeed  *event.Feed
+	SlashingsFeed           *event.Feed
 }
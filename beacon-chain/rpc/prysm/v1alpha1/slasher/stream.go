@@ -0,0 +1,61 @@
+package slasher
+
+import (
+	"io"
+
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// StreamSlasherData feeds the indexed attestations and block headers observed by a remote
+// beacon node to a standalone slasher, and streams back the slashings it detects.
+func (s *Server) StreamSlasherData(stream ethpb.Slasher_StreamSlasherDataServer) error {
+	if s.SlashingsFeed == nil {
+		return status.Error(codes.Unimplemented, "Slasher does not accept data from remote beacon nodes")
+	}
+	slashingsChan := make(chan *ethpb.DetectedSlashings, 1)
+	sub := s.SlashingsFeed.Subscribe(slashingsChan)
+	defer sub.Unsubscribe()
+
+	recvErr := make(chan error, 1)
+	go func() {
+		recvErr <- s.receiveSlasherData(stream)
+	}()
+	for {
+		select {
+		case slashings := <-slashingsChan:
+			if err := stream.Send(slashings); err != nil {
+				return status.Errorf(codes.Unavailable, "Could not send over stream: %v", err)
+			}
+		case err := <-recvErr:
+			if err != nil {
+				return status.Errorf(codes.Unavailable, "Could not receive over stream: %v", err)
+			}
+			return nil
+		case <-sub.Err():
+			return status.Error(codes.Aborted, "Subscriber closed, exiting goroutine")
+		case <-stream.Context().Done():
+			return status.Error(codes.Canceled, "Context canceled")
+		}
+	}
+}
+
+// Receives the data of a remote beacon node until it closes the stream.
+func (s *Server) receiveSlasherData(stream ethpb.Slasher_StreamSlasherDataServer) error {
+	for {
+		data, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch d := data.Data.(type) {
+		case *ethpb.SlasherData_IndexedAttestation:
+			s.IndexedAttestationsFeed.Send(d.IndexedAttestation)
+		case *ethpb.SlasherData_BlockHeader:
+			s.BeaconBlockHeadersFeed.Send(d.BlockHeader)
+		}
+	}
+}
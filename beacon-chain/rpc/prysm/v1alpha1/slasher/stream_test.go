@@ -0,0 +1,80 @@
+package slasher
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/async/event"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"google.golang.org/grpc"
+)
+
+type mockStreamSlasherDataServer struct {
+	grpc.ServerStream
+	ctx  context.Context
+	recv chan *ethpb.SlasherData
+	sent chan *ethpb.DetectedSlashings
+}
+
+func (m *mockStreamSlasherDataServer) Context() context.Context {
+	return m.ctx
+}
+
+func (m *mockStreamSlasherDataServer) Recv() (*ethpb.SlasherData, error) {
+	data, ok := <-m.recv
+	if !ok {
+		return nil, io.EOF
+	}
+	return data, nil
+}
+
+func (m *mockStreamSlasherDataServer) Send(slashings *ethpb.DetectedSlashings) error {
+	m.sent <- slashings
+	return nil
+}
+
+func TestServer_StreamSlasherData(t *testing.T) {
+	s := &Server{
+		IndexedAttestationsFeed: new(event.Feed),
+		BeaconBlockHeadersFeed:  new(event.Feed),
+		SlashingsFeed:           new(event.Feed),
+	}
+	attsChan := make(chan *ethpb.IndexedAttestation, 1)
+	attsSub := s.IndexedAttestationsFeed.Subscribe(attsChan)
+	defer attsSub.Unsubscribe()
+	headersChan := make(chan *ethpb.SignedBeaconBlockHeader, 1)
+	headersSub := s.BeaconBlockHeadersFeed.Subscribe(headersChan)
+	defer headersSub.Unsubscribe()
+
+	stream := &mockStreamSlasherDataServer{
+		ctx:  context.Background(),
+		recv: make(chan *ethpb.SlasherData),
+		sent: make(chan *ethpb.DetectedSlashings, 1),
+	}
+	exitRoutine := make(chan error)
+	go func() {
+		exitRoutine <- s.StreamSlasherData(stream)
+	}()
+
+	att := &ethpb.IndexedAttestation{AttestingIndices: []uint64{1}}
+	stream.recv <- &ethpb.SlasherData{Data: &ethpb.SlasherData_IndexedAttestation{IndexedAttestation: att}}
+	require.DeepEqual(t, att, <-attsChan)
+	header := &ethpb.SignedBeaconBlockHeader{Header: &ethpb.BeaconBlockHeader{ProposerIndex: 1}}
+	stream.recv <- &ethpb.SlasherData{Data: &ethpb.SlasherData_BlockHeader{BlockHeader: header}}
+	require.DeepEqual(t, header, <-headersChan)
+
+	slashings := &ethpb.DetectedSlashings{ProposerSlashings: []*ethpb.ProposerSlashing{{Header_1: header, Header_2: header}}}
+	require.Equal(t, 1, s.SlashingsFeed.Send(slashings))
+	require.DeepEqual(t, slashings, <-stream.sent)
+
+	close(stream.recv)
+	require.NoError(t, <-exitRoutine)
+}
+
+func TestServer_StreamSlasherData_NotStandalone(t *testing.T) {
+	s := &Server{}
+	err := s.StreamSlasherData(&mockStreamSlasherDataServer{ctx: context.Background()})
+	require.ErrorContains(t, "does not accept data from remote beacon nodes", err)
+}
@@ -3,6 +3,7 @@ package validator
 import (
 	"context"
 	"sort"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/go-bitfield"
@@ -10,6 +11,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/config/features"
 	"github.com/prysmaticlabs/prysm/config/params"
 	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
@@ -24,6 +26,7 @@ type proposerAtts []*ethpb.Attestation
 func (vs *Server) packAttestations(ctx context.Context, latestState state.BeaconState) ([]*ethpb.Attestation, error) {
 	ctx, span := trace.StartSpan(ctx, "ProposerServer.packAttestations")
 	defer span.End()
+	deadline := time.Now().Add(attestationPackingTimeBudget)
 
 	atts := vs.AttPool.AggregatedAttestations()
 	atts, err := vs.validateAndDeleteAttsInPool(ctx, latestState, atts)
@@ -69,7 +72,16 @@ func (vs *Server) packAttestations(ctx context.Context, latestState state.Beacon
 	if err != nil {
 		return nil, err
 	}
-	sorted, err := deduped.sortByProfitability()
+	var sorted proposerAtts
+	if features.Get().EnableRewardWeightedPacking && latestState.Version() != version.Phase0 {
+		sorted, err = deduped.sortByReward(ctx, latestState, deadline)
+		if err != nil && ctx.Err() == nil {
+			log.WithError(err).Warn("Could not sort attestations by proposer reward, sorting them by profitability")
+			sorted, err = deduped.sortByProfitability()
+		}
+	} else {
+		sorted, err = deduped.sortByProfitability()
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,356 @@
+package validator
+
+import (
+	"container/heap"
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	coreTime "github.com/prysmaticlabs/prysm/beacon-chain/core/time"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/attestation"
+	attaggregation "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/attestation/aggregation/attestations"
+	"github.com/prysmaticlabs/prysm/runtime/version"
+	"go.opencensus.io/trace"
+)
+
+// attestationPackingTimeBudget bounds the time spent packing attestations into a block. Once it is
+// spent, the reward weighted selection stops re-scoring candidates and fills the rest of the block
+// by their last known reward.
+var attestationPackingTimeBudget = 250 * time.Millisecond
+
+// aggregatePair alias for testing substitution, the attestations built in tests have no valid
+// signatures to aggregate.
+var aggregatePair = attaggregation.AggregatePair
+
+// rewardCandidate is an attestation considered for inclusion, along with the participation flags
+// it sets for its attesters and the base reward of each of them.
+type rewardCandidate struct {
+	att          *ethpb.Attestation
+	indices      []uint64
+	baseRewards  []uint64
+	flags        uint8
+	currentEpoch bool
+	// reward is the proposer reward numerator of the candidate, as of its last scoring. Rewards
+	// only decrease as more attestations are selected.
+	reward uint64
+}
+
+// rewardCandidates is a max-heap of candidates by reward.
+type rewardCandidates []*rewardCandidate
+
+func (c rewardCandidates) Len() int { return len(c) }
+
+func (c rewardCandidates) Less(i, j int) bool {
+	if c[i].reward != c[j].reward {
+		return c[i].reward > c[j].reward
+	}
+	if c[i].att.Data.Slot != c[j].att.Data.Slot {
+		return c[i].att.Data.Slot > c[j].att.Data.Slot
+	}
+	return c[i].att.AggregationBits.Count() > c[j].att.AggregationBits.Count()
+}
+
+func (c rewardCandidates) Swap(i, j int) { c[i], c[j] = c[j], c[i] }
+
+func (c *rewardCandidates) Push(x interface{}) { *c = append(*c, x.(*rewardCandidate)) }
+
+func (c *rewardCandidates) Pop() interface{} {
+	old := *c
+	n := len(old)
+	x := old[n-1]
+	old[n-1] = nil
+	*c = old[:n-1]
+	return x
+}
+
+type flagWeight struct {
+	flag   uint8
+	weight uint64
+}
+
+// attRewardCalculator credits attestations with the proposer reward of the participation flags
+// they newly set. It tracks the flags set in the state and by the attestations included so far,
+// so that an attester covered by several attestations is only rewarded once per flag.
+type attRewardCalculator struct {
+	st                 state.BeaconState
+	currentEpoch       types.Epoch
+	baseRewardPerInc   uint64
+	currParticipation  []byte
+	prevParticipation  []byte
+	flagWeights        []flagWeight
+	effectiveIncrement uint64
+}
+
+func newAttRewardCalculator(st state.BeaconState) (*attRewardCalculator, error) {
+	if st.Version() == version.Phase0 {
+		return nil, errors.New("participation flags are not supported before altair")
+	}
+	totalBalance, err := helpers.TotalActiveBalance(st)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get total active balance")
+	}
+	baseRewardPerInc, err := altair.BaseRewardPerIncrement(totalBalance)
+	if err != nil {
+		return nil, err
+	}
+	currParticipation, err := st.CurrentEpochParticipation()
+	if err != nil {
+		return nil, err
+	}
+	prevParticipation, err := st.PreviousEpochParticipation()
+	if err != nil {
+		return nil, err
+	}
+	cfg := params.BeaconConfig()
+	return &attRewardCalculator{
+		st:                st,
+		currentEpoch:      coreTime.CurrentEpoch(st),
+		baseRewardPerInc:  baseRewardPerInc,
+		currParticipation: currParticipation,
+		prevParticipation: prevParticipation,
+		flagWeights: []flagWeight{
+			{flag: cfg.TimelySourceFlagIndex, weight: cfg.TimelySourceWeight},
+			{flag: cfg.TimelyTargetFlagIndex, weight: cfg.TimelyTargetWeight},
+			{flag: cfg.TimelyHeadFlagIndex, weight: cfg.TimelyHeadWeight},
+		},
+		effectiveIncrement: cfg.EffectiveBalanceIncrement,
+	}, nil
+}
+
+// candidate resolves the attesters of the attestation, and the flags it earns if included in a
+// block at the slot of the state.
+func (c *attRewardCalculator) candidate(ctx context.Context, att *ethpb.Attestation) (*rewardCandidate, error) {
+	if att.Data.Slot >= c.st.Slot() {
+		return nil, errors.Errorf("attestation slot %d is not before the proposal slot %d", att.Data.Slot, c.st.Slot())
+	}
+	participatedFlags, err := altair.AttestationParticipationFlagIndices(c.st, att.Data, c.st.Slot()-att.Data.Slot)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get attestation participation flags")
+	}
+	var flags uint8
+	for flag := range participatedFlags {
+		if flags, err = altair.AddValidatorFlag(flags, flag); err != nil {
+			return nil, err
+		}
+	}
+	committee, err := helpers.BeaconCommitteeFromState(ctx, c.st, att.Data.Slot, att.Data.CommitteeIndex)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get beacon committee")
+	}
+	indices, err := attestation.AttestingIndices(att.AggregationBits, committee)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get attesting indices")
+	}
+	baseRewards := make([]uint64, len(indices))
+	for i, idx := range indices {
+		val, err := c.st.ValidatorAtIndexReadOnly(types.ValidatorIndex(idx))
+		if err != nil {
+			return nil, err
+		}
+		baseRewards[i] = val.EffectiveBalance() / c.effectiveIncrement * c.baseRewardPerInc
+	}
+	return &rewardCandidate{
+		att:          att,
+		indices:      indices,
+		baseRewards:  baseRewards,
+		flags:        flags,
+		currentEpoch: att.Data.Target.Epoch == c.currentEpoch,
+	}, nil
+}
+
+// participation returns the epoch participation the candidate sets flags in.
+func (c *attRewardCalculator) participation(cand *rewardCandidate) []byte {
+	if cand.currentEpoch {
+		return c.currParticipation
+	}
+	return c.prevParticipation
+}
+
+// score returns the proposer reward numerator of the flags the candidate would newly set, as in
+// altair.EpochParticipation.
+func (c *attRewardCalculator) score(cand *rewardCandidate) uint64 {
+	participation := c.participation(cand)
+	var reward uint64
+	for i, idx := range cand.indices {
+		if idx >= uint64(len(participation)) {
+			continue
+		}
+		newFlags := cand.flags &^ participation[idx]
+		if newFlags == 0 {
+			continue
+		}
+		for _, fw := range c.flagWeights {
+			if newFlags&(1<<fw.flag) != 0 {
+				reward += cand.baseRewards[i] * fw.weight
+			}
+		}
+	}
+	return reward
+}
+
+// include marks the flags of the candidate as set for its attesters.
+func (c *attRewardCalculator) include(cand *rewardCandidate) {
+	participation := c.participation(cand)
+	for _, idx := range cand.indices {
+		if idx < uint64(len(participation)) {
+			participation[idx] |= cand.flags
+		}
+	}
+}
+
+// merge aggregates two candidates attesting to the same data, when their attesters do not overlap.
+// As their attesters are disjoint, the reward of the aggregate is the sum of their rewards.
+func (c *attRewardCalculator) merge(a, b *rewardCandidate) (*rewardCandidate, bool) {
+	overlaps, err := a.att.AggregationBits.Overlaps(b.att.AggregationBits)
+	if err != nil || overlaps {
+		return nil, false
+	}
+	att, err := aggregatePair(a.att, b.att)
+	if err != nil {
+		log.WithError(err).WithField("slot", a.att.Data.Slot).Debug("Could not aggregate attestations")
+		return nil, false
+	}
+	indices := make([]uint64, 0, len(a.indices)+len(b.indices))
+	indices = append(append(indices, a.indices...), b.indices...)
+	baseRewards := make([]uint64, 0, len(a.baseRewards)+len(b.baseRewards))
+	baseRewards = append(append(baseRewards, a.baseRewards...), b.baseRewards...)
+	return &rewardCandidate{
+		att:          att,
+		indices:      indices,
+		baseRewards:  baseRewards,
+		flags:        a.flags,
+		currentEpoch: a.currentEpoch,
+		reward:       a.reward + b.reward,
+	}, true
+}
+
+// reaggregate merges the candidates attesting to the same data whose attesters do not overlap,
+// whether they come from the pool aggregates or from the aggregates built on the fly out of
+// unaggregated attestations, so that they take a single spot in the block. Candidates are merged
+// by decreasing reward, each into the first aggregate of the same data it does not overlap. The
+// candidates left when the deadline passes are kept as they are.
+func (c *attRewardCalculator) reaggregate(ctx context.Context, candidates rewardCandidates, deadline time.Time) (rewardCandidates, error) {
+	sort.Sort(candidates)
+	merged := make(rewardCandidates, 0, len(candidates))
+	byDataRoot := make(map[[32]byte][]int, len(candidates))
+	for i, cand := range candidates {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if time.Now().After(deadline) {
+			return append(merged, candidates[i:]...), nil
+		}
+		dataRoot, err := cand.att.Data.HashTreeRoot()
+		if err != nil {
+			return nil, err
+		}
+		aggregated := false
+		for _, j := range byDataRoot[dataRoot] {
+			if agg, ok := c.merge(merged[j], cand); ok {
+				merged[j] = agg
+				aggregated = true
+				break
+			}
+		}
+		if !aggregated {
+			byDataRoot[dataRoot] = append(byDataRoot[dataRoot], len(merged))
+			merged = append(merged, cand)
+		}
+	}
+	return merged, nil
+}
+
+// proposerReward converts a proposer reward numerator to gwei, as in altair.RewardProposer.
+func proposerReward(numerator uint64) uint64 {
+	cfg := params.BeaconConfig()
+	return numerator / ((cfg.WeightDenominator - cfg.ProposerWeight) * cfg.WeightDenominator / cfg.ProposerWeight)
+}
+
+// sortByReward orders attestations by the proposer reward they are worth when included in a block
+// on top of the given state, greedily selecting the attestation that newly sets the most valuable
+// participation flags given the ones selected before it. This is a weighted max-cover over
+// (validator, flag) pairs, evaluated lazily: as rewards only decrease when attestations get
+// selected, a candidate whose refreshed reward is still the highest is the best one.
+// Attestations worth nothing, and the ones left over when the deadline passes, are appended by
+// their last known reward. Attestations whose reward can not be computed are left out.
+//
+// Before selecting, the candidates with the same attestation data are re-aggregated by reward. The
+// attestations not scored yet when the deadline passes are appended last, in their given order.
+func (a proposerAtts) sortByReward(ctx context.Context, st state.BeaconState, deadline time.Time) (proposerAtts, error) {
+	ctx, span := trace.StartSpan(ctx, "ProposerServer.sortByReward")
+	defer span.End()
+
+	if len(a) == 0 {
+		return a, nil
+	}
+	calc, err := newAttRewardCalculator(st)
+	if err != nil {
+		return nil, err
+	}
+	candidates := make(rewardCandidates, 0, len(a))
+	var unscored proposerAtts
+	for i, att := range a {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if time.Now().After(deadline) {
+			log.WithField("unscored", len(a)-i).Warn("Attestation packing time budget exceeded before scoring all attestations")
+			unscored = a[i:]
+			break
+		}
+		cand, err := calc.candidate(ctx, att)
+		if err != nil {
+			log.WithError(err).WithField("slot", att.Data.Slot).Debug("Could not compute proposer reward of attestation, leaving it out")
+			continue
+		}
+		cand.reward = calc.score(cand)
+		candidates = append(candidates, cand)
+	}
+	candidates, err = calc.reaggregate(ctx, candidates, deadline)
+	if err != nil {
+		return nil, err
+	}
+	heap.Init(&candidates)
+
+	maxAtts := int(params.BeaconConfig().MaxAttestations)
+	sorted := make(proposerAtts, 0, len(a))
+	var totalReward uint64
+	for candidates.Len() > 0 && len(sorted) < maxAtts {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if time.Now().After(deadline) {
+			log.WithField("selected", len(sorted)).Warn("Attestation packing time budget exceeded, filling the block by last known reward")
+			break
+		}
+		best := heap.Pop(&candidates).(*rewardCandidate)
+		best.reward = calc.score(best)
+		if candidates.Len() > 0 && best.reward < candidates[0].reward {
+			// The reward of another candidate may be higher now, score it first.
+			heap.Push(&candidates, best)
+			continue
+		}
+		if best.reward == 0 {
+			// No candidate can be worth more than the best one.
+			heap.Push(&candidates, best)
+			break
+		}
+		calc.include(best)
+		totalReward += best.reward
+		sorted = append(sorted, best.att)
+	}
+	span.AddAttributes(trace.Int64Attribute("proposerReward", int64(proposerReward(totalReward))))
+
+	sort.Sort(candidates)
+	for _, cand := range candidates {
+		sorted = append(sorted, cand.att)
+	}
+	return append(sorted, unscored...), nil
+}
@@ -0,0 +1,307 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/crypto/rand"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	attaggregation "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/attestation/aggregation/attestations"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+	"github.com/prysmaticlabs/prysm/time/slots"
+)
+
+// rewardPackingState returns an altair state at the given slot. Validators with an even index have
+// the maximum effective balance, the others the minimum one to be active.
+func rewardPackingState(t testing.TB, numValidators uint64, slot types.Slot) state.BeaconState {
+	helpers.ClearCache()
+	cfg := params.BeaconConfig()
+	st, err := util.NewBeaconStateAltair(func(s *ethpb.BeaconStateAltair) error {
+		s.Slot = slot
+		s.Validators = make([]*ethpb.Validator, numValidators)
+		s.Balances = make([]uint64, numValidators)
+		for i := range s.Validators {
+			balance := cfg.MaxEffectiveBalance
+			if i%2 == 1 {
+				balance = cfg.EjectionBalance + cfg.EffectiveBalanceIncrement
+			}
+			s.Validators[i] = &ethpb.Validator{
+				PublicKey:             make([]byte, 48),
+				WithdrawalCredentials: make([]byte, 32),
+				EffectiveBalance:      balance,
+				ExitEpoch:             cfg.FarFutureEpoch,
+				WithdrawableEpoch:     cfg.FarFutureEpoch,
+			}
+			s.Balances[i] = balance
+		}
+		s.CurrentEpochParticipation = make([]byte, numValidators)
+		s.PreviousEpochParticipation = make([]byte, numValidators)
+		return nil
+	})
+	require.NoError(t, err)
+	return st
+}
+
+// rewardPackingAtt returns an attestation of the committee at the slot, from the members at the given
+// positions of the committee.
+func rewardPackingAtt(t testing.TB, st state.BeaconState, slot types.Slot, committeeIndex types.CommitteeIndex, positions []int) *ethpb.Attestation {
+	committee, err := helpers.BeaconCommitteeFromState(context.Background(), st, slot, committeeIndex)
+	require.NoError(t, err)
+	bits := bitfield.NewBitlist(uint64(len(committee)))
+	for _, p := range positions {
+		bits.SetBitAt(uint64(p), true)
+	}
+	return util.NewAttestationUtil().HydrateAttestation(&ethpb.Attestation{
+		AggregationBits: bits,
+		Data: &ethpb.AttestationData{
+			Slot:           slot,
+			CommitteeIndex: committeeIndex,
+			Target:         &ethpb.Checkpoint{Epoch: slots.ToEpoch(slot)},
+		},
+	})
+}
+
+// committeePositions returns the first n positions in the committee of validators with an even index
+// if even is true, or with an odd index otherwise.
+func committeePositions(t testing.TB, st state.BeaconState, slot types.Slot, even bool, n int) []int {
+	committee, err := helpers.BeaconCommitteeFromState(context.Background(), st, slot, 0)
+	require.NoError(t, err)
+	var positions []int
+	for i, idx := range committee {
+		if (idx%2 == 0) == even && len(positions) < n {
+			positions = append(positions, i)
+		}
+	}
+	require.Equal(t, n, len(positions))
+	return positions
+}
+
+// packedProposerReward returns the proposer reward of including the attestations in a block, in gwei.
+func packedProposerReward(t testing.TB, st state.BeaconState, atts proposerAtts) uint64 {
+	calc, err := newAttRewardCalculator(st)
+	require.NoError(t, err)
+	var numerator uint64
+	for _, att := range atts.limitToMaxAttestations() {
+		cand, err := calc.candidate(context.Background(), att)
+		require.NoError(t, err)
+		numerator += calc.score(cand)
+		calc.include(cand)
+	}
+	return proposerReward(numerator)
+}
+
+// aggregateBitsOnly substitutes aggregatePair in tests, as the attestations built in tests have no
+// valid signatures to aggregate.
+func aggregateBitsOnly(a1, a2 *ethpb.Attestation) (*ethpb.Attestation, error) {
+	bits, err := a1.AggregationBits.Or(a2.AggregationBits)
+	if err != nil {
+		return nil, err
+	}
+	att := ethpb.CopyAttestation(a1)
+	att.AggregationBits = bits
+	return att, nil
+}
+
+func TestProposer_ProposerAtts_sortByReward(t *testing.T) {
+	ctx := context.Background()
+	aggregatePair = aggregateBitsOnly
+	defer func() {
+		aggregatePair = attaggregation.AggregatePair
+	}()
+	slot := params.BeaconConfig().SlotsPerEpoch + 2
+	attSlot := slot - 1
+
+	t.Run("effective balance", func(t *testing.T) {
+		st := rewardPackingState(t, 2048, slot)
+		// More attesters, with a lower effective balance. The attestations overlap, so that they are
+		// not re-aggregated.
+		highPositions := committeePositions(t, st, attSlot, true, 4)
+		low := rewardPackingAtt(t, st, attSlot, 0, append(committeePositions(t, st, attSlot, false, 5), highPositions[0]))
+		high := rewardPackingAtt(t, st, attSlot, 0, highPositions)
+		sorted, err := proposerAtts{low, high}.sortByReward(ctx, st, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		assert.DeepEqual(t, proposerAtts{high, low}, sorted)
+	})
+
+	t.Run("flags already set in state", func(t *testing.T) {
+		st := rewardPackingState(t, 2048, slot)
+		included := committeePositions(t, st, attSlot, true, 8)
+		committee, err := helpers.BeaconCommitteeFromState(ctx, st, attSlot, 0)
+		require.NoError(t, err)
+		participation, err := st.CurrentEpochParticipation()
+		require.NoError(t, err)
+		for _, p := range included {
+			participation[committee[p]] = 0b111
+		}
+		require.NoError(t, st.SetCurrentParticipationBits(participation))
+
+		seen := rewardPackingAtt(t, st, attSlot, 0, included)
+		unseen := rewardPackingAtt(t, st, attSlot, 0, committeePositions(t, st, attSlot, true, 10)[7:])
+		sorted, err := proposerAtts{seen, unseen}.sortByReward(ctx, st, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		assert.DeepEqual(t, proposerAtts{unseen, seen}, sorted)
+	})
+
+	t.Run("overlapping attesters counted once", func(t *testing.T) {
+		st := rewardPackingState(t, 2048, slot)
+		positions := committeePositions(t, st, attSlot, true, 16)
+		first := rewardPackingAtt(t, st, attSlot, 0, positions[:8])
+		overlapping := rewardPackingAtt(t, st, attSlot, 0, positions[1:8])
+		// Attestations for other data are not re-aggregated with the first one.
+		disjoint := rewardPackingAtt(t, st, attSlot, 0, positions[8:12])
+		disjoint.Data.BeaconBlockRoot = bytesOf(0xaa)
+		sorted, err := proposerAtts{overlapping, disjoint, first}.sortByReward(ctx, st, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		assert.DeepEqual(t, proposerAtts{first, disjoint, overlapping}, sorted)
+	})
+
+	t.Run("re-aggregated", func(t *testing.T) {
+		st := rewardPackingState(t, 2048, slot)
+		positions := committeePositions(t, st, attSlot, true, 16)
+		// An aggregate from the pool, an aggregate built from unaggregated attestations, and an
+		// aggregate overlapping the first one.
+		pooled := rewardPackingAtt(t, st, attSlot, 0, positions[:8])
+		onTheFly := rewardPackingAtt(t, st, attSlot, 0, positions[8:12])
+		overlapping := rewardPackingAtt(t, st, attSlot, 0, positions[4:10])
+		sorted, err := proposerAtts{onTheFly, overlapping, pooled}.sortByReward(ctx, st, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		require.Equal(t, 2, len(sorted))
+		assert.DeepEqual(t, rewardPackingAtt(t, st, attSlot, 0, positions[:12]).AggregationBits, sorted[0].AggregationBits)
+		assert.DeepEqual(t, overlapping, sorted[1])
+	})
+
+	t.Run("wrong head", func(t *testing.T) {
+		st := rewardPackingState(t, 2048, slot)
+		positions := committeePositions(t, st, attSlot, true, 8)
+		wrongHead := rewardPackingAtt(t, st, attSlot, 0, positions)
+		wrongHead.Data.BeaconBlockRoot = bytesOf(0xaa)
+		rightHead := rewardPackingAtt(t, st, attSlot, 0, positions[:7])
+		sorted, err := proposerAtts{wrongHead, rightHead}.sortByReward(ctx, st, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		assert.DeepEqual(t, proposerAtts{rightHead, wrongHead}, sorted)
+	})
+
+	t.Run("unscorable attestation left out", func(t *testing.T) {
+		st := rewardPackingState(t, 2048, slot)
+		positions := committeePositions(t, st, attSlot, true, 8)
+		scorable := rewardPackingAtt(t, st, attSlot, 0, positions)
+		// An attestation from the proposal slot can not be included in the block.
+		unscorable := rewardPackingAtt(t, st, attSlot, 0, positions)
+		unscorable.Data.Slot = slot
+		sorted, err := proposerAtts{unscorable, scorable}.sortByReward(ctx, st, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		assert.DeepEqual(t, proposerAtts{scorable}, sorted)
+	})
+
+	t.Run("deadline passed", func(t *testing.T) {
+		st := rewardPackingState(t, 2048, slot)
+		positions := committeePositions(t, st, attSlot, true, 16)
+		first := rewardPackingAtt(t, st, attSlot, 0, positions[:8])
+		overlapping := rewardPackingAtt(t, st, attSlot, 0, positions[1:8])
+		disjoint := rewardPackingAtt(t, st, attSlot, 0, positions[8:12])
+		// Attestations not scored before the deadline are kept in their given order.
+		sorted, err := proposerAtts{overlapping, disjoint, first}.sortByReward(ctx, st, time.Now().Add(-time.Second))
+		require.NoError(t, err)
+		assert.DeepEqual(t, proposerAtts{overlapping, disjoint, first}, sorted)
+	})
+}
+
+func TestProposer_ProposerAtts_sortByReward_AtLeastMaxCover(t *testing.T) {
+	st, atts := rewardPackingCandidates(t, 16384, 3)
+	legacy, err := atts.sortByProfitability()
+	require.NoError(t, err)
+	weighted, err := atts.sortByReward(context.Background(), st, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, len(atts), len(weighted))
+	legacyReward := packedProposerReward(t, st, legacy)
+	weightedReward := packedProposerReward(t, st, weighted)
+	assert.Equal(t, true, weightedReward >= legacyReward, "reward weighted packing earned %d gwei, max-cover packing %d gwei", weightedReward, legacyReward)
+}
+
+// rewardPackingCandidates returns a state in the middle of an epoch, and overlapping aggregates for
+// every committee since the start of the previous epoch, as found in the pool of a node. It uses the
+// mainnet config, so that there are more aggregates than fit in a block. Half of the attesters of the
+// previous epoch are already included in blocks, and some aggregates vote for the wrong head.
+func rewardPackingCandidates(t testing.TB, numValidators uint64, aggregatesPerCommittee int) (state.BeaconState, proposerAtts) {
+	params.SetupTestConfigCleanup(t)
+	params.OverrideBeaconConfig(params.MainnetConfig().Copy())
+	ctx := context.Background()
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	st := rewardPackingState(t, numValidators, 2*slotsPerEpoch+slotsPerEpoch/2)
+	gen := rand.NewDeterministicGenerator()
+
+	committeesPerSlot := helpers.SlotCommitteeCount(uint64(len(st.Validators())))
+	participation, err := st.PreviousEpochParticipation()
+	require.NoError(t, err)
+	var atts proposerAtts
+	for slot := slotsPerEpoch; slot < st.Slot(); slot++ {
+		for ci := types.CommitteeIndex(0); uint64(ci) < committeesPerSlot; ci++ {
+			committee, err := helpers.BeaconCommitteeFromState(ctx, st, slot, ci)
+			require.NoError(t, err)
+			if slot < 2*slotsPerEpoch {
+				for _, idx := range committee[:len(committee)/2] {
+					participation[idx] = 0b111
+				}
+			}
+			for a := 0; a < aggregatesPerCommittee; a++ {
+				var positions []int
+				for p := range committee {
+					if gen.Intn(100) < 70 {
+						positions = append(positions, p)
+					}
+				}
+				att := rewardPackingAtt(t, st, slot, ci, positions)
+				if gen.Intn(4) == 0 {
+					att.Data.BeaconBlockRoot = bytesOf(0xaa)
+				}
+				atts = append(atts, att)
+			}
+		}
+	}
+	require.Equal(t, true, uint64(len(atts)) > params.BeaconConfig().MaxAttestations)
+	require.NoError(t, st.SetPreviousParticipationBits(participation))
+	return st, atts
+}
+
+func BenchmarkProposer_PackAttestations(b *testing.B) {
+	for _, numValidators := range []uint64{16384, 65536} {
+		st, atts := rewardPackingCandidates(b, numValidators, 3)
+		b.Run(fmt.Sprintf("max_cover_%d_validators", numValidators), func(b *testing.B) {
+			var packed proposerAtts
+			for i := 0; i < b.N; i++ {
+				var err error
+				packed, err = atts.sortByProfitability()
+				require.NoError(b, err)
+			}
+			b.StopTimer()
+			b.ReportMetric(float64(packedProposerReward(b, st, packed)), "gwei/block")
+		})
+		b.Run(fmt.Sprintf("reward_weighted_%d_validators", numValidators), func(b *testing.B) {
+			var packed proposerAtts
+			for i := 0; i < b.N; i++ {
+				var err error
+				packed, err = atts.sortByReward(context.Background(), st, time.Now().Add(time.Minute))
+				require.NoError(b, err)
+			}
+			b.StopTimer()
+			b.ReportMetric(float64(packedProposerReward(b, st, packed)), "gwei/block")
+		})
+	}
+}
+
+func bytesOf(b byte) []byte {
+	r := make([]byte, 32)
+	for i := range r {
+		r[i] = b
+	}
+	return r
+}
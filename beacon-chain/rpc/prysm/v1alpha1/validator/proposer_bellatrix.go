@@ -33,7 +33,7 @@ func (vs *Server) getBellatrixBeaconBlock(ctx context.Context, req *ethpb.BlockR
 		return b, nil
 	}
 
-	payload, err := vs.getExecutionPayload(ctx, req.Slot, altairBlk.ProposerIndex)
+	payload, err := vs.getExecutionPayload(ctx, req.Slot, altairBlk.ProposerIndex, bytesutil.ToBytes32(altairBlk.ParentRoot))
 	if err != nil {
 		return nil, err
 	}
@@ -72,16 +72,28 @@ func (vs *Server) getBellatrixBeaconBlock(ctx context.Context, req *ethpb.BlockR
 	return &ethpb.GenericBeaconBlock{Block: &ethpb.GenericBeaconBlock_Bellatrix{Bellatrix: blk}}, nil
 }
 
-// This function retrieves the payload header given the slot number and the validator index.
-// It's a no-op if the latest head block is not versioned bellatrix.
-func (vs *Server) getPayloadHeader(ctx context.Context, slot types.Slot, idx types.ValidatorIndex) (*enginev1.ExecutionPayloadHeader, error) {
+// This function retrieves the payload header given the slot number, the validator index and the parent block root.
+// It's a no-op if the parent block is not versioned bellatrix.
+func (vs *Server) getPayloadHeader(ctx context.Context, slot types.Slot, idx types.ValidatorIndex, parentRoot [32]byte) (*enginev1.ExecutionPayloadHeader, error) {
 	if err := vs.BlockBuilder.Status(); err != nil {
 		return nil, err
 	}
-	b, err := vs.HeadFetcher.HeadBlock(ctx)
+	onHead, err := vs.buildsOnHead(ctx, parentRoot)
 	if err != nil {
 		return nil, err
 	}
+	var b interfaces.SignedBeaconBlock
+	if onHead {
+		b, err = vs.HeadFetcher.HeadBlock(ctx)
+	} else {
+		b, err = vs.BeaconDB.Block(ctx, parentRoot)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := coreBlock.BeaconBlockIsNil(b); err != nil {
+		return nil, err
+	}
 	if blocks.IsPreBellatrixVersion(b.Version()) {
 		return nil, nil
 	}
@@ -264,7 +276,7 @@ func (vs *Server) getAndBuildHeaderBlock(ctx context.Context, b *ethpb.BeaconBlo
 	if !ready {
 		return false, nil, nil
 	}
-	h, err := vs.getPayloadHeader(ctx, b.Slot, b.ProposerIndex)
+	h, err := vs.getPayloadHeader(ctx, b.Slot, b.ProposerIndex, bytesutil.ToBytes32(b.ParentRoot))
 	if err != nil {
 		return false, nil, errors.Wrap(err, "could not get payload header")
 	}
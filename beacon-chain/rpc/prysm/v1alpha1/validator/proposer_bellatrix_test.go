@@ -13,7 +13,10 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/altair"
 	b "github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/transition"
 	dbTest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	doublylinkedtree "github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/doubly-linked-tree"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/synccommittee"
@@ -21,6 +24,7 @@ import (
 	mockPOW "github.com/prysmaticlabs/prysm/beacon-chain/powchain/testing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
 	mockSync "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync/testing"
+	"github.com/prysmaticlabs/prysm/config/features"
 	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/config/params"
 	"github.com/prysmaticlabs/prysm/consensus-types/interfaces"
@@ -154,7 +158,7 @@ func TestServer_getPayloadHeader(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			vs := &Server{BlockBuilder: tc.mock, HeadFetcher: tc.fetcher}
-			h, err := vs.getPayloadHeader(context.Background(), 0, 0)
+			h, err := vs.getPayloadHeader(context.Background(), 0, 0, [32]byte{})
 			if err != nil {
 				require.ErrorContains(t, tc.err, err)
 			} else {
@@ -494,6 +498,113 @@ func TestServer_GetBellatrixBeaconBlock_HappyCase(t *testing.T) {
 	require.DeepEqual(t, emptyPayload, bellatrixBlk.Bellatrix.Body.ExecutionPayload) // Payload should equal.
 }
 
+// fcuRecordingEngine counts the forkchoice updates sent to the execution engine.
+type fcuRecordingEngine struct {
+	*mockPOW.EngineClient
+	fcuCalls int
+}
+
+func (e *fcuRecordingEngine) ForkchoiceUpdated(
+	ctx context.Context, fcs *v1.ForkchoiceState, attrs *v1.PayloadAttributes,
+) (*v1.PayloadIDBytes, []byte, error) {
+	e.fcuCalls++
+	return e.EngineClient.ForkchoiceUpdated(ctx, fcs, attrs)
+}
+
+func TestServer_GetBellatrixBeaconBlock_ReorgLateBlock(t *testing.T) {
+	resetCfg := features.InitWithReset(&features.Flags{EnableReorgLateBlocks: true})
+	defer resetCfg()
+
+	db := dbTest.SetupDB(t)
+	ctx := context.Background()
+
+	terminalBlockHash := bytesutil.PadTo([]byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF,
+		0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}, 32)
+	params.SetupTestConfigCleanup(t)
+	cfg := params.MainnetConfig().Copy()
+	cfg.BellatrixForkEpoch = 2
+	cfg.AltairForkEpoch = 1
+	cfg.TerminalBlockHash = common.BytesToHash(terminalBlockHash)
+	cfg.TerminalBlockHashActivationEpoch = 2
+	params.OverrideBeaconConfig(cfg)
+
+	beaconState, privKeys := util.DeterministicGenesisState(t, 64)
+	stateRoot, err := beaconState.HashTreeRoot(ctx)
+	require.NoError(t, err, "Could not hash genesis state")
+
+	genesis := b.NewGenesisBlock(stateRoot[:])
+	wsb, err := wrapper.WrappedSignedBeaconBlock(genesis)
+	require.NoError(t, err)
+	require.NoError(t, db.SaveBlock(ctx, wsb), "Could not save genesis block")
+
+	parentRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err, "Could not get signing root")
+	require.NoError(t, db.SaveState(ctx, beaconState, parentRoot), "Could not save genesis state")
+
+	bellatrixSlot, err := slots.EpochStart(params.BeaconConfig().BellatrixForkEpoch)
+	require.NoError(t, err)
+	req := &ethpb.BlockRequest{Slot: bellatrixSlot + 1}
+	req.RandaoReveal, err = util.RandaoReveal(beaconState, 0, privKeys)
+	require.NoError(t, err)
+
+	parentState, err := transition.ProcessSlots(ctx, beaconState.Copy(), req.Slot)
+	require.NoError(t, err)
+	proposerIdx, err := helpers.BeaconProposerIndex(ctx, parentState)
+	require.NoError(t, err)
+
+	// The late head block is past the requested slot, its state can not be used to build the proposal.
+	headState := beaconState.Copy()
+	require.NoError(t, headState.SetSlot(req.Slot+1))
+	headRoot := [32]byte{'h'}
+
+	emptyPayload := &v1.ExecutionPayload{
+		ParentHash:    make([]byte, fieldparams.RootLength),
+		FeeRecipient:  make([]byte, fieldparams.FeeRecipientLength),
+		StateRoot:     make([]byte, fieldparams.RootLength),
+		ReceiptsRoot:  make([]byte, fieldparams.RootLength),
+		LogsBloom:     make([]byte, fieldparams.LogsBloomLength),
+		PrevRandao:    make([]byte, fieldparams.RootLength),
+		BaseFeePerGas: make([]byte, fieldparams.RootLength),
+		BlockHash:     make([]byte, fieldparams.RootLength),
+	}
+	engine := &fcuRecordingEngine{EngineClient: &mockPOW.EngineClient{
+		PayloadIDBytes:   &v1.PayloadIDBytes{1},
+		ExecutionPayload: emptyPayload,
+	}}
+	proposerServer := &Server{
+		HeadFetcher:            &blockchainTest.ChainService{State: headState, Root: headRoot[:]},
+		ForkFetcher:            &blockchainTest.ChainService{ForkChoiceStore: &reorgForkChoicer{ForkChoicer: doublylinkedtree.New(), proposerHead: parentRoot}},
+		TimeFetcher:            &blockchainTest.ChainService{Genesis: time.Now()},
+		SyncChecker:            &mockSync.Sync{IsSyncing: false},
+		BlockReceiver:          &blockchainTest.ChainService{},
+		HeadUpdater:            &blockchainTest.ChainService{},
+		ChainStartFetcher:      &mockPOW.POWChain{},
+		Eth1InfoFetcher:        &mockPOW.POWChain{},
+		Eth1BlockFetcher:       &mockPOW.POWChain{HashesByHeight: map[int][]byte{1: terminalBlockHash}},
+		MockEth1Votes:          true,
+		AttPool:                attestations.NewPool(),
+		SlashingsPool:          slashings.NewPool(),
+		ExitPool:               voluntaryexits.NewPool(),
+		StateGen:               stategen.New(db),
+		SyncCommitteePool:      synccommittee.NewStore(),
+		ExecutionEngineCaller:  engine,
+		BeaconDB:               db,
+		ProposerSlotIndexCache: cache.NewProposerPayloadIDsCache(),
+		BlockBuilder:           &builderTest.MockBuilderService{},
+	}
+	// The cached payload ID was prepared on top of the late head block.
+	proposerServer.ProposerSlotIndexCache.SetProposerAndPayloadIDs(req.Slot, proposerIdx, [8]byte{'a'})
+
+	block, err := proposerServer.getBellatrixBeaconBlock(ctx, req)
+	require.NoError(t, err)
+	bellatrixBlk, ok := block.GetBlock().(*ethpb.GenericBeaconBlock_Bellatrix)
+	require.Equal(t, true, ok)
+	require.DeepEqual(t, parentRoot[:], bellatrixBlk.Bellatrix.ParentRoot)
+	require.Equal(t, proposerIdx, bellatrixBlk.Bellatrix.ProposerIndex)
+	require.Equal(t, 1, engine.fcuCalls, "Expected a new payload to be prepared on the parent block")
+	require.DeepEqual(t, emptyPayload, bellatrixBlk.Bellatrix.Body.ExecutionPayload)
+}
+
 func TestServer_GetBellatrixBeaconBlock_BuilderCase(t *testing.T) {
 	db := dbTest.SetupDB(t)
 	ctx := context.Background()
@@ -13,6 +13,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/time"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/config/params"
 	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
@@ -37,11 +38,16 @@ var (
 	})
 )
 
-// This returns the execution payload of a given slot. The function has full awareness of pre and post merge.
-// The payload is computed given the respected time of merge.
-func (vs *Server) getExecutionPayload(ctx context.Context, slot types.Slot, vIdx types.ValidatorIndex) (*enginev1.ExecutionPayload, error) {
+// This returns the execution payload of a given slot built on top of the given parent block root. The function has
+// full awareness of pre and post merge. The payload is computed given the respected time of merge.
+func (vs *Server) getExecutionPayload(ctx context.Context, slot types.Slot, vIdx types.ValidatorIndex, parentRoot [32]byte) (*enginev1.ExecutionPayload, error) {
+	onHead, err := vs.buildsOnHead(ctx, parentRoot)
+	if err != nil {
+		return nil, err
+	}
+	// The cached payload ID was prepared on top of the head, which a late block re-org does not build on.
 	proposerID, payloadId, ok := vs.ProposerSlotIndexCache.GetProposerPayloadIDs(slot)
-	if ok && proposerID == vIdx && payloadId != [8]byte{} { // Payload ID is cache hit. Return the cached payload ID.
+	if onHead && ok && proposerID == vIdx && payloadId != [8]byte{} { // Payload ID is cache hit. Return the cached payload ID.
 		var pid [8]byte
 		copy(pid[:], payloadId[:])
 		payloadIDCacheHit.Inc()
@@ -49,7 +55,12 @@ func (vs *Server) getExecutionPayload(ctx context.Context, slot types.Slot, vIdx
 	}
 	payloadIDCacheMiss.Inc()
 
-	st, err := vs.HeadFetcher.HeadState(ctx)
+	var st state.BeaconState
+	if onHead {
+		st, err = vs.HeadFetcher.HeadState(ctx)
+	} else {
+		st, err = vs.StateGen.StateByRoot(ctx, parentRoot)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -12,6 +12,7 @@ import (
 	dbTest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
 	powtesting "github.com/prysmaticlabs/prysm/beacon-chain/powchain/testing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
 	"github.com/prysmaticlabs/prysm/config/params"
 	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
@@ -121,7 +122,7 @@ func TestServer_getExecutionPayload(t *testing.T) {
 				ProposerSlotIndexCache: cache.NewProposerPayloadIDsCache(),
 			}
 			vs.ProposerSlotIndexCache.SetProposerAndPayloadIDs(tt.st.Slot(), 100, [8]byte{100})
-			_, err := vs.getExecutionPayload(context.Background(), tt.st.Slot(), tt.validatorIndx)
+			_, err := vs.getExecutionPayload(context.Background(), tt.st.Slot(), tt.validatorIndx, [32]byte{})
 			if tt.errString != "" {
 				require.ErrorContains(t, tt.errString, err)
 			} else {
@@ -131,6 +132,39 @@ func TestServer_getExecutionPayload(t *testing.T) {
 	}
 }
 
+func TestServer_getExecutionPayload_ReorgLateBlock(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbTest.SetupDB(t)
+	finalizedBlk := util.NewBeaconBlockBellatrix()
+	finalizedRoot, err := finalizedBlk.Block.HashTreeRoot()
+	require.NoError(t, err)
+	util.SaveBlock(t, ctx, beaconDB, finalizedBlk)
+
+	st, err := util.NewBeaconStateBellatrix()
+	require.NoError(t, err)
+	require.NoError(t, st.SetLatestExecutionPayloadHeader(&pb.ExecutionPayloadHeader{BlockNumber: 1}))
+	require.NoError(t, st.SetFinalizedCheckpoint(&ethpb.Checkpoint{Root: finalizedRoot[:]}))
+	parentRoot := [32]byte{'a'}
+	headRoot := [32]byte{'b'}
+	vs := &Server{
+		ExecutionEngineCaller:  &powtesting.EngineClient{ExecutionPayload: emptyPayload()},
+		HeadFetcher:            &chainMock.ChainService{State: st, Root: headRoot[:]},
+		StateGen:               stategen.New(beaconDB),
+		BeaconDB:               beaconDB,
+		ProposerSlotIndexCache: cache.NewProposerPayloadIDsCache(),
+	}
+	require.NoError(t, vs.StateGen.SaveState(ctx, parentRoot, st))
+	vs.ProposerSlotIndexCache.SetProposerAndPayloadIDs(st.Slot(), 100, [8]byte{100})
+
+	// The cached payload ID is used when building on the head.
+	_, err = vs.getExecutionPayload(ctx, st.Slot(), 100, headRoot)
+	require.NoError(t, err)
+
+	// The cached payload ID was prepared on top of the head, a new one is requested for the parent.
+	_, err = vs.getExecutionPayload(ctx, st.Slot(), 100, parentRoot)
+	require.ErrorContains(t, "nil payload id", err)
+}
+
 func TestServer_getExecutionPayload_UnexpectedFeeRecipient(t *testing.T) {
 	hook := logTest.NewGlobal()
 	beaconDB := dbTest.SetupDB(t)
@@ -170,7 +204,7 @@ func TestServer_getExecutionPayload_UnexpectedFeeRecipient(t *testing.T) {
 		BeaconDB:               beaconDB,
 		ProposerSlotIndexCache: cache.NewProposerPayloadIDsCache(),
 	}
-	gotPayload, err := vs.getExecutionPayload(context.Background(), transitionSt.Slot(), 0)
+	gotPayload, err := vs.getExecutionPayload(context.Background(), transitionSt.Slot(), 0, [32]byte{})
 	require.NoError(t, err)
 	require.NotNil(t, gotPayload)
 
@@ -182,7 +216,7 @@ func TestServer_getExecutionPayload_UnexpectedFeeRecipient(t *testing.T) {
 	payload.FeeRecipient = evilRecipientAddress[:]
 	vs.ProposerSlotIndexCache = cache.NewProposerPayloadIDsCache()
 
-	gotPayload, err = vs.getExecutionPayload(context.Background(), transitionSt.Slot(), 0)
+	gotPayload, err = vs.getExecutionPayload(context.Background(), transitionSt.Slot(), 0, [32]byte{})
 	require.NoError(t, err)
 	require.NotNil(t, gotPayload)
 
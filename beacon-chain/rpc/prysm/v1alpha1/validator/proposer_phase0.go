@@ -10,11 +10,13 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/transition/interop"
 	v "github.com/prysmaticlabs/prysm/beacon-chain/core/validators"
+	"github.com/prysmaticlabs/prysm/config/features"
 	"github.com/prysmaticlabs/prysm/config/params"
 	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/consensus-types/wrapper"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
 )
 
@@ -31,6 +33,16 @@ type blockData struct {
 	VoluntaryExits    []*ethpb.SignedVoluntaryExit
 }
 
+// buildsOnHead returns true if a proposal with the given parent root builds on the current head. This is
+// not the case when the proposer re-orgs a late head block.
+func (vs *Server) buildsOnHead(ctx context.Context, parentRoot [32]byte) (bool, error) {
+	headRoot, err := vs.HeadFetcher.HeadRoot(ctx)
+	if err != nil {
+		return false, err
+	}
+	return parentRoot == bytesutil.ToBytes32(headRoot), nil
+}
+
 func (vs *Server) getPhase0BeaconBlock(ctx context.Context, req *ethpb.BlockRequest) (*ethpb.BeaconBlock, error) {
 	ctx, span := trace.StartSpan(ctx, "ProposerServer.getPhase0BeaconBlock")
 	defer span.End()
@@ -97,6 +109,23 @@ func (vs *Server) buildPhase0BlockData(ctx context.Context, req *ethpb.BlockRequ
 		return nil, fmt.Errorf("could not get head state %v", err)
 	}
 
+	// A late and weak head block may be re-orged out by building on its parent instead.
+	if features.Get().EnableReorgLateBlocks {
+		proposerHead := vs.ForkFetcher.ForkChoicer().ProposerHead(req.Slot)
+		if proposerHead != [32]byte{} && proposerHead != bytesutil.ToBytes32(parentRoot) {
+			head, err = vs.StateGen.StateByRoot(ctx, proposerHead)
+			if err != nil {
+				return nil, fmt.Errorf("could not get proposer head state %v", err)
+			}
+			log.WithFields(logrus.Fields{
+				"slot":       req.Slot,
+				"headRoot":   fmt.Sprintf("%#x", bytesutil.Trunc(parentRoot)),
+				"parentRoot": fmt.Sprintf("%#x", bytesutil.Trunc(proposerHead[:])),
+			}).Info("Building on the parent of a late head block")
+			parentRoot = proposerHead[:]
+		}
+	}
+
 	head, err = transition.ProcessSlotsUsingNextSlotCache(ctx, head, parentRoot, req.Slot)
 	if err != nil {
 		return nil, fmt.Errorf("could not advance slots to calculate proposer index: %v", err)
@@ -14,10 +14,15 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache/depositcache"
 	b "github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	opfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/operation"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/signing"
 	coretime "github.com/prysmaticlabs/prysm/beacon-chain/core/time"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/transition"
 	dbutil "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice"
+	doublylinkedtree "github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/doubly-linked-tree"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/synccommittee"
@@ -28,6 +33,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
 	v1 "github.com/prysmaticlabs/prysm/beacon-chain/state/v1"
 	mockSync "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync/testing"
+	"github.com/prysmaticlabs/prysm/config/features"
 	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/config/params"
 	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
@@ -121,6 +127,62 @@ func TestProposer_GetBlock_OK(t *testing.T) {
 	assert.DeepEqual(t, attSlashings, block.Body.AttesterSlashings)
 }
 
+// reorgForkChoicer reports a fixed proposer head to simulate a late head block being re-orged.
+type reorgForkChoicer struct {
+	forkchoice.ForkChoicer
+	proposerHead [32]byte
+}
+
+func (f *reorgForkChoicer) ProposerHead(_ types.Slot) [32]byte {
+	return f.proposerHead
+}
+
+func TestProposer_BuildPhase0BlockData_ReorgLateBlock(t *testing.T) {
+	hook := logTest.NewGlobal()
+	resetCfg := features.InitWithReset(&features.Flags{EnableReorgLateBlocks: true})
+	defer resetCfg()
+
+	db := dbutil.SetupDB(t)
+	ctx := context.Background()
+
+	params.SetupTestConfigCleanup(t)
+	params.OverrideBeaconConfig(params.MainnetConfig())
+
+	beaconState, parentRoot, _ := util.DeterministicGenesisStateWithGenesisBlock(t, ctx, db, 64)
+
+	// The late head block is past the requested slot, its state can not be used to build the proposal.
+	headState := beaconState.Copy()
+	require.NoError(t, headState.SetSlot(3))
+	headRoot := [32]byte{'h'}
+
+	proposerServer := &Server{
+		HeadFetcher:       &mock.ChainService{State: headState, Root: headRoot[:]},
+		ForkFetcher:       &mock.ChainService{ForkChoiceStore: &reorgForkChoicer{ForkChoicer: doublylinkedtree.New(), proposerHead: parentRoot}},
+		SyncChecker:       &mockSync.Sync{IsSyncing: false},
+		HeadUpdater:       &mock.ChainService{},
+		ChainStartFetcher: &mockPOW.POWChain{},
+		Eth1InfoFetcher:   &mockPOW.POWChain{},
+		Eth1BlockFetcher:  &mockPOW.POWChain{},
+		MockEth1Votes:     true,
+		AttPool:           attestations.NewPool(),
+		SlashingsPool:     slashings.NewPool(),
+		ExitPool:          voluntaryexits.NewPool(),
+		StateGen:          stategen.New(db),
+	}
+
+	req := &ethpb.BlockRequest{Slot: 2}
+	blkData, err := proposerServer.buildPhase0BlockData(ctx, req)
+	require.NoError(t, err)
+	assert.DeepEqual(t, parentRoot[:], blkData.ParentRoot, "Expected block to build on the parent of the late head")
+
+	parentState, err := transition.ProcessSlots(ctx, beaconState.Copy(), req.Slot)
+	require.NoError(t, err)
+	wantedIdx, err := helpers.BeaconProposerIndex(ctx, parentState)
+	require.NoError(t, err)
+	assert.Equal(t, wantedIdx, blkData.ProposerIdx)
+	require.LogsContain(t, hook, "Building on the parent of a late head block")
+}
+
 func TestProposer_GetBlock_AddsUnaggregatedAtts(t *testing.T) {
 	db := dbutil.SetupDB(t)
 	ctx := context.Background()
@@ -2494,7 +2556,11 @@ func TestProposer_PrepareBeaconProposer(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			db := dbutil.SetupDB(t)
 			ctx := context.Background()
-			proposerServer := &Server{BeaconDB: db}
+			notifier := &mock.MockOperationNotifier{}
+			opChannel := make(chan *feed.Event, 1)
+			opSub := notifier.OperationFeed().Subscribe(opChannel)
+			defer opSub.Unsubscribe()
+			proposerServer := &Server{BeaconDB: db, OperationNotifier: notifier}
 			_, err := proposerServer.PrepareBeaconProposer(ctx, tt.args.request)
 			if tt.wantErr != "" {
 				require.ErrorContains(t, tt.wantErr, err)
@@ -2504,6 +2570,11 @@ func TestProposer_PrepareBeaconProposer(t *testing.T) {
 			address, err := proposerServer.BeaconDB.FeeRecipientByValidatorID(ctx, 1)
 			require.NoError(t, err)
 			require.Equal(t, common.BytesToAddress(tt.args.request.Recipients[0].FeeRecipient), address)
+			e := <-opChannel
+			require.Equal(t, opfeed.ProposerPreparationReceived, int(e.Type))
+			data, ok := e.Data.(*opfeed.ProposerPreparationReceivedData)
+			require.Equal(t, true, ok)
+			require.DeepEqual(t, []types.ValidatorIndex{1}, data.ValidatorIndices)
 
 		})
 	}
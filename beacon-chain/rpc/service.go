@@ -113,6 +113,7 @@ type Config struct {
 	ExecutionEngineCaller   powchain.EngineCaller
 	ProposerIdsCache        *cache.ProposerPayloadIDsCache
 	OptimisticModeFetcher   blockchain.OptimisticModeFetcher
+	PayloadInvalidator      blockchain.PayloadInvalidator
 	BlockBuilder            builder.BlockBuilder
 }
 
@@ -337,6 +338,8 @@ func (s *Service) Start() {
 			PeerManager:        s.cfg.PeerManager,
 			PeersFetcher:       s.cfg.PeersFetcher,
 			ReplayerBuilder:    ch,
+			StateNotifier:      s.cfg.StateNotifier,
+			PayloadInvalidator: s.cfg.PayloadInvalidator,
 		}
 		debugServerV1 := &debug.Server{
 			BeaconDB:    s.cfg.BeaconDB,
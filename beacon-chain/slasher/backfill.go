@@ -0,0 +1,161 @@
+package slasher
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
+	slashertypes "github.com/prysmaticlabs/prysm/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/consensus-types/interfaces"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/attestation"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// Time waited between two epochs of a backfill, to throttle the load it adds to the beacon node.
+	backfillEpochInterval = 250 * time.Millisecond
+	// Number of epochs between two backfill progress logs.
+	backfillLogEpochs = 32
+)
+
+// Backfill replays the finalized blocks of the beacon database through slashing detection,
+// one epoch at a time, alongside the processing of live data. The block headers and indexed
+// attestations of an epoch are processed as if they were received at the current slot.
+func (s *Service) backfill(ctx context.Context) {
+	if err := s.backfillEpochs(ctx); err != nil {
+		log.WithError(err).Error("Could not backfill slasher")
+	}
+}
+
+func (s *Service) backfillEpochs(ctx context.Context) error {
+	startEpoch, endEpoch, err := s.backfillRange(ctx)
+	if err != nil {
+		return err
+	}
+	if startEpoch > endEpoch {
+		log.WithFields(logrus.Fields{
+			"startEpoch": startEpoch,
+			"endEpoch":   endEpoch,
+		}).Warn("No finalized epochs to backfill slasher with")
+		return nil
+	}
+	log.WithFields(logrus.Fields{
+		"startEpoch": startEpoch,
+		"endEpoch":   endEpoch,
+	}).Info("Backfilling slasher with finalized blocks")
+
+	start := time.Now()
+	numEpochs := uint64(endEpoch - startEpoch + 1)
+	ticker := time.NewTicker(backfillEpochInterval)
+	defer ticker.Stop()
+	for epoch := startEpoch; epoch <= endEpoch; epoch++ {
+		blocks, atts, err := s.backfillEpochData(ctx, epoch)
+		if err != nil {
+			return errors.Wrapf(err, "could not backfill epoch %d", epoch)
+		}
+		currentSlot := slots.CurrentSlot(uint64(s.genesisTime.Unix()))
+		s.processBlocks(ctx, currentSlot, blocks)
+		s.attsQueue.extend(s.processAttestations(ctx, currentSlot, atts))
+		backfilledEpochsTotal.Inc()
+
+		done := uint64(epoch-startEpoch) + 1
+		if done%backfillLogEpochs == 0 || epoch == endEpoch {
+			log.WithFields(logrus.Fields{
+				"epoch":     epoch,
+				"endEpoch":  endEpoch,
+				"numBlocks": len(blocks),
+				"numAtts":   len(atts),
+				"progress":  float64(done) / float64(numEpochs),
+				"elapsed":   time.Since(start),
+			}).Info("Slasher backfill progress")
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	log.WithField("elapsed", time.Since(start)).Info("Finished backfilling slasher")
+	return nil
+}
+
+// The epochs to backfill, the end epoch being capped to the last epoch whose
+// blocks are all finalized, and the start epoch to the oldest epoch slashing
+// detection keeps a history of.
+func (s *Service) backfillRange(ctx context.Context) (types.Epoch, types.Epoch, error) {
+	finalized, err := s.serviceCfg.BeaconDatabase.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "could not get finalized checkpoint")
+	}
+	startEpoch := s.serviceCfg.BackfillStartEpoch
+	if finalized.Epoch == 0 {
+		// Nothing is finalized yet, return an empty range.
+		return startEpoch, 0, nil
+	}
+	if finalized.Epoch > s.params.historyLength && startEpoch < finalized.Epoch-s.params.historyLength {
+		oldestEpoch := finalized.Epoch - s.params.historyLength
+		log.WithFields(logrus.Fields{
+			"startEpoch":    startEpoch,
+			"oldestEpoch":   oldestEpoch,
+			"historyLength": s.params.historyLength,
+		}).Warn("Slasher backfill start epoch is older than the history kept by the slasher, starting from the oldest epoch kept")
+		startEpoch = oldestEpoch
+	}
+	endEpoch := s.serviceCfg.BackfillEndEpoch
+	if endEpoch == 0 || endEpoch >= finalized.Epoch {
+		endEpoch = finalized.Epoch - 1
+	}
+	return startEpoch, endEpoch, nil
+}
+
+// Gets the block headers and indexed attestations of all the blocks of an epoch in the beacon
+// database, including the blocks which are not canonical as they may be double proposals.
+func (s *Service) backfillEpochData(
+	ctx context.Context, epoch types.Epoch,
+) ([]*slashertypes.SignedBlockHeaderWrapper, []*slashertypes.IndexedAttestationWrapper, error) {
+	blks, _, err := s.serviceCfg.BeaconDatabase.Blocks(ctx, filters.NewFilter().SetStartEpoch(epoch).SetEndEpoch(epoch))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not get blocks")
+	}
+	blocks := make([]*slashertypes.SignedBlockHeaderWrapper, 0, len(blks))
+	atts := make([]*slashertypes.IndexedAttestationWrapper, 0)
+	for _, blk := range blks {
+		blockHeader, err := interfaces.SignedBeaconBlockHeaderFromBlockInterface(blk)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "could not get header of block at slot %d", blk.Block().Slot())
+		}
+		if wrappedProposal := wrapBlockHeader(blockHeader); wrappedProposal != nil {
+			blocks = append(blocks, wrappedProposal)
+		}
+		for _, att := range blk.Block().Body().Attestations() {
+			indexedAtt, err := s.indexedAttestation(ctx, att)
+			if err != nil {
+				return nil, nil, errors.Wrapf(err, "could not convert attestation of block at slot %d", blk.Block().Slot())
+			}
+			if attWrapper := wrapAttestation(indexedAtt); attWrapper != nil {
+				atts = append(atts, attWrapper)
+			}
+		}
+	}
+	return blocks, atts, nil
+}
+
+// Converts an attestation to an indexed attestation, looking up its committee in the
+// state of its target checkpoint.
+func (s *Service) indexedAttestation(ctx context.Context, att *ethpb.Attestation) (*ethpb.IndexedAttestation, error) {
+	targetState, err := s.serviceCfg.AttestationStateFetcher.AttestationTargetState(ctx, att.Data.Target)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get target state")
+	}
+	committee, err := helpers.BeaconCommitteeFromState(ctx, targetState, att.Data.Slot, att.Data.CommitteeIndex)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get attestation committee")
+	}
+	return attestation.ConvertToIndexed(ctx, att, committee)
+}
@@ -0,0 +1,196 @@
+package slasher
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/async/event"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/consensus-types/wrapper"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestService_backfillRange(t *testing.T) {
+	tests := []struct {
+		name           string
+		finalizedEpoch types.Epoch
+		startEpoch     types.Epoch
+		endEpoch       types.Epoch
+		wantStart      types.Epoch
+		wantEnd        types.Epoch
+	}{
+		{
+			name:           "nothing finalized",
+			finalizedEpoch: 0,
+			startEpoch:     1,
+			wantStart:      1,
+			wantEnd:        0,
+		},
+		{
+			name:           "defaults to the last finalized epoch",
+			finalizedEpoch: 10,
+			startEpoch:     2,
+			wantStart:      2,
+			wantEnd:        9,
+		},
+		{
+			name:           "end epoch within the finalized epochs",
+			finalizedEpoch: 10,
+			startEpoch:     2,
+			endEpoch:       5,
+			wantStart:      2,
+			wantEnd:        5,
+		},
+		{
+			name:           "start epoch clamped to the history length",
+			finalizedEpoch: 5000,
+			startEpoch:     2,
+			wantStart:      5000 - 4096,
+			wantEnd:        4999,
+		},
+		{
+			name:           "end epoch capped to the last finalized epoch",
+			finalizedEpoch: 10,
+			startEpoch:     2,
+			endEpoch:       20,
+			wantStart:      2,
+			wantEnd:        9,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			beaconDB := dbtest.SetupDB(t)
+			saveFinalizedCheckpoint(t, ctx, beaconDB, tt.finalizedEpoch)
+			s := &Service{
+				params: DefaultParams(),
+				serviceCfg: &ServiceConfig{
+					BeaconDatabase:     beaconDB,
+					BackfillStartEpoch: tt.startEpoch,
+					BackfillEndEpoch:   tt.endEpoch,
+				},
+			}
+			start, end, err := s.backfillRange(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStart, start)
+			assert.Equal(t, tt.wantEnd, end)
+		})
+	}
+}
+
+func TestService_backfillEpochs(t *testing.T) {
+	hook := logTest.NewGlobal()
+	ctx := context.Background()
+	beaconDB := dbtest.SetupDB(t)
+
+	// Two different blocks for the same slot and proposer, one of them with an attestation.
+	slot, err := slots.EpochStart(1)
+	require.NoError(t, err)
+	blk1 := util.NewBeaconBlock()
+	blk1.Block.Slot = slot + 1
+	blk1.Block.ProposerIndex = 1
+	blk1.Signature = bytesutil.PadTo([]byte{1}, 96)
+	blk1.Block.Body.Attestations = []*ethpb.Attestation{
+		{
+			AggregationBits: bitfield.Bitlist{0b111},
+			Data: &ethpb.AttestationData{
+				Slot:            slot,
+				BeaconBlockRoot: make([]byte, 32),
+				Source:          &ethpb.Checkpoint{Epoch: 0, Root: make([]byte, 32)},
+				Target:          &ethpb.Checkpoint{Epoch: 1, Root: make([]byte, 32)},
+			},
+			Signature: make([]byte, 96),
+		},
+	}
+	blk2 := util.NewBeaconBlock()
+	blk2.Block.Slot = slot + 1
+	blk2.Block.ProposerIndex = 1
+	blk2.Block.Body.Graffiti = bytesutil.PadTo([]byte("other"), 32)
+	blk2.Signature = bytesutil.PadTo([]byte{2}, 96)
+	for _, blk := range []*ethpb.SignedBeaconBlock{blk1, blk2} {
+		wsb, err := wrapper.WrappedSignedBeaconBlock(blk)
+		require.NoError(t, err)
+		require.NoError(t, beaconDB.SaveBlock(ctx, wsb))
+	}
+	saveFinalizedCheckpoint(t, ctx, beaconDB, 2)
+
+	// Committees of two validators for each slot.
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	validators := make([]*ethpb.Validator, 2*params.BeaconConfig().SlotsPerEpoch)
+	for i := range validators {
+		validators[i] = &ethpb.Validator{
+			PublicKey:        make([]byte, 48),
+			ExitEpoch:        params.BeaconConfig().FarFutureEpoch,
+			EffectiveBalance: params.BeaconConfig().MaxEffectiveBalance,
+		}
+	}
+	require.NoError(t, st.SetValidators(validators))
+	require.NoError(t, st.SetSlot(slot))
+
+	slashingsFeed := new(event.Feed)
+	slashingsChan := make(chan *ethpb.DetectedSlashings, 1)
+	sub := slashingsFeed.Subscribe(slashingsChan)
+	defer sub.Unsubscribe()
+	secondsPerEpoch := time.Duration(params.BeaconConfig().SecondsPerSlot*uint64(params.BeaconConfig().SlotsPerEpoch)) * time.Second
+	s := &Service{
+		params: DefaultParams(),
+		serviceCfg: &ServiceConfig{
+			Database:                dbtest.SetupSlasherDB(t),
+			BeaconDatabase:          beaconDB,
+			AttestationStateFetcher: &mock.ChainService{State: st},
+			BackfillStartEpoch:      1,
+			Standalone:              true,
+			SlashingsFeed:           slashingsFeed,
+		},
+		attsQueue:                      newAttestationsQueue(),
+		blksQueue:                      newBlocksQueue(),
+		genesisTime:                    time.Now().Add(-3 * secondsPerEpoch),
+		latestEpochWrittenForValidator: make(map[types.ValidatorIndex]types.Epoch),
+	}
+	// Live data queued in the meantime is left to the processing routines.
+	s.queueBlockHeader(createProposalWrapper(t, 3*params.BeaconConfig().SlotsPerEpoch, 2, nil).SignedBeaconBlockHeader)
+	require.NoError(t, s.backfillEpochs(ctx))
+
+	select {
+	case slashings := <-slashingsChan:
+		require.Equal(t, 1, len(slashings.ProposerSlashings))
+		assert.Equal(t, slot+1, slashings.ProposerSlashings[0].Header_1.Header.Slot)
+	default:
+		t.Fatal("Double proposal was not detected")
+	}
+	numRecords := 0
+	for i := range validators {
+		record, err := s.serviceCfg.Database.AttestationRecordForValidator(ctx, types.ValidatorIndex(i), 1)
+		require.NoError(t, err)
+		if record != nil {
+			numRecords++
+		}
+	}
+	assert.Equal(t, 2, numRecords, "Attestation records of the committee were not saved")
+	assert.Equal(t, 1, s.blksQueue.size())
+	assert.LogsContain(t, hook, "Finished backfilling slasher")
+}
+
+func saveFinalizedCheckpoint(t *testing.T, ctx context.Context, beaconDB db.Database, epoch types.Epoch) {
+	genesis, err := wrapper.WrappedSignedBeaconBlock(util.NewBeaconBlock())
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveBlock(ctx, genesis))
+	root, err := genesis.Block().HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, root))
+	require.NoError(t, beaconDB.SaveStateSummary(ctx, &ethpb.StateSummary{Root: root[:]}))
+	require.NoError(t, beaconDB.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: epoch, Root: root[:]}))
+}
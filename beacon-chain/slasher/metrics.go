@@ -37,6 +37,10 @@ var (
 		Name: "slasher_blocks_processed_total",
 		Help: "Total number of blocks successfully processed by slasher",
 	})
+	backfilledEpochsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "slasher_backfilled_epochs_total",
+		Help: "Total number of epochs of finalized blocks replayed by the slasher backfill",
+	})
 	doubleProposalsTotal = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "slasher_double_proposals_total",
 		Help: "Total slashable proposals successfully detected by slasher",
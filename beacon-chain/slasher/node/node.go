@@ -0,0 +1,285 @@
+// Package node is the main process which handles the lifecycle of a standalone slasher,
+// detecting slashable offenses in the attestations and blocks streamed by remote beacon nodes
+// and sending the slashings it finds back to them.
+package node
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/async/event"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/slasherkv"
+	"github.com/prysmaticlabs/prysm/beacon-chain/slasher"
+	"github.com/prysmaticlabs/prysm/cmd"
+	"github.com/prysmaticlabs/prysm/cmd/slasher/flags"
+	"github.com/prysmaticlabs/prysm/config/features"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/monitoring/prometheus"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/runtime"
+	"github.com/prysmaticlabs/prysm/runtime/debug"
+	"github.com/prysmaticlabs/prysm/runtime/version"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// SlasherNode defines a struct that handles the services running a standalone slasher.
+type SlasherNode struct {
+	cliCtx                  *cli.Context
+	ctx                     context.Context
+	cancel                  context.CancelFunc
+	services                *runtime.ServiceRegistry
+	lock                    sync.RWMutex
+	stop                    chan struct{} // Channel to wait for termination notifications.
+	db                      db.SlasherDatabase
+	beaconConn              *grpc.ClientConn
+	indexedAttestationsFeed *event.Feed
+	beaconBlockHeadersFeed  *event.Feed
+	slashingsFeed           *event.Feed
+}
+
+// New creates a new standalone slasher instance, sets up configuration options, and registers
+// every required service to the node.
+func New(cliCtx *cli.Context) (*SlasherNode, error) {
+	if err := features.ConfigureSlasher(cliCtx); err != nil {
+		return nil, err
+	}
+	if cliCtx.IsSet(cmd.ChainConfigFileFlag.Name) {
+		if err := params.LoadChainConfigFile(cliCtx.String(cmd.ChainConfigFileFlag.Name), nil); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, cancel := context.WithCancel(cliCtx.Context)
+	slasherNode := &SlasherNode{
+		cliCtx:                  cliCtx,
+		ctx:                     ctx,
+		cancel:                  cancel,
+		services:                runtime.NewServiceRegistry(),
+		stop:                    make(chan struct{}),
+		indexedAttestationsFeed: new(event.Feed),
+		beaconBlockHeadersFeed:  new(event.Feed),
+		slashingsFeed:           new(event.Feed),
+	}
+
+	if !cliCtx.Bool(cmd.DisableMonitoringFlag.Name) {
+		if err := slasherNode.registerPrometheusService(); err != nil {
+			return nil, err
+		}
+	}
+
+	log.Debugln("Starting Slashing DB")
+	if err := slasherNode.startDB(); err != nil {
+		return nil, err
+	}
+
+	log.Debugln("Connecting to beacon node")
+	if err := slasherNode.connectToBeaconNode(); err != nil {
+		return nil, err
+	}
+
+	log.Debugln("Registering Slasher Service")
+	if err := slasherNode.registerSlasherService(); err != nil {
+		return nil, err
+	}
+
+	log.Debugln("Registering RPC Service")
+	if err := slasherNode.registerRPCService(); err != nil {
+		return nil, err
+	}
+	return slasherNode, nil
+}
+
+// Start the slasher node and its services, and wait for an interrupt.
+func (n *SlasherNode) Start() {
+	n.lock.Lock()
+
+	log.WithFields(logrus.Fields{
+		"version": version.Version(),
+	}).Info("Starting slasher node")
+
+	n.services.StartAll()
+
+	stop := n.stop
+	n.lock.Unlock()
+
+	go func() {
+		sigc := make(chan os.Signal, 1)
+		signal.Notify(sigc, syscall.SIGINT, syscall.SIGTERM)
+		defer signal.Stop(sigc)
+		<-sigc
+		log.Info("Got interrupt, shutting down...")
+		debug.Exit(n.cliCtx) // Ensure trace and CPU profile data are flushed.
+		go n.Close()
+		for i := 10; i > 0; i-- {
+			<-sigc
+			if i > 1 {
+				log.WithField("times", i-1).Info("Already shutting down, interrupt more to panic")
+			}
+		}
+		panic("Panic closing the slasher node")
+	}()
+
+	// Wait for stop channel to be closed.
+	<-stop
+}
+
+// Close handles graceful shutdown of the system.
+func (n *SlasherNode) Close() {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	log.Info("Stopping slasher node")
+	n.services.StopAll()
+	if err := n.db.Close(); err != nil {
+		log.Errorf("Failed to close database: %v", err)
+	}
+	if err := n.beaconConn.Close(); err != nil {
+		log.Errorf("Failed to close beacon node connection: %v", err)
+	}
+	n.cancel()
+	close(n.stop)
+}
+
+// The database is at the same location as in a beacon node, so the slasher database of a beacon
+// node running with --slasher can be reused.
+func (n *SlasherNode) startDB() error {
+	baseDir := n.cliCtx.String(cmd.DataDirFlag.Name)
+	dbPath := filepath.Join(baseDir, kv.BeaconNodeDbDirName)
+	clearDB := n.cliCtx.Bool(cmd.ClearDB.Name)
+	forceClearDB := n.cliCtx.Bool(cmd.ForceClearDB.Name)
+
+	log.WithField("database-path", dbPath).Info("Checking DB")
+
+	d, err := slasherkv.NewKVStore(n.ctx, dbPath, &slasherkv.Config{
+		InitialMMapSize: n.cliCtx.Int(cmd.BoltMMapInitialSizeFlag.Name),
+	})
+	if err != nil {
+		return err
+	}
+	clearDBConfirmed := false
+	if clearDB && !forceClearDB {
+		actionText := "This will delete your slasher database stored in your data directory. " +
+			"Your database backups will not be removed - do you want to proceed? (Y/N)"
+		deniedText := "Database will not be deleted. No changes have been made."
+		clearDBConfirmed, err = cmd.ConfirmAction(actionText, deniedText)
+		if err != nil {
+			return err
+		}
+	}
+	if clearDBConfirmed || forceClearDB {
+		log.Warning("Removing database")
+		if err := d.Close(); err != nil {
+			return errors.Wrap(err, "could not close db prior to clearing")
+		}
+		if err := d.ClearDB(); err != nil {
+			return errors.Wrap(err, "could not clear database")
+		}
+		d, err = slasherkv.NewKVStore(n.ctx, dbPath, &slasherkv.Config{
+			InitialMMapSize: n.cliCtx.Int(cmd.BoltMMapInitialSizeFlag.Name),
+		})
+		if err != nil {
+			return errors.Wrap(err, "could not create new database")
+		}
+	}
+
+	n.db = d
+	return nil
+}
+
+func (n *SlasherNode) connectToBeaconNode() error {
+	var transportSecurity grpc.DialOption
+	if cert := n.cliCtx.String(flags.BeaconCertFlag.Name); cert != "" {
+		creds, err := credentials.NewClientTLSFromFile(cert, "")
+		if err != nil {
+			return errors.Wrap(err, "could not get valid credentials")
+		}
+		transportSecurity = grpc.WithTransportCredentials(creds)
+	} else {
+		transportSecurity = grpc.WithInsecure()
+	}
+	endpoint := n.cliCtx.String(flags.BeaconRPCProviderFlag.Name)
+	conn, err := grpc.DialContext(n.ctx, endpoint, transportSecurity)
+	if err != nil {
+		return errors.Wrapf(err, "could not dial beacon node endpoint %s", endpoint)
+	}
+	n.beaconConn = conn
+	return nil
+}
+
+func (n *SlasherNode) registerSlasherService() error {
+	genesis, err := ethpb.NewNodeClient(n.beaconConn).GetGenesis(n.ctx, &emptypb.Empty{})
+	if err != nil {
+		return errors.Wrap(err, "could not get genesis from beacon node")
+	}
+	genesisTime := time.Unix(genesis.GenesisTime.Seconds, 0)
+	log.WithField("genesisTime", genesisTime).Info("Retrieved chain genesis from beacon node")
+
+	slasherSrv, err := slasher.New(n.ctx, &slasher.ServiceConfig{
+		IndexedAttestationsFeed: n.indexedAttestationsFeed,
+		BeaconBlockHeadersFeed:  n.beaconBlockHeadersFeed,
+		Database:                n.db,
+		Standalone:              true,
+		GenesisTime:             genesisTime,
+		ValidatorCountFetcher:   &beaconNodeValidatorCounter{client: ethpb.NewBeaconChainClient(n.beaconConn)},
+		SlashingsFeed:           n.slashingsFeed,
+	})
+	if err != nil {
+		return err
+	}
+	return n.services.RegisterService(slasherSrv)
+}
+
+func (n *SlasherNode) registerRPCService() error {
+	var slasherService *slasher.Service
+	if err := n.services.FetchService(&slasherService); err != nil {
+		return err
+	}
+	rpcService := newRPCService(n.ctx, &rpcConfig{
+		Host:                    n.cliCtx.String(flags.RPCHost.Name),
+		Port:                    n.cliCtx.Int(flags.RPCPort.Name),
+		CertFlag:                n.cliCtx.String(flags.CertFlag.Name),
+		KeyFlag:                 n.cliCtx.String(flags.KeyFlag.Name),
+		MaxMsgSize:              n.cliCtx.Int(cmd.GrpcMaxCallRecvMsgSizeFlag.Name),
+		SlashingChecker:         slasherService,
+		IndexedAttestationsFeed: n.indexedAttestationsFeed,
+		BeaconBlockHeadersFeed:  n.beaconBlockHeadersFeed,
+		SlashingsFeed:           n.slashingsFeed,
+	})
+	return n.services.RegisterService(rpcService)
+}
+
+func (n *SlasherNode) registerPrometheusService() error {
+	service := prometheus.NewService(
+		fmt.Sprintf("%s:%d", n.cliCtx.String(cmd.MonitoringHostFlag.Name), n.cliCtx.Int(flags.MonitoringPortFlag.Name)),
+		n.services,
+	)
+	logrus.AddHook(prometheus.NewLogrusCollector())
+	return n.services.RegisterService(service)
+}
+
+// beaconNodeValidatorCounter reads the size of the validator registry from a beacon node.
+type beaconNodeValidatorCounter struct {
+	client ethpb.BeaconChainClient
+}
+
+// ValidatorCount returns the number of validators in the head state of the beacon node.
+func (c *beaconNodeValidatorCounter) ValidatorCount(ctx context.Context) (int, error) {
+	resp, err := c.client.ListValidators(ctx, &ethpb.ListValidatorsRequest{PageSize: 1})
+	if err != nil {
+		return 0, errors.Wrap(err, "could not list validators")
+	}
+	return int(resp.TotalSize), nil
+}
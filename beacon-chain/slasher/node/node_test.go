@@ -0,0 +1,32 @@
+package node
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/mock"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestBeaconNodeValidatorCounter_ValidatorCount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	client := mock.NewMockBeaconChainClient(ctrl)
+	counter := &beaconNodeValidatorCounter{client: client}
+
+	client.EXPECT().ListValidators(gomock.Any(), &ethpb.ListValidatorsRequest{PageSize: 1}).Return(
+		&ethpb.Validators{TotalSize: 64},
+		nil,
+	)
+	count, err := counter.ValidatorCount(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 64, count)
+
+	client.EXPECT().ListValidators(gomock.Any(), gomock.Any()).Return(nil, errors.New("unavailable"))
+	_, err = counter.ValidatorCount(ctx)
+	require.ErrorContains(t, "could not list validators", err)
+}
@@ -0,0 +1,141 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
+	grpcprometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
+	"github.com/prysmaticlabs/prysm/async/event"
+	slasherrpc "github.com/prysmaticlabs/prysm/beacon-chain/rpc/prysm/v1alpha1/slasher"
+	"github.com/prysmaticlabs/prysm/beacon-chain/slasher"
+	"github.com/prysmaticlabs/prysm/monitoring/tracing"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/reflection"
+)
+
+// rpcConfig options for the gRPC server of a standalone slasher.
+type rpcConfig struct {
+	Host                    string
+	Port                    int
+	CertFlag                string
+	KeyFlag                 string
+	MaxMsgSize              int
+	SlashingChecker         slasher.SlashingChecker
+	IndexedAttestationsFeed *event.Feed
+	BeaconBlockHeadersFeed  *event.Feed
+	SlashingsFeed           *event.Feed
+}
+
+// rpcService serves the slasher gRPC API, on which beacon nodes stream their data and check
+// whether attestations and blocks are slashable.
+type rpcService struct {
+	cfg        *rpcConfig
+	ctx        context.Context
+	cancel     context.CancelFunc
+	listener   net.Listener
+	grpcServer *grpc.Server
+	credError  error
+}
+
+func newRPCService(ctx context.Context, cfg *rpcConfig) *rpcService {
+	ctx, cancel := context.WithCancel(ctx)
+	return &rpcService{
+		cfg:    cfg,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Start the gRPC server.
+func (s *rpcService) Start() {
+	address := fmt.Sprintf("%s:%d", s.cfg.Host, s.cfg.Port)
+	lis, err := net.Listen("tcp", address)
+	if err != nil {
+		log.WithError(err).Errorf("Could not listen to port in Start() %s", address)
+		return
+	}
+	s.listener = lis
+	log.WithField("address", address).Info("gRPC server listening on port")
+
+	opts := []grpc.ServerOption{
+		grpc.StreamInterceptor(middleware.ChainStreamServer(
+			recovery.StreamServerInterceptor(
+				recovery.WithRecoveryHandlerContext(tracing.RecoveryHandlerFunc),
+			),
+			grpcprometheus.StreamServerInterceptor,
+			s.streamConnectionInterceptor,
+		)),
+		grpc.UnaryInterceptor(middleware.ChainUnaryServer(
+			recovery.UnaryServerInterceptor(
+				recovery.WithRecoveryHandlerContext(tracing.RecoveryHandlerFunc),
+			),
+			grpcprometheus.UnaryServerInterceptor,
+		)),
+		grpc.MaxRecvMsgSize(s.cfg.MaxMsgSize),
+	}
+	grpcprometheus.EnableHandlingTimeHistogram()
+	if s.cfg.CertFlag != "" && s.cfg.KeyFlag != "" {
+		creds, err := credentials.NewServerTLSFromFile(s.cfg.CertFlag, s.cfg.KeyFlag)
+		if err != nil {
+			log.WithError(err).Error("Could not load TLS keys")
+			s.credError = err
+			return
+		}
+		opts = append(opts, grpc.Creds(creds))
+	} else {
+		log.Warn("You are using an insecure gRPC server. If you are running your slasher and " +
+			"beacon nodes on the same machines, you can ignore this message. If you want to know " +
+			"how to enable secure connections, see: https://docs.prylabs.network/docs/prysm-usage/secure-grpc")
+	}
+	s.grpcServer = grpc.NewServer(opts...)
+
+	ethpb.RegisterSlasherServer(s.grpcServer, &slasherrpc.Server{
+		SlashingChecker:         s.cfg.SlashingChecker,
+		IndexedAttestationsFeed: s.cfg.IndexedAttestationsFeed,
+		BeaconBlockHeadersFeed:  s.cfg.BeaconBlockHeadersFeed,
+		SlashingsFeed:           s.cfg.SlashingsFeed,
+	})
+	// Register reflection service on gRPC server.
+	reflection.Register(s.grpcServer)
+
+	go func() {
+		if err := s.grpcServer.Serve(s.listener); err != nil {
+			log.Errorf("Could not serve gRPC: %v", err)
+		}
+	}()
+}
+
+// Stop the service.
+func (s *rpcService) Stop() error {
+	s.cancel()
+	if s.grpcServer != nil {
+		// Streams from beacon nodes never end by themselves, so they are not waited for.
+		s.grpcServer.Stop()
+		log.Debug("Initiated stop of gRPC server")
+	}
+	return nil
+}
+
+// Status returns nil or credError.
+func (s *rpcService) Status() error {
+	return s.credError
+}
+
+// Logs the beacon nodes opening a stream to the slasher.
+func (s *rpcService) streamConnectionInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if clientInfo, ok := peer.FromContext(ss.Context()); ok {
+		log.WithField("addr", clientInfo.Addr.String()).WithField("method", info.FullMethod).Info("Beacon node connected to slasher")
+	}
+	return handler(srv, ss)
+}
@@ -4,14 +4,23 @@ import (
 	"context"
 
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed/operation"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 )
 
 // Verifies attester slashings, logs them, and submits them to the slashing operations pool
-// in the beacon node if they pass validation.
+// in the beacon node if they pass validation. A standalone slasher sends them to the
+// connected beacon nodes instead.
 func (s *Service) processAttesterSlashings(ctx context.Context, slashings []*ethpb.AttesterSlashing) error {
+	if s.serviceCfg.Standalone {
+		for _, sl := range slashings {
+			logAttesterSlashing(sl)
+		}
+		s.sendSlashings(&ethpb.DetectedSlashings{AttesterSlashings: slashings})
+		return nil
+	}
 	var beaconState state.BeaconState
 	var err error
 	if len(slashings) > 0 {
@@ -40,14 +49,25 @@ func (s *Service) processAttesterSlashings(ctx context.Context, slashings []*eth
 			ctx, beaconState, sl,
 		); err != nil {
 			log.WithError(err).Error("Could not insert attester slashing into operations pool")
+			continue
 		}
+		operation.Notify(s.serviceCfg.OperationNotifier, operation.AttesterSlashingReceived,
+			&operation.AttesterSlashingReceivedData{AttesterSlashing: sl})
 	}
 	return nil
 }
 
 // Verifies proposer slashings, logs them, and submits them to the slashing operations pool
-// in the beacon node if they pass validation.
+// in the beacon node if they pass validation. A standalone slasher sends them to the
+// connected beacon nodes instead.
 func (s *Service) processProposerSlashings(ctx context.Context, slashings []*ethpb.ProposerSlashing) error {
+	if s.serviceCfg.Standalone {
+		for _, sl := range slashings {
+			logProposerSlashing(sl)
+		}
+		s.sendSlashings(&ethpb.DetectedSlashings{ProposerSlashings: slashings})
+		return nil
+	}
 	var beaconState state.BeaconState
 	var err error
 	if len(slashings) > 0 {
@@ -73,11 +93,24 @@ func (s *Service) processProposerSlashings(ctx context.Context, slashings []*eth
 		logProposerSlashing(sl)
 		if err := s.serviceCfg.SlashingPoolInserter.InsertProposerSlashing(ctx, beaconState, sl); err != nil {
 			log.WithError(err).Error("Could not insert attester slashing into operations pool")
+			continue
 		}
+		operation.Notify(s.serviceCfg.OperationNotifier, operation.ProposerSlashingReceived,
+			&operation.ProposerSlashingReceivedData{ProposerSlashing: sl})
 	}
 	return nil
 }
 
+// Sends the slashings detected by a standalone slasher to the connected beacon nodes. A standalone
+// slasher has no beacon state to verify signatures against, the beacon nodes verify the slashings
+// when inserting them into their operations pool.
+func (s *Service) sendSlashings(slashings *ethpb.DetectedSlashings) {
+	if len(slashings.AttesterSlashings) == 0 && len(slashings.ProposerSlashings) == 0 {
+		return
+	}
+	s.serviceCfg.SlashingsFeed.Send(slashings)
+}
+
 func (s *Service) verifyBlockSignature(ctx context.Context, header *ethpb.SignedBeaconBlockHeader) error {
 	parentState, err := s.serviceCfg.StateGen.StateByRoot(ctx, bytesutil.ToBytes32(header.Header.ParentRoot))
 	if err != nil {
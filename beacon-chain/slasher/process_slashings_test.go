@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/prysmaticlabs/prysm/async/event"
 	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/signing"
 	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
@@ -251,3 +252,36 @@ func TestService_processProposerSlashings(t *testing.T) {
 		require.LogsDoNotContain(tt, hook, "Invalid signature")
 	})
 }
+
+func TestService_processSlashings_Standalone(t *testing.T) {
+	ctx := context.Background()
+	s := &Service{
+		serviceCfg: &ServiceConfig{
+			Standalone:    true,
+			SlashingsFeed: new(event.Feed),
+		},
+	}
+	slashingsChan := make(chan *ethpb.DetectedSlashings, 2)
+	sub := s.serviceCfg.SlashingsFeed.Subscribe(slashingsChan)
+	defer sub.Unsubscribe()
+
+	au := util.AttestationUtil{}
+	attesterSlashing := &ethpb.AttesterSlashing{
+		Attestation_1: au.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{0}}),
+		Attestation_2: au.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{0}}),
+	}
+	proposerSlashing := &ethpb.ProposerSlashing{
+		Header_1: util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{}),
+		Header_2: util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{}),
+	}
+	require.NoError(t, s.processAttesterSlashings(ctx, nil))
+	require.NoError(t, s.processAttesterSlashings(ctx, []*ethpb.AttesterSlashing{attesterSlashing}))
+	require.NoError(t, s.processProposerSlashings(ctx, []*ethpb.ProposerSlashing{proposerSlashing}))
+
+	require.DeepEqual(t, &ethpb.DetectedSlashings{
+		AttesterSlashings: []*ethpb.AttesterSlashing{attesterSlashing},
+	}, <-slashingsChan)
+	require.DeepEqual(t, &ethpb.DetectedSlashings{
+		ProposerSlashings: []*ethpb.ProposerSlashing{proposerSlashing},
+	}, <-slashingsChan)
+}
@@ -21,19 +21,7 @@ func (s *Service) receiveAttestations(ctx context.Context, indexedAttsChan chan
 	for {
 		select {
 		case att := <-indexedAttsChan:
-			if !validateAttestationIntegrity(att) {
-				continue
-			}
-			signingRoot, err := att.Data.HashTreeRoot()
-			if err != nil {
-				log.WithError(err).Error("Could not get hash tree root of attestation")
-				continue
-			}
-			attWrapper := &slashertypes.IndexedAttestationWrapper{
-				IndexedAttestation: att,
-				SigningRoot:        signingRoot,
-			}
-			s.attsQueue.push(attWrapper)
+			s.queueAttestation(att)
 		case err := <-sub.Err():
 			log.WithError(err).Debug("Subscriber closed with error")
 			return
@@ -50,19 +38,7 @@ func (s *Service) receiveBlocks(ctx context.Context, beaconBlockHeadersChan chan
 	for {
 		select {
 		case blockHeader := <-beaconBlockHeadersChan:
-			if !validateBlockHeaderIntegrity(blockHeader) {
-				continue
-			}
-			signingRoot, err := blockHeader.Header.HashTreeRoot()
-			if err != nil {
-				log.WithError(err).Error("Could not get hash tree root of signed block header")
-				continue
-			}
-			wrappedProposal := &slashertypes.SignedBlockHeaderWrapper{
-				SignedBeaconBlockHeader: blockHeader,
-				SigningRoot:             signingRoot,
-			}
-			s.blksQueue.push(wrappedProposal)
+			s.queueBlockHeader(blockHeader)
 		case err := <-sub.Err():
 			log.WithError(err).Debug("Subscriber closed with error")
 			return
@@ -72,6 +48,56 @@ func (s *Service) receiveBlocks(ctx context.Context, beaconBlockHeadersChan chan
 	}
 }
 
+// Validates the integrity of an indexed attestation before appending it
+// to the attestation queue.
+func (s *Service) queueAttestation(att *ethpb.IndexedAttestation) {
+	if attWrapper := wrapAttestation(att); attWrapper != nil {
+		s.attsQueue.push(attWrapper)
+	}
+}
+
+// Wraps an indexed attestation with its signing root, or returns nil if the
+// attestation does not pass integrity checks.
+func wrapAttestation(att *ethpb.IndexedAttestation) *slashertypes.IndexedAttestationWrapper {
+	if !validateAttestationIntegrity(att) {
+		return nil
+	}
+	signingRoot, err := att.Data.HashTreeRoot()
+	if err != nil {
+		log.WithError(err).Error("Could not get hash tree root of attestation")
+		return nil
+	}
+	return &slashertypes.IndexedAttestationWrapper{
+		IndexedAttestation: att,
+		SigningRoot:        signingRoot,
+	}
+}
+
+// Validates the integrity of a signed block header before appending it
+// to the block queue.
+func (s *Service) queueBlockHeader(blockHeader *ethpb.SignedBeaconBlockHeader) {
+	if wrappedProposal := wrapBlockHeader(blockHeader); wrappedProposal != nil {
+		s.blksQueue.push(wrappedProposal)
+	}
+}
+
+// Wraps a signed block header with its signing root, or returns nil if the
+// block header does not pass integrity checks.
+func wrapBlockHeader(blockHeader *ethpb.SignedBeaconBlockHeader) *slashertypes.SignedBlockHeaderWrapper {
+	if !validateBlockHeaderIntegrity(blockHeader) {
+		return nil
+	}
+	signingRoot, err := blockHeader.Header.HashTreeRoot()
+	if err != nil {
+		log.WithError(err).Error("Could not get hash tree root of signed block header")
+		return nil
+	}
+	return &slashertypes.SignedBlockHeaderWrapper{
+		SignedBeaconBlockHeader: blockHeader,
+		SigningRoot:             signingRoot,
+	}
+}
+
 // Process queued attestations every time a slot ticker fires. We retrieve
 // these attestations from a queue, then group them all by validator chunk index.
 // This grouping will allow us to perform detection on batches of attestations
@@ -81,52 +107,61 @@ func (s *Service) processQueuedAttestations(ctx context.Context, slotTicker <-ch
 		select {
 		case currentSlot := <-slotTicker:
 			attestations := s.attsQueue.dequeue()
-			currentEpoch := slots.ToEpoch(currentSlot)
-			// We take all the attestations in the queue and filter out
-			// those which are valid now and valid in the future.
-			validAtts, validInFuture, numDropped := s.filterAttestations(attestations, currentEpoch)
+			// We add back those attestations that are valid in the future to the queue.
+			s.attsQueue.extend(s.processAttestations(ctx, currentSlot, attestations))
+		case <-ctx.Done():
+			return
+		}
+	}
+}
 
-			deferredAttestationsTotal.Add(float64(len(validInFuture)))
-			droppedAttestationsTotal.Add(float64(numDropped))
+// Performs slashing detection on the attestations which are valid at the current slot,
+// and returns the ones which are only valid in the future.
+func (s *Service) processAttestations(
+	ctx context.Context, currentSlot types.Slot, attestations []*slashertypes.IndexedAttestationWrapper,
+) []*slashertypes.IndexedAttestationWrapper {
+	s.attsProcessingLock.Lock()
+	defer s.attsProcessingLock.Unlock()
+	currentEpoch := slots.ToEpoch(currentSlot)
+	// We take all the attestations and filter out
+	// those which are valid now and valid in the future.
+	validAtts, validInFuture, numDropped := s.filterAttestations(attestations, currentEpoch)
 
-			// We add back those attestations that are valid in the future to the queue.
-			s.attsQueue.extend(validInFuture)
-
-			log.WithFields(logrus.Fields{
-				"currentSlot":     currentSlot,
-				"currentEpoch":    currentEpoch,
-				"numValidAtts":    len(validAtts),
-				"numDeferredAtts": len(validInFuture),
-				"numDroppedAtts":  numDropped,
-			}).Info("Processing queued attestations for slashing detection")
-
-			// Save the attestation records to our database.
-			if err := s.serviceCfg.Database.SaveAttestationRecordsForValidators(
-				ctx, validAtts,
-			); err != nil {
-				log.WithError(err).Error("Could not save attestation records to DB")
-				continue
-			}
+	deferredAttestationsTotal.Add(float64(len(validInFuture)))
+	droppedAttestationsTotal.Add(float64(numDropped))
 
-			// Check for slashings.
-			slashings, err := s.checkSlashableAttestations(ctx, currentEpoch, validAtts)
-			if err != nil {
-				log.WithError(err).Error("Could not check slashable attestations")
-				continue
-			}
+	log.WithFields(logrus.Fields{
+		"currentSlot":     currentSlot,
+		"currentEpoch":    currentEpoch,
+		"numValidAtts":    len(validAtts),
+		"numDeferredAtts": len(validInFuture),
+		"numDroppedAtts":  numDropped,
+	}).Info("Processing queued attestations for slashing detection")
 
-			// Process attester slashings by verifying their signatures, submitting
-			// to the beacon node's operations pool, and logging them.
-			if err := s.processAttesterSlashings(ctx, slashings); err != nil {
-				log.WithError(err).Error("Could not process attester slashings")
-				continue
-			}
+	// Save the attestation records to our database.
+	if err := s.serviceCfg.Database.SaveAttestationRecordsForValidators(
+		ctx, validAtts,
+	); err != nil {
+		log.WithError(err).Error("Could not save attestation records to DB")
+		return validInFuture
+	}
 
-			processedAttestationsTotal.Add(float64(len(validAtts)))
-		case <-ctx.Done():
-			return
-		}
+	// Check for slashings.
+	slashings, err := s.checkSlashableAttestations(ctx, currentEpoch, validAtts)
+	if err != nil {
+		log.WithError(err).Error("Could not check slashable attestations")
+		return validInFuture
+	}
+
+	// Process attester slashings by verifying their signatures, submitting
+	// to the beacon node's operations pool, and logging them.
+	if err := s.processAttesterSlashings(ctx, slashings); err != nil {
+		log.WithError(err).Error("Could not process attester slashings")
+		return validInFuture
 	}
+
+	processedAttestationsTotal.Add(float64(len(validAtts)))
+	return validInFuture
 }
 
 // Process queued blocks every time an epoch ticker fires. We retrieve
@@ -135,47 +170,57 @@ func (s *Service) processQueuedBlocks(ctx context.Context, slotTicker <-chan typ
 	for {
 		select {
 		case currentSlot := <-slotTicker:
-			blocks := s.blksQueue.dequeue()
-			currentEpoch := slots.ToEpoch(currentSlot)
-
-			receivedBlocksTotal.Add(float64(len(blocks)))
-
-			log.WithFields(logrus.Fields{
-				"currentSlot":  currentSlot,
-				"currentEpoch": currentEpoch,
-				"numBlocks":    len(blocks),
-			}).Info("Processing queued blocks for slashing detection")
-
-			start := time.Now()
-			// Check for slashings.
-			slashings, err := s.detectProposerSlashings(ctx, blocks)
-			if err != nil {
-				log.WithError(err).Error("Could not detect proposer slashings")
-				continue
-			}
-
-			// Process proposer slashings by verifying their signatures, submitting
-			// to the beacon node's operations pool, and logging them.
-			if err := s.processProposerSlashings(ctx, slashings); err != nil {
-				log.WithError(err).Error("Could not process proposer slashings")
-				continue
-			}
-
-			log.WithField("elapsed", time.Since(start)).Debug("Done checking slashable blocks")
-
-			processedBlocksTotal.Add(float64(len(blocks)))
+			s.processBlocks(ctx, currentSlot, s.blksQueue.dequeue())
 		case <-ctx.Done():
 			return
 		}
 	}
 }
 
+// Performs double proposal detection on the blocks.
+func (s *Service) processBlocks(ctx context.Context, currentSlot types.Slot, blocks []*slashertypes.SignedBlockHeaderWrapper) {
+	s.blocksProcessingLock.Lock()
+	defer s.blocksProcessingLock.Unlock()
+	currentEpoch := slots.ToEpoch(currentSlot)
+
+	receivedBlocksTotal.Add(float64(len(blocks)))
+
+	log.WithFields(logrus.Fields{
+		"currentSlot":  currentSlot,
+		"currentEpoch": currentEpoch,
+		"numBlocks":    len(blocks),
+	}).Info("Processing queued blocks for slashing detection")
+
+	start := time.Now()
+	// Check for slashings.
+	slashings, err := s.detectProposerSlashings(ctx, blocks)
+	if err != nil {
+		log.WithError(err).Error("Could not detect proposer slashings")
+		return
+	}
+
+	// Process proposer slashings by verifying their signatures, submitting
+	// to the beacon node's operations pool, and logging them.
+	if err := s.processProposerSlashings(ctx, slashings); err != nil {
+		log.WithError(err).Error("Could not process proposer slashings")
+		return
+	}
+
+	log.WithField("elapsed", time.Since(start)).Debug("Done checking slashable blocks")
+
+	processedBlocksTotal.Add(float64(len(blocks)))
+}
+
 // Prunes slasher data on each slot tick to prevent unnecessary build-up of disk space usage.
 func (s *Service) pruneSlasherData(ctx context.Context, slotTicker <-chan types.Slot) {
 	for {
 		select {
-		case <-slotTicker:
-			headEpoch := slots.ToEpoch(s.serviceCfg.HeadStateFetcher.HeadSlot())
+		case currentSlot := <-slotTicker:
+			// A standalone slasher has no head, it prunes according to the current slot.
+			headEpoch := slots.ToEpoch(currentSlot)
+			if !s.serviceCfg.Standalone {
+				headEpoch = slots.ToEpoch(s.serviceCfg.HeadStateFetcher.HeadSlot())
+			}
 			if err := s.pruneSlasherDataWithinSlidingWindow(ctx, headEpoch); err != nil {
 				log.WithError(err).Error("Could not prune slasher data")
 				continue
@@ -0,0 +1,212 @@
+// Package remote connects a beacon node to a standalone slasher. The attestations and block
+// headers the beacon node receives are streamed to the slasher, and the slashings it detects
+// are inserted into the beacon node's slashing operations pool.
+package remote
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/async/event"
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed/operation"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// The time to wait before reconnecting to the slasher after the stream broke.
+const reconnectPeriod = 5 * time.Second
+
+// ServiceConfig for the remote slasher service in the beacon node.
+type ServiceConfig struct {
+	Endpoint                string
+	CertFlag                string
+	IndexedAttestationsFeed *event.Feed
+	BeaconBlockHeadersFeed  *event.Feed
+	SlashingPoolInserter    slashings.PoolInserter
+	HeadStateFetcher        blockchain.HeadFetcher
+	OperationNotifier       operation.Notifier
+}
+
+// Service streaming the data of the beacon node to a standalone slasher.
+type Service struct {
+	cfg       *ServiceConfig
+	ctx       context.Context
+	cancel    context.CancelFunc
+	conn      *grpc.ClientConn
+	client    ethpb.SlasherClient
+	streamErr error
+	lock      sync.RWMutex
+}
+
+// New instantiates a new remote slasher service from configuration values.
+func New(ctx context.Context, cfg *ServiceConfig) (*Service, error) {
+	var transportSecurity grpc.DialOption
+	if cfg.CertFlag != "" {
+		creds, err := credentials.NewClientTLSFromFile(cfg.CertFlag, "")
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get valid credentials")
+		}
+		transportSecurity = grpc.WithTransportCredentials(creds)
+	} else {
+		transportSecurity = grpc.WithInsecure()
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	conn, err := grpc.DialContext(ctx, cfg.Endpoint, transportSecurity)
+	if err != nil {
+		cancel()
+		return nil, errors.Wrapf(err, "could not dial slasher endpoint %s", cfg.Endpoint)
+	}
+	return &Service{
+		cfg:    cfg,
+		ctx:    ctx,
+		cancel: cancel,
+		conn:   conn,
+		client: ethpb.NewSlasherClient(conn),
+	}, nil
+}
+
+// Start streaming data to the slasher.
+func (s *Service) Start() {
+	go s.run()
+}
+
+// Stop the remote slasher service.
+func (s *Service) Stop() error {
+	s.cancel()
+	return s.conn.Close()
+}
+
+// Status of the remote slasher service, which is the error that broke the last stream
+// until it is reopened.
+func (s *Service) Status() error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.streamErr
+}
+
+func (s *Service) run() {
+	indexedAttsChan := make(chan *ethpb.IndexedAttestation, 1)
+	attsSub := s.cfg.IndexedAttestationsFeed.Subscribe(indexedAttsChan)
+	defer attsSub.Unsubscribe()
+	beaconBlockHeadersChan := make(chan *ethpb.SignedBeaconBlockHeader, 1)
+	blocksSub := s.cfg.BeaconBlockHeadersFeed.Subscribe(beaconBlockHeadersChan)
+	defer blocksSub.Unsubscribe()
+
+	for {
+		err := s.streamSlasherData(s.ctx, indexedAttsChan, beaconBlockHeadersChan)
+		if s.ctx.Err() != nil {
+			return
+		}
+		log.WithError(err).WithField("endpoint", s.cfg.Endpoint).Warn("Lost connection to slasher, reconnecting")
+		s.lock.Lock()
+		s.streamErr = err
+		s.lock.Unlock()
+		if !s.waitToReconnect(indexedAttsChan, beaconBlockHeadersChan) {
+			return
+		}
+	}
+}
+
+// Streams the data received on the channels to the slasher, and inserts the slashings it sends
+// back into the operations pool, until the stream breaks or the context is canceled.
+func (s *Service) streamSlasherData(
+	ctx context.Context,
+	indexedAttsChan <-chan *ethpb.IndexedAttestation,
+	beaconBlockHeadersChan <-chan *ethpb.SignedBeaconBlockHeader,
+) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := s.client.StreamSlasherData(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not open stream")
+	}
+	s.lock.Lock()
+	s.streamErr = nil
+	s.lock.Unlock()
+
+	recvErr := make(chan error, 1)
+	go func() {
+		recvErr <- s.receiveSlashings(ctx, stream)
+	}()
+	for {
+		select {
+		case att := <-indexedAttsChan:
+			if err := stream.Send(&ethpb.SlasherData{
+				Data: &ethpb.SlasherData_IndexedAttestation{IndexedAttestation: att},
+			}); err != nil {
+				return errors.Wrap(err, "could not send indexed attestation")
+			}
+		case blockHeader := <-beaconBlockHeadersChan:
+			if err := stream.Send(&ethpb.SlasherData{
+				Data: &ethpb.SlasherData_BlockHeader{BlockHeader: blockHeader},
+			}); err != nil {
+				return errors.Wrap(err, "could not send block header")
+			}
+		case err := <-recvErr:
+			return err
+		case <-ctx.Done():
+			return stream.CloseSend()
+		}
+	}
+}
+
+func (s *Service) receiveSlashings(ctx context.Context, stream ethpb.Slasher_StreamSlasherDataClient) error {
+	for {
+		detected, err := stream.Recv()
+		if err != nil {
+			return errors.Wrap(err, "could not receive slashings")
+		}
+		s.insertSlashings(ctx, detected)
+	}
+}
+
+// Inserts the slashings detected by the slasher into the operations pool, which verifies them
+// against the head state.
+func (s *Service) insertSlashings(ctx context.Context, detected *ethpb.DetectedSlashings) {
+	headState, err := s.cfg.HeadStateFetcher.HeadState(ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not get head state")
+		return
+	}
+	for _, sl := range detected.AttesterSlashings {
+		if err := s.cfg.SlashingPoolInserter.InsertAttesterSlashing(ctx, headState, sl); err != nil {
+			log.WithError(err).Error("Could not insert attester slashing into operations pool")
+			continue
+		}
+		operation.Notify(s.cfg.OperationNotifier, operation.AttesterSlashingReceived,
+			&operation.AttesterSlashingReceivedData{AttesterSlashing: sl})
+	}
+	for _, sl := range detected.ProposerSlashings {
+		if err := s.cfg.SlashingPoolInserter.InsertProposerSlashing(ctx, headState, sl); err != nil {
+			log.WithError(err).Error("Could not insert proposer slashing into operations pool")
+			continue
+		}
+		operation.Notify(s.cfg.OperationNotifier, operation.ProposerSlashingReceived,
+			&operation.ProposerSlashingReceivedData{ProposerSlashing: sl})
+	}
+}
+
+// Waits before reconnecting to the slasher. The data received meanwhile is dropped, not to
+// block the senders on the feeds. Returns false if the service is stopped.
+func (s *Service) waitToReconnect(
+	indexedAttsChan <-chan *ethpb.IndexedAttestation,
+	beaconBlockHeadersChan <-chan *ethpb.SignedBeaconBlockHeader,
+) bool {
+	timer := time.NewTimer(reconnectPeriod)
+	defer timer.Stop()
+	for {
+		select {
+		case <-indexedAttsChan:
+		case <-beaconBlockHeadersChan:
+		case <-timer.C:
+			return true
+		case <-s.ctx.Done():
+			return false
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package remote
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	slashingsmock "github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings/mock"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	mockslasher "github.com/prysmaticlabs/prysm/testing/mock"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+	"google.golang.org/grpc"
+)
+
+type mockStreamSlasherDataClient struct {
+	grpc.ClientStream
+	sent chan *ethpb.SlasherData
+	recv chan *ethpb.DetectedSlashings
+}
+
+func (m *mockStreamSlasherDataClient) Send(data *ethpb.SlasherData) error {
+	m.sent <- data
+	return nil
+}
+
+func (m *mockStreamSlasherDataClient) Recv() (*ethpb.DetectedSlashings, error) {
+	detected, ok := <-m.recv
+	if !ok {
+		return nil, io.EOF
+	}
+	return detected, nil
+}
+
+func (*mockStreamSlasherDataClient) CloseSend() error {
+	return nil
+}
+
+func TestService_streamSlasherData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	beaconState, err := util.NewBeaconState()
+	require.NoError(t, err)
+	pool := &slashingsmock.PoolMock{}
+	client := mockslasher.NewMockSlasherClient(ctrl)
+	stream := &mockStreamSlasherDataClient{
+		sent: make(chan *ethpb.SlasherData),
+		recv: make(chan *ethpb.DetectedSlashings),
+	}
+	client.EXPECT().StreamSlasherData(gomock.Any()).Return(stream, nil)
+	s := &Service{
+		cfg: &ServiceConfig{
+			SlashingPoolInserter: pool,
+			HeadStateFetcher:     &mock.ChainService{State: beaconState},
+			OperationNotifier:    &mock.MockOperationNotifier{},
+		},
+		ctx:    ctx,
+		client: client,
+	}
+
+	indexedAttsChan := make(chan *ethpb.IndexedAttestation, 1)
+	beaconBlockHeadersChan := make(chan *ethpb.SignedBeaconBlockHeader, 1)
+	exitRoutine := make(chan error)
+	go func() {
+		exitRoutine <- s.streamSlasherData(ctx, indexedAttsChan, beaconBlockHeadersChan)
+	}()
+
+	au := util.AttestationUtil{}
+	att := au.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{1}})
+	indexedAttsChan <- att
+	require.DeepEqual(t, att, (<-stream.sent).GetIndexedAttestation())
+	header := util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{})
+	beaconBlockHeadersChan <- header
+	require.DeepEqual(t, header, (<-stream.sent).GetBlockHeader())
+
+	attesterSlashing := &ethpb.AttesterSlashing{Attestation_1: att, Attestation_2: att}
+	proposerSlashing := &ethpb.ProposerSlashing{Header_1: header, Header_2: header}
+	stream.recv <- &ethpb.DetectedSlashings{
+		AttesterSlashings: []*ethpb.AttesterSlashing{attesterSlashing},
+		ProposerSlashings: []*ethpb.ProposerSlashing{proposerSlashing},
+	}
+	close(stream.recv)
+	require.ErrorContains(t, "could not receive slashings", <-exitRoutine)
+	require.DeepEqual(t, []*ethpb.AttesterSlashing{attesterSlashing}, pool.PendingAttSlashings)
+	require.DeepEqual(t, []*ethpb.ProposerSlashing{proposerSlashing}, pool.PendingPropSlashings)
+}
+
+func TestService_insertSlashings_NoOperationNotifier(t *testing.T) {
+	beaconState, err := util.NewBeaconState()
+	require.NoError(t, err)
+	pool := &slashingsmock.PoolMock{}
+	s := &Service{
+		cfg: &ServiceConfig{
+			SlashingPoolInserter: pool,
+			HeadStateFetcher:     &mock.ChainService{State: beaconState},
+		},
+	}
+
+	header := util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{})
+	proposerSlashing := &ethpb.ProposerSlashing{Header_1: header, Header_2: header}
+	s.insertSlashings(context.Background(), &ethpb.DetectedSlashings{
+		ProposerSlashings: []*ethpb.ProposerSlashing{proposerSlashing},
+	})
+	require.DeepEqual(t, []*ethpb.ProposerSlashing{proposerSlashing}, pool.PendingPropSlashings)
+}
@@ -6,16 +6,19 @@ package slasher
 
 import (
 	"context"
+	"sync"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/async/event"
 	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed/operation"
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
-	"github.com/prysmaticlabs/prysm/beacon-chain/sync"
+	regularsync "github.com/prysmaticlabs/prysm/beacon-chain/sync"
 	"github.com/prysmaticlabs/prysm/config/params"
 	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
@@ -38,7 +41,27 @@ type ServiceConfig struct {
 	StateGen                stategen.StateManager
 	SlashingPoolInserter    slashings.PoolInserter
 	HeadStateFetcher        blockchain.HeadFetcher
-	SyncChecker             sync.Checker
+	SyncChecker             regularsync.Checker
+	OperationNotifier       operation.Notifier
+	// A standalone slasher runs in its own process and receives its data from remote beacon
+	// nodes. It knows the chain from the parameters below, and sends the slashings it detects
+	// on the slashings feed for the beacon nodes to verify and insert into their pools.
+	Standalone            bool
+	GenesisTime           time.Time
+	ValidatorCountFetcher ValidatorCountFetcher
+	SlashingsFeed         *event.Feed
+	// A backfill replays the finalized blocks of the beacon database from the start epoch
+	// to the end epoch through slashing detection on startup, see backfill.go.
+	BeaconDatabase     db.ReadOnlyDatabase
+	Backfill           bool
+	BackfillStartEpoch types.Epoch
+	BackfillEndEpoch   types.Epoch
+}
+
+// ValidatorCountFetcher defines a source for the number of validators in the registry,
+// for a standalone slasher which has no beacon state of its own.
+type ValidatorCountFetcher interface {
+	ValidatorCount(ctx context.Context) (int, error)
 }
 
 // SlashingChecker is an interface for defining services that the beacon node may interact with to provide slashing data.
@@ -66,6 +89,10 @@ type Service struct {
 	blocksSlotTicker               *slots.SlotTicker
 	pruningSlotTicker              *slots.SlotTicker
 	latestEpochWrittenForValidator map[types.ValidatorIndex]types.Epoch
+	// The processing locks serialize slashing detection between the processing
+	// routines and a backfill.
+	attsProcessingLock   sync.Mutex
+	blocksProcessingLock sync.Mutex
 }
 
 // New instantiates a new slasher from configuration values.
@@ -91,18 +118,21 @@ func (s *Service) Start() {
 }
 
 func (s *Service) run() {
-	s.waitForChainInitialization()
-	s.waitForSync(s.genesisTime)
+	if s.serviceCfg.Standalone {
+		s.genesisTime = s.serviceCfg.GenesisTime
+	} else {
+		s.waitForChainInitialization()
+		s.waitForSync(s.genesisTime)
+	}
 
 	log.Info("Completed chain sync, starting slashing detection")
 
 	// Get the latest eopch written for each validator from disk on startup.
-	headState, err := s.serviceCfg.HeadStateFetcher.HeadState(s.ctx)
+	numVals, err := s.numValidators()
 	if err != nil {
-		log.WithError(err).Error("Failed to fetch head state")
+		log.WithError(err).Error("Failed to fetch number of validators")
 		return
 	}
-	numVals := headState.NumValidators()
 	validatorIndices := make([]types.ValidatorIndex, numVals)
 	for i := 0; i < numVals; i++ {
 		validatorIndices[i] = types.ValidatorIndex(i)
@@ -135,6 +165,10 @@ func (s *Service) run() {
 	go s.processQueuedAttestations(s.ctx, s.attsSlotTicker.C())
 	go s.processQueuedBlocks(s.ctx, s.blocksSlotTicker.C())
 	go s.pruneSlasherData(s.ctx, s.pruningSlotTicker.C())
+	// A backfill runs alongside the processing of live data.
+	if s.serviceCfg.Backfill && !s.serviceCfg.Standalone {
+		go s.backfill(s.ctx)
+	}
 }
 
 // Stop the slasher service.
@@ -166,6 +200,19 @@ func (s *Service) Stop() error {
 	return nil
 }
 
+// The number of validators in the registry, read from the head state or from the
+// validator count fetcher of a standalone slasher.
+func (s *Service) numValidators() (int, error) {
+	if s.serviceCfg.Standalone {
+		return s.serviceCfg.ValidatorCountFetcher.ValidatorCount(s.ctx)
+	}
+	headState, err := s.serviceCfg.HeadStateFetcher.HeadState(s.ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not fetch head state")
+	}
+	return headState.NumValidators(), nil
+}
+
 // Status of the slasher service.
 func (_ *Service) Status() error {
 	return nil
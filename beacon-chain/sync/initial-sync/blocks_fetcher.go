@@ -64,8 +64,8 @@ type blocksFetcherConfig struct {
 }
 
 // blocksFetcher is a service to fetch chain data from peers.
-// On an incoming requests, requested block range is evenly divided
-// among available peers (for fair network load distribution).
+// On an incoming requests, requested block range is divided among available
+// peers, proportionally to their measured throughput.
 type blocksFetcher struct {
 	sync.Mutex
 	ctx             context.Context
@@ -79,9 +79,10 @@ type blocksFetcher struct {
 	peerLocks       map[peer.ID]*peerLock
 	fetchRequests   chan *fetchRequestParams
 	fetchResponses  chan *fetchRequestResponse
-	capacityWeight  float64       // how remaining capacity affects peer selection
-	mode            syncMode      // allows to use fetcher in different sync scenarios
-	quit            chan struct{} // termination notifier
+	capacityWeight  float64        // how remaining capacity affects peer selection
+	mode            syncMode       // allows to use fetcher in different sync scenarios
+	quit            chan struct{}  // termination notifier
+	requestsWg      sync.WaitGroup // tracks requests that may outlive their originating fetch request
 }
 
 // peerLock restricts fetcher actions on per peer basis. Currently, used for rate limiting.
@@ -161,6 +162,7 @@ func (f *blocksFetcher) stop() {
 	}()
 	f.cancel()
 	<-f.quit // make sure that loop() is done
+	f.requestsWg.Wait()
 }
 
 // requestResponses exposes a channel into which fetcher pushes generated request responses.
@@ -269,11 +271,12 @@ func (f *blocksFetcher) handleRequest(ctx context.Context, start types.Slot, cou
 		}
 	}
 
-	response.blocks, response.pid, response.err = f.fetchBlocksFromPeer(ctx, start, count, peers)
+	response.blocks, response.pid, response.err = f.fetchBlocksFromPeers(ctx, start, count, peers)
 	return response
 }
 
-// fetchBlocksFromPeer fetches blocks from a single randomly selected peer.
+// fetchBlocksFromPeer fetches blocks from a single peer, trying the given peers in order. Peers are
+// expected to be filtered by the caller already.
 func (f *blocksFetcher) fetchBlocksFromPeer(
 	ctx context.Context,
 	start types.Slot, count uint64,
@@ -282,7 +285,6 @@ func (f *blocksFetcher) fetchBlocksFromPeer(
 	ctx, span := trace.StartSpan(ctx, "initialsync.fetchBlocksFromPeer")
 	defer span.End()
 
-	peers = f.filterPeers(ctx, peers, peersPercentagePerRequest)
 	req := &p2ppb.BeaconBlocksByRangeRequest{
 		StartSlot: start,
 		Count:     count,
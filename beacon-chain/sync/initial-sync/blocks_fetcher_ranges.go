@@ -0,0 +1,354 @@
+package initialsync
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/prysmaticlabs/prysm/consensus-types/interfaces"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	p2ppb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+const (
+	// minRangeSplitSize is the smallest sub-range (in slots) that is assigned to a single peer
+	// when a requested range is split among several peers.
+	minRangeSplitSize = 16
+	// maxPeersPerRange caps how many peers a single requested range is split among.
+	maxPeersPerRange = 4
+	// minSplitScoreRatio defines how high (relative to the best candidate) a peer's block provider
+	// score must be for the peer to be assigned a sub-range. This keeps peers that have not proven
+	// themselves to be good block providers from stalling every range they are assigned to.
+	minSplitScoreRatio = 0.5
+	// stalledRequestFactor defines how many times slower than its measured throughput a peer
+	// must be before its request is considered stalled and is re-requested from another peer.
+	stalledRequestFactor = 3
+	// minStalledRequestTimeout is the lower bound on time a peer is given before its request
+	// is re-requested from another peer.
+	minStalledRequestTimeout = 2 * time.Second
+	// maxStalledRequestTimeout is the upper bound on time a peer is given before its request
+	// is re-requested from another peer. It is also used for peers without measured throughput.
+	maxStalledRequestTimeout = 8 * time.Second
+)
+
+// rangeAssignment is a sub-range of a requested block range, assigned to a single peer.
+type rangeAssignment struct {
+	pid   peer.ID
+	start types.Slot
+	count uint64
+}
+
+// rangeResult holds outcome of a single sub-range request.
+type rangeResult struct {
+	pid    peer.ID
+	blocks []interfaces.SignedBeaconBlock
+	err    error
+}
+
+// fetchBlocksFromPeers fetches the requested range by splitting it among several peers, with
+// sub-range sizes proportional to peers' measured throughput. Sub-ranges are requested in
+// parallel, and a stalled sub-range is speculatively re-requested from a fallback peer. Whenever
+// the range is too small to be split, fetching is done from a single peer.
+func (f *blocksFetcher) fetchBlocksFromPeers(
+	ctx context.Context,
+	start types.Slot, count uint64,
+	peers []peer.ID,
+) ([]interfaces.SignedBeaconBlock, peer.ID, error) {
+	ctx, span := trace.StartSpan(ctx, "initialsync.fetchBlocksFromPeers")
+	defer span.End()
+
+	peers = f.filterPeers(ctx, peers, peersPercentagePerRequest)
+	assignments := f.splitRange(start, count, peers)
+	if len(assignments) <= 1 {
+		return f.fetchBlocksFromPeer(ctx, start, count, peers)
+	}
+	assigned := make(map[peer.ID]bool, len(assignments))
+	for _, a := range assignments {
+		assigned[a.pid] = true
+	}
+	fallbacks := make([]peer.ID, 0, len(peers))
+	for _, pid := range peers {
+		if !assigned[pid] {
+			fallbacks = append(fallbacks, pid)
+		}
+	}
+	if len(fallbacks) == 0 {
+		fallbacks = peers
+	}
+
+	results := make([]chan *rangeResult, len(assignments))
+	for i, a := range assignments {
+		results[i] = make(chan *rangeResult, 1)
+		go func(a *rangeAssignment, ch chan<- *rangeResult) {
+			ch <- f.fetchRangeWithFallback(ctx, a, fallbacks)
+		}(a, results[i])
+	}
+
+	rangeResults := make([]*rangeResult, len(results))
+	for i, ch := range results {
+		rangeResults[i] = <-ch
+		if rangeResults[i].err != nil {
+			return nil, "", rangeResults[i].err
+		}
+	}
+	return f.stitchRanges(ctx, start, assignments, rangeResults)
+}
+
+// stitchRanges concatenates sub-range results into a single ordered range. Since a peer might
+// withhold blocks of its sub-range, boundaries between sub-ranges are checked to link into the
+// preceding blocks. Whenever links are broken, everything preceding the last broken boundary is
+// re-requested from the peer that served blocks past that boundary (the peer evidently has the
+// blocks being linked to). Any remaining inconsistencies are left for block processing to reject.
+func (f *blocksFetcher) stitchRanges(
+	ctx context.Context,
+	start types.Slot,
+	assignments []*rangeAssignment,
+	results []*rangeResult,
+) ([]interfaces.SignedBeaconBlock, peer.ID, error) {
+	var blocks []interfaces.SignedBeaconBlock
+	lastBroken := 0
+	for i, res := range results {
+		if len(res.blocks) > 0 && len(blocks) > 0 {
+			linked, err := isLinked(blocks[len(blocks)-1], res.blocks[0])
+			if err != nil {
+				return nil, "", err
+			}
+			if !linked {
+				lastBroken = i
+			}
+		}
+		blocks = append(blocks, res.blocks...)
+	}
+	if lastBroken == 0 {
+		return blocks, mostServingPeer(results), nil
+	}
+
+	res := results[lastBroken]
+	log.WithFields(logrus.Fields{
+		"peer":  res.pid,
+		"start": start,
+		"slot":  assignments[lastBroken].start,
+	}).Debug("Sub-ranges do not link, re-requesting preceding blocks")
+	req := &p2ppb.BeaconBlocksByRangeRequest{
+		StartSlot: start,
+		Count:     uint64(assignments[lastBroken].start - start),
+		Step:      1,
+	}
+	prefix, err := f.requestBlocks(ctx, req, res.pid)
+	if err != nil {
+		log.WithError(err).WithField("peer", res.pid).Debug("Could not re-request blocks")
+		return blocks, mostServingPeer(results), nil
+	}
+	blocks = prefix
+	for _, res := range results[lastBroken:] {
+		blocks = append(blocks, res.blocks...)
+	}
+	results = append([]*rangeResult{{pid: res.pid, blocks: prefix}}, results[lastBroken:]...)
+	return blocks, mostServingPeer(results), nil
+}
+
+// mostServingPeer returns the peer that has served most of the blocks in the given results.
+func mostServingPeer(results []*rangeResult) peer.ID {
+	servedBy := make(map[peer.ID]int, len(results))
+	var pid peer.ID
+	var mostServed int
+	for _, res := range results {
+		servedBy[res.pid] += len(res.blocks)
+		if servedBy[res.pid] > mostServed {
+			pid, mostServed = res.pid, servedBy[res.pid]
+		}
+	}
+	return pid
+}
+
+// isLinked checks whether the given block is a child of the parent block.
+func isLinked(parent, child interfaces.SignedBeaconBlock) (bool, error) {
+	parentRoot, err := parent.Block().HashTreeRoot()
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(child.Block().ParentRoot(), parentRoot[:]), nil
+}
+
+// splitRange divides [start, start+count) range among peers, proportionally to their measured
+// throughput. Peers with no measurements yet are assumed to be as fast as an average measured peer.
+func (f *blocksFetcher) splitRange(start types.Slot, count uint64, peers []peer.ID) []*rangeAssignment {
+	scorer := f.p2p.Peers().Scorers().BlockProviderScorer()
+	peers = f.splitCandidates(peers)
+	numPeers := count / minRangeSplitSize
+	if numPeers > maxPeersPerRange {
+		numPeers = maxPeersPerRange
+	}
+	if numPeers > uint64(len(peers)) {
+		numPeers = uint64(len(peers))
+	}
+	if numPeers <= 1 {
+		if len(peers) == 0 {
+			return []*rangeAssignment{}
+		}
+		return []*rangeAssignment{{pid: peers[0], start: start, count: count}}
+	}
+	peers = peers[:numPeers]
+
+	throughputs := make([]float64, len(peers))
+	var measured, total float64
+	var numMeasured int
+	for i, pid := range peers {
+		throughputs[i] = scorer.Throughput(pid)
+		if throughputs[i] > 0 {
+			measured += throughputs[i]
+			numMeasured++
+		}
+	}
+	average := float64(1)
+	if numMeasured > 0 {
+		average = measured / float64(numMeasured)
+	}
+	for i := range throughputs {
+		if throughputs[i] == 0 {
+			throughputs[i] = average
+		}
+		total += throughputs[i]
+	}
+
+	// Every peer gets a minimum sized sub-range, the rest is split proportionally to throughput.
+	remaining := count - numPeers*minRangeSplitSize
+	assignments := make([]*rangeAssignment, len(peers))
+	slot := start
+	for i, pid := range peers {
+		size := minRangeSplitSize + uint64(float64(remaining)*throughputs[i]/total)
+		if i == len(peers)-1 {
+			size = count - uint64(slot-start)
+		}
+		assignments[i] = &rangeAssignment{pid: pid, start: slot, count: size}
+		slot = slot.Add(size)
+	}
+	return assignments
+}
+
+// splitCandidates returns peers, in their original order, that score high enough to be assigned
+// a sub-range.
+func (f *blocksFetcher) splitCandidates(peers []peer.ID) []peer.ID {
+	scorer := f.p2p.Peers().Scorers().BlockProviderScorer()
+	scores := make([]float64, len(peers))
+	var best float64
+	for i, pid := range peers {
+		scores[i] = scorer.Score(pid)
+		if scores[i] > best {
+			best = scores[i]
+		}
+	}
+	candidates := make([]peer.ID, 0, len(peers))
+	for i, pid := range peers {
+		if scores[i] >= best*minSplitScoreRatio {
+			candidates = append(candidates, pid)
+		}
+	}
+	return candidates
+}
+
+// fetchRangeWithFallback requests a sub-range from its assigned peer. Should the peer fail to
+// respond within the time expected from its measured throughput, the same sub-range is
+// re-requested from a fallback peer, and whichever response comes first successfully is used.
+func (f *blocksFetcher) fetchRangeWithFallback(
+	ctx context.Context, a *rangeAssignment, fallbacks []peer.ID,
+) *rangeResult {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	req := &p2ppb.BeaconBlocksByRangeRequest{
+		StartSlot: a.start,
+		Count:     a.count,
+		Step:      1,
+	}
+	// Requests are buffered, so that the slower of two competing requests doesn't block once
+	// the faster one is returned. Fetcher waits for such stragglers before releasing resources.
+	results := make(chan *rangeResult, 2)
+	request := func(pid peer.ID) {
+		defer f.requestsWg.Done()
+		started := time.Now()
+		blocks, err := f.requestBlocks(ctx, req, pid)
+		if err == nil {
+			scorer := f.p2p.Peers().Scorers().BlockProviderScorer()
+			scorer.Touch(pid)
+			scorer.RecordThroughput(pid, uint64(len(blocks)), time.Since(started))
+		}
+		results <- &rangeResult{pid: pid, blocks: blocks, err: err}
+	}
+
+	f.requestsWg.Add(1)
+	go request(a.pid)
+	inFlight := 1
+	fallbackStarted := false
+	startFallback := func() {
+		if fallbackStarted {
+			return
+		}
+		if pid, ok := f.selectFallbackPeer(a.pid, fallbacks); ok {
+			fallbackStarted = true
+			inFlight++
+			f.requestsWg.Add(1)
+			go request(pid)
+		}
+	}
+	timer := time.NewTimer(f.stalledRequestTimeout(a.pid, a.count))
+	defer timer.Stop()
+	var lastErr error
+	for inFlight > 0 {
+		select {
+		case res := <-results:
+			inFlight--
+			if res.err == nil {
+				return res
+			}
+			lastErr = res.err
+			log.WithError(res.err).WithField("peer", res.pid).Debug("Could not request blocks by range")
+			// Do not wait for the timer, if the assigned peer failed outright.
+			startFallback()
+		case <-timer.C:
+			log.WithFields(logrus.Fields{
+				"peer":  a.pid,
+				"start": a.start,
+				"count": a.count,
+			}).Debug("Request is stalled, re-requesting blocks from another peer")
+			startFallback()
+		case <-ctx.Done():
+			return &rangeResult{err: ctx.Err()}
+		}
+	}
+	if lastErr == nil {
+		lastErr = errNoPeersAvailable
+	}
+	return &rangeResult{err: lastErr}
+}
+
+// stalledRequestTimeout returns time a peer is given to serve the given number of blocks, before
+// its request is considered stalled.
+func (f *blocksFetcher) stalledRequestTimeout(pid peer.ID, count uint64) time.Duration {
+	throughput := f.p2p.Peers().Scorers().BlockProviderScorer().Throughput(pid)
+	if throughput <= 0 {
+		return maxStalledRequestTimeout
+	}
+	timeout := time.Duration(stalledRequestFactor * float64(count) / throughput * float64(time.Second))
+	if timeout < minStalledRequestTimeout {
+		return minStalledRequestTimeout
+	}
+	if timeout > maxStalledRequestTimeout {
+		return maxStalledRequestTimeout
+	}
+	return timeout
+}
+
+// selectFallbackPeer selects a peer, other than the excluded one, to re-request blocks from.
+func (f *blocksFetcher) selectFallbackPeer(excludedPID peer.ID, peers []peer.ID) (peer.ID, bool) {
+	candidates := make([]peer.ID, len(peers))
+	copy(candidates, peers)
+	pid, err := f.selectFailOverPeer(excludedPID, candidates)
+	if err != nil {
+		return "", false
+	}
+	return pid, true
+}
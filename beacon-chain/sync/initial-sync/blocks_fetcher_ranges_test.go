@@ -0,0 +1,238 @@
+package initialsync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/time/slots"
+)
+
+func TestBlocksFetcher_splitRange(t *testing.T) {
+	tests := []struct {
+		name        string
+		start       types.Slot
+		count       uint64
+		peers       []peer.ID
+		throughputs map[peer.ID]float64
+		want        []*rangeAssignment
+	}{
+		{
+			name:  "no peers",
+			start: 1,
+			count: 64,
+			peers: []peer.ID{},
+			want:  []*rangeAssignment{},
+		},
+		{
+			name:  "single peer",
+			start: 1,
+			count: 64,
+			peers: []peer.ID{"a"},
+			want:  []*rangeAssignment{{pid: "a", start: 1, count: 64}},
+		},
+		{
+			name:  "range too small to split",
+			start: 1,
+			count: minRangeSplitSize + 1,
+			peers: []peer.ID{"a", "b"},
+			want:  []*rangeAssignment{{pid: "a", start: 1, count: minRangeSplitSize + 1}},
+		},
+		{
+			name:  "no measurements, split evenly",
+			start: 1,
+			count: 64,
+			peers: []peer.ID{"a", "b"},
+			want: []*rangeAssignment{
+				{pid: "a", start: 1, count: 32},
+				{pid: "b", start: 33, count: 32},
+			},
+		},
+		{
+			name:  "split proportionally to throughput",
+			start: 100,
+			count: 64,
+			peers: []peer.ID{"a", "b"},
+			throughputs: map[peer.ID]float64{
+				"a": 30,
+				"b": 10,
+			},
+			want: []*rangeAssignment{
+				{pid: "a", start: 100, count: 40},
+				{pid: "b", start: 140, count: 24},
+			},
+		},
+		{
+			name:  "unmeasured peer is treated as average",
+			start: 1,
+			count: 96,
+			peers: []peer.ID{"a", "b", "c"},
+			throughputs: map[peer.ID]float64{
+				"a": 40,
+				"b": 20,
+			},
+			want: []*rangeAssignment{
+				{pid: "a", start: 1, count: 37},
+				{pid: "b", start: 38, count: 26},
+				{pid: "c", start: 64, count: 33},
+			},
+		},
+		{
+			name:  "number of peers is capped",
+			start: 1,
+			count: 640,
+			peers: []peer.ID{"a", "b", "c", "d", "e", "f"},
+			want: []*rangeAssignment{
+				{pid: "a", start: 1, count: 160},
+				{pid: "b", start: 161, count: 160},
+				{pid: "c", start: 321, count: 160},
+				{pid: "d", start: 481, count: 160},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc, p2p, _ := initializeTestServices(t, []types.Slot{}, []*peerData{})
+			fetcher := newBlocksFetcher(context.Background(), &blocksFetcherConfig{
+				chain: mc,
+				p2p:   p2p,
+			})
+			for pid, throughput := range tt.throughputs {
+				p2p.Peers().Scorers().BlockProviderScorer().RecordThroughput(pid, uint64(throughput), time.Second)
+			}
+			assert.DeepEqual(t, tt.want, fetcher.splitRange(tt.start, tt.count, tt.peers))
+		})
+	}
+}
+
+func TestBlocksFetcher_stalledRequestTimeout(t *testing.T) {
+	mc, p2p, _ := initializeTestServices(t, []types.Slot{}, []*peerData{})
+	fetcher := newBlocksFetcher(context.Background(), &blocksFetcherConfig{
+		chain: mc,
+		p2p:   p2p,
+	})
+	scorer := p2p.Peers().Scorers().BlockProviderScorer()
+	scorer.RecordThroughput("fast", 640, time.Second)
+	scorer.RecordThroughput("medium", 64, time.Second)
+	scorer.RecordThroughput("slow", 1, time.Second)
+
+	assert.Equal(t, maxStalledRequestTimeout, fetcher.stalledRequestTimeout("unknown", 64))
+	assert.Equal(t, minStalledRequestTimeout, fetcher.stalledRequestTimeout("fast", 64))
+	assert.Equal(t, 3*time.Second, fetcher.stalledRequestTimeout("medium", 64))
+	assert.Equal(t, maxStalledRequestTimeout, fetcher.stalledRequestTimeout("slow", 64))
+}
+
+func TestBlocksFetcher_fetchRangeWithFallback(t *testing.T) {
+	mc, p2p, _ := initializeTestServices(t, makeSequence(1, 128), []*peerData{})
+	healthy := connectPeer(t, p2p, &peerData{
+		blocks:         makeSequence(1, 128),
+		finalizedEpoch: 3,
+		headSlot:       128,
+	}, p2p.Peers())
+	failing := connectPeer(t, p2p, &peerData{
+		blocks:         makeSequence(1, 128),
+		finalizedEpoch: 3,
+		headSlot:       128,
+		failureSlots:   makeSequence(1, 128),
+	}, p2p.Peers())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fetcher := newBlocksFetcher(ctx, &blocksFetcherConfig{
+		chain: mc,
+		p2p:   p2p,
+	})
+
+	t.Run("assigned peer responds", func(t *testing.T) {
+		res := fetcher.fetchRangeWithFallback(ctx, &rangeAssignment{pid: healthy, start: 1, count: 32}, []peer.ID{failing})
+		require.NoError(t, res.err)
+		assert.Equal(t, healthy, res.pid)
+		assert.Equal(t, 32, len(res.blocks))
+		assert.Equal(t, true, p2p.Peers().Scorers().BlockProviderScorer().Throughput(healthy) > 0)
+	})
+
+	t.Run("assigned peer fails", func(t *testing.T) {
+		res := fetcher.fetchRangeWithFallback(ctx, &rangeAssignment{pid: failing, start: 33, count: 32}, []peer.ID{healthy})
+		require.NoError(t, res.err)
+		assert.Equal(t, healthy, res.pid)
+		assert.Equal(t, 32, len(res.blocks))
+		assert.Equal(t, types.Slot(33), res.blocks[0].Block().Slot())
+	})
+
+	t.Run("no fallback available", func(t *testing.T) {
+		res := fetcher.fetchRangeWithFallback(ctx, &rangeAssignment{pid: failing, start: 1, count: 32}, []peer.ID{failing})
+		assert.NotNil(t, res.err)
+	})
+}
+
+func TestBlocksFetcher_fetchBlocksFromPeers(t *testing.T) {
+	chainSlots := makeSequence(1, 320)
+	mc, p2p, _ := initializeTestServices(t, chainSlots, []*peerData{
+		{blocks: chainSlots, finalizedEpoch: 8, headSlot: 320},
+		{blocks: chainSlots, finalizedEpoch: 8, headSlot: 320},
+		{blocks: chainSlots, finalizedEpoch: 8, headSlot: 320},
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fetcher := newBlocksFetcher(ctx, &blocksFetcherConfig{
+		chain: mc,
+		p2p:   p2p,
+	})
+
+	_, peerIDs := p2p.Peers().BestFinalized(params.BeaconConfig().MaxPeersToSync, slots.ToEpoch(mc.HeadSlot()))
+	blocks, pid, err := fetcher.fetchBlocksFromPeers(ctx, 1, 64, peerIDs)
+	require.NoError(t, err)
+	assert.NotEqual(t, peer.ID(""), pid)
+	require.Equal(t, 64, len(blocks))
+	for i, blk := range blocks {
+		assert.Equal(t, types.Slot(i+1), blk.Block().Slot(), "Blocks are not in order")
+	}
+}
+
+func TestBlocksFetcher_fetchBlocksFromPeers_withheldBlocks(t *testing.T) {
+	chainSlots := makeSequence(1, 320)
+	mc, p2p, _ := initializeTestServices(t, chainSlots, []*peerData{})
+	// The first peer withholds most of the blocks, so its sub-range doesn't link with the next one.
+	withholding := connectPeer(t, p2p, &peerData{
+		blocks:         []types.Slot{1, 2},
+		finalizedEpoch: 8,
+		headSlot:       320,
+	}, p2p.Peers())
+	healthy := connectPeer(t, p2p, &peerData{
+		blocks:         chainSlots,
+		finalizedEpoch: 8,
+		headSlot:       320,
+	}, p2p.Peers())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fetcher := newBlocksFetcher(ctx, &blocksFetcherConfig{
+		chain: mc,
+		p2p:   p2p,
+	})
+
+	assignments := []*rangeAssignment{
+		{pid: withholding, start: 1, count: 32},
+		{pid: healthy, start: 33, count: 32},
+	}
+	results := []*rangeResult{
+		fetcher.fetchRangeWithFallback(ctx, assignments[0], nil),
+		fetcher.fetchRangeWithFallback(ctx, assignments[1], nil),
+	}
+	require.NoError(t, results[0].err)
+	require.NoError(t, results[1].err)
+	require.Equal(t, 2, len(results[0].blocks))
+
+	blocks, pid, err := fetcher.stitchRanges(ctx, 1, assignments, results)
+	require.NoError(t, err)
+	assert.Equal(t, healthy, pid)
+	require.Equal(t, 64, len(blocks))
+	for i, blk := range blocks {
+		assert.Equal(t, types.Slot(i+1), blk.Block().Slot(), "Blocks are not in order")
+	}
+}
@@ -90,6 +90,7 @@ type blocksQueue struct {
 type blocksQueueFetchedData struct {
 	pid    peer.ID
 	blocks []interfaces.SignedBeaconBlock
+	roots  [][32]byte // block roots, computed ahead of processing (optional)
 }
 
 // newBlocksQueue creates initialized priority queue.
@@ -21,6 +21,8 @@ import (
 const (
 	// counterSeconds is an interval over which an average rate will be calculated.
 	counterSeconds = 20
+	// preparedBatchesBuffer is a number of fetched batches that can be prepared ahead of processing.
+	preparedBatchesBuffer = 2
 )
 
 // blockReceiverFn defines block receiving function.
@@ -82,7 +84,7 @@ func (s *Service) syncToFinalizedEpoch(ctx context.Context, genesis time.Time) e
 		return err
 	}
 
-	for data := range queue.fetchedData {
+	for data := range s.prepareFetchedData(ctx, queue.fetchedData) {
 		s.processFetchedData(ctx, genesis, s.cfg.Chain.HeadSlot(), data)
 	}
 
@@ -130,7 +132,13 @@ func (s *Service) processFetchedData(
 	defer s.updatePeerScorerStats(data.pid, startSlot)
 
 	// Use Batch Block Verify to process and verify batches directly.
-	if err := s.processBatchedBlocks(ctx, genesis, data.blocks, s.cfg.Chain.ReceiveBlockBatch); err != nil {
+	var err error
+	if data.roots != nil {
+		err = s.processPreparedBlocks(ctx, genesis, data.blocks, data.roots, s.cfg.Chain.ReceiveBlockBatch)
+	} else {
+		err = s.processBatchedBlocks(ctx, genesis, data.blocks, s.cfg.Chain.ReceiveBlockBatch)
+	}
+	if err != nil {
 		log.WithError(err).Warn("Batch is not processed")
 	}
 }
@@ -252,43 +260,87 @@ func (s *Service) processBatchedBlocks(ctx context.Context, genesis time.Time,
 	if len(blks) == 0 {
 		return errors.New("0 blocks provided into method")
 	}
-	firstBlock := blks[0]
-	blkRoot, err := firstBlock.Block().HashTreeRoot()
+	blockRoots, err := computeBlockRoots(blks)
 	if err != nil {
 		return err
 	}
+	return s.processPreparedBlocks(ctx, genesis, blks, blockRoots, bFunc)
+}
+
+// processPreparedBlocks processes a batch of blocks, whose roots have already been computed.
+func (s *Service) processPreparedBlocks(ctx context.Context, genesis time.Time,
+	blks []interfaces.SignedBeaconBlock, blockRoots [][32]byte, bFunc batchBlockReceiverFn) error {
+	if len(blks) == 0 {
+		return errors.New("0 blocks provided into method")
+	}
+	if len(blks) != len(blockRoots) {
+		return fmt.Errorf("expected %d block roots, received %d", len(blks), len(blockRoots))
+	}
+	firstBlock := blks[0]
 	headSlot := s.cfg.Chain.HeadSlot()
-	for headSlot >= firstBlock.Block().Slot() && s.isProcessedBlock(ctx, firstBlock, blkRoot) {
+	for headSlot >= firstBlock.Block().Slot() && s.isProcessedBlock(ctx, firstBlock, blockRoots[0]) {
 		if len(blks) == 1 {
 			return errors.New("no good blocks in batch")
 		}
 		blks = blks[1:]
+		blockRoots = blockRoots[1:]
 		firstBlock = blks[0]
-		blkRoot, err = firstBlock.Block().HashTreeRoot()
-		if err != nil {
-			return err
-		}
 	}
-	s.logBatchSyncStatus(genesis, blks, blkRoot)
+	s.logBatchSyncStatus(genesis, blks, blockRoots[0])
 	parentRoot := bytesutil.ToBytes32(firstBlock.Block().ParentRoot())
 	if !s.cfg.Chain.HasBlock(ctx, parentRoot) {
 		return fmt.Errorf("%w: %#x (in processBatchedBlocks, slot=%d)", errParentDoesNotExist, firstBlock.Block().ParentRoot(), firstBlock.Block().Slot())
 	}
-	blockRoots := make([][32]byte, len(blks))
-	blockRoots[0] = blkRoot
 	for i := 1; i < len(blks); i++ {
 		b := blks[i]
 		if !bytes.Equal(b.Block().ParentRoot(), blockRoots[i-1][:]) {
 			return fmt.Errorf("expected linear block list with parent root of %#x but received %#x",
 				blockRoots[i-1][:], b.Block().ParentRoot())
 		}
+	}
+	return bFunc(ctx, blks, blockRoots)
+}
+
+// prepareFetchedData is a pipeline stage, which computes block roots and verifies signatures of
+// fetched batches ahead of their processing. This way, hashing and signature verification of
+// upcoming batches overlap with fetching and with the state transition of the batch that is
+// currently being processed.
+func (s *Service) prepareFetchedData(
+	ctx context.Context, fetchedData <-chan *blocksQueueFetchedData) <-chan *blocksQueueFetchedData {
+	preparedData := make(chan *blocksQueueFetchedData, preparedBatchesBuffer)
+	go func() {
+		defer close(preparedData)
+		for data := range fetchedData {
+			roots, err := computeBlockRoots(data.blocks)
+			if err != nil {
+				log.WithError(err).Debug("Could not compute block roots, deferring to processing")
+			} else {
+				data.roots = roots
+			}
+			if err := s.cfg.Chain.PreVerifyBlockBatchSignatures(ctx, data.blocks); err != nil {
+				log.WithError(err).Debug("Could not verify batch signatures, deferring to processing")
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case preparedData <- data:
+			}
+		}
+	}()
+	return preparedData
+}
+
+// computeBlockRoots returns hash tree roots of a given list of blocks.
+func computeBlockRoots(blks []interfaces.SignedBeaconBlock) ([][32]byte, error) {
+	blockRoots := make([][32]byte, len(blks))
+	for i, b := range blks {
 		blkRoot, err := b.Block().HashTreeRoot()
 		if err != nil {
-			return err
+			return nil, err
 		}
 		blockRoots[i] = blkRoot
 	}
-	return bFunc(ctx, blks, blockRoots)
+	return blockRoots, nil
 }
 
 // updatePeerScorerStats adjusts monitored metrics for a peer.
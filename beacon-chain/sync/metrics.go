@@ -76,6 +76,20 @@ var (
 		},
 	)
 
+	upcomingSubnetSearchFailedCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "p2p_upcoming_subnet_search_failed_total",
+			Help: "Count of searches for peers on an upcoming duty's subnet that did not complete before the duty.",
+		},
+		[]string{"topic"},
+	)
+	surplusPeersEvictedCounter = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "p2p_surplus_peers_evicted_total",
+			Help: "Count of peers disconnected to make room for peers on upcoming duty subnets.",
+		},
+	)
+
 	arrivalBlockPropagationHistogram = promauto.NewHistogram(
 		prometheus.HistogramOpts{
 			Name:    "block_arrival_latency_milliseconds",
@@ -135,6 +135,8 @@ type Service struct {
 	syncContributionBitsOverlapLock  sync.RWMutex
 	syncContributionBitsOverlapCache *lru.Cache
 	signatureChan                    chan *signatureVerifier
+	upcomingSubnetsLock              sync.Mutex
+	upcomingSubnetSearches           map[string]bool
 }
 
 // NewService initializes new regular sync service.
@@ -272,6 +274,9 @@ func (s *Service) registerHandlers() {
 				}
 				currentEpoch := slots.ToEpoch(slots.CurrentSlot(uint64(s.cfg.chain.GenesisTime().Unix())))
 				s.registerSubscribers(currentEpoch, digest)
+				if !flags.Get().SubscribeToAllSubnets {
+					s.maintainUpcomingSubnetPeers()
+				}
 				go s.forkWatcher()
 				return
 			}
@@ -659,7 +659,7 @@ func (_ *Service) retrieveActiveSyncSubnets(currEpoch types.Epoch) []uint64 {
 }
 
 // filters out required peers for the node to function, not
-// pruning peers who are in our attestation subnets.
+// pruning peers who are in our attestation or sync committee subnets.
 func (s *Service) filterNeededPeers(pids []peer.ID) []peer.ID {
 	// Exit early if nothing to filter.
 	if len(pids) == 0 {
@@ -675,8 +675,9 @@ func (s *Service) filterNeededPeers(pids []peer.ID) []peer.ID {
 	wantedSubs = slice.SetUint64(append(wantedSubs, s.attesterSubnetIndices(currSlot)...))
 	topic := p2p.GossipTypeMapping[reflect.TypeOf(&ethpb.Attestation{})]
 
-	// Map of peers in subnets
-	peerMap := make(map[peer.ID]bool)
+	// Map of peers in subnets, starting with the peers
+	// needed for our active and upcoming sync subnets.
+	peerMap := s.neededSyncSubnetPeers(digest, currSlot)
 
 	for _, sub := range wantedSubs {
 		subnetTopic := fmt.Sprintf(topic, digest, sub) + s.cfg.p2p.Encoding().ProtocolSuffix()
@@ -0,0 +1,204 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	p2ptypes "github.com/prysmaticlabs/prysm/beacon-chain/p2p/types"
+	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/container/slice"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// The number of slots ahead of an aggregation duty in which we start
+// looking for peers on the duty's attestation subnet.
+const aggregatorSubnetLookahead = 4
+
+// upcomingSubnet represents a subnet required by a validator duty in
+// the near future, along with the slot by which peers are needed.
+type upcomingSubnet struct {
+	topic    string
+	index    uint64
+	deadline types.Slot
+}
+
+// maintainUpcomingSubnetPeers is a background routine which, every slot, looks
+// ahead at the aggregator and sync committee subnets registered by validators
+// and searches for peers on those subnets before the duties are due. Searches are
+// prioritized by how close their duty is, and surplus peers are evicted in the
+// event we are at our peer limit.
+func (s *Service) maintainUpcomingSubnetPeers() {
+	genesis := s.cfg.chain.GenesisTime()
+	ticker := slots.NewSlotTicker(genesis, params.BeaconConfig().SecondsPerSlot)
+
+	go func() {
+		for {
+			select {
+			case <-s.ctx.Done():
+				ticker.Done()
+				return
+			case currentSlot := <-ticker.C():
+				if s.chainStarted.IsSet() && s.cfg.initialSync.Syncing() {
+					continue
+				}
+				digest, err := s.currentForkDigest()
+				if err != nil {
+					log.WithError(err).Error("Could not retrieve current fork digest")
+					continue
+				}
+				for _, sub := range s.upcomingSubnets(currentSlot, digest) {
+					s.prepareUpcomingSubnet(sub)
+				}
+			}
+		}
+	}()
+}
+
+// upcomingSubnets returns the subnets which are needed by aggregators within the lookahead
+// window and by sync committee members joining in the next epoch, for which we do not yet
+// have enough peers. The returned subnets are sorted by their deadline, earliest first.
+func (s *Service) upcomingSubnets(currentSlot types.Slot, digest [4]byte) []*upcomingSubnet {
+	var subnets []*upcomingSubnet
+	seen := make(map[string]bool)
+	add := func(topicFormat string, idx uint64, deadline types.Slot) {
+		topic := fmt.Sprintf(topicFormat, digest, idx)
+		if seen[topic] || s.validPeersExist(topic) {
+			return
+		}
+		seen[topic] = true
+		subnets = append(subnets, &upcomingSubnet{topic: topic, index: idx, deadline: deadline})
+	}
+
+	attTopic := p2p.GossipTypeMapping[reflect.TypeOf(&ethpb.Attestation{})]
+	for slot := currentSlot + 1; slot <= currentSlot+aggregatorSubnetLookahead; slot++ {
+		for _, idx := range cache.SubnetIDs.GetAggregatorSubnetIDs(slot) {
+			add(attTopic, idx, slot)
+		}
+	}
+
+	currEpoch := slots.ToEpoch(currentSlot)
+	if currEpoch+1 >= params.BeaconConfig().AltairForkEpoch {
+		nextEpochStart, err := slots.EpochStart(currEpoch + 1)
+		if err != nil {
+			log.WithError(err).Error("Could not compute start slot of next epoch")
+			return subnets
+		}
+		syncTopic := p2p.GossipTypeMapping[reflect.TypeOf(&ethpb.SyncCommitteeMessage{})]
+		active := cache.SyncSubnetIDs.GetAllSubnets(currEpoch)
+		for _, idx := range cache.SyncSubnetIDs.GetAllSubnets(currEpoch + 1) {
+			if slice.IsInUint64(idx, active) {
+				continue
+			}
+			add(syncTopic, idx, nextEpochStart)
+		}
+	}
+
+	sort.SliceStable(subnets, func(i, j int) bool {
+		return subnets[i].deadline < subnets[j].deadline
+	})
+	return subnets
+}
+
+// prepareUpcomingSubnet starts a search for peers on the provided subnet which
+// is bound by the subnet's deadline. If we are already at our peer limit, surplus
+// peers which do not serve any needed subnet are disconnected first to make room.
+// Only one search per subnet is ever in flight.
+func (s *Service) prepareUpcomingSubnet(sub *upcomingSubnet) {
+	s.upcomingSubnetsLock.Lock()
+	if s.upcomingSubnetSearches == nil {
+		s.upcomingSubnetSearches = make(map[string]bool)
+	}
+	if s.upcomingSubnetSearches[sub.topic] {
+		s.upcomingSubnetsLock.Unlock()
+		return
+	}
+	s.upcomingSubnetSearches[sub.topic] = true
+	s.upcomingSubnetsLock.Unlock()
+
+	numOfPeers := len(s.cfg.p2p.PubSub().ListPeers(sub.topic + s.cfg.p2p.Encoding().ProtocolSuffix()))
+	wanted := flags.Get().MinimumPeersPerSubnet - numOfPeers
+	s.evictSurplusPeers(wanted)
+
+	deadline := slots.StartTime(uint64(s.cfg.chain.GenesisTime().Unix()), sub.deadline)
+	go func() {
+		defer func() {
+			s.upcomingSubnetsLock.Lock()
+			delete(s.upcomingSubnetSearches, sub.topic)
+			s.upcomingSubnetsLock.Unlock()
+		}()
+		ctx, cancel := context.WithDeadline(s.ctx, deadline)
+		defer cancel()
+		log.WithFields(logrus.Fields{
+			"topic":    sub.topic,
+			"deadline": sub.deadline,
+		}).Debug("Searching network for peers subscribed to upcoming subnet")
+		if _, err := s.cfg.p2p.FindPeersWithSubnet(ctx, sub.topic, sub.index, flags.Get().MinimumPeersPerSubnet); err != nil {
+			log.WithError(err).Debug("Could not find peers for upcoming subnet")
+			upcomingSubnetSearchFailedCounter.WithLabelValues(sub.topic).Inc()
+		}
+	}()
+}
+
+// evictSurplusPeers disconnects up to the provided number of the lowest scored peers which
+// are not needed for any of our current or upcoming subnets. This is only done when the node
+// is at its peer limit, so as to make room for peers on the subnets that are needed.
+func (s *Service) evictSurplusPeers(amount int) {
+	if amount <= 0 {
+		return
+	}
+	peers := s.cfg.p2p.Peers()
+	if uint64(len(peers.Active())) < peers.ConnectedPeerLimit() {
+		return
+	}
+	candidates := s.filterNeededPeers(peers.Connected())
+	sort.Slice(candidates, func(i, j int) bool {
+		return peers.Scorers().Score(candidates[i]) < peers.Scorers().Score(candidates[j])
+	})
+	if amount < len(candidates) {
+		candidates = candidates[:amount]
+	}
+	for _, id := range candidates {
+		if err := s.sendGoodByeAndDisconnect(s.ctx, p2ptypes.GoodbyeCodeTooManyPeers, id); err != nil {
+			log.WithField("peer", id).WithError(err).Debug("Could not disconnect with peer")
+			continue
+		}
+		surplusPeersEvictedCounter.Inc()
+	}
+}
+
+// neededSyncSubnetPeers returns the peers which are required for the node's
+// active sync committee subnets, in the current epoch, and upcoming ones, in the
+// next epoch.
+func (s *Service) neededSyncSubnetPeers(digest [4]byte, currSlot types.Slot) map[peer.ID]bool {
+	peerMap := make(map[peer.ID]bool)
+	currEpoch := slots.ToEpoch(currSlot)
+	if currEpoch+1 < params.BeaconConfig().AltairForkEpoch {
+		return peerMap
+	}
+	var subs []uint64
+	if currEpoch >= params.BeaconConfig().AltairForkEpoch {
+		subs = cache.SyncSubnetIDs.GetAllSubnets(currEpoch)
+	}
+	subs = slice.SetUint64(append(subs, cache.SyncSubnetIDs.GetAllSubnets(currEpoch+1)...))
+	topic := p2p.GossipTypeMapping[reflect.TypeOf(&ethpb.SyncCommitteeMessage{})]
+	for _, sub := range subs {
+		subnetTopic := fmt.Sprintf(topic, digest, sub) + s.cfg.p2p.Encoding().ProtocolSuffix()
+		ps := s.cfg.p2p.PubSub().ListPeers(subnetTopic)
+		if len(ps) > flags.Get().MinimumPeersPerSubnet {
+			ps = ps[:flags.Get().MinimumPeersPerSubnet]
+		}
+		for _, p := range ps {
+			peerMap[p] = true
+		}
+	}
+	return peerMap
+}
@@ -0,0 +1,252 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/prysmaticlabs/prysm/async/abool"
+	mockChain "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+	p2ptest "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/time/slots"
+)
+
+func TestUpcomingSubnets_SortedByDeadline(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.MainnetConfig().Copy()
+	cfg.AltairForkEpoch = 0
+	params.OverrideBeaconConfig(cfg)
+
+	gFlags := new(flags.GlobalFlags)
+	gFlags.MinimumPeersPerSubnet = 1
+	flags.Init(gFlags)
+	defer flags.Init(new(flags.GlobalFlags))
+	defer cache.SubnetIDs.EmptyAllCaches()
+	defer cache.SyncSubnetIDs.EmptyAllCaches()
+
+	currSlot := types.Slot(100)
+	r := Service{
+		ctx: context.Background(),
+		cfg: &config{
+			chain: &mockChain.ChainService{
+				Genesis:        time.Now(),
+				ValidatorsRoot: [32]byte{'A'},
+				Slot:           &currSlot,
+			},
+			p2p: p2ptest.NewTestP2P(t),
+		},
+		chainStarted: abool.New(),
+		subHandler:   newSubTopicHandler(),
+	}
+	digest, err := r.currentForkDigest()
+	require.NoError(t, err)
+
+	// Aggregator duties, one outside of the lookahead window.
+	cache.SubnetIDs.AddAggregatorSubnetID(currSlot+3, 7)
+	cache.SubnetIDs.AddAggregatorSubnetID(currSlot+3, 5)
+	cache.SubnetIDs.AddAggregatorSubnetID(currSlot+1, 5)
+	cache.SubnetIDs.AddAggregatorSubnetID(currSlot+aggregatorSubnetLookahead+1, 9)
+
+	subnets := r.upcomingSubnets(currSlot, digest)
+	require.Equal(t, 2, len(subnets))
+	assert.Equal(t, fmt.Sprintf("/eth2/%x/beacon_attestation_%d", digest, 5), subnets[0].topic)
+	assert.Equal(t, currSlot+1, subnets[0].deadline)
+	assert.Equal(t, uint64(7), subnets[1].index)
+	assert.Equal(t, currSlot+3, subnets[1].deadline)
+}
+
+func TestUpcomingSubnets_SkipsSubnetsWithPeers(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	gFlags := new(flags.GlobalFlags)
+	gFlags.MinimumPeersPerSubnet = 1
+	flags.Init(gFlags)
+	defer flags.Init(new(flags.GlobalFlags))
+	defer cache.SubnetIDs.EmptyAllCaches()
+
+	p := p2ptest.NewTestP2P(t)
+	currSlot := types.Slot(100)
+	r := Service{
+		ctx: context.Background(),
+		cfg: &config{
+			chain: &mockChain.ChainService{
+				Genesis:        time.Now(),
+				ValidatorsRoot: [32]byte{'A'},
+				Slot:           &currSlot,
+			},
+			p2p: p,
+		},
+		chainStarted: abool.New(),
+		subHandler:   newSubTopicHandler(),
+	}
+	digest, err := r.currentForkDigest()
+	require.NoError(t, err)
+
+	topic := "/eth2/%x/beacon_attestation_%d" + r.cfg.p2p.Encoding().ProtocolSuffix()
+	p1 := createPeer(t, r.addDigestAndIndexToTopic(topic, digest, 10))
+	p.Connect(p1)
+	// Sleep a while to allow peers to connect.
+	time.Sleep(100 * time.Millisecond)
+
+	cache.SubnetIDs.AddAggregatorSubnetID(currSlot+2, 10)
+	cache.SubnetIDs.AddAggregatorSubnetID(currSlot+2, 11)
+
+	subnets := r.upcomingSubnets(currSlot, digest)
+	require.Equal(t, 1, len(subnets))
+	assert.Equal(t, uint64(11), subnets[0].index)
+}
+
+func TestFilterNeededPeers_ProtectsSyncSubnets(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.MainnetConfig().Copy()
+	cfg.AltairForkEpoch = 0
+	params.OverrideBeaconConfig(cfg)
+
+	gFlags := new(flags.GlobalFlags)
+	gFlags.MinimumPeersPerSubnet = 4
+	flags.Init(gFlags)
+	defer flags.Init(new(flags.GlobalFlags))
+	defer cache.SyncSubnetIDs.EmptyAllCaches()
+
+	p := p2ptest.NewTestP2P(t)
+	currSlot := types.Slot(100)
+	r := Service{
+		ctx: context.Background(),
+		cfg: &config{
+			chain: &mockChain.ChainService{
+				Genesis:        time.Now(),
+				ValidatorsRoot: [32]byte{'A'},
+				Slot:           &currSlot,
+			},
+			p2p: p,
+		},
+		chainStarted: abool.New(),
+		subHandler:   newSubTopicHandler(),
+	}
+	digest, err := r.currentForkDigest()
+	require.NoError(t, err)
+
+	syncTopic := "/eth2/%x/sync_committee_%d" + r.cfg.p2p.Encoding().ProtocolSuffix()
+	subnet1 := r.addDigestAndIndexToTopic(syncTopic, digest, 1)
+	cache.SyncSubnetIDs.AddSyncCommitteeSubnets([]byte{'A'}, 0, []uint64{1}, time.Hour)
+
+	p1 := createPeer(t, subnet1)
+	p2 := createPeer(t)
+	p.Connect(p1)
+	p.Connect(p2)
+	// Sleep a while to allow peers to connect.
+	time.Sleep(100 * time.Millisecond)
+
+	recPeers := r.filterNeededPeers([]peer.ID{p1.PeerID(), p2.PeerID()})
+	assert.DeepEqual(t, []peer.ID{p2.PeerID()}, recPeers)
+}
+
+func TestPrepareUpcomingSubnet_SingleSearchPerSubnet(t *testing.T) {
+	gFlags := new(flags.GlobalFlags)
+	gFlags.MinimumPeersPerSubnet = 1
+	flags.Init(gFlags)
+	defer flags.Init(new(flags.GlobalFlags))
+
+	currSlot := types.Slot(100)
+	r := Service{
+		ctx: context.Background(),
+		cfg: &config{
+			chain: &mockChain.ChainService{
+				Genesis:        time.Now(),
+				ValidatorsRoot: [32]byte{'A'},
+				Slot:           &currSlot,
+			},
+			p2p: p2ptest.NewTestP2P(t),
+		},
+		chainStarted: abool.New(),
+		subHandler:   newSubTopicHandler(),
+	}
+
+	inFlight := &upcomingSubnet{topic: "/eth2/%x/beacon_attestation_1", index: 1, deadline: currSlot + 1}
+	r.upcomingSubnetSearches = map[string]bool{inFlight.topic: true}
+	// A search already in flight is not started again, nor cleared.
+	r.prepareUpcomingSubnet(inFlight)
+	r.upcomingSubnetsLock.Lock()
+	assert.Equal(t, true, r.upcomingSubnetSearches[inFlight.topic])
+	r.upcomingSubnetsLock.Unlock()
+
+	// A new search is cleared once done.
+	sub := &upcomingSubnet{topic: "/eth2/%x/beacon_attestation_2", index: 2, deadline: currSlot + 1}
+	r.prepareUpcomingSubnet(sub)
+	searching := func() bool {
+		r.upcomingSubnetsLock.Lock()
+		defer r.upcomingSubnetsLock.Unlock()
+		return r.upcomingSubnetSearches[sub.topic]
+	}
+	for i := 0; i < 100 && searching(); i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, false, searching())
+	r.upcomingSubnetsLock.Lock()
+	assert.Equal(t, true, r.upcomingSubnetSearches[inFlight.topic])
+	r.upcomingSubnetsLock.Unlock()
+}
+
+func TestEvictSurplusPeers_KeepsNeededPeers(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.MainnetConfig().Copy()
+	cfg.AltairForkEpoch = 0
+	params.OverrideBeaconConfig(cfg)
+
+	gFlags := new(flags.GlobalFlags)
+	gFlags.MinimumPeersPerSubnet = 4
+	flags.Init(gFlags)
+	defer flags.Init(new(flags.GlobalFlags))
+	defer cache.SyncSubnetIDs.EmptyAllCaches()
+
+	p := p2ptest.NewTestP2P(t)
+	currSlot := types.Slot(100)
+	r := Service{
+		ctx: context.Background(),
+		cfg: &config{
+			chain: &mockChain.ChainService{
+				Genesis:        time.Now(),
+				ValidatorsRoot: [32]byte{'A'},
+				Slot:           &currSlot,
+			},
+			p2p: p,
+		},
+		chainStarted: abool.New(),
+		subHandler:   newSubTopicHandler(),
+	}
+	digest, err := r.currentForkDigest()
+	require.NoError(t, err)
+
+	// The node is a member of sync subnet 1 in the next epoch only.
+	syncTopic := "/eth2/%x/sync_committee_%d" + r.cfg.p2p.Encoding().ProtocolSuffix()
+	cache.SyncSubnetIDs.AddSyncCommitteeSubnets([]byte{'A'}, slots.ToEpoch(currSlot)+1, []uint64{1}, time.Hour)
+
+	needed := createPeer(t, r.addDigestAndIndexToTopic(syncTopic, digest, 1))
+	surplus1 := createPeer(t)
+	surplus2 := createPeer(t)
+	for _, pr := range []*p2ptest.TestP2P{needed, surplus1, surplus2} {
+		p.Connect(pr)
+		p.Peers().Add(new(enr.Record), pr.PeerID(), nil, network.DirOutbound)
+		p.Peers().SetConnectionState(pr.PeerID(), peers.PeerConnected)
+	}
+	// Sleep a while to allow peers to connect.
+	time.Sleep(100 * time.Millisecond)
+
+	r.evictSurplusPeers(0)
+	assert.Equal(t, 3, len(p.BHost.Network().Peers()))
+
+	r.evictSurplusPeers(5)
+	assert.Equal(t, network.Connected, p.BHost.Network().Connectedness(needed.PeerID()))
+	assert.Equal(t, network.NotConnected, p.BHost.Network().Connectedness(surplus1.PeerID()))
+	assert.Equal(t, network.NotConnected, p.BHost.Network().Connectedness(surplus2.PeerID()))
+}
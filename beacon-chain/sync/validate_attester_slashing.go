@@ -6,6 +6,8 @@ import (
 	"github.com/libp2p/go-libp2p-core/peer"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	opfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/operation"
 	"github.com/prysmaticlabs/prysm/container/slice"
 	"github.com/prysmaticlabs/prysm/monitoring/tracing"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
@@ -67,6 +69,16 @@ func (s *Service) validateAttesterSlashing(ctx context.Context, pid peer.ID, msg
 	s.cfg.chain.ReceiveAttesterSlashing(ctx, slashing)
 
 	msg.ValidatorData = slashing // Used in downstream subscriber
+
+	// Broadcast the attester slashing on a feed to notify other services in the beacon node
+	// of a received attester slashing.
+	s.cfg.operationNotifier.OperationFeed().Send(&feed.Event{
+		Type: opfeed.AttesterSlashingReceived,
+		Data: &opfeed.AttesterSlashingReceivedData{
+			AttesterSlashing: slashing,
+		},
+	})
+
 	return pubsub.ValidationAccept, nil
 }
 
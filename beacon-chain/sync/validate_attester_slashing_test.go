@@ -82,9 +82,10 @@ func TestValidateAttesterSlashing_ValidSlashing(t *testing.T) {
 
 	r := &Service{
 		cfg: &config{
-			p2p:         p,
-			chain:       &mock.ChainService{State: s, Genesis: time.Now()},
-			initialSync: &mockSync.Sync{IsSyncing: false},
+			p2p:               p,
+			chain:             &mock.ChainService{State: s, Genesis: time.Now()},
+			initialSync:       &mockSync.Sync{IsSyncing: false},
+			operationNotifier: (&mock.ChainService{}).OperationNotifier(),
 		},
 		seenAttesterSlashingCache: make(map[uint64]bool),
 		subHandler:                newSubTopicHandler(),
@@ -115,9 +115,10 @@ func TestValidateProposerSlashing_ValidSlashing(t *testing.T) {
 
 	r := &Service{
 		cfg: &config{
-			p2p:         p,
-			chain:       &mock.ChainService{State: s, Genesis: time.Now()},
-			initialSync: &mockSync.Sync{IsSyncing: false},
+			p2p:               p,
+			chain:             &mock.ChainService{State: s, Genesis: time.Now()},
+			initialSync:       &mockSync.Sync{IsSyncing: false},
+			operationNotifier: (&mock.ChainService{}).OperationNotifier(),
 		},
 		seenProposerSlashingCache: lruwrpr.New(10),
 	}
@@ -167,8 +167,9 @@ var (
 	}
 	// EnableDebugRPCEndpoints as /v1/beacon/state.
 	EnableDebugRPCEndpoints = &cli.BoolFlag{
-		Name:  "enable-debug-rpc-endpoints",
-		Usage: "Enables the debug rpc service, containing utility endpoints such as /eth/v1alpha1/beacon/state.",
+		Name: "enable-debug-rpc-endpoints",
+		Usage: "Enables the debug rpc service, containing utility endpoints such as /eth/v1alpha1/beacon/state, " +
+			"and serves the fork choice store dump at /debug/forkchoice on the monitoring port.",
 	}
 	// SubscribeToAllSubnets defines a flag to specify whether to subscribe to all possible attestation/sync subnets or not.
 	SubscribeToAllSubnets = &cli.BoolFlag{
@@ -180,6 +181,52 @@ var (
 		Name:  "historical-slasher-node",
 		Usage: "Enables required flags for serving historical data to a slasher client. Results in additional storage usage",
 	}
+	// RemoteSlasherEndpoint is the gRPC endpoint of the standalone slasher used with --remote-slasher.
+	RemoteSlasherEndpoint = &cli.StringFlag{
+		Name:  "remote-slasher-endpoint",
+		Usage: "gRPC endpoint of a standalone slasher to stream attestations and blocks to, used with --remote-slasher",
+		Value: "127.0.0.1:4002",
+	}
+	// RemoteSlasherCertFlag defines a flag for the certificate of the standalone slasher used with --remote-slasher.
+	RemoteSlasherCertFlag = &cli.StringFlag{
+		Name:  "remote-slasher-tls-cert",
+		Usage: "Certificate for secure gRPC connection to the standalone slasher. Pass this in order to use TLS",
+	}
+	// SlasherBackfillFlag enables replaying the finalized blocks of the beacon DB through the slasher.
+	SlasherBackfillFlag = &cli.BoolFlag{
+		Name: "slasher-backfill",
+		Usage: "Replays the finalized blocks of the beacon DB through the slasher on startup, to detect slashable " +
+			"offenses from before it was enabled. Used with --slasher, see --slasher-backfill-start-epoch and --slasher-backfill-end-epoch",
+	}
+	// SlasherBackfillStartEpochFlag is the first epoch replayed by --slasher-backfill.
+	SlasherBackfillStartEpochFlag = &cli.Uint64Flag{
+		Name: "slasher-backfill-start-epoch",
+		Usage: "The first epoch of finalized blocks replayed through the slasher with --slasher-backfill. Epochs older than " +
+			"the history kept by the slasher (4096 epochs before the finalized epoch) are skipped",
+	}
+	// SlasherBackfillEndEpochFlag is the last epoch replayed by --slasher-backfill.
+	SlasherBackfillEndEpochFlag = &cli.Uint64Flag{
+		Name:  "slasher-backfill-end-epoch",
+		Usage: "The last epoch of finalized blocks replayed through the slasher with --slasher-backfill. Defaults to the finalized epoch",
+	}
+	// MonitorAttachedValidatorsFlag enables tracking the validators of the attached validator clients.
+	MonitorAttachedValidatorsFlag = &cli.BoolFlag{
+		Name: "monitor-attached-validators",
+		Usage: "Tracks the performance of the validators of the validator clients attached to the beacon node, " +
+			"in addition to the validators given with --monitor-indices",
+	}
+	// MonitorWebhookURLFlag defines the webhook alerted about the tracked validators.
+	MonitorWebhookURLFlag = &cli.StringFlag{
+		Name: "monitor-webhook-url",
+		Usage: "URL of an HTTP webhook receiving JSON alerts when a tracked validator is slashed, " +
+			"misses a proposal or misses consecutive attestations",
+	}
+	// MonitorMissedAttestationsThresholdFlag defines the number of consecutive missed attestations triggering an alert.
+	MonitorMissedAttestationsThresholdFlag = &cli.Uint64Flag{
+		Name:  "monitor-missed-attestations-threshold",
+		Usage: "Number of consecutive attestations a tracked validator can miss before an alert is sent, 0 disables the alert",
+		Value: 3,
+	}
 	// ChainID defines a flag to set the chain id. If none is set, it derives this value from NetworkConfig
 	ChainID = &cli.Uint64Flag{
 		Name:  "chain-id",
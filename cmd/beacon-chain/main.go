@@ -65,6 +65,11 @@ var appFlags = []cli.Flag{
 	flags.EnableDebugRPCEndpoints,
 	flags.SubscribeToAllSubnets,
 	flags.HistoricalSlasherNode,
+	flags.RemoteSlasherEndpoint,
+	flags.RemoteSlasherCertFlag,
+	flags.SlasherBackfillFlag,
+	flags.SlasherBackfillStartEpochFlag,
+	flags.SlasherBackfillEndEpochFlag,
 	flags.ChainID,
 	flags.NetworkID,
 	flags.WeakSubjectivityCheckpoint,
@@ -90,6 +95,8 @@ var appFlags = []cli.Flag{
 	cmd.P2PHost,
 	cmd.P2PHostDNS,
 	cmd.P2PMaxPeers,
+	cmd.P2PMaxPeersPerSubnet,
+	cmd.P2PMaxPeersPerClient,
 	cmd.P2PPrivKey,
 	cmd.P2PMetadata,
 	cmd.P2PAllowList,
@@ -125,6 +132,9 @@ var appFlags = []cli.Flag{
 	cmd.RestoreTargetDirFlag,
 	cmd.BoltMMapInitialSizeFlag,
 	cmd.ValidatorMonitorIndicesFlag,
+	flags.MonitorAttachedValidatorsFlag,
+	flags.MonitorWebhookURLFlag,
+	flags.MonitorMissedAttestationsThresholdFlag,
 	cmd.ApiTimeoutFlag,
 	checkpoint.BlockPath,
 	checkpoint.StatePath,
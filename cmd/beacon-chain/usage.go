@@ -76,6 +76,9 @@ var appHelpFlagGroups = []flagGroup{
 			cmd.RestoreTargetDirFlag,
 			cmd.BoltMMapInitialSizeFlag,
 			cmd.ValidatorMonitorIndicesFlag,
+			flags.MonitorAttachedValidatorsFlag,
+			flags.MonitorWebhookURLFlag,
+			flags.MonitorMissedAttestationsThresholdFlag,
 			cmd.ApiTimeoutFlag,
 		},
 	},
@@ -121,6 +124,11 @@ var appHelpFlagGroups = []flagGroup{
 			flags.EnableDebugRPCEndpoints,
 			flags.SubscribeToAllSubnets,
 			flags.HistoricalSlasherNode,
+			flags.RemoteSlasherEndpoint,
+			flags.RemoteSlasherCertFlag,
+			flags.SlasherBackfillFlag,
+			flags.SlasherBackfillStartEpochFlag,
+			flags.SlasherBackfillEndEpochFlag,
 			flags.ChainID,
 			flags.NetworkID,
 			flags.WeakSubjectivityCheckpoint,
@@ -150,6 +158,8 @@ var appHelpFlagGroups = []flagGroup{
 			cmd.P2PHost,
 			cmd.P2PHostDNS,
 			cmd.P2PMaxPeers,
+			cmd.P2PMaxPeersPerSubnet,
+			cmd.P2PMaxPeersPerClient,
 			cmd.P2PPrivKey,
 			cmd.P2PMetadata,
 			cmd.P2PAllowList,
@@ -155,6 +155,20 @@ var (
 		Usage: "The max number of p2p peers to maintain.",
 		Value: 45,
 	}
+	// P2PMaxPeersPerSubnet defines a flag to limit the number of peers from a single IP subnet.
+	P2PMaxPeersPerSubnet = &cli.UintFlag{
+		Name: "p2p-max-peers-per-subnet",
+		Usage: "The max number of p2p peers to maintain from a single /24 (IPv4) or /48 (IPv6) subnet. " +
+			"Limits the share of peers a single hosting provider can eclipse. 0 means no limit.",
+		Value: 0,
+	}
+	// P2PMaxPeersPerClient defines a flag to limit the number of peers running the same client.
+	P2PMaxPeersPerClient = &cli.UintFlag{
+		Name: "p2p-max-peers-per-client",
+		Usage: "The max number of p2p peers to maintain that run the same client implementation, " +
+			"as reported by their agent string. 0 means no limit.",
+		Value: 0,
+	}
 	// P2PAllowList defines a CIDR subnet to exclusively allow connections.
 	P2PAllowList = &cli.StringFlag{
 		Name: "p2p-allowlist",
@@ -4,6 +4,10 @@ import (
 	"os"
 
 	"github.com/prysmaticlabs/prysm/cmd/prysmctl/checkpoint"
+	"github.com/prysmaticlabs/prysm/cmd/prysmctl/optimistic"
+	"github.com/prysmaticlabs/prysm/cmd/prysmctl/p2p"
+	"github.com/prysmaticlabs/prysm/cmd/prysmctl/replay"
+	"github.com/prysmaticlabs/prysm/cmd/prysmctl/statediff"
 	log "github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v2"
 )
@@ -22,4 +26,8 @@ func main() {
 
 func init() {
 	prysmctlCommands = append(prysmctlCommands, checkpoint.Commands...)
+	prysmctlCommands = append(prysmctlCommands, p2p.Commands...)
+	prysmctlCommands = append(prysmctlCommands, optimistic.Commands...)
+	prysmctlCommands = append(prysmctlCommands, replay.Commands...)
+	prysmctlCommands = append(prysmctlCommands, statediff.Commands...)
 }
@@ -0,0 +1,52 @@
+package optimistic
+
+import (
+	"context"
+
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/urfave/cli/v2"
+)
+
+var invalidateFlags = struct {
+	BlockRoot string
+}{}
+
+var invalidateCmd = &cli.Command{
+	Name: "invalidate",
+	Usage: "Mark an optimistically imported block and all of its descendants as invalid, as if the execution " +
+		"engine had rejected its payload. The beacon node prunes them and computes a new head.",
+	Action: cliActionInvalidate,
+	Flags: []cli.Flag{
+		beaconNodeHostFlag,
+		timeoutFlag,
+		&cli.StringFlag{
+			Name:        "block-root",
+			Usage:       "hex encoded root of the block to invalidate",
+			Destination: &invalidateFlags.BlockRoot,
+			Required:    true,
+		},
+	},
+}
+
+func cliActionInvalidate(c *cli.Context) error {
+	root, err := parseRoot(invalidateFlags.BlockRoot)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), c.Duration(timeoutFlag.Name))
+	defer cancel()
+	client, closeConn, err := debugClient(ctx, c.String(beaconNodeHostFlag.Name))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = closeConn()
+	}()
+
+	verdict, err := client.InvalidateBlock(ctx, &ethpb.InvalidateBlockRequest{BlockRoot: root})
+	if err != nil {
+		return err
+	}
+	printVerdict(verdict)
+	return nil
+}
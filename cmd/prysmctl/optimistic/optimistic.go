@@ -0,0 +1,82 @@
+package optimistic
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc"
+)
+
+var Commands = []*cli.Command{
+	{
+		Name:  "optimistic",
+		Usage: "commands for inspecting execution payload verdicts and optimistic blocks",
+		Subcommands: []*cli.Command{
+			verdictsCmd,
+			invalidateCmd,
+		},
+	},
+}
+
+var (
+	beaconNodeHostFlag = &cli.StringFlag{
+		Name:  "beacon-node-host",
+		Usage: "host:port for the gRPC endpoint of the beacon node to query, with debug rpc endpoints enabled",
+		Value: "localhost:4000",
+	}
+	timeoutFlag = &cli.DurationFlag{
+		Name:  "grpc-timeout",
+		Usage: "timeout for grpc requests made to the beacon node (uses duration format, ex: 2m31s)",
+		Value: time.Minute,
+	}
+)
+
+// debugClient dials the beacon node and returns a client for its debug service, along with a
+// function to close the connection.
+func debugClient(ctx context.Context, host string) (ethpb.DebugClient, func() error, error) {
+	conn, err := grpc.DialContext(ctx, host, grpc.WithInsecure())
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not dial beacon node at %s: %w", host, err)
+	}
+	return ethpb.NewDebugClient(conn), conn.Close, nil
+}
+
+// parseRoot decodes a 0x prefixed hex encoded block root.
+func parseRoot(s string) ([]byte, error) {
+	root, err := hexutil.Decode(s)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode block root %s: %w", s, err)
+	}
+	if len(root) != fieldparams.RootLength {
+		return nil, fmt.Errorf("block root %s is not %d bytes long", s, fieldparams.RootLength)
+	}
+	return root, nil
+}
+
+// printVerdict writes a one line summary of a verdict to stdout.
+func printVerdict(v *ethpb.PayloadVerdict) {
+	fmt.Printf("%s slot=%d root=%#x payload=%#x status=%s source=%s",
+		time.Unix(int64(v.Timestamp), 0).UTC().Format(time.RFC3339),
+		v.Slot,
+		v.BlockRoot,
+		bytesutil.Trunc(v.PayloadHash),
+		v.Status,
+		v.Source,
+	)
+	if len(v.LatestValidHash) > 0 {
+		fmt.Printf(" latestValidHash=%#x", bytesutil.Trunc(v.LatestValidHash))
+	}
+	if len(v.PrunedRoots) > 0 {
+		fmt.Printf(" pruned=%d", len(v.PrunedRoots))
+	}
+	fmt.Println()
+	for _, r := range v.PrunedRoots {
+		fmt.Printf("  pruned root=%#x\n", r)
+	}
+}
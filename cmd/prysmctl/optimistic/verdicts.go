@@ -0,0 +1,85 @@
+package optimistic
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/urfave/cli/v2"
+)
+
+var verdictsFlags = struct {
+	BlockRoot string
+	Follow    bool
+}{}
+
+var verdictsCmd = &cli.Command{
+	Name:   "verdicts",
+	Usage:  "List the verdicts of the execution engine on the payloads of blocks, as recorded by the beacon node.",
+	Action: cliActionVerdicts,
+	Flags: []cli.Flag{
+		beaconNodeHostFlag,
+		timeoutFlag,
+		&cli.StringFlag{
+			Name:        "block-root",
+			Usage:       "hex encoded root of the block to list verdicts for. Lists the most recent verdicts when empty",
+			Destination: &verdictsFlags.BlockRoot,
+		},
+		&cli.BoolFlag{
+			Name:        "follow",
+			Usage:       "keep printing verdicts as they are recorded by the beacon node",
+			Destination: &verdictsFlags.Follow,
+		},
+	},
+}
+
+func cliActionVerdicts(c *cli.Context) error {
+	var root []byte
+	if verdictsFlags.BlockRoot != "" {
+		r, err := parseRoot(verdictsFlags.BlockRoot)
+		if err != nil {
+			return err
+		}
+		root = r
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), c.Duration(timeoutFlag.Name))
+	defer cancel()
+	client, closeConn, err := debugClient(ctx, c.String(beaconNodeHostFlag.Name))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = closeConn()
+	}()
+
+	res, err := client.GetPayloadVerdicts(ctx, &ethpb.PayloadVerdictsRequest{BlockRoot: root})
+	if err != nil {
+		return err
+	}
+	for _, v := range res.Verdicts {
+		printVerdict(v)
+	}
+	if !verdictsFlags.Follow {
+		return nil
+	}
+
+	stream, err := client.StreamPayloadVerdicts(context.Background(), &empty.Empty{})
+	if err != nil {
+		return err
+	}
+	for {
+		v, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if root != nil && !bytes.Equal(root, v.BlockRoot) {
+			continue
+		}
+		printVerdict(v)
+	}
+}
@@ -0,0 +1,549 @@
+package p2p
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	noise "github.com/libp2p/go-libp2p/p2p/security/noise"
+	"github.com/libp2p/go-libp2p/p2p/transport/tcp"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/pkg/errors"
+	ssz "github.com/prysmaticlabs/fastssz"
+	prysmP2P "github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
+	p2ptypes "github.com/prysmaticlabs/prysm/beacon-chain/p2p/types"
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	ecdsaprysm "github.com/prysmaticlabs/prysm/crypto/ecdsa"
+	"github.com/prysmaticlabs/prysm/io/file"
+	"github.com/prysmaticlabs/prysm/network"
+	pb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/runtime/version"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	censusFormatCSV  = "csv"
+	censusFormatJSON = "json"
+)
+
+var crawlFlags = struct {
+	Bootnodes        cli.StringSlice
+	IP               string
+	UDPPort          uint64
+	TCPPort          uint64
+	Duration         time.Duration
+	ForkDigest       string
+	Handshake        bool
+	HandshakeTimeout time.Duration
+	MaxDials         uint64
+	Output           string
+	Format           string
+}{}
+
+var crawlCmd = &cli.Command{
+	Name: "crawl",
+	Usage: "Walk the discv5 DHT without running a beacon chain and write a census of the discovered peers. " +
+		"Optionally connects to every peer to perform Status and Metadata handshakes.",
+	Action: cliActionCrawl,
+	Flags: []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:        "bootstrap-node",
+			Usage:       "ENR of a node to bootstrap discovery from, can be repeated",
+			Destination: &crawlFlags.Bootnodes,
+			Required:    true,
+		},
+		&cli.StringFlag{
+			Name:        "ip",
+			Usage:       "IP address to advertise in the crawler's ENR, defaults to the host's external IP. Use 127.0.0.1 for local test networks",
+			Destination: &crawlFlags.IP,
+		},
+		&cli.Uint64Flag{
+			Name:        "udp-port",
+			Usage:       "UDP port used by the discovery listener",
+			Destination: &crawlFlags.UDPPort,
+			Value:       12100,
+		},
+		&cli.Uint64Flag{
+			Name:        "tcp-port",
+			Usage:       "TCP port used by the libp2p host for handshakes",
+			Destination: &crawlFlags.TCPPort,
+			Value:       13100,
+		},
+		&cli.DurationFlag{
+			Name:        "duration",
+			Usage:       "how long to walk the DHT for (uses duration format, ex: 2m31s)",
+			Destination: &crawlFlags.Duration,
+			Value:       5 * time.Minute,
+		},
+		&cli.StringFlag{
+			Name:        "fork-digest",
+			Usage:       "hex encoded fork digest, when set only peers advertising it are included in the census",
+			Destination: &crawlFlags.ForkDigest,
+		},
+		&cli.BoolFlag{
+			Name:        "handshake",
+			Usage:       "connect to discovered peers over libp2p to retrieve their client agent, head and finalized checkpoint",
+			Destination: &crawlFlags.Handshake,
+		},
+		&cli.DurationFlag{
+			Name:        "handshake-timeout",
+			Usage:       "time allowed to dial a peer and complete both handshakes",
+			Destination: &crawlFlags.HandshakeTimeout,
+			Value:       15 * time.Second,
+		},
+		&cli.Uint64Flag{
+			Name:        "max-concurrent-dials",
+			Usage:       "maximum number of peers being handshaked with at the same time",
+			Destination: &crawlFlags.MaxDials,
+			Value:       32,
+		},
+		&cli.StringFlag{
+			Name:        "output",
+			Usage:       "path of the census file to write",
+			Destination: &crawlFlags.Output,
+			Value:       "census.csv",
+		},
+		&cli.StringFlag{
+			Name:        "format",
+			Usage:       "format of the census file, one of csv or json",
+			Destination: &crawlFlags.Format,
+			Value:       censusFormatCSV,
+		},
+	},
+}
+
+// censusRecord describes a single peer found while crawling. Subnets are taken from the peer's metadata
+// when a handshake succeeded, otherwise from its ENR.
+type censusRecord struct {
+	NodeID          string       `json:"node_id"`
+	PeerID          string       `json:"peer_id"`
+	Seq             uint64       `json:"seq"`
+	IP              string       `json:"ip"`
+	TCPPort         int          `json:"tcp_port"`
+	UDPPort         int          `json:"udp_port"`
+	ForkDigest      string       `json:"fork_digest"`
+	NextForkVersion string       `json:"next_fork_version"`
+	NextForkEpoch   string       `json:"next_fork_epoch"`
+	Attnets         string       `json:"attnets"`
+	Syncnets        string       `json:"syncnets"`
+	Agent           string       `json:"agent,omitempty"`
+	HeadSlot        *types.Slot  `json:"head_slot,omitempty"`
+	FinalizedEpoch  *types.Epoch `json:"finalized_epoch,omitempty"`
+	HandshakeError  string       `json:"handshake_error,omitempty"`
+	ENR             string       `json:"enr"`
+
+	node *enode.Node
+}
+
+var censusCSVHeader = []string{
+	"node_id", "peer_id", "seq", "ip", "tcp_port", "udp_port", "fork_digest", "next_fork_version", "next_fork_epoch",
+	"attnets", "syncnets", "agent", "head_slot", "finalized_epoch", "handshake_error", "enr",
+}
+
+func cliActionCrawl(cliCtx *cli.Context) error {
+	f := crawlFlags
+	if f.Format != censusFormatCSV && f.Format != censusFormatJSON {
+		return fmt.Errorf("unsupported census format %s, expected one of %s or %s", f.Format, censusFormatCSV, censusFormatJSON)
+	}
+	var digest []byte
+	if f.ForkDigest != "" {
+		var err error
+		digest, err = decodeFixedHex(f.ForkDigest, 4)
+		if err != nil {
+			return errors.Wrap(err, "invalid fork digest")
+		}
+	}
+	ip, err := crawlerIP(f.IP)
+	if err != nil {
+		return err
+	}
+	// The crawler uses an ephemeral identity, so it can't be confused with a node of the network.
+	key, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+	if err != nil {
+		return errors.Wrap(err, "could not generate key")
+	}
+	ecdsaKey, err := ecdsaprysm.ConvertFromInterfacePrivKey(key)
+	if err != nil {
+		return err
+	}
+	listener, err := prysmP2P.CreateStandaloneListener(ecdsaKey, ip, int(f.UDPPort), 0, f.Bootnodes.Value())
+	if err != nil {
+		return errors.Wrap(err, "could not start discovery listener")
+	}
+	defer listener.Close()
+	log.WithField("ENR", listener.Self().String()).Info("Started discovery v5")
+
+	c := newCrawler(digest, f.MaxDials, f.HandshakeTimeout)
+	if f.Handshake {
+		c.host, err = newCrawlerHost(key, ip, f.TCPPort)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := c.host.Close(); err != nil {
+				log.WithError(err).Error("Could not close libp2p host")
+			}
+		}()
+	}
+
+	ctx, cancel := context.WithTimeout(cliCtx.Context, f.Duration)
+	defer cancel()
+	c.crawl(ctx, listener.RandomNodes())
+	records := c.census()
+	log.WithField("peers", len(records)).Info("Crawl finished, writing census")
+	return writeCensusFile(f.Output, f.Format, records)
+}
+
+func crawlerIP(flagIP string) (net.IP, error) {
+	if flagIP == "" {
+		ip, err := network.ExternalIP()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not determine external IP, use --ip to set one")
+		}
+		flagIP = ip
+	}
+	ip := net.ParseIP(flagIP)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid IP address: %s", flagIP)
+	}
+	return ip, nil
+}
+
+// crawler collects the nodes returned by a discovery iterator, keeping the latest record of each node.
+type crawler struct {
+	forkDigest       []byte
+	handshakeTimeout time.Duration
+	host             host.Host // nil when handshakes are disabled.
+	dials            chan struct{}
+	wg               sync.WaitGroup
+	lock             sync.Mutex
+	records          map[enode.ID]*censusRecord
+}
+
+func newCrawler(forkDigest []byte, maxDials uint64, handshakeTimeout time.Duration) *crawler {
+	if maxDials == 0 {
+		maxDials = 1
+	}
+	return &crawler{
+		forkDigest:       forkDigest,
+		handshakeTimeout: handshakeTimeout,
+		dials:            make(chan struct{}, maxDials),
+		records:          make(map[enode.ID]*censusRecord),
+	}
+}
+
+// crawl consumes the iterator until the context is done, then waits for in-flight handshakes.
+func (c *crawler) crawl(ctx context.Context, iterator enode.Iterator) {
+	go func() {
+		<-ctx.Done()
+		iterator.Close()
+	}()
+	for iterator.Next() {
+		node := iterator.Node()
+		record, err := c.add(node)
+		if err != nil {
+			log.WithError(err).WithField("node", node.ID()).Debug("Ignoring node")
+			continue
+		}
+		if record == nil || c.host == nil || node.IP() == nil || node.TCP() == 0 {
+			continue
+		}
+		select {
+		case c.dials <- struct{}{}:
+		case <-ctx.Done():
+			continue
+		}
+		c.wg.Add(1)
+		go func(record *censusRecord) {
+			defer func() {
+				<-c.dials
+				c.wg.Done()
+			}()
+			hctx, cancel := context.WithTimeout(context.Background(), c.handshakeTimeout)
+			defer cancel()
+			c.handshake(hctx, record)
+		}(record)
+	}
+	c.wg.Wait()
+}
+
+// add records the node, returning the new census record or nil if the node was already known
+// with the same or a newer record, or does not match the fork digest being crawled.
+func (c *crawler) add(node *enode.Node) (*censusRecord, error) {
+	record, err := censusRecordFromNode(node)
+	if err != nil {
+		return nil, err
+	}
+	if c.forkDigest != nil && record.ForkDigest != fmt.Sprintf("%#x", c.forkDigest) {
+		return nil, nil
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if known, ok := c.records[node.ID()]; ok && known.Seq >= node.Seq() {
+		return nil, nil
+	}
+	c.records[node.ID()] = record
+	return record, nil
+}
+
+// census returns all the records collected so far, sorted by node id.
+func (c *crawler) census() []*censusRecord {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	records := make([]*censusRecord, 0, len(c.records))
+	for _, r := range c.records {
+		records = append(records, r)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].NodeID < records[j].NodeID
+	})
+	return records
+}
+
+func censusRecordFromNode(node *enode.Node) (*censusRecord, error) {
+	pid, err := peerIDFromNode(node)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := loadETH2Fields(node)
+	if err != nil {
+		return nil, err
+	}
+	record := &censusRecord{
+		NodeID:  node.ID().String(),
+		PeerID:  pid.String(),
+		Seq:     node.Seq(),
+		TCPPort: node.TCP(),
+		UDPPort: node.UDP(),
+		ENR:     node.String(),
+		node:    node,
+	}
+	if ip := node.IP(); ip != nil {
+		record.IP = ip.String()
+	}
+	if fields.forkID != nil {
+		record.ForkDigest = fmt.Sprintf("%#x", fields.forkID.CurrentForkDigest)
+		record.NextForkVersion = fmt.Sprintf("%#x", fields.forkID.NextForkVersion)
+		record.NextForkEpoch = strconv.FormatUint(uint64(fields.forkID.NextForkEpoch), 10)
+	}
+	if fields.attnets != nil {
+		record.Attnets = fmt.Sprintf("%#x", fields.attnets.Bytes())
+	}
+	if fields.syncnets != nil {
+		record.Syncnets = fmt.Sprintf("%#x", fields.syncnets.Bytes())
+	}
+	return record, nil
+}
+
+func newCrawlerHost(key crypto.PrivKey, ip net.IP, tcpPort uint64) (host.Host, error) {
+	listen, err := tcpMultiAddr(ip, tcpPort)
+	if err != nil {
+		return nil, err
+	}
+	h, err := libp2p.New(
+		libp2p.Identity(key),
+		libp2p.ListenAddrs(listen),
+		libp2p.UserAgent(version.BuildData()),
+		libp2p.Transport(tcp.NewTCPTransport),
+		libp2p.Security(noise.ID, noise.New),
+		libp2p.DisableRelay(),
+		libp2p.Ping(false),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not start libp2p host")
+	}
+	return h, nil
+}
+
+func tcpMultiAddr(ip net.IP, port uint64) (ma.Multiaddr, error) {
+	if ip.To4() != nil {
+		return ma.NewMultiaddr(fmt.Sprintf("/ip4/%s/tcp/%d", ip, port))
+	}
+	return ma.NewMultiaddr(fmt.Sprintf("/ip6/%s/tcp/%d", ip, port))
+}
+
+// handshake connects to the peer, and completes the census record with the outcome of the Status
+// and Metadata requests. Failures are recorded rather than returned, a partial census is still useful.
+func (c *crawler) handshake(ctx context.Context, record *censusRecord) {
+	err := c.doHandshake(ctx, record)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if err != nil {
+		record.HandshakeError = err.Error()
+	}
+}
+
+func (c *crawler) doHandshake(ctx context.Context, record *censusRecord) error {
+	pid, err := peer.Decode(record.PeerID)
+	if err != nil {
+		return err
+	}
+	addr, err := tcpMultiAddr(record.node.IP(), uint64(record.node.TCP()))
+	if err != nil {
+		return err
+	}
+	if err := c.host.Connect(ctx, peer.AddrInfo{ID: pid, Addrs: []ma.Multiaddr{addr}}); err != nil {
+		return errors.Wrap(err, "could not connect")
+	}
+	defer func() {
+		if err := c.host.Network().ClosePeer(pid); err != nil {
+			log.WithError(err).WithField("peer", pid).Debug("Could not disconnect")
+		}
+	}()
+	if agent, err := c.host.Peerstore().Get(pid, "AgentVersion"); err == nil {
+		if s, ok := agent.(string); ok {
+			c.lock.Lock()
+			record.Agent = s
+			c.lock.Unlock()
+		}
+	}
+
+	// Peers drop requests carrying a fork digest different from theirs, so use the advertised one.
+	// A zero finalized checkpoint and head is accepted by all peers as a genesis node.
+	digest := make([]byte, 4)
+	if record.ForkDigest != "" {
+		digest, err = decodeFixedHex(record.ForkDigest, 4)
+		if err != nil {
+			return err
+		}
+	}
+	zeroRoot := params.BeaconConfig().ZeroHash
+	req := &pb.Status{
+		ForkDigest:     digest,
+		FinalizedRoot:  zeroRoot[:],
+		FinalizedEpoch: 0,
+		HeadRoot:       zeroRoot[:],
+		HeadSlot:       0,
+	}
+	status := &pb.Status{}
+	if err := c.request(ctx, pid, prysmP2P.RPCStatusTopicV1, req, status); err != nil {
+		return errors.Wrap(err, "status request failed")
+	}
+	c.lock.Lock()
+	record.HeadSlot = &status.HeadSlot
+	record.FinalizedEpoch = &status.FinalizedEpoch
+	c.lock.Unlock()
+
+	// Altair peers serve the v2 metadata, which includes sync committee subnets.
+	md := &pb.MetaDataV1{}
+	if err := c.request(ctx, pid, prysmP2P.RPCMetaDataTopicV2, nil, md); err == nil {
+		c.lock.Lock()
+		record.Attnets = fmt.Sprintf("%#x", md.Attnets.Bytes())
+		record.Syncnets = fmt.Sprintf("%#x", md.Syncnets.Bytes())
+		c.lock.Unlock()
+		return nil
+	}
+	mdV0 := &pb.MetaDataV0{}
+	if err := c.request(ctx, pid, prysmP2P.RPCMetaDataTopicV1, nil, mdV0); err != nil {
+		return errors.Wrap(err, "metadata request failed")
+	}
+	c.lock.Lock()
+	record.Attnets = fmt.Sprintf("%#x", mdV0.Attnets.Bytes())
+	c.lock.Unlock()
+	return nil
+}
+
+// request sends a single req/resp request, a nil request is sent as an empty message, and decodes
+// the response into resp.
+func (c *crawler) request(ctx context.Context, pid peer.ID, topic string, req ssz.Marshaler, resp ssz.Unmarshaler) error {
+	enc := encoder.SszNetworkEncoder{}
+	stream, err := c.host.NewStream(ctx, pid, protocol.ID(topic+enc.ProtocolSuffix()))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := stream.Close(); err != nil {
+			log.WithError(err).Trace("Could not close stream")
+		}
+	}()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := stream.SetDeadline(deadline); err != nil {
+			return err
+		}
+	}
+	if req != nil {
+		if _, err := enc.EncodeWithMaxLength(stream, req); err != nil {
+			return err
+		}
+	}
+	if err := stream.CloseWrite(); err != nil {
+		return err
+	}
+	code := make([]byte, 1)
+	if _, err := io.ReadFull(stream, code); err != nil {
+		return err
+	}
+	if code[0] != 0 {
+		errMsg := &p2ptypes.ErrorMessage{}
+		if err := enc.DecodeWithMaxLength(stream, errMsg); err != nil {
+			return err
+		}
+		return fmt.Errorf("peer responded with code %d: %s", code[0], string(*errMsg))
+	}
+	return enc.DecodeWithMaxLength(stream, resp)
+}
+
+func writeCensusFile(path, format string, records []*censusRecord) error {
+	var buf bytes.Buffer
+	if err := writeCensus(&buf, format, records); err != nil {
+		return err
+	}
+	if err := file.WriteFile(path, buf.Bytes()); err != nil {
+		return errors.Wrap(err, "could not write census file")
+	}
+	log.WithField("path", path).Info("Wrote census")
+	return nil
+}
+
+func writeCensus(w io.Writer, format string, records []*censusRecord) error {
+	switch format {
+	case censusFormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(records)
+	case censusFormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(censusCSVHeader); err != nil {
+			return err
+		}
+		for _, r := range records {
+			headSlot, finalizedEpoch := "", ""
+			if r.HeadSlot != nil {
+				headSlot = strconv.FormatUint(uint64(*r.HeadSlot), 10)
+			}
+			if r.FinalizedEpoch != nil {
+				finalizedEpoch = strconv.FormatUint(uint64(*r.FinalizedEpoch), 10)
+			}
+			row := []string{
+				r.NodeID, r.PeerID, strconv.FormatUint(r.Seq, 10), r.IP, strconv.Itoa(r.TCPPort), strconv.Itoa(r.UDPPort),
+				r.ForkDigest, r.NextForkVersion, r.NextForkEpoch, r.Attnets, r.Syncnets, r.Agent, headSlot,
+				finalizedEpoch, r.HandshakeError, r.ENR,
+			}
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	default:
+		return fmt.Errorf("unsupported census format %s", format)
+	}
+}
@@ -0,0 +1,249 @@
+package p2p
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	"github.com/prysmaticlabs/go-bitfield"
+	prysmP2P "github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	ecdsaprysm "github.com/prysmaticlabs/prysm/crypto/ecdsa"
+	pb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func testNode(t *testing.T, key crypto.PrivKey, seq uint64, digest []byte) *enode.Node {
+	attnets := bitfield.NewBitvector64()
+	attnets.SetBitAt(3, true)
+	cfg := &enrConfig{
+		seq:     seq,
+		ip:      net.ParseIP("10.0.0.1"),
+		tcpPort: 13000,
+		udpPort: 12000,
+		attnets: attnets,
+	}
+	if digest != nil {
+		cfg.forkID = &pb.ENRForkID{
+			CurrentForkDigest: digest,
+			NextForkVersion:   []byte{0x02, 0x00, 0x00, 0x00},
+			NextForkEpoch:     144896,
+		}
+	}
+	node, err := buildENR(key, cfg)
+	require.NoError(t, err)
+	return node
+}
+
+func TestCensusRecordFromNode(t *testing.T) {
+	key, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+	require.NoError(t, err)
+	node := testNode(t, key, 3, []byte{0x4a, 0x26, 0xc5, 0x8b})
+
+	record, err := censusRecordFromNode(node)
+	require.NoError(t, err)
+	pid, err := peerIDFromNode(node)
+	require.NoError(t, err)
+	assert.Equal(t, node.ID().String(), record.NodeID)
+	assert.Equal(t, pid.String(), record.PeerID)
+	assert.Equal(t, uint64(3), record.Seq)
+	assert.Equal(t, "10.0.0.1", record.IP)
+	assert.Equal(t, 13000, record.TCPPort)
+	assert.Equal(t, 12000, record.UDPPort)
+	assert.Equal(t, "0x4a26c58b", record.ForkDigest)
+	assert.Equal(t, "0x02000000", record.NextForkVersion)
+	assert.Equal(t, "144896", record.NextForkEpoch)
+	assert.Equal(t, "0x0800000000000000", record.Attnets)
+	assert.Equal(t, "", record.Syncnets)
+	assert.Equal(t, node.String(), record.ENR)
+}
+
+func TestCrawler_Add(t *testing.T) {
+	digest := []byte{0x4a, 0x26, 0xc5, 0x8b}
+	c := newCrawler(digest, 1, time.Second)
+
+	key, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+	require.NoError(t, err)
+	record, err := c.add(testNode(t, key, 2, digest))
+	require.NoError(t, err)
+	require.NotNil(t, record)
+
+	// Same or older records of a known node are ignored, newer ones replace it.
+	record, err = c.add(testNode(t, key, 2, digest))
+	require.NoError(t, err)
+	assert.Equal(t, (*censusRecord)(nil), record)
+	record, err = c.add(testNode(t, key, 1, digest))
+	require.NoError(t, err)
+	assert.Equal(t, (*censusRecord)(nil), record)
+	record, err = c.add(testNode(t, key, 3, digest))
+	require.NoError(t, err)
+	require.NotNil(t, record)
+
+	// Nodes of other networks are filtered out.
+	otherKey, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+	require.NoError(t, err)
+	record, err = c.add(testNode(t, otherKey, 1, []byte{0x01, 0x02, 0x03, 0x04}))
+	require.NoError(t, err)
+	assert.Equal(t, (*censusRecord)(nil), record)
+	record, err = c.add(testNode(t, otherKey, 1, nil))
+	require.NoError(t, err)
+	assert.Equal(t, (*censusRecord)(nil), record)
+
+	census := c.census()
+	require.Equal(t, 1, len(census))
+	assert.Equal(t, uint64(3), census[0].Seq)
+}
+
+func TestWriteCensus(t *testing.T) {
+	headSlot, finalizedEpoch := types.Slot(4000), types.Epoch(123)
+	records := []*censusRecord{
+		{NodeID: "aa", PeerID: "16Uiu2a", Seq: 1, IP: "10.0.0.1", TCPPort: 13000, UDPPort: 12000, ForkDigest: "0x4a26c58b",
+			Agent: "Prysm/v2.1.3", HeadSlot: &headSlot, FinalizedEpoch: &finalizedEpoch, ENR: "enr:-a"},
+		{NodeID: "bb", PeerID: "16Uiu2b", Seq: 2, HandshakeError: "could not connect", ENR: "enr:-b"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeCensus(&buf, censusFormatCSV, records))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Equal(t, 3, len(lines))
+	assert.Equal(t, strings.Join(censusCSVHeader, ","), lines[0])
+	assert.Equal(t, "aa,16Uiu2a,1,10.0.0.1,13000,12000,0x4a26c58b,,,,,Prysm/v2.1.3,4000,123,,enr:-a", lines[1])
+	assert.Equal(t, "bb,16Uiu2b,2,,0,0,,,,,,,,,could not connect,enr:-b", lines[2])
+
+	buf.Reset()
+	require.NoError(t, writeCensus(&buf, censusFormatJSON, records))
+	var decoded []map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	require.Equal(t, 2, len(decoded))
+	assert.Equal(t, float64(4000), decoded[0]["head_slot"])
+	assert.Equal(t, float64(123), decoded[0]["finalized_epoch"])
+	_, ok := decoded[1]["head_slot"]
+	assert.Equal(t, false, ok, "Head slot should be omitted without a handshake")
+
+	assert.ErrorContains(t, "unsupported census format", writeCensus(&buf, "xml", records))
+}
+
+func TestCrawler_LocalNetwork(t *testing.T) {
+	ip := net.ParseIP("127.0.0.1")
+	newListener := func(port int, bootnodes []string) *enode.Node {
+		key, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+		require.NoError(t, err)
+		ecdsaKey, err := ecdsaprysm.ConvertFromInterfacePrivKey(key)
+		require.NoError(t, err)
+		listener, err := prysmP2P.CreateStandaloneListener(ecdsaKey, ip, port, port+1000, bootnodes)
+		require.NoError(t, err)
+		t.Cleanup(listener.Close)
+		return listener.Self()
+	}
+	bootnode := newListener(14500, nil)
+	for i := 1; i <= 3; i++ {
+		newListener(14500+i, []string{bootnode.String()})
+	}
+
+	key, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+	require.NoError(t, err)
+	ecdsaKey, err := ecdsaprysm.ConvertFromInterfacePrivKey(key)
+	require.NoError(t, err)
+	listener, err := prysmP2P.CreateStandaloneListener(ecdsaKey, ip, 14520, 0, []string{bootnode.String()})
+	require.NoError(t, err)
+	defer listener.Close()
+
+	c := newCrawler(nil, 1, time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	c.crawl(ctx, listener.RandomNodes())
+
+	// All the nodes of the network, including the bootnode, are found.
+	census := c.census()
+	assert.Equal(t, true, len(census) >= 4, "Expected at least 4 nodes, got %d", len(census))
+	found := false
+	for _, r := range census {
+		if r.NodeID == bootnode.ID().String() {
+			found = true
+		}
+	}
+	assert.Equal(t, true, found, "Bootnode missing from census")
+}
+
+func TestCrawler_Handshake(t *testing.T) {
+	enc := encoder.SszNetworkEncoder{}
+	peerKey, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+	require.NoError(t, err)
+	listen, err := tcpMultiAddr(net.ParseIP("127.0.0.1"), 14600)
+	require.NoError(t, err)
+	h, err := libp2p.New(libp2p.Identity(peerKey), libp2p.ListenAddrs(listen), libp2p.UserAgent("Lighthouse/v2.3.1"))
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, h.Close())
+	}()
+
+	digest := []byte{0x4a, 0x26, 0xc5, 0x8b}
+	h.SetStreamHandler(protocol.ID(prysmP2P.RPCStatusTopicV1+enc.ProtocolSuffix()), func(stream network.Stream) {
+		req := &pb.Status{}
+		if err := enc.DecodeWithMaxLength(stream, req); err != nil || !bytes.Equal(req.ForkDigest, digest) {
+			_ = stream.Reset()
+			return
+		}
+		_, _ = stream.Write([]byte{0x00})
+		_, _ = enc.EncodeWithMaxLength(stream, &pb.Status{
+			ForkDigest:     digest,
+			FinalizedRoot:  make([]byte, 32),
+			FinalizedEpoch: 120,
+			HeadRoot:       make([]byte, 32),
+			HeadSlot:       3900,
+		})
+		_ = stream.Close()
+	})
+	h.SetStreamHandler(protocol.ID(prysmP2P.RPCMetaDataTopicV2+enc.ProtocolSuffix()), func(stream network.Stream) {
+		_, _ = stream.Write([]byte{0x00})
+		_, _ = enc.EncodeWithMaxLength(stream, &pb.MetaDataV1{
+			SeqNumber: 4,
+			Attnets:   bitfield.Bitvector64{0x03, 0, 0, 0, 0, 0, 0, 0},
+			Syncnets:  bitfield.Bitvector4{0x01},
+		})
+		_ = stream.Close()
+	})
+
+	node, err := buildENR(peerKey, &enrConfig{
+		seq:     1,
+		ip:      net.ParseIP("127.0.0.1"),
+		tcpPort: 14600,
+		forkID:  &pb.ENRForkID{CurrentForkDigest: digest, NextForkVersion: make([]byte, 4)},
+	})
+	require.NoError(t, err)
+	record, err := censusRecordFromNode(node)
+	require.NoError(t, err)
+
+	crawlerKey, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+	require.NoError(t, err)
+	c := newCrawler(nil, 1, 5*time.Second)
+	c.host, err = newCrawlerHost(crawlerKey, net.ParseIP("127.0.0.1"), 14601)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, c.host.Close())
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	c.handshake(ctx, record)
+	assert.Equal(t, "", record.HandshakeError)
+	assert.Equal(t, "Lighthouse/v2.3.1", record.Agent)
+	require.NotNil(t, record.HeadSlot)
+	assert.Equal(t, types.Slot(3900), *record.HeadSlot)
+	require.NotNil(t, record.FinalizedEpoch)
+	assert.Equal(t, types.Epoch(120), *record.FinalizedEpoch)
+	assert.Equal(t, "0x0300000000000000", record.Attnets)
+	assert.Equal(t, "0x01", record.Syncnets)
+}
@@ -0,0 +1,363 @@
+package p2p
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	ecdsaprysm "github.com/prysmaticlabs/prysm/crypto/ecdsa"
+	pb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/urfave/cli/v2"
+)
+
+var enrBuildFlags = struct {
+	IP              string
+	TCPPort         uint64
+	UDPPort         uint64
+	ForkDigest      string
+	NextForkVersion string
+	NextForkEpoch   uint64
+	Attnets         string
+	Syncnets        string
+	Seq             uint64
+}{}
+
+var enrCmd = &cli.Command{
+	Name:  "enr",
+	Usage: "commands for building and inspecting ENRs",
+	Subcommands: []*cli.Command{
+		enrBuildCmd,
+		enrDecodeCmd,
+	},
+}
+
+var enrBuildCmd = &cli.Command{
+	Name:   "build",
+	Usage:  "Build and sign an ENR for the node's p2p private key, with custom fields.",
+	Action: cliActionENRBuild,
+	Flags: append([]cli.Flag{
+		&cli.StringFlag{
+			Name:        "ip",
+			Usage:       "IPv4 or IPv6 address to advertise",
+			Destination: &enrBuildFlags.IP,
+		},
+		&cli.Uint64Flag{
+			Name:        "tcp-port",
+			Usage:       "TCP port to advertise, under tcp6 for an IPv6 address, omitted from the ENR if not set",
+			Destination: &enrBuildFlags.TCPPort,
+		},
+		&cli.Uint64Flag{
+			Name:        "udp-port",
+			Usage:       "UDP (discovery) port to advertise, under udp6 for an IPv6 address, omitted from the ENR if not set",
+			Destination: &enrBuildFlags.UDPPort,
+		},
+		&cli.StringFlag{
+			Name:        "fork-digest",
+			Usage:       "hex encoded current fork digest, the eth2 field is omitted from the ENR if not set",
+			Destination: &enrBuildFlags.ForkDigest,
+		},
+		&cli.StringFlag{
+			Name:        "next-fork-version",
+			Usage:       "hex encoded next fork version, required along with --fork-digest",
+			Destination: &enrBuildFlags.NextForkVersion,
+		},
+		&cli.Uint64Flag{
+			Name:        "next-fork-epoch",
+			Usage:       "epoch of the next fork, defaults to far future epoch (no fork scheduled)",
+			Destination: &enrBuildFlags.NextForkEpoch,
+			Value:       uint64(params.BeaconConfig().FarFutureEpoch),
+		},
+		&cli.StringFlag{
+			Name:        "attnets",
+			Usage:       "hex encoded attestation subnets bitvector, ex: 0xffffffffffffffff",
+			Destination: &enrBuildFlags.Attnets,
+		},
+		&cli.StringFlag{
+			Name:        "syncnets",
+			Usage:       "hex encoded sync committee subnets bitvector, ex: 0x0f",
+			Destination: &enrBuildFlags.Syncnets,
+		},
+		&cli.Uint64Flag{
+			Name:        "seq",
+			Usage:       "sequence number of the record, must be higher than the one of the record being replaced",
+			Destination: &enrBuildFlags.Seq,
+			Value:       1,
+		},
+	}, keyPathFlags...),
+}
+
+var enrDecodeCmd = &cli.Command{
+	Name:      "decode",
+	Usage:     "Decode and verify an ENR, printing all known fields.",
+	ArgsUsage: "<enr>",
+	Action:    cliActionENRDecode,
+}
+
+// enrConfig holds custom fields of an ENR being built.
+type enrConfig struct {
+	seq      uint64
+	ip       net.IP
+	tcpPort  uint64
+	udpPort  uint64
+	forkID   *pb.ENRForkID
+	attnets  bitfield.Bitvector64
+	syncnets bitfield.Bitvector4
+}
+
+func cliActionENRBuild(_ *cli.Context) error {
+	key, err := loadKey(privateKeyPath())
+	if err != nil {
+		return errors.Wrap(err, "could not load p2p private key")
+	}
+	cfg, err := enrConfigFromFlags()
+	if err != nil {
+		return err
+	}
+	node, err := buildENR(key, cfg)
+	if err != nil {
+		return err
+	}
+	fmt.Println(node.String())
+	return nil
+}
+
+func cliActionENRDecode(cliCtx *cli.Context) error {
+	if cliCtx.NArg() != 1 {
+		return errors.New("expected exactly one ENR to decode")
+	}
+	node, err := parseENR(cliCtx.Args().First())
+	if err != nil {
+		return err
+	}
+	desc, err := describeENR(node)
+	if err != nil {
+		return err
+	}
+	fmt.Print(desc)
+	return nil
+}
+
+func enrConfigFromFlags() (*enrConfig, error) {
+	f := enrBuildFlags
+	cfg := &enrConfig{
+		seq:     f.Seq,
+		tcpPort: f.TCPPort,
+		udpPort: f.UDPPort,
+	}
+	if f.IP != "" {
+		cfg.ip = net.ParseIP(f.IP)
+		if cfg.ip == nil {
+			return nil, fmt.Errorf("invalid IP address: %s", f.IP)
+		}
+	}
+	if f.ForkDigest != "" {
+		digest, err := decodeFixedHex(f.ForkDigest, 4)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid fork digest")
+		}
+		if f.NextForkVersion == "" {
+			return nil, errors.New("--next-fork-version must be provided along with --fork-digest")
+		}
+		nextVersion, err := decodeFixedHex(f.NextForkVersion, 4)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid next fork version")
+		}
+		cfg.forkID = &pb.ENRForkID{
+			CurrentForkDigest: digest,
+			NextForkVersion:   nextVersion,
+			NextForkEpoch:     types.Epoch(f.NextForkEpoch),
+		}
+	}
+	if f.Attnets != "" {
+		attnets, err := decodeFixedHex(f.Attnets, len(bitfield.NewBitvector64()))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid attestation subnets bitvector")
+		}
+		cfg.attnets = attnets
+	}
+	if f.Syncnets != "" {
+		syncnets, err := decodeFixedHex(f.Syncnets, len(bitfield.NewBitvector4()))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid sync committee subnets bitvector")
+		}
+		cfg.syncnets = syncnets
+	}
+	return cfg, nil
+}
+
+// buildENR builds a record out of the given config, and signs it with the given key.
+func buildENR(key crypto.PrivKey, cfg *enrConfig) (*enode.Node, error) {
+	ecdsaKey, err := ecdsaprysm.ConvertFromInterfacePrivKey(key)
+	if err != nil {
+		return nil, err
+	}
+	record := &enr.Record{}
+	record.SetSeq(cfg.seq)
+	// IPv6 addresses and their ports go under the ip6, tcp6 and udp6 keys.
+	if cfg.ip != nil && cfg.ip.To4() == nil {
+		record.Set(enr.IPv6(cfg.ip))
+		if cfg.tcpPort != 0 {
+			record.Set(enr.TCP6(cfg.tcpPort))
+		}
+		if cfg.udpPort != 0 {
+			record.Set(enr.UDP6(cfg.udpPort))
+		}
+	} else {
+		if cfg.ip != nil {
+			record.Set(enr.IPv4(cfg.ip))
+		}
+		if cfg.tcpPort != 0 {
+			record.Set(enr.TCP(cfg.tcpPort))
+		}
+		if cfg.udpPort != 0 {
+			record.Set(enr.UDP(cfg.udpPort))
+		}
+	}
+	if cfg.forkID != nil {
+		enc, err := cfg.forkID.MarshalSSZ()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not marshal fork id")
+		}
+		record.Set(enr.WithEntry(params.BeaconNetworkConfig().ETH2Key, enc))
+	}
+	if cfg.attnets != nil {
+		record.Set(enr.WithEntry(params.BeaconNetworkConfig().AttSubnetKey, cfg.attnets.Bytes()))
+	}
+	if cfg.syncnets != nil {
+		record.Set(enr.WithEntry(params.BeaconNetworkConfig().SyncCommsSubnetKey, cfg.syncnets.Bytes()))
+	}
+	if err := enode.SignV4(record, ecdsaKey); err != nil {
+		return nil, errors.Wrap(err, "could not sign record")
+	}
+	return enode.New(enode.ValidSchemes, record)
+}
+
+// parseENR parses and verifies a textual ENR, with or without the "enr:" prefix.
+func parseENR(s string) (*enode.Node, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "enr:") {
+		s = "enr:" + s
+	}
+	node, err := enode.Parse(enode.ValidSchemes, s)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse ENR")
+	}
+	return node, nil
+}
+
+// describeENR returns a human-readable description of all known fields of the record.
+func describeENR(node *enode.Node) (string, error) {
+	var b strings.Builder
+	pid, err := peerIDFromNode(node)
+	if err != nil {
+		return "", err
+	}
+	fields, err := loadETH2Fields(node)
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(&b, "seq:               %d\n", node.Seq())
+	fmt.Fprintf(&b, "node id:           %s\n", node.ID())
+	fmt.Fprintf(&b, "peer id:           %s\n", pid)
+	var ip4 enr.IPv4
+	if node.Load(&ip4) == nil {
+		fmt.Fprintf(&b, "ip:                %s\n", net.IP(ip4))
+	}
+	if port := node.TCP(); port != 0 {
+		fmt.Fprintf(&b, "tcp:               %d\n", port)
+	}
+	if port := node.UDP(); port != 0 {
+		fmt.Fprintf(&b, "udp:               %d\n", port)
+	}
+	var ip6 enr.IPv6
+	if node.Load(&ip6) == nil {
+		fmt.Fprintf(&b, "ip6:               %s\n", net.IP(ip6))
+	}
+	var tcp6 enr.TCP6
+	if node.Load(&tcp6) == nil {
+		fmt.Fprintf(&b, "tcp6:              %d\n", tcp6)
+	}
+	var udp6 enr.UDP6
+	if node.Load(&udp6) == nil {
+		fmt.Fprintf(&b, "udp6:              %d\n", udp6)
+	}
+	if fields.forkID != nil {
+		fmt.Fprintf(&b, "fork digest:       %#x\n", fields.forkID.CurrentForkDigest)
+		fmt.Fprintf(&b, "next fork version: %#x\n", fields.forkID.NextForkVersion)
+		fmt.Fprintf(&b, "next fork epoch:   %d\n", fields.forkID.NextForkEpoch)
+	}
+	if fields.attnets != nil {
+		fmt.Fprintf(&b, "attnets:           %#x %v\n", fields.attnets.Bytes(), fields.attnets.BitIndices())
+	}
+	if fields.syncnets != nil {
+		fmt.Fprintf(&b, "syncnets:          %#x %v\n", fields.syncnets.Bytes(), fields.syncnets.BitIndices())
+	}
+	return b.String(), nil
+}
+
+// eth2Fields holds the consensus specific entries of a record, each one is nil when absent.
+type eth2Fields struct {
+	forkID   *pb.ENRForkID
+	attnets  bitfield.Bitvector64
+	syncnets bitfield.Bitvector4
+}
+
+// loadETH2Fields decodes the eth2, attnets and syncnets entries of the record.
+func loadETH2Fields(node *enode.Node) (*eth2Fields, error) {
+	fields := &eth2Fields{}
+	forkEntry := make([]byte, 16)
+	if err := node.Load(enr.WithEntry(params.BeaconNetworkConfig().ETH2Key, &forkEntry)); err == nil {
+		fields.forkID = &pb.ENRForkID{}
+		if err := fields.forkID.UnmarshalSSZ(forkEntry); err != nil {
+			return nil, errors.Wrap(err, "could not decode eth2 field")
+		}
+	} else if !enr.IsNotFound(err) {
+		return nil, errors.Wrap(err, "could not load eth2 field")
+	}
+	attnets := bitfield.NewBitvector64()
+	if err := node.Load(enr.WithEntry(params.BeaconNetworkConfig().AttSubnetKey, &attnets)); err == nil {
+		fields.attnets = attnets
+	} else if !enr.IsNotFound(err) {
+		return nil, errors.Wrap(err, "could not load attnets field")
+	}
+	syncnets := bitfield.NewBitvector4()
+	if err := node.Load(enr.WithEntry(params.BeaconNetworkConfig().SyncCommsSubnetKey, &syncnets)); err == nil {
+		fields.syncnets = syncnets
+	} else if !enr.IsNotFound(err) {
+		return nil, errors.Wrap(err, "could not load syncnets field")
+	}
+	return fields, nil
+}
+
+// peerIDFromNode derives the libp2p peer id from the record's public key.
+func peerIDFromNode(node *enode.Node) (peer.ID, error) {
+	pubkey, err := ecdsaprysm.ConvertToInterfacePubkey(node.Pubkey())
+	if err != nil {
+		return "", err
+	}
+	return peer.IDFromPublicKey(pubkey)
+}
+
+// decodeFixedHex decodes a hex string (with or without 0x prefix) of the given byte length.
+func decodeFixedHex(s string, size int) ([]byte, error) {
+	if !strings.HasPrefix(s, "0x") {
+		s = "0x" + s
+	}
+	b, err := hexutil.Decode(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != size {
+		return nil, fmt.Errorf("expected %d bytes, got %d", size, len(b))
+	}
+	return b, nil
+}
@@ -0,0 +1,130 @@
+package p2p
+
+import (
+	"crypto/rand"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/prysmaticlabs/go-bitfield"
+	pb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestBuildENR_Decode(t *testing.T) {
+	key, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+	require.NoError(t, err)
+	pid, err := peer.IDFromPrivateKey(key)
+	require.NoError(t, err)
+
+	attnets := bitfield.NewBitvector64()
+	attnets.SetBitAt(1, true)
+	attnets.SetBitAt(63, true)
+	syncnets := bitfield.NewBitvector4()
+	syncnets.SetBitAt(2, true)
+	cfg := &enrConfig{
+		seq:     5,
+		ip:      net.ParseIP("192.168.0.1"),
+		tcpPort: 13000,
+		udpPort: 12000,
+		forkID: &pb.ENRForkID{
+			CurrentForkDigest: []byte{0x4a, 0x26, 0xc5, 0x8b},
+			NextForkVersion:   []byte{0x02, 0x00, 0x00, 0x00},
+			NextForkEpoch:     144896,
+		},
+		attnets:  attnets,
+		syncnets: syncnets,
+	}
+	node, err := buildENR(key, cfg)
+	require.NoError(t, err)
+
+	// Round trip through the textual representation, with and without the prefix.
+	for _, s := range []string{node.String(), strings.TrimPrefix(node.String(), "enr:")} {
+		parsed, err := parseENR(s)
+		require.NoError(t, err)
+		assert.Equal(t, node.ID(), parsed.ID())
+
+		desc, err := describeENR(parsed)
+		require.NoError(t, err)
+		assert.Equal(t, true, strings.Contains(desc, "seq:               5\n"), desc)
+		assert.Equal(t, true, strings.Contains(desc, "peer id:           "+pid.String()+"\n"), desc)
+		assert.Equal(t, true, strings.Contains(desc, "ip:                192.168.0.1\n"), desc)
+		assert.Equal(t, true, strings.Contains(desc, "tcp:               13000\n"), desc)
+		assert.Equal(t, true, strings.Contains(desc, "udp:               12000\n"), desc)
+		assert.Equal(t, true, strings.Contains(desc, "fork digest:       0x4a26c58b\n"), desc)
+		assert.Equal(t, true, strings.Contains(desc, "next fork version: 0x02000000\n"), desc)
+		assert.Equal(t, true, strings.Contains(desc, "next fork epoch:   144896\n"), desc)
+		assert.Equal(t, true, strings.Contains(desc, "attnets:           0x0200000000000080 [1 63]\n"), desc)
+		assert.Equal(t, true, strings.Contains(desc, "syncnets:          0x04 [2]\n"), desc)
+	}
+}
+
+func TestBuildENR_OptionalFields(t *testing.T) {
+	key, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+	require.NoError(t, err)
+	node, err := buildENR(key, &enrConfig{seq: 1})
+	require.NoError(t, err)
+
+	desc, err := describeENR(node)
+	require.NoError(t, err)
+	assert.Equal(t, false, strings.Contains(desc, "ip:"), desc)
+	assert.Equal(t, false, strings.Contains(desc, "fork digest:"), desc)
+	assert.Equal(t, false, strings.Contains(desc, "attnets:"), desc)
+}
+
+func TestBuildENR_IPv6(t *testing.T) {
+	key, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+	require.NoError(t, err)
+	node, err := buildENR(key, &enrConfig{
+		seq:     1,
+		ip:      net.ParseIP("2001:db8::1"),
+		tcpPort: 13000,
+		udpPort: 12000,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, node.TCP())
+	assert.Equal(t, 0, node.UDP())
+	var tcp6 enr.TCP6
+	require.NoError(t, node.Load(&tcp6))
+	assert.Equal(t, enr.TCP6(13000), tcp6)
+	var udp6 enr.UDP6
+	require.NoError(t, node.Load(&udp6))
+	assert.Equal(t, enr.UDP6(12000), udp6)
+
+	desc, err := describeENR(node)
+	require.NoError(t, err)
+	assert.Equal(t, false, strings.Contains(desc, "ip:"), desc)
+	assert.Equal(t, true, strings.Contains(desc, "ip6:               2001:db8::1\n"), desc)
+	assert.Equal(t, true, strings.Contains(desc, "tcp6:              13000\n"), desc)
+	assert.Equal(t, true, strings.Contains(desc, "udp6:              12000\n"), desc)
+}
+
+func TestParseENR_Invalid(t *testing.T) {
+	_, err := parseENR("enr:invalid")
+	assert.ErrorContains(t, "could not parse ENR", err)
+}
+
+func TestENRConfigFromFlags(t *testing.T) {
+	defer func() {
+		enrBuildFlags.ForkDigest, enrBuildFlags.NextForkVersion, enrBuildFlags.Attnets = "", "", ""
+	}()
+
+	enrBuildFlags.ForkDigest = "0x4a26c58b"
+	_, err := enrConfigFromFlags()
+	assert.ErrorContains(t, "--next-fork-version must be provided", err)
+
+	enrBuildFlags.NextForkVersion = "02000000"
+	enrBuildFlags.Attnets = "0xff"
+	_, err = enrConfigFromFlags()
+	assert.ErrorContains(t, "invalid attestation subnets bitvector", err)
+
+	enrBuildFlags.Attnets = "0xffffffffffffffff"
+	cfg, err := enrConfigFromFlags()
+	require.NoError(t, err)
+	assert.DeepEqual(t, []byte{0x02, 0x00, 0x00, 0x00}, cfg.forkID.NextForkVersion)
+	assert.Equal(t, uint64(64), cfg.attnets.Count())
+}
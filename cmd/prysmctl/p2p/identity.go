@@ -0,0 +1,76 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/api/client/beacon"
+	"github.com/urfave/cli/v2"
+)
+
+var identityFlags = struct {
+	BeaconNodeHost string
+	Timeout        time.Duration
+}{}
+
+var identityCmd = &cli.Command{
+	Name:   "identity",
+	Usage:  "Query a running beacon node for its peer id, ENR, addresses and p2p metadata.",
+	Action: cliActionIdentity,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "beacon-node-host",
+			Usage:       "host:port for beacon node to query",
+			Destination: &identityFlags.BeaconNodeHost,
+			Value:       "http://localhost:3500",
+		},
+		&cli.DurationFlag{
+			Name:        "http-timeout",
+			Usage:       "timeout for http requests made to beacon-node-url (uses duration format, ex: 2m31s). default: 1m",
+			Destination: &identityFlags.Timeout,
+			Value:       time.Minute,
+		},
+	},
+}
+
+func cliActionIdentity(_ *cli.Context) error {
+	ctx := context.Background()
+	f := identityFlags
+
+	opts := []beacon.ClientOpt{beacon.WithTimeout(f.Timeout)}
+	client, err := beacon.NewClient(f.BeaconNodeHost, opts...)
+	if err != nil {
+		return err
+	}
+	identity, err := client.GetNodeIdentity(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("peer id:             %s\n", identity.PeerID)
+	fmt.Printf("enr:                 %s\n", identity.ENR)
+	fmt.Printf("p2p addresses:       %s\n", strings.Join(identity.P2PAddresses, ", "))
+	fmt.Printf("discovery addresses: %s\n", strings.Join(identity.DiscoveryAddresses, ", "))
+	fmt.Printf("metadata seq:        %d\n", identity.SeqNumber)
+	fmt.Printf("metadata attnets:    %#x\n", identity.Attnets)
+	if len(identity.Syncnets) > 0 {
+		fmt.Printf("metadata syncnets:   %#x\n", identity.Syncnets)
+	}
+	if identity.ENR == "" {
+		return nil
+	}
+
+	node, err := parseENR(identity.ENR)
+	if err != nil {
+		return errors.Wrap(err, "node reported an invalid ENR")
+	}
+	desc, err := describeENR(node)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("\ndecoded enr:\n%s", desc)
+	return nil
+}
@@ -0,0 +1,183 @@
+package p2p
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/cmd"
+	"github.com/prysmaticlabs/prysm/io/file"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+// keyFileName is the name of the file, within beacon node's data directory, the node
+// loads its p2p private key from.
+const keyFileName = "network-keys"
+
+var keyFlags = struct {
+	DataDir string
+	KeyPath string
+	Force   bool
+}{}
+
+var keyPathFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:        "datadir",
+		Usage:       "beacon node's data directory, the key is stored at <datadir>/" + keyFileName,
+		Destination: &keyFlags.DataDir,
+		Value:       cmd.DefaultDataDir(),
+	},
+	&cli.StringFlag{
+		Name:        "p2p-priv-key",
+		Usage:       "path to the hex-encoded secp256k1 private key file, overrides --datadir",
+		Destination: &keyFlags.KeyPath,
+	},
+}
+
+var keyCmd = &cli.Command{
+	Name:  "key",
+	Usage: "commands for managing node's secp256k1 p2p identity key",
+	Subcommands: []*cli.Command{
+		keyGenerateCmd,
+		keyRotateCmd,
+	},
+}
+
+var keyGenerateCmd = &cli.Command{
+	Name:   "generate",
+	Usage:  "Generate a new p2p private key and store it where the beacon node expects to find it.",
+	Action: cliActionKeyGenerate,
+	Flags: append([]cli.Flag{
+		&cli.BoolFlag{
+			Name:        "force",
+			Usage:       "overwrite the key file if it already exists",
+			Destination: &keyFlags.Force,
+		},
+	}, keyPathFlags...),
+}
+
+var keyRotateCmd = &cli.Command{
+	Name: "rotate",
+	Usage: "Replace the existing p2p private key with a newly generated one. The old key is kept as a " +
+		"timestamped backup next to the key file.",
+	Action: cliActionKeyRotate,
+	Flags:  keyPathFlags,
+}
+
+func cliActionKeyGenerate(_ *cli.Context) error {
+	keyPath := privateKeyPath()
+	if file.FileExists(keyPath) && !keyFlags.Force {
+		return fmt.Errorf("key file %s already exists, use --force to overwrite it or the rotate command to replace it", keyPath)
+	}
+	key, err := generateKey(keyPath)
+	if err != nil {
+		return err
+	}
+	pid, err := peer.IDFromPrivateKey(key)
+	if err != nil {
+		return err
+	}
+	log.WithFields(log.Fields{
+		"path":   keyPath,
+		"peerID": pid.String(),
+	}).Info("Generated new p2p private key")
+	return nil
+}
+
+func cliActionKeyRotate(_ *cli.Context) error {
+	keyPath := privateKeyPath()
+	oldKey, err := loadKey(keyPath)
+	if err != nil {
+		return errors.Wrap(err, "could not load the key to rotate")
+	}
+	oldPID, err := peer.IDFromPrivateKey(oldKey)
+	if err != nil {
+		return err
+	}
+	backupPath, err := rotateKey(keyPath, time.Now())
+	if err != nil {
+		return err
+	}
+	key, err := generateKey(keyPath)
+	if err != nil {
+		return err
+	}
+	pid, err := peer.IDFromPrivateKey(key)
+	if err != nil {
+		return err
+	}
+	log.WithFields(log.Fields{
+		"path":      keyPath,
+		"backup":    backupPath,
+		"oldPeerID": oldPID.String(),
+		"peerID":    pid.String(),
+	}).Info("Rotated p2p private key, restart the beacon node for the new identity to take effect")
+	return nil
+}
+
+// privateKeyPath returns location of the key file, as the beacon node would resolve it.
+func privateKeyPath() string {
+	if keyFlags.KeyPath != "" {
+		return keyFlags.KeyPath
+	}
+	return filepath.Join(keyFlags.DataDir, keyFileName)
+}
+
+// generateKey creates a new secp256k1 key and saves it to the given path, in the format expected by
+// the beacon node's --p2p-priv-key flag.
+func generateKey(keyPath string) (crypto.PrivKey, error) {
+	key, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not generate key")
+	}
+	raw, err := key.Raw()
+	if err != nil {
+		return nil, err
+	}
+	hasDir, err := file.HasDir(filepath.Dir(keyPath))
+	if err != nil {
+		return nil, err
+	}
+	if !hasDir {
+		if err := file.MkdirAll(filepath.Dir(keyPath)); err != nil {
+			return nil, errors.Wrap(err, "could not create key directory")
+		}
+	}
+	if err := file.WriteFile(keyPath, []byte(hex.EncodeToString(raw))); err != nil {
+		return nil, errors.Wrap(err, "could not write key file")
+	}
+	return key, nil
+}
+
+// loadKey reads a hex-encoded secp256k1 key from the given path.
+func loadKey(keyPath string) (crypto.PrivKey, error) {
+	src, err := file.ReadFileAsBytes(keyPath)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := hex.DecodeString(strings.TrimSpace(string(src)))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode hex string")
+	}
+	return crypto.UnmarshalSecp256k1PrivateKey(raw)
+}
+
+// rotateKey moves the existing key file to a timestamped backup, returning the backup's path.
+func rotateKey(keyPath string, now time.Time) (string, error) {
+	backupPath := fmt.Sprintf("%s.%s.bak", keyPath, now.UTC().Format("20060102150405"))
+	if file.FileExists(backupPath) {
+		return "", fmt.Errorf("backup file %s already exists", backupPath)
+	}
+	if err := os.Rename(keyPath, backupPath); err != nil {
+		return "", errors.Wrap(err, "could not back up the existing key")
+	}
+	return backupPath, nil
+}
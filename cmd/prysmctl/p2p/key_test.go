@@ -0,0 +1,58 @@
+package p2p
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/prysmaticlabs/prysm/io/file"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestGenerateKey_LoadKey(t *testing.T) {
+	keyPath := filepath.Join(t.TempDir(), "beacon", keyFileName)
+	key, err := generateKey(keyPath)
+	require.NoError(t, err)
+
+	loaded, err := loadKey(keyPath)
+	require.NoError(t, err)
+	assert.Equal(t, true, key.Equals(loaded), "Loaded key does not match the generated one")
+}
+
+func TestRotateKey(t *testing.T) {
+	keyPath := filepath.Join(t.TempDir(), keyFileName)
+	oldKey, err := generateKey(keyPath)
+	require.NoError(t, err)
+	oldPID, err := peer.IDFromPrivateKey(oldKey)
+	require.NoError(t, err)
+
+	now := time.Date(2022, 7, 1, 12, 0, 0, 0, time.UTC)
+	backupPath, err := rotateKey(keyPath, now)
+	require.NoError(t, err)
+	assert.Equal(t, keyPath+".20220701120000.bak", backupPath)
+	assert.Equal(t, false, file.FileExists(keyPath))
+
+	backup, err := loadKey(backupPath)
+	require.NoError(t, err)
+	backupPID, err := peer.IDFromPrivateKey(backup)
+	require.NoError(t, err)
+	assert.Equal(t, oldPID, backupPID)
+
+	// Backups are never overwritten.
+	_, err = generateKey(keyPath)
+	require.NoError(t, err)
+	_, err = rotateKey(keyPath, now)
+	assert.ErrorContains(t, "already exists", err)
+}
+
+func TestPrivateKeyPath(t *testing.T) {
+	defer func() {
+		keyFlags.DataDir, keyFlags.KeyPath = "", ""
+	}()
+	keyFlags.DataDir = "/data"
+	assert.Equal(t, filepath.Join("/data", keyFileName), privateKeyPath())
+	keyFlags.KeyPath = "/keys/p2p.key"
+	assert.Equal(t, "/keys/p2p.key", privateKeyPath())
+}
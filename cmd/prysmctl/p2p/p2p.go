@@ -0,0 +1,16 @@
+package p2p
+
+import "github.com/urfave/cli/v2"
+
+var Commands = []*cli.Command{
+	{
+		Name:  "p2p",
+		Usage: "commands for managing node's p2p identity and ENRs",
+		Subcommands: []*cli.Command{
+			keyCmd,
+			enrCmd,
+			identityCmd,
+			crawlCmd,
+		},
+	},
+}
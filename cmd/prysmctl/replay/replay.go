@@ -0,0 +1,176 @@
+package replay
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/io/file"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var replayFlags = struct {
+	DataDir          string
+	Network          string
+	ChainConfigFile  string
+	StartSlot        uint64
+	EndSlot          uint64
+	VerifySignatures bool
+	DiffDir          string
+	ReportPath       string
+}{}
+
+var Commands = []*cli.Command{
+	{
+		Name:   "replay",
+		Usage:  "Replay the canonical blocks of a slot range from a beacon node database through the state transition, reporting timings and state root mismatches.",
+		Action: cliActionReplay,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name: "datadir",
+				Usage: "path to the directory of the beacon node database to read blocks and states from. The database is opened read-only, " +
+					"the beacon node using it must be stopped first, or a copy of the database used",
+				Destination: &replayFlags.DataDir,
+				Required:    true,
+			},
+			&cli.StringFlag{
+				Name:        "network",
+				Usage:       "name of the network the database belongs to, ex: mainnet, prater",
+				Destination: &replayFlags.Network,
+				Value:       params.MainnetName,
+			},
+			&cli.StringFlag{
+				Name:        "chain-config-file",
+				Usage:       "path to a chain config yaml file, used instead of --network",
+				Destination: &replayFlags.ChainConfigFile,
+			},
+			&cli.Uint64Flag{
+				Name:        "start-slot",
+				Usage:       "slot of the first block to replay",
+				Destination: &replayFlags.StartSlot,
+				Required:    true,
+			},
+			&cli.Uint64Flag{
+				Name:        "end-slot",
+				Usage:       "slot of the last block to replay. The canonical chain is the one leading to the highest block at or below this slot",
+				Destination: &replayFlags.EndSlot,
+				Required:    true,
+			},
+			&cli.BoolFlag{
+				Name:        "verify-signatures",
+				Usage:       "verify the proposer, randao and attestation signatures of the blocks, and report the time spent doing so",
+				Destination: &replayFlags.VerifySignatures,
+			},
+			&cli.StringFlag{
+				Name:        "diff-dir",
+				Usage:       "directory to write the pre-state, the computed and stored post-states and their diff to, at the first state root mismatch",
+				Destination: &replayFlags.DiffDir,
+			},
+			&cli.StringFlag{
+				Name:        "report",
+				Usage:       "path to write the full replay report to as json, for comparison between runs",
+				Destination: &replayFlags.ReportPath,
+			},
+		},
+	},
+}
+
+func cliActionReplay(_ *cli.Context) error {
+	ctx := context.Background()
+	f := replayFlags
+	if f.EndSlot < f.StartSlot {
+		return fmt.Errorf("end slot %d is lower than start slot %d", f.EndSlot, f.StartSlot)
+	}
+	if err := setChainConfig(f.Network, f.ChainConfigFile); err != nil {
+		return err
+	}
+
+	db, err := kv.NewKVStore(ctx, f.DataDir, &kv.Config{ReadOnly: true})
+	if err != nil {
+		return errors.Wrap(err, "could not open beacon node database")
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.WithError(err).Error("Could not close database")
+		}
+	}()
+	sg := stategen.New(db)
+
+	// The genesis block has no parent state to be replayed onto.
+	startSlot := types.Slot(f.StartSlot)
+	if startSlot == 0 {
+		startSlot = 1
+	}
+	endSlot := types.Slot(f.EndSlot)
+	_, endRoots, err := db.HighestRootsBelowSlot(ctx, endSlot+1)
+	if err != nil {
+		return errors.Wrap(err, "could not get highest block below end slot")
+	}
+	if len(endRoots) == 0 {
+		return fmt.Errorf("no block found at or below slot %d", endSlot)
+	}
+	if len(endRoots) > 1 {
+		log.WithField("blockRoot", fmt.Sprintf("%#x", endRoots[0])).Warn("Several blocks at the end slot, replaying the chain of the first one")
+	}
+	blocks, err := sg.LoadBlocks(ctx, startSlot, endSlot, endRoots[0])
+	if err != nil {
+		return errors.Wrap(err, "could not load blocks")
+	}
+	if len(blocks) == 0 {
+		return fmt.Errorf("no block found between slots %d and %d", startSlot, endSlot)
+	}
+	// Blocks are loaded in descending slot order.
+	for i, j := 0, len(blocks)-1; i < j; i, j = i+1, j-1 {
+		blocks[i], blocks[j] = blocks[j], blocks[i]
+	}
+	preState, err := sg.StateByRoot(ctx, bytesutil.ToBytes32(blocks[0].Block().ParentRoot()))
+	if err != nil {
+		return errors.Wrap(err, "could not get pre-state of the first block")
+	}
+
+	r := &replayer{
+		db:               db,
+		verifySignatures: f.VerifySignatures,
+		diffDir:          f.DiffDir,
+	}
+	rep, err := r.replay(ctx, preState, blocks)
+	if err != nil {
+		return err
+	}
+	rep.print(os.Stdout)
+
+	if f.ReportPath != "" {
+		enc, err := json.MarshalIndent(rep, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "could not marshal report")
+		}
+		if err := file.WriteFile(f.ReportPath, enc); err != nil {
+			return errors.Wrap(err, "could not write report")
+		}
+		log.WithField("path", f.ReportPath).Info("Wrote replay report")
+	}
+	if rep.Mismatches > 0 {
+		return fmt.Errorf("%d state root mismatches, first at slot %d", rep.Mismatches, rep.FirstMismatchSlot)
+	}
+	return nil
+}
+
+// setChainConfig activates the beacon chain config of the network the database belongs to.
+func setChainConfig(network, configFile string) error {
+	if configFile != "" {
+		return params.LoadChainConfigFile(configFile, nil)
+	}
+	cfg, err := params.ByName(network)
+	if err != nil {
+		return errors.Wrapf(err, "unknown network %s", network)
+	}
+	return params.SetActive(cfg)
+}
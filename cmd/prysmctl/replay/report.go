@@ -0,0 +1,213 @@
+package replay
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/consensus-types/interfaces"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/encoding/ssz/diff"
+	"github.com/prysmaticlabs/prysm/io/file"
+	log "github.com/sirupsen/logrus"
+)
+
+// blockReport holds the diagnostics of the replay of a single block. Durations are in nanoseconds
+// once encoded to json.
+type blockReport struct {
+	Slot              types.Slot               `json:"slot"`
+	Root              string                   `json:"root"`
+	SlotsTime         time.Duration            `json:"slots_time"`
+	BlockTime         time.Duration            `json:"block_time"`
+	StateRootTime     time.Duration            `json:"state_root_time"`
+	Phases            map[string]time.Duration `json:"phases"`
+	ExpectedStateRoot string                   `json:"expected_state_root"`
+	ComputedStateRoot string                   `json:"computed_state_root"`
+	Mismatch          bool                     `json:"mismatch"`
+}
+
+// epochReport holds the time spent in the transition at the end of an epoch.
+type epochReport struct {
+	Epoch    types.Epoch   `json:"epoch"`
+	Duration time.Duration `json:"duration"`
+}
+
+// report is the outcome of the replay of a block range.
+type report struct {
+	StartSlot         types.Slot               `json:"start_slot"`
+	EndSlot           types.Slot               `json:"end_slot"`
+	Blocks            []*blockReport           `json:"blocks"`
+	Epochs            []*epochReport           `json:"epochs"`
+	Phases            map[string]time.Duration `json:"phases"`
+	Mismatches        int                      `json:"mismatches"`
+	FirstMismatchSlot types.Slot               `json:"first_mismatch_slot,omitempty"`
+}
+
+// add records the diagnostics of a replayed block, along with the epoch transitions processed before it.
+func (r *report) add(br *blockReport, epochs []*epochReport) {
+	if r.Phases == nil {
+		r.Phases = make(map[string]time.Duration)
+	}
+	r.Blocks = append(r.Blocks, br)
+	r.Epochs = append(r.Epochs, epochs...)
+	for phase, d := range br.Phases {
+		r.Phases[phase] += d
+	}
+	if br.Mismatch {
+		if r.Mismatches == 0 {
+			r.FirstMismatchSlot = br.Slot
+		}
+		r.Mismatches++
+	}
+}
+
+// totals returns the time spent processing empty slots, blocks, state roots and epoch transitions.
+func (r *report) totals() (slotsTime, blockTime, stateRootTime, epochTime time.Duration) {
+	for _, br := range r.Blocks {
+		slotsTime += br.SlotsTime
+		blockTime += br.BlockTime
+		stateRootTime += br.StateRootTime
+	}
+	for _, er := range r.Epochs {
+		epochTime += er.Duration
+	}
+	return
+}
+
+// print writes a human readable summary of the replay.
+func (r *report) print(out io.Writer) {
+	slotsTime, blockTime, stateRootTime, epochTime := r.totals()
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(w, "Replayed %d blocks from slot %d to slot %d\n\n", len(r.Blocks), r.StartSlot, r.EndSlot)
+	fmt.Fprintf(w, "step\ttotal\taverage\n")
+	fmt.Fprintf(w, "slots\t%v\t%v\n", slotsTime, average(slotsTime, len(r.Blocks)))
+	fmt.Fprintf(w, "epoch transitions\t%v\t%v\n", epochTime, average(epochTime, len(r.Epochs)))
+	fmt.Fprintf(w, "blocks\t%v\t%v\n", blockTime, average(blockTime, len(r.Blocks)))
+	phases := make([]string, 0, len(r.Phases))
+	for phase := range r.Phases {
+		phases = append(phases, phase)
+	}
+	sort.Slice(phases, func(i, j int) bool {
+		return r.Phases[phases[i]] > r.Phases[phases[j]]
+	})
+	for _, phase := range phases {
+		fmt.Fprintf(w, "  %s\t%v\t%v\n", phase, r.Phases[phase], average(r.Phases[phase], len(r.Blocks)))
+	}
+	fmt.Fprintf(w, "state roots\t%v\t%v\n", stateRootTime, average(stateRootTime, len(r.Blocks)))
+	if len(r.Epochs) > 0 {
+		fmt.Fprintf(w, "\nepoch\ttransition\n")
+		for _, er := range r.Epochs {
+			fmt.Fprintf(w, "%d\t%v\n", er.Epoch, er.Duration)
+		}
+	}
+	if r.Mismatches > 0 {
+		fmt.Fprintf(w, "\n%d state root mismatches, first at slot %d\n", r.Mismatches, r.FirstMismatchSlot)
+	} else {
+		fmt.Fprintf(w, "\nAll state roots match\n")
+	}
+	if err := w.Flush(); err != nil {
+		log.WithError(err).Error("Could not write replay summary")
+	}
+}
+
+func average(d time.Duration, n int) time.Duration {
+	if n == 0 {
+		return 0
+	}
+	return d / time.Duration(n)
+}
+
+// log writes the diagnostics of the block at debug level, or at error level on a state root mismatch.
+func (br *blockReport) log() {
+	fields := log.Fields{
+		"slot":          br.Slot,
+		"blockRoot":     br.Root,
+		"slotsTime":     br.SlotsTime,
+		"blockTime":     br.BlockTime,
+		"stateRootTime": br.StateRootTime,
+	}
+	for phase, d := range br.Phases {
+		fields[phase] = d
+	}
+	if br.Mismatch {
+		fields["expectedStateRoot"] = br.ExpectedStateRoot
+		fields["computedStateRoot"] = br.ComputedStateRoot
+		log.WithFields(fields).Error("State root mismatch")
+		return
+	}
+	log.WithFields(fields).Debug("Replayed block")
+}
+
+// writeDivergence writes the state before the diverging block and the post-state computed by
+// the replay as SSZ and YAML. If the database holds the post-state of the block, it is written
+// too, along with its structured diff to the computed one as text and JSON.
+func (r *replayer) writeDivergence(ctx context.Context, blk interfaces.SignedBeaconBlock, preState, computed state.BeaconState) error {
+	root, err := blk.Block().HashTreeRoot()
+	if err != nil {
+		return err
+	}
+	if err := file.MkdirAll(r.diffDir); err != nil {
+		return err
+	}
+	prefix := fmt.Sprintf("slot_%d", blk.Block().Slot())
+	if preState != nil {
+		if err := writeState(filepath.Join(r.diffDir, prefix+"_pre_state"), preState); err != nil {
+			return err
+		}
+	}
+	if err := writeState(filepath.Join(r.diffDir, prefix+"_computed_post_state"), computed); err != nil {
+		return err
+	}
+	stored, err := r.db.State(ctx, root)
+	if err != nil {
+		return errors.Wrap(err, "could not get stored post-state")
+	}
+	if stored == nil || stored.IsNil() {
+		log.WithField("dir", r.diffDir).Warn("Post-state of the diverging block is not stored in the database, wrote the computed states only")
+		return nil
+	}
+	if err := writeState(filepath.Join(r.diffDir, prefix+"_stored_post_state"), stored); err != nil {
+		return err
+	}
+	d, err := diff.States(stored, computed)
+	if err != nil {
+		return errors.Wrap(err, "could not diff post-states")
+	}
+	diffPath := filepath.Join(r.diffDir, prefix+"_post_state.diff")
+	if err := file.WriteFile(diffPath, []byte(d.Summary()+"\n"+d.String())); err != nil {
+		return err
+	}
+	enc, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "could not marshal state diff")
+	}
+	if err := file.WriteFile(diffPath+".json", enc); err != nil {
+		return err
+	}
+	log.WithField("path", diffPath).Info("Wrote state diff at first divergence")
+	return nil
+}
+
+// writeState writes the state to the path with .ssz and .yaml extensions.
+func writeState(path string, st state.BeaconState) error {
+	enc, err := st.MarshalSSZ()
+	if err != nil {
+		return errors.Wrap(err, "could not marshal state to ssz")
+	}
+	if err := file.WriteFile(path+".ssz", enc); err != nil {
+		return err
+	}
+	enc, err = yaml.Marshal(st.InnerStateUnsafe())
+	if err != nil {
+		return errors.Wrap(err, "could not marshal state to yaml")
+	}
+	return file.WriteFile(path+".yaml", enc)
+}
@@ -0,0 +1,54 @@
+package replay
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestReport_Add(t *testing.T) {
+	r := &report{}
+	r.add(&blockReport{
+		Slot:      1,
+		SlotsTime: time.Millisecond,
+		BlockTime: 3 * time.Millisecond,
+		Phases:    map[string]time.Duration{phaseAttestations: 2 * time.Millisecond, phaseRandao: time.Millisecond},
+	}, nil)
+	r.add(&blockReport{
+		Slot:      33,
+		BlockTime: 2 * time.Millisecond,
+		Phases:    map[string]time.Duration{phaseAttestations: 2 * time.Millisecond},
+		Mismatch:  true,
+	}, []*epochReport{{Epoch: 0, Duration: 10 * time.Millisecond}})
+	r.add(&blockReport{Slot: 34, Phases: map[string]time.Duration{}, Mismatch: true}, nil)
+
+	require.Equal(t, 3, len(r.Blocks))
+	require.Equal(t, 1, len(r.Epochs))
+	assert.Equal(t, 4*time.Millisecond, r.Phases[phaseAttestations])
+	assert.Equal(t, time.Millisecond, r.Phases[phaseRandao])
+	assert.Equal(t, 2, r.Mismatches)
+	assert.Equal(t, r.Blocks[1].Slot, r.FirstMismatchSlot)
+
+	slotsTime, blockTime, stateRootTime, epochTime := r.totals()
+	assert.Equal(t, time.Millisecond, slotsTime)
+	assert.Equal(t, 5*time.Millisecond, blockTime)
+	assert.Equal(t, time.Duration(0), stateRootTime)
+	assert.Equal(t, 10*time.Millisecond, epochTime)
+}
+
+func TestReport_Print(t *testing.T) {
+	r := &report{StartSlot: 1, EndSlot: 2}
+	r.add(&blockReport{Slot: 1, BlockTime: time.Millisecond, Phases: map[string]time.Duration{phaseDeposits: time.Millisecond}}, nil)
+	r.add(&blockReport{Slot: 2, Phases: map[string]time.Duration{}, Mismatch: true}, nil)
+
+	var buf bytes.Buffer
+	r.print(&buf)
+	out := buf.String()
+	assert.Equal(t, true, strings.Contains(out, "Replayed 2 blocks from slot 1 to slot 2"))
+	assert.Equal(t, true, strings.Contains(out, phaseDeposits))
+	assert.Equal(t, true, strings.Contains(out, "1 state root mismatches, first at slot 2"))
+}
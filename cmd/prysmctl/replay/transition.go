@@ -0,0 +1,297 @@
+package replay
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/altair"
+	b "github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	coreTime "github.com/prysmaticlabs/prysm/beacon-chain/core/time"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/transition"
+	v "github.com/prysmaticlabs/prysm/beacon-chain/core/validators"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/crypto/bls"
+	"github.com/prysmaticlabs/prysm/runtime/version"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	log "github.com/sirupsen/logrus"
+)
+
+// Names of the steps of block processing that are timed separately.
+const (
+	phaseBlockHeader       = "block_header"
+	phaseExecutionPayload  = "execution_payload"
+	phaseRandao            = "randao"
+	phaseEth1Data          = "eth1_data"
+	phaseProposerSlashings = "proposer_slashings"
+	phaseAttesterSlashings = "attester_slashings"
+	phaseAttestations      = "attestations"
+	phaseDeposits          = "deposits"
+	phaseVoluntaryExits    = "voluntary_exits"
+	phaseSyncAggregate     = "sync_aggregate"
+	phaseSignatures        = "signatures"
+)
+
+// replayer applies blocks to a state one at a time, mirroring transition.ExecuteStateTransition
+// step by step so that each step can be timed.
+type replayer struct {
+	db               db.ReadOnlyDatabase
+	verifySignatures bool
+	diffDir          string
+}
+
+// replay applies the blocks, which must form a chain in ascending slot order, on top of the
+// given pre-state. It keeps going after a state root mismatch, on top of the computed state.
+func (r *replayer) replay(ctx context.Context, st state.BeaconState, blks []interfaces.SignedBeaconBlock) (*report, error) {
+	// The skip slot cache would hide the cost of epoch transitions.
+	transition.SkipSlotCache.Disable()
+	defer transition.SkipSlotCache.Enable()
+
+	rep := &report{
+		StartSlot: blks[0].Block().Slot(),
+		EndSlot:   blks[len(blks)-1].Block().Slot(),
+	}
+	for _, blk := range blks {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		var preState state.BeaconState
+		if r.diffDir != "" && rep.Mismatches == 0 {
+			preState = st.Copy()
+		}
+		br, epochs, post, err := r.applyBlock(ctx, st, blk)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not replay block at slot %d", blk.Block().Slot())
+		}
+		st = post
+		rep.add(br, epochs)
+		br.log()
+		if br.Mismatch && rep.Mismatches == 1 && r.diffDir != "" {
+			if err := r.writeDivergence(ctx, blk, preState, st); err != nil {
+				log.WithError(err).Error("Could not write state diff at first divergence")
+			}
+		}
+	}
+	return rep, nil
+}
+
+// applyBlock processes the slots up to the block and the block itself, timing every step,
+// and compares the resulting state root to the one committed to by the block.
+func (r *replayer) applyBlock(ctx context.Context, st state.BeaconState, signed interfaces.SignedBeaconBlock) (*blockReport, []*epochReport, state.BeaconState, error) {
+	blk := signed.Block()
+	root, err := blk.HashTreeRoot()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	br := &blockReport{
+		Slot:   blk.Slot(),
+		Root:   fmt.Sprintf("%#x", root),
+		Phases: make(map[string]time.Duration),
+	}
+
+	var epochs []*epochReport
+	for st.Slot() < blk.Slot() {
+		epochBoundary := coreTime.CanProcessEpoch(st)
+		epoch := slots.ToEpoch(st.Slot())
+		start := time.Now()
+		st, err = transition.ProcessSlots(ctx, st, st.Slot()+1)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if epochBoundary {
+			epochs = append(epochs, &epochReport{Epoch: epoch, Duration: time.Since(start)})
+		} else {
+			br.SlotsTime += time.Since(start)
+		}
+	}
+
+	if st.Version() != blk.Version() {
+		return nil, nil, nil, fmt.Errorf("state and block are different version. %d != %d", st.Version(), blk.Version())
+	}
+	start := time.Now()
+	st, err = r.processBlock(ctx, st, signed, br)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	br.BlockTime = time.Since(start)
+
+	start = time.Now()
+	stateRoot, err := st.HashTreeRoot(ctx)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "could not compute state root")
+	}
+	br.StateRootTime = time.Since(start)
+	br.ComputedStateRoot = fmt.Sprintf("%#x", stateRoot)
+	br.ExpectedStateRoot = fmt.Sprintf("%#x", blk.StateRoot())
+	br.Mismatch = br.ComputedStateRoot != br.ExpectedStateRoot
+	return br, epochs, st, nil
+}
+
+// processBlock is transition.ProcessBlockForStateRoot with every step timed, followed by the
+// verification of the block signatures if enabled.
+func (r *replayer) processBlock(ctx context.Context, st state.BeaconState, signed interfaces.SignedBeaconBlock, br *blockReport) (state.BeaconState, error) {
+	blk := signed.Block()
+	body := blk.Body()
+	var err error
+	timed := func(phase string, fn func() error) error {
+		start := time.Now()
+		err := fn()
+		br.Phases[phase] += time.Since(start)
+		return err
+	}
+
+	if err := timed(phaseBlockHeader, func() error {
+		bodyRoot, err := body.HashTreeRoot()
+		if err != nil {
+			return errors.Wrap(err, "could not hash tree root beacon block body")
+		}
+		st, err = b.ProcessBlockHeaderNoVerify(ctx, st, blk.Slot(), blk.ProposerIndex(), blk.ParentRoot(), bodyRoot[:])
+		return errors.Wrap(err, "could not process block header")
+	}); err != nil {
+		return nil, err
+	}
+
+	enabled, err := b.IsExecutionEnabled(st, body)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not check if execution is enabled")
+	}
+	if enabled {
+		if err := timed(phaseExecutionPayload, func() error {
+			if blk.IsBlinded() {
+				header, err := body.ExecutionPayloadHeader()
+				if err != nil {
+					return err
+				}
+				st, err = b.ProcessPayloadHeader(st, header)
+				return errors.Wrap(err, "could not process execution payload header")
+			}
+			payload, err := body.ExecutionPayload()
+			if err != nil {
+				return err
+			}
+			st, err = b.ProcessPayload(st, payload)
+			return errors.Wrap(err, "could not process execution payload")
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := timed(phaseRandao, func() error {
+		st, err = b.ProcessRandaoNoVerify(st, body.RandaoReveal())
+		return errors.Wrap(err, "could not process randao")
+	}); err != nil {
+		return nil, err
+	}
+	if err := timed(phaseEth1Data, func() error {
+		st, err = b.ProcessEth1DataInBlock(ctx, st, body.Eth1Data())
+		return errors.Wrap(err, "could not process eth1 data")
+	}); err != nil {
+		return nil, err
+	}
+	if st, err = r.processOperations(ctx, st, signed, timed); err != nil {
+		return nil, err
+	}
+	if blk.Version() != version.Phase0 {
+		if err := timed(phaseSyncAggregate, func() error {
+			sa, err := body.SyncAggregate()
+			if err != nil {
+				return errors.Wrap(err, "could not get sync aggregate from block")
+			}
+			st, err = altair.ProcessSyncAggregate(ctx, st, sa)
+			return errors.Wrap(err, "could not process sync aggregate")
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if !r.verifySignatures {
+		return st, nil
+	}
+	if err := timed(phaseSignatures, func() error {
+		return verifyBlockSignatures(ctx, st, signed)
+	}); err != nil {
+		return nil, err
+	}
+	return st, nil
+}
+
+// processOperations processes the operations of the block, timing each kind of operation separately.
+func (r *replayer) processOperations(
+	ctx context.Context,
+	st state.BeaconState,
+	signed interfaces.SignedBeaconBlock,
+	timed func(string, func() error) error,
+) (state.BeaconState, error) {
+	body := signed.Block().Body()
+	if _, err := transition.VerifyOperationLengths(ctx, st, signed); err != nil {
+		return nil, errors.Wrap(err, "could not verify operation lengths")
+	}
+	var err error
+	if err := timed(phaseProposerSlashings, func() error {
+		st, err = b.ProcessProposerSlashings(ctx, st, body.ProposerSlashings(), v.SlashValidator)
+		return errors.Wrap(err, "could not process proposer slashings")
+	}); err != nil {
+		return nil, err
+	}
+	if err := timed(phaseAttesterSlashings, func() error {
+		st, err = b.ProcessAttesterSlashings(ctx, st, body.AttesterSlashings(), v.SlashValidator)
+		return errors.Wrap(err, "could not process attester slashings")
+	}); err != nil {
+		return nil, err
+	}
+	if err := timed(phaseAttestations, func() error {
+		if signed.Version() == version.Phase0 {
+			st, err = b.ProcessAttestationsNoVerifySignature(ctx, st, signed)
+		} else {
+			st, err = altair.ProcessAttestationsNoVerifySignature(ctx, st, signed)
+		}
+		return errors.Wrap(err, "could not process attestations")
+	}); err != nil {
+		return nil, err
+	}
+	if err := timed(phaseDeposits, func() error {
+		if signed.Version() == version.Phase0 {
+			st, err = b.ProcessDeposits(ctx, st, body.Deposits())
+		} else {
+			st, err = altair.ProcessDeposits(ctx, st, body.Deposits())
+		}
+		return errors.Wrap(err, "could not process deposits")
+	}); err != nil {
+		return nil, err
+	}
+	if err := timed(phaseVoluntaryExits, func() error {
+		st, err = b.ProcessVoluntaryExits(ctx, st, body.VoluntaryExits())
+		return errors.Wrap(err, "could not process voluntary exits")
+	}); err != nil {
+		return nil, err
+	}
+	return st, nil
+}
+
+// verifyBlockSignatures batch verifies the proposer, randao and attestation signatures of the block.
+func verifyBlockSignatures(ctx context.Context, st state.BeaconState, signed interfaces.SignedBeaconBlock) error {
+	blk := signed.Block()
+	bSet, err := b.BlockSignatureBatch(st, blk.ProposerIndex(), signed.Signature(), blk.HashTreeRoot)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve block signature set")
+	}
+	rSet, err := b.RandaoSignatureBatch(ctx, st, blk.Body().RandaoReveal())
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve randao signature set")
+	}
+	aSet, err := b.AttestationSignatureBatch(ctx, st, blk.Body().Attestations())
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve attestation signature set")
+	}
+	valid, err := bls.NewSet().Join(bSet).Join(rSet).Join(aSet).Verify()
+	if err != nil {
+		return errors.Wrap(err, "could not batch verify signatures")
+	}
+	if !valid {
+		return errors.New("block signatures are invalid")
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package replay
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/transition"
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/consensus-types/interfaces"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/consensus-types/wrapper"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/io/file"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+func TestReplayer_Replay(t *testing.T) {
+	ctx := context.Background()
+	genesis, pks := util.DeterministicGenesisState(t, 64)
+
+	st := genesis.Copy()
+	var blks []*ethpb.SignedBeaconBlock
+	for _, slot := range []types.Slot{1, params.BeaconConfig().SlotsPerEpoch + 1} {
+		blk, err := util.GenerateFullBlock(st, pks, util.DefaultBlockGenConfig(), slot)
+		require.NoError(t, err)
+		wsb, err := wrapper.WrappedSignedBeaconBlock(blk)
+		require.NoError(t, err)
+		st, err = transition.ExecuteStateTransition(ctx, st, wsb)
+		require.NoError(t, err)
+		blks = append(blks, blk)
+	}
+
+	r := &replayer{db: testDB.SetupDB(t), verifySignatures: true}
+	rep, err := r.replay(ctx, genesis.Copy(), wrapBlocks(t, blks))
+	require.NoError(t, err)
+	assert.Equal(t, types.Slot(1), rep.StartSlot)
+	assert.Equal(t, params.BeaconConfig().SlotsPerEpoch+1, rep.EndSlot)
+	require.Equal(t, 2, len(rep.Blocks))
+	require.Equal(t, 1, len(rep.Epochs))
+	assert.Equal(t, types.Epoch(0), rep.Epochs[0].Epoch)
+	assert.Equal(t, 0, rep.Mismatches)
+	for _, br := range rep.Blocks {
+		assert.Equal(t, false, br.Mismatch)
+		assert.Equal(t, br.ExpectedStateRoot, br.ComputedStateRoot)
+		for _, phase := range []string{phaseBlockHeader, phaseRandao, phaseEth1Data, phaseAttestations, phaseSignatures} {
+			_, ok := br.Phases[phase]
+			assert.Equal(t, true, ok, "missing phase %s", phase)
+		}
+	}
+}
+
+func TestReplayer_Replay_Mismatch(t *testing.T) {
+	ctx := context.Background()
+	genesis, pks := util.DeterministicGenesisState(t, 64)
+
+	st := genesis.Copy()
+	var blks []*ethpb.SignedBeaconBlock
+	for _, slot := range []types.Slot{1, 2, 3} {
+		blk, err := util.GenerateFullBlock(st, pks, util.DefaultBlockGenConfig(), slot)
+		require.NoError(t, err)
+		wsb, err := wrapper.WrappedSignedBeaconBlock(blk)
+		require.NoError(t, err)
+		st, err = transition.ExecuteStateTransition(ctx, st, wsb)
+		require.NoError(t, err)
+		blks = append(blks, blk)
+	}
+	blks[1].Block.StateRoot = bytesutil.PadTo([]byte{'a'}, 32)
+
+	dir := t.TempDir()
+	r := &replayer{db: testDB.SetupDB(t), diffDir: dir}
+	rep, err := r.replay(ctx, genesis.Copy(), wrapBlocks(t, blks))
+	require.NoError(t, err)
+	assert.Equal(t, 1, rep.Mismatches)
+	assert.Equal(t, types.Slot(2), rep.FirstMismatchSlot)
+	assert.Equal(t, true, rep.Blocks[1].Mismatch)
+	assert.Equal(t, false, rep.Blocks[2].Mismatch)
+	assert.Equal(t, true, file.FileExists(filepath.Join(dir, "slot_2_pre_state.ssz")))
+	assert.Equal(t, true, file.FileExists(filepath.Join(dir, "slot_2_computed_post_state.ssz")))
+	assert.Equal(t, true, file.FileExists(filepath.Join(dir, "slot_2_computed_post_state.yaml")))
+}
+
+func wrapBlocks(t *testing.T, blks []*ethpb.SignedBeaconBlock) []interfaces.SignedBeaconBlock {
+	wrapped := make([]interfaces.SignedBeaconBlock, len(blks))
+	for i, blk := range blks {
+		wsb, err := wrapper.WrappedSignedBeaconBlock(blk)
+		require.NoError(t, err)
+		wrapped[i] = wsb
+	}
+	return wrapped
+}
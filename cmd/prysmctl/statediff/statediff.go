@@ -0,0 +1,137 @@
+package statediff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/encoding/ssz/detect"
+	"github.com/prysmaticlabs/prysm/encoding/ssz/diff"
+	"github.com/prysmaticlabs/prysm/io/file"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/runtime/version"
+	"github.com/urfave/cli/v2"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	formatText = "text"
+	formatJSON = "json"
+)
+
+var diffFlags = struct {
+	StateA      string
+	StateB      string
+	Fork        string
+	Format      string
+	SummaryOnly bool
+}{}
+
+var Commands = []*cli.Command{
+	{
+		Name:   "state-diff",
+		Usage:  "Compare two ssz encoded beacon states, of any fork, field by field, with per-index changes of list fields.",
+		Action: cliActionDiff,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "state-a",
+				Usage:       "path to the first ssz encoded beacon state",
+				Destination: &diffFlags.StateA,
+				Required:    true,
+			},
+			&cli.StringFlag{
+				Name:        "state-b",
+				Usage:       "path to the second ssz encoded beacon state",
+				Destination: &diffFlags.StateB,
+				Required:    true,
+			},
+			&cli.StringFlag{
+				Name:        "fork",
+				Usage:       "fork of both states: phase0, altair or bellatrix. Detected from the fork version of each state by default, which only works for mainnet states",
+				Destination: &diffFlags.Fork,
+			},
+			&cli.StringFlag{
+				Name:        "format",
+				Usage:       "output format: text or json",
+				Destination: &diffFlags.Format,
+				Value:       formatText,
+			},
+			&cli.BoolFlag{
+				Name:        "summary",
+				Usage:       "only print the number of changes under each field",
+				Destination: &diffFlags.SummaryOnly,
+			},
+		},
+	},
+}
+
+func cliActionDiff(_ *cli.Context) error {
+	f := diffFlags
+	if f.Format != formatText && f.Format != formatJSON {
+		return fmt.Errorf("unknown output format %s, expected %s or %s", f.Format, formatText, formatJSON)
+	}
+	a, err := loadState(f.StateA, f.Fork)
+	if err != nil {
+		return err
+	}
+	b, err := loadState(f.StateB, f.Fork)
+	if err != nil {
+		return err
+	}
+	return writeDiff(os.Stdout, diff.Messages(a, b), f.Format, f.SummaryOnly)
+}
+
+// writeDiff writes the diff, or only the per field change counts, in the requested format.
+func writeDiff(out io.Writer, d *diff.Diff, format string, summaryOnly bool) error {
+	if format == formatJSON {
+		var v interface{} = d
+		if summaryOnly {
+			v = d.CountByField()
+		}
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+	if summaryOnly {
+		_, err := fmt.Fprint(out, d.Summary())
+		return err
+	}
+	_, err := fmt.Fprint(out, d.String())
+	return err
+}
+
+// loadState reads an ssz encoded beacon state of the given fork, or of the fork detected from its
+// fork version if empty.
+func loadState(path, fork string) (proto.Message, error) {
+	enc, err := file.ReadFileAsBytes(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read state %s", path)
+	}
+	if fork == "" {
+		u, err := detect.FromState(enc)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not detect fork of state %s, use --fork", path)
+		}
+		fork = version.String(u.Fork)
+	}
+	var st interface {
+		proto.Message
+		UnmarshalSSZ([]byte) error
+	}
+	switch fork {
+	case version.String(version.Phase0):
+		st = &ethpb.BeaconState{}
+	case version.String(version.Altair):
+		st = &ethpb.BeaconStateAltair{}
+	case version.String(version.Bellatrix):
+		st = &ethpb.BeaconStateBellatrix{}
+	default:
+		return nil, fmt.Errorf("unsupported fork %s", fork)
+	}
+	if err := st.UnmarshalSSZ(enc); err != nil {
+		return nil, errors.Wrapf(err, "could not unmarshal %s state %s", fork, path)
+	}
+	return st, nil
+}
@@ -0,0 +1,61 @@
+package statediff
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/encoding/ssz/diff"
+	"github.com/prysmaticlabs/prysm/io/file"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+)
+
+func TestLoadState(t *testing.T) {
+	st, err := util.NewBeaconStateAltair()
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(5))
+	enc, err := st.MarshalSSZ()
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "state.ssz")
+	require.NoError(t, file.WriteFile(path, enc))
+
+	loaded, err := loadState(path, "altair")
+	require.NoError(t, err)
+	altair, ok := loaded.(*ethpb.BeaconStateAltair)
+	require.Equal(t, true, ok)
+	assert.Equal(t, st.Slot(), altair.Slot)
+
+	_, err = loadState(path, "capella")
+	require.ErrorContains(t, "unsupported fork capella", err)
+}
+
+func TestWriteDiff(t *testing.T) {
+	d := diff.Messages(
+		&ethpb.BeaconState{Slot: 1, Balances: []uint64{1, 2}},
+		&ethpb.BeaconState{Slot: 2, Balances: []uint64{1, 3}},
+	)
+
+	var buf bytes.Buffer
+	require.NoError(t, writeDiff(&buf, d, formatText, false))
+	assert.Equal(t, "~ slot: 1 -> 2\n~ balances[1]: 2 -> 3\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, writeDiff(&buf, d, formatText, true))
+	assert.Equal(t, "2 changes\n  balances: 1\n  slot: 1\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, writeDiff(&buf, d, formatJSON, false))
+	decoded := &diff.Diff{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), decoded))
+	require.DeepEqual(t, d, decoded)
+
+	buf.Reset()
+	require.NoError(t, writeDiff(&buf, d, formatJSON, true))
+	counts := make(map[string]int)
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &counts))
+	assert.Equal(t, 1, counts["balances"])
+}
@@ -0,0 +1,50 @@
+// Package flags defines the runtime flags of the standalone slasher, such as the ports
+// it listens on and the beacon node it reads the chain information from.
+package flags
+
+import (
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	// BeaconRPCProviderFlag defines the beacon node the slasher reads the genesis time and the size of
+	// the validator registry from.
+	BeaconRPCProviderFlag = &cli.StringFlag{
+		Name:  "beacon-rpc-provider",
+		Usage: "Beacon node RPC provider endpoint, used to read the chain genesis and validator registry size",
+		Value: "127.0.0.1:4000",
+	}
+	// BeaconCertFlag defines a flag for the certificate of the beacon node RPC provider.
+	BeaconCertFlag = &cli.StringFlag{
+		Name:  "beacon-tls-cert",
+		Usage: "Certificate for secure gRPC connection to the beacon node. Pass this in order to use TLS",
+	}
+	// RPCHost defines the host on which the RPC server should listen.
+	RPCHost = &cli.StringFlag{
+		Name:  "rpc-host",
+		Usage: "Host on which the RPC server should listen",
+		Value: "127.0.0.1",
+	}
+	// RPCPort defines a slasher RPC port to open, on which beacon nodes stream their data.
+	RPCPort = &cli.IntFlag{
+		Name:  "rpc-port",
+		Usage: "RPC port exposed by the slasher, beacon nodes running with --remote-slasher connect to it",
+		Value: 4002,
+	}
+	// CertFlag defines a flag for the slasher's TLS certificate.
+	CertFlag = &cli.StringFlag{
+		Name:  "tls-cert",
+		Usage: "Certificate for secure gRPC. Pass this and the tls-key flag in order to use gRPC securely.",
+	}
+	// KeyFlag defines a flag for the slasher's TLS key.
+	KeyFlag = &cli.StringFlag{
+		Name:  "tls-key",
+		Usage: "Key for secure gRPC. Pass this and the tls-cert flag in order to use gRPC securely.",
+	}
+	// MonitoringPortFlag defines the http port used to serve prometheus metrics.
+	MonitoringPortFlag = &cli.IntFlag{
+		Name:  "monitoring-port",
+		Usage: "Port used to listening and respond metrics for prometheus.",
+		Value: 8082,
+	}
+)
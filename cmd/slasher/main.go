@@ -0,0 +1,148 @@
+// Package slasher defines the runtime of a standalone slasher, detecting slashable offenses
+// in the attestations and blocks streamed by a fleet of beacon nodes.
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	runtimeDebug "runtime/debug"
+
+	joonix "github.com/joonix/log"
+	"github.com/prysmaticlabs/prysm/beacon-chain/slasher/node"
+	"github.com/prysmaticlabs/prysm/cmd"
+	"github.com/prysmaticlabs/prysm/cmd/slasher/flags"
+	"github.com/prysmaticlabs/prysm/config/features"
+	"github.com/prysmaticlabs/prysm/io/file"
+	"github.com/prysmaticlabs/prysm/io/logs"
+	"github.com/prysmaticlabs/prysm/monitoring/journald"
+	"github.com/prysmaticlabs/prysm/runtime/debug"
+	_ "github.com/prysmaticlabs/prysm/runtime/maxprocs"
+	"github.com/prysmaticlabs/prysm/runtime/version"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+	prefixed "github.com/x-cray/logrus-prefixed-formatter"
+)
+
+var appFlags = []cli.Flag{
+	flags.BeaconRPCProviderFlag,
+	flags.BeaconCertFlag,
+	flags.RPCHost,
+	flags.RPCPort,
+	flags.CertFlag,
+	flags.KeyFlag,
+	cmd.DataDirFlag,
+	cmd.VerbosityFlag,
+	cmd.MonitoringHostFlag,
+	flags.MonitoringPortFlag,
+	cmd.DisableMonitoringFlag,
+	cmd.ClearDB,
+	cmd.ForceClearDB,
+	cmd.LogFormat,
+	debug.PProfFlag,
+	debug.PProfAddrFlag,
+	debug.PProfPortFlag,
+	debug.MemProfileRateFlag,
+	debug.CPUProfileFlag,
+	debug.TraceFlag,
+	debug.BlockProfileRateFlag,
+	debug.MutexProfileFractionFlag,
+	cmd.LogFileName,
+	cmd.ConfigFileFlag,
+	cmd.ChainConfigFileFlag,
+	cmd.GrpcMaxCallRecvMsgSizeFlag,
+	cmd.BoltMMapInitialSizeFlag,
+}
+
+func init() {
+	appFlags = cmd.WrapFlags(append(appFlags, features.SlasherFlags...))
+}
+
+func main() {
+	app := cli.App{}
+	app.Name = "slasher"
+	app.Usage = "this is a standalone slasher detecting slashable offenses for a fleet of Ethereum beacon nodes"
+	app.Action = startNode
+	app.Version = version.Version()
+	app.Flags = appFlags
+
+	app.Before = func(ctx *cli.Context) error {
+		// Load flags from config file, if specified.
+		if err := cmd.LoadFlagsFromConfig(ctx, app.Flags); err != nil {
+			return err
+		}
+
+		format := ctx.String(cmd.LogFormat.Name)
+		switch format {
+		case "text":
+			formatter := new(prefixed.TextFormatter)
+			formatter.TimestampFormat = "2006-01-02 15:04:05"
+			formatter.FullTimestamp = true
+			// If persistent log files are written - we disable the log messages coloring because
+			// the colors are ANSI codes and seen as gibberish in the log files.
+			formatter.DisableColors = ctx.String(cmd.LogFileName.Name) != ""
+			logrus.SetFormatter(formatter)
+		case "fluentd":
+			f := joonix.NewFormatter()
+			if err := joonix.DisableTimestampFormat(f); err != nil {
+				panic(err)
+			}
+			logrus.SetFormatter(f)
+		case "json":
+			logrus.SetFormatter(&logrus.JSONFormatter{})
+		case "journald":
+			if err := journald.Enable(); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unknown log format %s", format)
+		}
+
+		logFileName := ctx.String(cmd.LogFileName.Name)
+		if logFileName != "" {
+			if err := logs.ConfigurePersistentLogging(logFileName); err != nil {
+				log.WithError(err).Error("Failed to configuring logging to disk.")
+			}
+		}
+		runtime.GOMAXPROCS(runtime.NumCPU())
+		if err := debug.Setup(ctx); err != nil {
+			return err
+		}
+		return cmd.ValidateNoArgs(ctx)
+	}
+
+	defer func() {
+		if x := recover(); x != nil {
+			log.Errorf("Runtime panic: %v\n%v", x, string(runtimeDebug.Stack()))
+			panic(x)
+		}
+	}()
+
+	if err := app.Run(os.Args); err != nil {
+		log.Error(err.Error())
+	}
+}
+
+func startNode(ctx *cli.Context) error {
+	// Fix data dir for Windows users.
+	outdatedDataDir := filepath.Join(file.HomeDir(), "AppData", "Roaming", "Eth2")
+	currentDataDir := ctx.String(cmd.DataDirFlag.Name)
+	if err := cmd.FixDefaultDataDir(outdatedDataDir, currentDataDir); err != nil {
+		return err
+	}
+
+	verbosity := ctx.String(cmd.VerbosityFlag.Name)
+	level, err := logrus.ParseLevel(verbosity)
+	if err != nil {
+		return err
+	}
+	logrus.SetLevel(level)
+
+	slasherNode, err := node.New(ctx)
+	if err != nil {
+		return err
+	}
+	slasherNode.Start()
+	return nil
+}
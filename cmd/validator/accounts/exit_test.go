@@ -13,6 +13,7 @@ import (
 	"github.com/prysmaticlabs/prysm/testing/assert"
 	mock2 "github.com/prysmaticlabs/prysm/testing/mock"
 	"github.com/prysmaticlabs/prysm/testing/require"
+	validatormock "github.com/prysmaticlabs/prysm/testing/validator-mock"
 	"github.com/prysmaticlabs/prysm/validator/accounts"
 	"github.com/prysmaticlabs/prysm/validator/accounts/wallet"
 	"github.com/prysmaticlabs/prysm/validator/keymanager"
@@ -22,7 +23,7 @@ import (
 func TestExitAccountsCli_OK(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	mockValidatorClient := mock2.NewMockBeaconNodeValidatorClient(ctrl)
+	mockValidatorClient := validatormock.NewMockValidatorClient(ctrl)
 	mockNodeClient := mock2.NewMockNodeClient(ctrl)
 
 	mockValidatorClient.EXPECT().
@@ -113,7 +114,7 @@ func TestExitAccountsCli_OK(t *testing.T) {
 func TestExitAccountsCli_OK_AllPublicKeys(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	mockValidatorClient := mock2.NewMockBeaconNodeValidatorClient(ctrl)
+	mockValidatorClient := validatormock.NewMockValidatorClient(ctrl)
 	mockNodeClient := mock2.NewMockNodeClient(ctrl)
 
 	mockValidatorClient.EXPECT().
@@ -2,6 +2,7 @@ package db
 
 import (
 	"github.com/prysmaticlabs/prysm/cmd"
+	"github.com/prysmaticlabs/prysm/cmd/validator/flags"
 	"github.com/prysmaticlabs/prysm/runtime/tos"
 	validatordb "github.com/prysmaticlabs/prysm/validator/db"
 	"github.com/sirupsen/logrus"
@@ -31,6 +32,23 @@ var Commands = &cli.Command{
 				return nil
 			},
 		},
+		{
+			Name:        "export-performance",
+			Description: `exports the performance records of the validating keys as a CSV file`,
+			Flags: cmd.WrapFlags([]cli.Flag{
+				cmd.DataDirFlag,
+				flags.PerformanceCSVFileFlag,
+				flags.PerformanceStartEpochFlag,
+				flags.PerformanceEndEpochFlag,
+			}),
+			Before: tos.VerifyTosAcceptedOrPrompt,
+			Action: func(cliCtx *cli.Context) error {
+				if err := validatordb.ExportPerformanceCSV(cliCtx); err != nil {
+					log.Fatalf("Could not export performance records: %v", err)
+				}
+				return nil
+			},
+		},
 		{
 			Name:     "migrate",
 			Category: "db",
@@ -29,8 +29,9 @@ var (
 	}
 	// BeaconRPCProviderFlag defines a beacon node RPC endpoint.
 	BeaconRPCProviderFlag = &cli.StringFlag{
-		Name:  "beacon-rpc-provider",
-		Usage: "Beacon node RPC provider endpoint",
+		Name: "beacon-rpc-provider",
+		Usage: "Beacon node RPC provider endpoint. Several comma-separated endpoints can be given, " +
+			"in which case duties are routed to the healthiest of them",
 		Value: "127.0.0.1:4000",
 	}
 	// BeaconRPCGatewayProviderFlag defines a beacon node JSON-RPC endpoint.
@@ -39,6 +40,19 @@ var (
 		Usage: "Beacon node RPC gateway provider endpoint",
 		Value: "127.0.0.1:3500",
 	}
+	// BeaconRESTApiProviderFlag defines a beacon node REST API endpoint.
+	BeaconRESTApiProviderFlag = &cli.StringFlag{
+		Name: "beacon-rest-api-provider",
+		Usage: "Beacon node REST API provider endpoint, used with --enable-beacon-rest-api. Several comma-separated " +
+			"endpoints can be given, in which case duties are routed to the healthiest of them",
+		Value: "http://127.0.0.1:3500",
+	}
+	// BeaconRESTApiTimeout defines the timeout of requests to the beacon node REST API.
+	BeaconRESTApiTimeout = &cli.DurationFlag{
+		Name:  "beacon-rest-api-timeout",
+		Usage: "Timeout of requests to the beacon node REST API, used with --enable-beacon-rest-api",
+		Value: 30 * time.Second,
+	}
 	// CertFlag defines a flag for the node's TLS certificate.
 	CertFlag = &cli.StringFlag{
 		Name:  "tls-cert",
@@ -230,6 +244,13 @@ var (
 		Name:  "slashing-protection-json-file",
 		Usage: "Path to an EIP-3076 compliant JSON file containing a user's slashing protection history",
 	}
+	// SlashingProtectionJSONFilesFlag is used to enter the file paths of several slashing protection JSON
+	// files to merge on import.
+	SlashingProtectionJSONFilesFlag = &cli.StringSliceFlag{
+		Name: "slashing-protection-json-files",
+		Usage: "Paths to several EIP-3076 compliant JSON files of a same chain which are merged on import, " +
+			"keeping the most conservative data when their records conflict",
+	}
 	// KeysDirFlag defines the path for a directory where keystores to be imported at stored.
 	KeysDirFlag = &cli.StringFlag{
 		Name:  "keys-dir",
@@ -287,6 +308,15 @@ var (
 		Value: "",
 	}
 
+	// Web3SignerPublicKeysRefreshIntervalFlag defines how often the web3signer public keys are fetched again from the url.
+	// example: --validators-external-signer-public-keys-refresh-interval=1m
+	Web3SignerPublicKeysRefreshIntervalFlag = &cli.DurationFlag{
+		Name: "validators-external-signer-public-keys-refresh-interval",
+		Usage: "How often to fetch the public keys again from the external url, picking up keys added to or removed from " +
+			"web3signer. Defaults to web3signer's own public keys endpoint if no public keys are provided. Disabled if 0",
+		Value: 0,
+	}
+
 	// KeymanagerKindFlag defines the kind of keymanager desired by a user during wallet creation.
 	KeymanagerKindFlag = &cli.StringFlag{
 		Name:  "keymanager-kind",
@@ -312,6 +342,19 @@ var (
 		Usage: "Allows users to specify the output directory to export their slashing protection EIP-3076 standard JSON File",
 		Value: "",
 	}
+	// SlashingProtectionExportPublicKeysFlag defines a comma-separated list of hex string public keys
+	// for which to export the slashing protection history.
+	SlashingProtectionExportPublicKeysFlag = &cli.StringFlag{
+		Name:  "slashing-protection-export-public-keys",
+		Usage: "Comma-separated list of public key hex strings to only export the slashing protection history of these keys",
+		Value: "",
+	}
+	// SlashingProtectionExportMinimalFlag exports the slashing protection history in its minimal form.
+	SlashingProtectionExportMinimalFlag = &cli.BoolFlag{
+		Name: "slashing-protection-export-minimal",
+		Usage: "Only exports the highest signed block slot and attestation source and target epochs of each key, " +
+			"which is as safe to import as the full history while being much smaller",
+	}
 	// GraffitiFileFlag specifies the file path to load graffiti values.
 	GraffitiFileFlag = &cli.StringFlag{
 		Name:  "graffiti-file",
@@ -349,6 +392,14 @@ var (
 		Usage: "Set URL to a REST endpoint containing validator settings used when proposing blocks such as (fee recipient) (i.e. --proposer-settings-url=https://example.com/api/getConfig). File format found in docs",
 		Value: "",
 	}
+	// ProposerSettingsRefreshIntervalFlag defines how often the proposer settings file or URL is read again.
+	ProposerSettingsRefreshIntervalFlag = &cli.DurationFlag{
+		Name: "proposer-settings-refresh-interval",
+		Usage: "How often to read the proposer settings file or URL again, pushing changed fee recipients and gas limits " +
+			"to the beacon node without a restart (i.e. --proposer-settings-refresh-interval=5m). " +
+			"The settings are also read again on SIGHUP. Disabled if 0",
+		Value: 0,
+	}
 
 	// SuggestedFeeRecipientFlag defines the address of the fee recipient.
 	SuggestedFeeRecipientFlag = &cli.StringFlag{
@@ -365,6 +416,64 @@ var (
 		Usage: "Enables validator registration APIs (MEV Builder APIs) for the validator client to update settings such as fee recipient and gas limit",
 		Value: false,
 	}
+
+	// DistributedFlag makes the validator client run as one of the members of a distributed validator cluster.
+	DistributedFlag = &cli.BoolFlag{
+		Name: "distributed",
+		Usage: "Runs the validator client as part of a distributed validator cluster, behind a middleware combining " +
+			"the partial selection proofs of the cluster for aggregation duties. Requires --enable-beacon-rest-api",
+		Value: false,
+	}
+
+	// DoppelgangerEpochsFlag defines for how many epochs the liveness of new validating keys is monitored before they perform duties.
+	DoppelgangerEpochsFlag = &cli.Uint64Flag{
+		Name: "doppelganger-epochs",
+		Usage: "Number of epochs new validating keys must not be seen live in the network before they start performing " +
+			"their duties, when doppelganger protection is enabled with --enable-doppelganger",
+		Value: 2,
+	}
+
+	// DoppelgangerDisableKeysFlag disables only the keys found live elsewhere instead of shutting the validator client down.
+	DoppelgangerDisableKeysFlag = &cli.BoolFlag{
+		Name: "doppelganger-disable-keys",
+		Usage: "Stops only the validating keys found live in the network from performing their duties, instead of " +
+			"shutting down the validator client, when doppelganger protection is enabled with --enable-doppelganger",
+		Value: false,
+	}
+
+	// PerformanceLedgerFlag enables recording the outcome of the duties of each validating key in the validator database.
+	PerformanceLedgerFlag = &cli.BoolFlag{
+		Name: "performance-ledger",
+		Usage: "Records the outcome of the duties of each validating key every epoch in the validator database, " +
+			"such as attestation inclusion and correctness, proposals, sync committee messages and estimated rewards. " +
+			"Not supported with the Beacon REST API",
+	}
+
+	// PerformanceLedgerRetentionFlag defines for how many epochs the performance records are kept.
+	PerformanceLedgerRetentionFlag = &cli.Uint64Flag{
+		Name:  "performance-ledger-retention-epochs",
+		Usage: "Number of epochs the performance records of the validating keys are kept for, 0 keeps them forever",
+		Value: 1575, // One week.
+	}
+
+	// PerformanceCSVFileFlag defines the path of the CSV file to export the performance records to.
+	PerformanceCSVFileFlag = &cli.StringFlag{
+		Name:  "performance-csv-file",
+		Usage: "Path of the CSV file to export the performance records of the validating keys to",
+		Value: "performance.csv",
+	}
+
+	// PerformanceStartEpochFlag defines the first epoch of the performance records to export.
+	PerformanceStartEpochFlag = &cli.Uint64Flag{
+		Name:  "performance-start-epoch",
+		Usage: "First epoch of the performance records to export",
+	}
+
+	// PerformanceEndEpochFlag defines the last epoch of the performance records to export.
+	PerformanceEndEpochFlag = &cli.Uint64Flag{
+		Name:  "performance-end-epoch",
+		Usage: "Last epoch of the performance records to export, the latest recorded one if 0",
+	}
 )
 
 // DefaultValidatorDir returns OS-specific default validator directory.
@@ -49,6 +49,8 @@ func startNode(ctx *cli.Context) error {
 var appFlags = []cli.Flag{
 	flags.BeaconRPCProviderFlag,
 	flags.BeaconRPCGatewayProviderFlag,
+	flags.BeaconRESTApiProviderFlag,
+	flags.BeaconRESTApiTimeout,
 	flags.CertFlag,
 	flags.GraffitiFlag,
 	flags.DisablePenaltyRewardLogFlag,
@@ -75,12 +77,19 @@ var appFlags = []cli.Flag{
 	// Consensys' Web3Signer flags
 	flags.Web3SignerURLFlag,
 	flags.Web3SignerPublicValidatorKeysFlag,
+	flags.Web3SignerPublicKeysRefreshIntervalFlag,
 	flags.FeeRecipientConfigFileFlag,
 	flags.FeeRecipientConfigURLFlag,
 	flags.SuggestedFeeRecipientFlag,
 	flags.ProposerSettingsURLFlag,
+	flags.ProposerSettingsRefreshIntervalFlag,
 	flags.ProposerSettingsFlag,
 	flags.EnableValidatorRegistrationFlag,
+	flags.DistributedFlag,
+	flags.DoppelgangerEpochsFlag,
+	flags.DoppelgangerDisableKeysFlag,
+	flags.PerformanceLedgerFlag,
+	flags.PerformanceLedgerRetentionFlag,
 	////////////////////
 	cmd.DisableMonitoringFlag,
 	cmd.MonitoringHostFlag,
@@ -0,0 +1,36 @@
+package historycmd
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	slashingprotection "github.com/prysmaticlabs/prysm/validator/slashing-protection-history"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+// Reads an input slashing protection EIP-3076 standard JSON file, or several ones to
+// merge, and looks for slashable pairs of messages signed by a same public key, which
+// an import would refuse. It does not need access to the validator database.
+func checkSlashingProtectionJSON(cliCtx *cli.Context) error {
+	interchangeJSON, err := readSlashingProtectionJSONs(cliCtx)
+	if err != nil {
+		return err
+	}
+	pairs, err := slashingprotection.FindSlashablePairs(cliCtx.Context, interchangeJSON)
+	if err != nil {
+		return errors.Wrap(err, "could not check slashing protection data")
+	}
+	for _, p := range pairs {
+		log.WithFields(logrus.Fields{
+			"pubKey": p.PubKey,
+			"first":  p.First,
+			"second": p.Second,
+		}).Warnf("Found slashable %s", p.Reason)
+	}
+	if len(pairs) > 0 {
+		return fmt.Errorf("found %d slashable pairs in slashing protection data", len(pairs))
+	}
+	log.Infof("No slashable data found for the %d entries of the slashing protection data", len(interchangeJSON.Data))
+	return nil
+}
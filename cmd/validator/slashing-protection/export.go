@@ -1,13 +1,16 @@
 package historycmd
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/cmd"
 	"github.com/prysmaticlabs/prysm/cmd/validator/flags"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/io/file"
 	"github.com/prysmaticlabs/prysm/validator/accounts/userprompt"
 	"github.com/prysmaticlabs/prysm/validator/db/kv"
@@ -27,7 +30,8 @@ const (
 // 1. Parse a path to the validator's datadir from the CLI context.
 // 2. Open the validator database.
 // 3. Call the function which actually exports the data from
-// from the validator's db into an EIP standard slashing protection format
+// from the validator's db into an EIP standard slashing protection format,
+// optionally for a set of public keys only and in its minimal form.
 // 4. Format and save the JSON file to a user's specified output directory.
 func exportSlashingProtectionJSON(cliCtx *cli.Context) error {
 	log.Info(
@@ -63,10 +67,20 @@ func exportSlashingProtectionJSON(cliCtx *cli.Context) error {
 			log.WithError(err).Errorf("Could not close validator DB")
 		}
 	}()
-	eipJSON, err := slashingprotection.ExportStandardProtectionJSON(cliCtx.Context, validatorDB)
+	filteredKeys, err := parsePublicKeys(cliCtx.String(flags.SlashingProtectionExportPublicKeysFlag.Name))
+	if err != nil {
+		return err
+	}
+	eipJSON, err := slashingprotection.ExportStandardProtectionJSON(cliCtx.Context, validatorDB, filteredKeys...)
 	if err != nil {
 		return errors.Wrap(err, "could not export slashing protection history")
 	}
+	if cliCtx.Bool(flags.SlashingProtectionExportMinimalFlag.Name) {
+		eipJSON, err = slashingprotection.MinimalStandardProtectionJSON(cliCtx.Context, eipJSON)
+		if err != nil {
+			return errors.Wrap(err, "could not convert slashing protection history to its minimal form")
+		}
+	}
 
 	// Check if JSON data is empty and issue a warning about common problems to the user.
 	if eipJSON == nil || len(eipJSON.Data) == 0 {
@@ -115,3 +129,24 @@ func exportSlashingProtectionJSON(cliCtx *cli.Context) error {
 	)
 	return nil
 }
+
+// Parses a comma-separated list of hex string public keys.
+func parsePublicKeys(pubKeysStr string) ([][]byte, error) {
+	if pubKeysStr == "" {
+		return nil, nil
+	}
+	pubKeyStrings := strings.Split(pubKeysStr, ",")
+	pubKeys := make([][]byte, 0, len(pubKeyStrings))
+	for _, str := range pubKeyStrings {
+		pkString := strings.TrimPrefix(strings.TrimSpace(str), "0x")
+		pubKeyBytes, err := hex.DecodeString(pkString)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not decode string %s as hex", pkString)
+		}
+		if len(pubKeyBytes) != fieldparams.BLSPubkeyLength {
+			return nil, fmt.Errorf("%#x is not a valid public key, wanted %d bytes", pubKeyBytes, fieldparams.BLSPubkeyLength)
+		}
+		pubKeys = append(pubKeys, pubKeyBytes)
+	}
+	return pubKeys, nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/prysmaticlabs/prysm/validator/accounts/userprompt"
 	"github.com/prysmaticlabs/prysm/validator/db/kv"
 	slashingprotection "github.com/prysmaticlabs/prysm/validator/slashing-protection-history"
+	"github.com/prysmaticlabs/prysm/validator/slashing-protection-history/format"
 	"github.com/urfave/cli/v2"
 )
 
@@ -20,7 +21,7 @@ import (
 // Steps:
 // 1. Parse a path to the validator's datadir from the CLI context.
 // 2. Open the validator database.
-// 3. Read the JSON file from user input.
+// 3. Read the JSON file from user input, or merge several JSON files.
 // 4. Call the function which actually imports the data from
 // from the standard slashing protection JSON file into our database.
 func importSlashingProtectionJSON(cliCtx *cli.Context) error {
@@ -54,28 +55,70 @@ func importSlashingProtectionJSON(cliCtx *cli.Context) error {
 			log.WithError(err).Errorf("Could not close validator DB")
 		}
 	}()
+	interchangeJSON, err := readSlashingProtectionJSONs(cliCtx)
+	if err != nil {
+		return err
+	}
+	if err := slashingprotection.ImportStandardProtection(
+		cliCtx.Context, valDB, interchangeJSON,
+	); err != nil {
+		return err
+	}
+	log.Infof("Slashing protection JSON successfully imported into %s", dataDir)
+	return nil
+}
+
+// Reads the slashing protection JSON files given with the --slashing-protection-json-files
+// flag and merges them, or the single file given by the user otherwise.
+func readSlashingProtectionJSONs(cliCtx *cli.Context) (*format.EIPSlashingProtectionFormat, error) {
+	protectionFilePaths := cliCtx.StringSlice(flags.SlashingProtectionJSONFilesFlag.Name)
+	if len(protectionFilePaths) == 0 {
+		protectionFilePath, err := readSlashingProtectionJSONPath(cliCtx)
+		if err != nil {
+			return nil, err
+		}
+		return readSlashingProtectionJSON(protectionFilePath)
+	}
+	interchangeJSONs := make([]*format.EIPSlashingProtectionFormat, len(protectionFilePaths))
+	for i, protectionFilePath := range protectionFilePaths {
+		interchangeJSON, err := readSlashingProtectionJSON(protectionFilePath)
+		if err != nil {
+			return nil, err
+		}
+		interchangeJSONs[i] = interchangeJSON
+	}
+	log.Infof("Merging %d slashing protection files", len(interchangeJSONs))
+	mergedJSON, err := slashingprotection.MergeStandardProtectionJSONs(cliCtx.Context, interchangeJSONs...)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not merge slashing protection files")
+	}
+	return mergedJSON, nil
+}
+
+func readSlashingProtectionJSONPath(cliCtx *cli.Context) (string, error) {
 	protectionFilePath, err := userprompt.InputDirectory(cliCtx, userprompt.SlashingProtectionJSONPromptText, flags.SlashingProtectionJSONFileFlag)
 	if err != nil {
-		return errors.Wrap(err, "could not get slashing protection json file")
+		return "", errors.Wrap(err, "could not get slashing protection json file")
 	}
 	if protectionFilePath == "" {
-		return fmt.Errorf(
+		return "", fmt.Errorf(
 			"no path to a slashing_protection.json file specified, please retry or "+
 				"you can also specify it with the %s flag",
 			flags.SlashingProtectionJSONFileFlag.Name,
 		)
 	}
+	return protectionFilePath, nil
+}
+
+func readSlashingProtectionJSON(protectionFilePath string) (*format.EIPSlashingProtectionFormat, error) {
 	enc, err := file.ReadFileAsBytes(protectionFilePath)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	log.Infof("Starting import of slashing protection file %s", protectionFilePath)
-	buf := bytes.NewBuffer(enc)
-	if err := slashingprotection.ImportStandardProtectionJSON(
-		cliCtx.Context, valDB, buf,
-	); err != nil {
-		return err
+	log.Infof("Reading slashing protection file %s", protectionFilePath)
+	interchangeJSON, err := slashingprotection.ParseStandardProtectionJSON(bytes.NewBuffer(enc))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not parse slashing protection file %s", protectionFilePath)
 	}
-	log.Infof("Slashing protection JSON successfully imported into %s", dataDir)
-	return nil
+	return interchangeJSON, nil
 }
@@ -3,11 +3,13 @@ package historycmd
 import (
 	"encoding/json"
 	"flag"
+	"fmt"
 	"path/filepath"
 	"testing"
 
 	"github.com/prysmaticlabs/prysm/cmd"
 	"github.com/prysmaticlabs/prysm/cmd/validator/flags"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/io/file"
 	"github.com/prysmaticlabs/prysm/testing/assert"
 	"github.com/prysmaticlabs/prysm/testing/require"
@@ -176,3 +178,91 @@ func TestImportExportSlashingProtectionCli_EmptyData(t *testing.T) {
 		require.DeepEqual(t, make([]*format.SignedAttestation, 0), item.SignedAttestations)
 	}
 }
+
+func TestImportExportSlashingProtectionCli_MergeMinimalFiltered(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "slashing-exports")
+	require.NoError(t, file.MkdirAll(outputPath))
+	pubKeys := [][fieldparams.BLSPubkeyLength]byte{{1}, {2}}
+	pubKeyHex := func(i int) string {
+		return fmt.Sprintf("%#x", pubKeys[i])
+	}
+	genesisValidatorsRoot := fmt.Sprintf("%#x", [fieldparams.RootLength]byte{3})
+	writeProtectionFile := func(name string, data ...*format.ProtectionData) string {
+		interchangeJSON := &format.EIPSlashingProtectionFormat{Data: data}
+		interchangeJSON.Metadata.InterchangeFormatVersion = format.InterchangeFormatVersion
+		interchangeJSON.Metadata.GenesisValidatorsRoot = genesisValidatorsRoot
+		encoded, err := json.Marshal(interchangeJSON)
+		require.NoError(t, err)
+		protectionFilePath := filepath.Join(outputPath, name)
+		require.NoError(t, file.WriteFile(protectionFilePath, encoded))
+		return protectionFilePath
+	}
+	firstFilePath := writeProtectionFile("first.json", &format.ProtectionData{
+		Pubkey: pubKeyHex(0),
+		SignedAttestations: []*format.SignedAttestation{
+			{SourceEpoch: "1", TargetEpoch: "2"},
+			{SourceEpoch: "2", TargetEpoch: "3"},
+		},
+	})
+	secondFilePath := writeProtectionFile("second.json",
+		&format.ProtectionData{
+			Pubkey:             pubKeyHex(0),
+			SignedAttestations: []*format.SignedAttestation{{SourceEpoch: "3", TargetEpoch: "4"}},
+		},
+		&format.ProtectionData{
+			Pubkey:       pubKeyHex(1),
+			SignedBlocks: []*format.SignedBlock{{Slot: "5"}},
+		},
+	)
+
+	validatorDB := dbTest.SetupDB(t, pubKeys)
+	dbPath := validatorDB.DatabasePath()
+	require.NoError(t, validatorDB.Close())
+
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.String(cmd.DataDirFlag.Name, dbPath, "")
+	set.String(flags.SlashingProtectionExportDirFlag.Name, outputPath, "")
+	set.String(flags.SlashingProtectionExportPublicKeysFlag.Name, pubKeyHex(0), "")
+	set.Bool(flags.SlashingProtectionExportMinimalFlag.Name, true, "")
+	set.Var(cli.NewStringSlice(firstFilePath, secondFilePath), flags.SlashingProtectionJSONFilesFlag.Name, "")
+	require.NoError(t, set.Set(cmd.DataDirFlag.Name, dbPath))
+	require.NoError(t, set.Set(flags.SlashingProtectionExportDirFlag.Name, outputPath))
+	cliCtx := cli.NewContext(&app, set, nil)
+
+	// The merged files are checked then imported via CLI.
+	require.NoError(t, checkSlashingProtectionJSON(cliCtx))
+	require.NoError(t, importSlashingProtectionJSON(cliCtx))
+
+	// We export the minimal slashing protection history of the first key via CLI.
+	require.NoError(t, exportSlashingProtectionJSON(cliCtx))
+	enc, err := file.ReadFileAsBytes(filepath.Join(outputPath, jsonExportFileName))
+	require.NoError(t, err)
+	receivedJSON := &format.EIPSlashingProtectionFormat{}
+	require.NoError(t, json.Unmarshal(enc, receivedJSON))
+	require.Equal(t, genesisValidatorsRoot, receivedJSON.Metadata.GenesisValidatorsRoot)
+	require.DeepEqual(t, []*format.ProtectionData{{
+		Pubkey:             pubKeyHex(0),
+		SignedBlocks:       []*format.SignedBlock{},
+		SignedAttestations: []*format.SignedAttestation{{SourceEpoch: "3", TargetEpoch: "4"}},
+	}}, receivedJSON.Data)
+}
+
+func TestCheckSlashingProtectionCli_Slashable(t *testing.T) {
+	interchangeJSON := &format.EIPSlashingProtectionFormat{Data: []*format.ProtectionData{{
+		Pubkey: fmt.Sprintf("%#x", [fieldparams.BLSPubkeyLength]byte{1}),
+		SignedAttestations: []*format.SignedAttestation{
+			{SourceEpoch: "1", TargetEpoch: "4"},
+			{SourceEpoch: "2", TargetEpoch: "3"},
+		},
+	}}}
+	interchangeJSON.Metadata.InterchangeFormatVersion = format.InterchangeFormatVersion
+	interchangeJSON.Metadata.GenesisValidatorsRoot = fmt.Sprintf("%#x", [fieldparams.RootLength]byte{3})
+	encoded, err := json.Marshal(interchangeJSON)
+	require.NoError(t, err)
+	protectionFilePath := filepath.Join(t.TempDir(), "slashing_history_import.json")
+	require.NoError(t, file.WriteFile(protectionFilePath, encoded))
+
+	cliCtx := setupCliCtx(t, t.TempDir(), protectionFilePath, t.TempDir())
+	require.ErrorContains(t, "found 1 slashable pairs", checkSlashingProtectionJSON(cliCtx))
+}
@@ -21,6 +21,8 @@ var Commands = &cli.Command{
 			Flags: cmd.WrapFlags([]cli.Flag{
 				cmd.DataDirFlag,
 				flags.SlashingProtectionExportDirFlag,
+				flags.SlashingProtectionExportPublicKeysFlag,
+				flags.SlashingProtectionExportMinimalFlag,
 				features.Mainnet,
 				features.PraterTestnet,
 				features.RopstenTestnet,
@@ -49,6 +51,7 @@ var Commands = &cli.Command{
 			Flags: cmd.WrapFlags([]cli.Flag{
 				cmd.DataDirFlag,
 				flags.SlashingProtectionJSONFileFlag,
+				flags.SlashingProtectionJSONFilesFlag,
 				features.Mainnet,
 				features.PraterTestnet,
 				features.RopstenTestnet,
@@ -72,5 +75,22 @@ var Commands = &cli.Command{
 				return nil
 			},
 		},
+		{
+			Name:        "check",
+			Description: `checks a selected EIP-3076 compliant slashing protection JSON for slashable data before importing it`,
+			Flags: cmd.WrapFlags([]cli.Flag{
+				flags.SlashingProtectionJSONFileFlag,
+				flags.SlashingProtectionJSONFilesFlag,
+			}),
+			Before: func(cliCtx *cli.Context) error {
+				return cmd.LoadFlagsFromConfig(cliCtx, cliCtx.Command.Flags)
+			},
+			Action: func(cliCtx *cli.Context) error {
+				if err := checkSlashingProtectionJSON(cliCtx); err != nil {
+					logrus.Fatalf("Could not check slashing protection file: %v", err)
+				}
+				return nil
+			},
+		},
 	},
 }
@@ -87,6 +87,8 @@ var appHelpFlagGroups = []flagGroup{
 		Flags: []cli.Flag{
 			flags.BeaconRPCProviderFlag,
 			flags.BeaconRPCGatewayProviderFlag,
+			flags.BeaconRESTApiProviderFlag,
+			flags.BeaconRESTApiTimeout,
 			flags.CertFlag,
 			flags.EnableWebFlag,
 			flags.DisablePenaltyRewardLogFlag,
@@ -109,12 +111,19 @@ var appHelpFlagGroups = []flagGroup{
 			flags.EnableDutyCountDown,
 			flags.Web3SignerURLFlag,
 			flags.Web3SignerPublicValidatorKeysFlag,
+			flags.Web3SignerPublicKeysRefreshIntervalFlag,
 			flags.FeeRecipientConfigFileFlag,
 			flags.FeeRecipientConfigURLFlag,
 			flags.ProposerSettingsFlag,
 			flags.ProposerSettingsURLFlag,
+			flags.ProposerSettingsRefreshIntervalFlag,
 			flags.SuggestedFeeRecipientFlag,
 			flags.EnableValidatorRegistrationFlag,
+			flags.DistributedFlag,
+			flags.DoppelgangerEpochsFlag,
+			flags.DoppelgangerDisableKeysFlag,
+			flags.PerformanceLedgerFlag,
+			flags.PerformanceLedgerRetentionFlag,
 		},
 	},
 	{
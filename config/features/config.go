@@ -44,8 +44,9 @@ type Flags struct {
 	EnableLargerGossipHistory           bool // EnableLargerGossipHistory increases the gossip history we store in our caches.
 	WriteWalletPasswordOnWebOnboarding  bool // WriteWalletPasswordOnWebOnboarding writes the password to disk after Prysm web signup.
 	DisableAttestingHistoryDBCache      bool // DisableAttestingHistoryDBCache for the validator client increases disk reads/writes.
-	EnableDoppelGanger                  bool // EnableDoppelGanger enables doppelganger protection on startup for the validator.
+	EnableDoppelGanger                  bool // EnableDoppelGanger enables doppelganger protection of new validating keys for the validator.
 	EnableHistoricalSpaceRepresentation bool // EnableHistoricalSpaceRepresentation enables the saving of registry validators in separate buckets to save space
+	EnableBeaconRESTApi                 bool // EnableBeaconRESTApi makes the validator talk to its beacon node over the standard Beacon REST API instead of gRPC.
 	// Logging related toggles.
 	DisableGRPCConnectionLogs bool // Disables logging when a new grpc client has connected.
 
@@ -56,7 +57,8 @@ type Flags struct {
 	// Bug fixes related flags.
 	AttestTimely bool // AttestTimely fixes #8185. It is gated behind a flag to ensure beacon node's fix can safely roll out first. We'll invert this in v1.1.0.
 
-	EnableSlasher bool // Enable slasher in the beacon node runtime.
+	EnableSlasher       bool // Enable slasher in the beacon node runtime.
+	EnableRemoteSlasher bool // EnableRemoteSlasher streams the slasher data of the beacon node to a standalone slasher.
 	// EnableSlashingProtectionPruning for the validator client.
 	EnableSlashingProtectionPruning bool
 
@@ -65,6 +67,8 @@ type Flags struct {
 	EnableVectorizedHTR              bool // EnableVectorizedHTR specifies whether the beacon state will use the optimized sha256 routines.
 	EnableForkChoiceDoublyLinkedTree bool // EnableForkChoiceDoublyLinkedTree specifies whether fork choice store will use a doubly linked tree.
 	EnableBatchGossipAggregation     bool // EnableBatchGossipAggregation specifies whether to further aggregate our gossip batches before verifying them.
+	EnableReorgLateBlocks            bool // EnableReorgLateBlocks specifies whether proposers may build on the parent of a late and weak head block.
+	EnableRewardWeightedPacking      bool // EnableRewardWeightedPacking specifies whether proposers select attestations by the proposer reward they are worth.
 
 	// KeystoreImportDebounceInterval specifies the time duration the validator waits to reload new keys if they have
 	// changed on disk. This feature is for advanced use cases only.
@@ -203,6 +207,10 @@ func ConfigureBeaconChain(ctx *cli.Context) error {
 		log.WithField(enableSlasherFlag.Name, enableSlasherFlag.Usage).Warn(enabledFeatureFlag)
 		cfg.EnableSlasher = true
 	}
+	if ctx.Bool(enableRemoteSlasherFlag.Name) {
+		logEnabled(enableRemoteSlasherFlag)
+		cfg.EnableRemoteSlasher = true
+	}
 	if ctx.Bool(enableHistoricalSpaceRepresentation.Name) {
 		log.WithField(enableHistoricalSpaceRepresentation.Name, enableHistoricalSpaceRepresentation.Usage).Warn(enabledFeatureFlag)
 		cfg.EnableHistoricalSpaceRepresentation = true
@@ -228,6 +236,29 @@ func ConfigureBeaconChain(ctx *cli.Context) error {
 		logEnabled(enableGossipBatchAggregation)
 		cfg.EnableBatchGossipAggregation = true
 	}
+	if ctx.Bool(enableReorgLateBlocks.Name) {
+		logEnabled(enableReorgLateBlocks)
+		cfg.EnableReorgLateBlocks = true
+		if !cfg.EnableForkChoiceDoublyLinkedTree {
+			log.Warnf("%s requires %s, late blocks will not be re-orged", enableReorgLateBlocks.Name, enableForkChoiceDoublyLinkedTree.Name)
+		}
+	}
+	if ctx.Bool(enableRewardWeightedPacking.Name) {
+		logEnabled(enableRewardWeightedPacking)
+		cfg.EnableRewardWeightedPacking = true
+	}
+	Init(cfg)
+	return nil
+}
+
+// ConfigureSlasher sets the global config based
+// on what flags are enabled for the standalone slasher.
+func ConfigureSlasher(ctx *cli.Context) error {
+	complainOnDeprecatedFlags(ctx)
+	cfg := &Flags{}
+	if err := configureTestnet(ctx); err != nil {
+		return err
+	}
 	Init(cfg)
 	return nil
 }
@@ -266,6 +297,10 @@ func ConfigureValidator(ctx *cli.Context) error {
 		logEnabled(enableDoppelGangerProtection)
 		cfg.EnableDoppelGanger = true
 	}
+	if ctx.Bool(enableBeaconRESTApi.Name) {
+		logEnabled(enableBeaconRESTApi)
+		cfg.EnableBeaconRESTApi = true
+	}
 	cfg.KeystoreImportDebounceInterval = ctx.Duration(dynamicKeyReloadDebounceInterval.Name)
 	Init(cfg)
 	return nil
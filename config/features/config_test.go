@@ -6,6 +6,7 @@ import (
 
 	"github.com/prysmaticlabs/prysm/testing/assert"
 	"github.com/prysmaticlabs/prysm/testing/require"
+	logTest "github.com/sirupsen/logrus/hooks/test"
 	"github.com/urfave/cli/v2"
 )
 
@@ -50,3 +51,21 @@ func TestConfigureBeaconConfig(t *testing.T) {
 	c := Get()
 	assert.Equal(t, true, c.EnablePeerScorer)
 }
+
+func TestConfigureBeaconConfig_ReorgLateBlocksWithoutDoublyLinkedTree(t *testing.T) {
+	defer Init(&Flags{})
+	hook := logTest.NewGlobal()
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.Bool(enableReorgLateBlocks.Name, true, "test")
+	context := cli.NewContext(&app, set, nil)
+	require.NoError(t, ConfigureBeaconChain(context))
+	assert.Equal(t, true, Get().EnableReorgLateBlocks)
+	require.LogsContain(t, hook, "late blocks will not be re-orged")
+
+	hook.Reset()
+	set.Bool(enableForkChoiceDoublyLinkedTree.Name, true, "test")
+	context = cli.NewContext(&app, set, nil)
+	require.NoError(t, ConfigureBeaconChain(context))
+	require.LogsDoNotContain(t, hook, "late blocks will not be re-orged")
+}
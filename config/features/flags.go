@@ -85,16 +85,25 @@ var (
 		Name:  "slasher",
 		Usage: "Enables a slasher in the beacon node for detecting slashable offenses",
 	}
+	enableRemoteSlasherFlag = &cli.BoolFlag{
+		Name:  "remote-slasher",
+		Usage: "Enables streaming the attestations and blocks of the beacon node to a standalone slasher, see --remote-slasher-endpoint",
+	}
 	enableSlashingProtectionPruning = &cli.BoolFlag{
 		Name:  "enable-slashing-protection-history-pruning",
 		Usage: "Enables the pruning of the validator client's slashing protection database",
 	}
 	enableDoppelGangerProtection = &cli.BoolFlag{
 		Name: "enable-doppelganger",
-		Usage: "Enables the validator to perform a doppelganger check. (Warning): This is not " +
+		Usage: "Enables the validator to withhold the duties of new validating keys, at startup or when imported, until they " +
+			"were not seen live in the network for --doppelganger-epochs. (Warning): This is not " +
 			"a foolproof method to find duplicate instances in the network. Your validator will still be" +
 			" vulnerable if it is being run in unsafe configurations.",
 	}
+	enableBeaconRESTApi = &cli.BoolFlag{
+		Name:  "enable-beacon-rest-api",
+		Usage: "Experimental: Enables the validator to talk to its beacon node over the standard Beacon REST API, which allows running against other beacon node implementations",
+	}
 	enableHistoricalSpaceRepresentation = &cli.BoolFlag{
 		Name: "enable-historical-state-representation",
 		Usage: "Enables the beacon chain to save historical states in a space efficient manner." +
@@ -123,6 +132,16 @@ var (
 		Name:  "enable-gossip-batch-aggregation",
 		Usage: "Enables new methods to further aggregate our gossip batches before verifying them.",
 	}
+	enableReorgLateBlocks = &cli.BoolFlag{
+		Name: "enable-reorg-late-blocks",
+		Usage: "Experimental: Enables proposers to build on the parent of the head block when the head arrived late " +
+			"and received little attestation weight. Requires the doubly linked tree fork choice store.",
+	}
+	enableRewardWeightedPacking = &cli.BoolFlag{
+		Name: "enable-reward-weighted-attestation-packing",
+		Usage: "Experimental: Selects the attestations of proposed blocks by the proposer reward of the participation " +
+			"flags they newly set, weighted by effective balance, instead of by the number of aggregation bits.",
+	}
 )
 
 // devModeFlags holds list of flags that are set when development mode is on.
@@ -146,6 +165,15 @@ var ValidatorFlags = append(deprecatedFlags, []cli.Flag{
 	attestTimely,
 	enableSlashingProtectionPruning,
 	enableDoppelGangerProtection,
+	enableBeaconRESTApi,
+}...)
+
+// SlasherFlags contains a list of all the feature flags that apply to the standalone slasher.
+var SlasherFlags = append(deprecatedFlags, []cli.Flag{
+	PraterTestnet,
+	RopstenTestnet,
+	SepoliaTestnet,
+	Mainnet,
 }...)
 
 // E2EValidatorFlags contains a list of the validator feature flags to be tested in E2E.
@@ -167,12 +195,15 @@ var BeaconChainFlags = append(deprecatedFlags, []cli.Flag{
 	checkPtInfoCache,
 	disableBroadcastSlashingFlag,
 	enableSlasherFlag,
+	enableRemoteSlasherFlag,
 	enableHistoricalSpaceRepresentation,
 	disableNativeState,
 	enablePullTips,
 	enableVecHTR,
 	enableForkChoiceDoublyLinkedTree,
 	enableGossipBatchAggregation,
+	enableReorgLateBlocks,
+	enableRewardWeightedPacking,
 }...)
 
 // E2EBeaconChainFlags contains a list of the beacon chain feature flags to be tested in E2E.
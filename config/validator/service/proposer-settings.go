@@ -16,9 +16,11 @@ type ProposerSettingsPayload struct {
 
 // ProposerOptionPayload is the struct representation of the JSON config file set in the validator through the CLI.
 // FeeRecipient is set to an eth address in hex string format with 0x prefix.
+// Graffiti is the graffiti included in the blocks proposed with the options.
 type ProposerOptionPayload struct {
 	FeeRecipient          string                 `json:"fee_recipient" yaml:"fee_recipient"`
 	ValidatorRegistration *ValidatorRegistration `json:"validator_registration" yaml:"validator_registration"`
+	Graffiti              string                 `json:"graffiti,omitempty" yaml:"graffiti,omitempty"`
 }
 
 // ValidatorRegistration is the struct representation of the JSON config file set in the validator through the CLI.
@@ -39,6 +41,7 @@ type ProposerSettings struct {
 type ProposerOption struct {
 	FeeRecipient          common.Address
 	ValidatorRegistration *ValidatorRegistration
+	Graffiti              string
 }
 
 // DefaultProposerOption returns a Proposer Option with defaults filled
@@ -48,3 +51,33 @@ func DefaultProposerOption() ProposerOption {
 		ValidatorRegistration: nil,
 	}
 }
+
+// Clone returns a copy of the proposer settings, so that they can be modified without affecting the settings in use.
+func (ps *ProposerSettings) Clone() *ProposerSettings {
+	if ps == nil {
+		return nil
+	}
+	clone := &ProposerSettings{
+		DefaultConfig: ps.DefaultConfig.Clone(),
+	}
+	if ps.ProposeConfig != nil {
+		clone.ProposeConfig = make(map[[fieldparams.BLSPubkeyLength]byte]*ProposerOption, len(ps.ProposeConfig))
+		for k, v := range ps.ProposeConfig {
+			clone.ProposeConfig[k] = v.Clone()
+		}
+	}
+	return clone
+}
+
+// Clone returns a copy of the proposer option.
+func (po *ProposerOption) Clone() *ProposerOption {
+	if po == nil {
+		return nil
+	}
+	clone := *po
+	if po.ValidatorRegistration != nil {
+		vr := *po.ValidatorRegistration
+		clone.ValidatorRegistration = &vr
+	}
+	return &clone
+}
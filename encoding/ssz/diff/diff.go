@@ -0,0 +1,309 @@
+// Package diff computes field by field differences between SSZ objects, such as beacon states,
+// through their protobuf representation. Changes to list fields, including SSZ byte lists such as
+// participation flags, are reported per index, so that a single changed balance does not show up
+// as a difference of the whole registry.
+package diff
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/prysmaticlabs/prysm/proto/eth/ext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ChangeType describes how a value differs between the two compared objects.
+type ChangeType string
+
+const (
+	// Modified is a value present in both objects, with different contents.
+	Modified ChangeType = "modified"
+	// Added is a value only present in the second object, such as a new list element.
+	Added ChangeType = "added"
+	// Removed is a value only present in the first object.
+	Removed ChangeType = "removed"
+)
+
+// Change is a single difference between two objects. Path is the spec name of the field,
+// with list indices and nested fields, such as validators[12].effective_balance.
+type Change struct {
+	Path string     `json:"path"`
+	Type ChangeType `json:"type"`
+	Old  string     `json:"old,omitempty"`
+	New  string     `json:"new,omitempty"`
+}
+
+// Diff is the ordered list of changes between two objects.
+type Diff struct {
+	Changes []*Change `json:"changes"`
+}
+
+// StateProvider is implemented by the beacon states of every fork, and gives access to their
+// protobuf representation.
+type StateProvider interface {
+	InnerStateUnsafe() interface{}
+}
+
+// States returns the differences from beacon state a to beacon state b. The states may
+// belong to different forks, in which case the fields of only one of them are reported
+// as added or removed.
+func States(a, b StateProvider) (*Diff, error) {
+	pa, ok := a.InnerStateUnsafe().(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("state of type %T is not a protobuf message", a.InnerStateUnsafe())
+	}
+	pb, ok := b.InnerStateUnsafe().(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("state of type %T is not a protobuf message", b.InnerStateUnsafe())
+	}
+	return Messages(pa, pb), nil
+}
+
+// Messages returns the differences from message a to message b. Fields are matched by name,
+// so messages of different types, such as the beacon states of two forks, can be compared.
+func Messages(a, b proto.Message) *Diff {
+	d := &Diff{Changes: make([]*Change, 0)}
+	d.messages("", a.ProtoReflect(), b.ProtoReflect())
+	return d
+}
+
+// Empty returns true if the compared objects are equal.
+func (d *Diff) Empty() bool {
+	return len(d.Changes) == 0
+}
+
+// CountByField returns the number of changes under each top level field.
+func (d *Diff) CountByField() map[string]int {
+	counts := make(map[string]int)
+	for _, c := range d.Changes {
+		counts[topLevelField(c.Path)]++
+	}
+	return counts
+}
+
+// String returns a human readable representation of the diff, with one line per change.
+func (d *Diff) String() string {
+	if d.Empty() {
+		return "no differences\n"
+	}
+	var b strings.Builder
+	for _, c := range d.Changes {
+		switch c.Type {
+		case Added:
+			fmt.Fprintf(&b, "+ %s: %s\n", c.Path, c.New)
+		case Removed:
+			fmt.Fprintf(&b, "- %s: %s\n", c.Path, c.Old)
+		default:
+			fmt.Fprintf(&b, "~ %s: %s -> %s\n", c.Path, c.Old, c.New)
+		}
+	}
+	return b.String()
+}
+
+// Summary returns a human readable count of the changes under each top level field.
+func (d *Diff) Summary() string {
+	counts := d.CountByField()
+	fields := make([]string, 0, len(counts))
+	for f := range counts {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d changes\n", len(d.Changes))
+	for _, f := range fields {
+		fmt.Fprintf(&b, "  %s: %d\n", f, counts[f])
+	}
+	return b.String()
+}
+
+func (d *Diff) add(path string, t ChangeType, oldVal, newVal string) {
+	d.Changes = append(d.Changes, &Change{Path: path, Type: t, Old: oldVal, New: newVal})
+}
+
+// messages compares the fields of two messages, in the field order of the first one. Fields
+// only present in the second one are reported last.
+func (d *Diff) messages(path string, a, b protoreflect.Message) {
+	aFields := a.Descriptor().Fields()
+	bFields := b.Descriptor().Fields()
+	for i := 0; i < aFields.Len(); i++ {
+		fa := aFields.Get(i)
+		p := fieldPath(path, fa)
+		fb := bFields.ByName(fa.Name())
+		if fb == nil {
+			d.add(p, Removed, renderField(fa, a.Get(fa)), "")
+			continue
+		}
+		d.field(p, fa, a.Get(fa), fb, b.Get(fb))
+	}
+	for i := 0; i < bFields.Len(); i++ {
+		fb := bFields.Get(i)
+		if aFields.ByName(fb.Name()) == nil {
+			d.add(fieldPath(path, fb), Added, "", renderField(fb, b.Get(fb)))
+		}
+	}
+}
+
+// field compares the values of a field in both messages, element by element for lists.
+func (d *Diff) field(path string, fa protoreflect.FieldDescriptor, va protoreflect.Value, fb protoreflect.FieldDescriptor, vb protoreflect.Value) {
+	if fa.Kind() != fb.Kind() || fa.IsList() != fb.IsList() || fa.IsMap() || fb.IsMap() {
+		if oldVal, newVal := renderField(fa, va), renderField(fb, vb); oldVal != newVal {
+			d.add(path, Modified, oldVal, newVal)
+		}
+		return
+	}
+	if !fa.IsList() {
+		if isByteList(fa) && isByteList(fb) {
+			d.byteList(path, va.Bytes(), vb.Bytes())
+			return
+		}
+		d.value(path, fa, va, vb)
+		return
+	}
+	la, lb := va.List(), vb.List()
+	n := la.Len()
+	if lb.Len() < n {
+		n = lb.Len()
+	}
+	for i := 0; i < n; i++ {
+		d.value(indexPath(path, i), fa, la.Get(i), lb.Get(i))
+	}
+	for i := n; i < la.Len(); i++ {
+		d.add(indexPath(path, i), Removed, renderValue(fa, la.Get(i)), "")
+	}
+	for i := n; i < lb.Len(); i++ {
+		d.add(indexPath(path, i), Added, "", renderValue(fb, lb.Get(i)))
+	}
+}
+
+// value compares two singular values of the same kind.
+func (d *Diff) value(path string, fd protoreflect.FieldDescriptor, va, vb protoreflect.Value) {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		ma, mb := va.Message(), vb.Message()
+		switch {
+		case !ma.IsValid() && !mb.IsValid():
+		case !ma.IsValid():
+			d.add(path, Added, "", renderMessage(mb))
+		case !mb.IsValid():
+			d.add(path, Removed, renderMessage(ma), "")
+		default:
+			d.messages(path, ma, mb)
+		}
+	case protoreflect.BytesKind:
+		if !bytes.Equal(va.Bytes(), vb.Bytes()) {
+			d.add(path, Modified, renderValue(fd, va), renderValue(fd, vb))
+		}
+	default:
+		if va.Interface() != vb.Interface() {
+			d.add(path, Modified, renderValue(fd, va), renderValue(fd, vb))
+		}
+	}
+}
+
+// byteList compares two SSZ lists of bytes, such as participation flags, element by element.
+func (d *Diff) byteList(path string, a, b []byte) {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	for i := 0; i < n; i++ {
+		if a[i] != b[i] {
+			d.add(indexPath(path, i), Modified, renderByte(a[i]), renderByte(b[i]))
+		}
+	}
+	for i := n; i < len(a); i++ {
+		d.add(indexPath(path, i), Removed, renderByte(a[i]), "")
+	}
+	for i := n; i < len(b); i++ {
+		d.add(indexPath(path, i), Added, "", renderByte(b[i]))
+	}
+}
+
+// isByteList returns true for a bytes field that is an SSZ list of bytes. Bitlists share the
+// ssz_max option but are cast to a bitfield type, and are compared as a whole.
+func isByteList(fd protoreflect.FieldDescriptor) bool {
+	if fd.Kind() != protoreflect.BytesKind || fd.IsList() {
+		return false
+	}
+	max, ok := proto.GetExtension(fd.Options(), ext.E_SszMax).(string)
+	if !ok || max == "" || strings.Contains(max, ",") {
+		return false
+	}
+	castType, ok := proto.GetExtension(fd.Options(), ext.E_CastType).(string)
+	return !ok || castType == ""
+}
+
+// renderField renders the whole value of a field, list or singular.
+func renderField(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	if fd.IsMap() {
+		return fmt.Sprintf("map[%d entries]", v.Map().Len())
+	}
+	if !fd.IsList() {
+		return renderValue(fd, v)
+	}
+	l := v.List()
+	elems := make([]string, l.Len())
+	for i := range elems {
+		elems[i] = renderValue(fd, l.Get(i))
+	}
+	return "[" + strings.Join(elems, ", ") + "]"
+}
+
+// renderValue renders a singular value, with byte slices in hex.
+func renderValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if !v.Message().IsValid() {
+			return "nil"
+		}
+		return renderMessage(v.Message())
+	case protoreflect.BytesKind:
+		if len(v.Bytes()) == 0 {
+			return "0x"
+		}
+		return fmt.Sprintf("%#x", v.Bytes())
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name())
+		}
+		return fmt.Sprint(v.Enum())
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
+
+// renderMessage renders every field of a message on a single line.
+func renderMessage(m protoreflect.Message) string {
+	fields := m.Descriptor().Fields()
+	parts := make([]string, fields.Len())
+	for i := range parts {
+		fd := fields.Get(i)
+		parts[i] = fmt.Sprintf("%s: %s", fd.Name(), renderField(fd, m.Get(fd)))
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+func renderByte(b byte) string {
+	return fmt.Sprintf("%#x", []byte{b})
+}
+
+func fieldPath(parent string, fd protoreflect.FieldDescriptor) string {
+	if parent == "" {
+		return string(fd.Name())
+	}
+	return parent + "." + string(fd.Name())
+}
+
+func indexPath(parent string, i int) string {
+	return fmt.Sprintf("%s[%d]", parent, i)
+}
+
+func topLevelField(path string) string {
+	if i := strings.IndexAny(path, ".["); i >= 0 {
+		return path[:i]
+	}
+	return path
+}
@@ -0,0 +1,119 @@
+package diff_test
+
+import (
+	"strings"
+	"testing"
+
+	v1 "github.com/prysmaticlabs/prysm/beacon-chain/state/v1"
+	v2 "github.com/prysmaticlabs/prysm/beacon-chain/state/v2"
+	"github.com/prysmaticlabs/prysm/encoding/ssz/diff"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestMessages_Equal(t *testing.T) {
+	a := &ethpb.BeaconState{Slot: 1, Balances: []uint64{1, 2}}
+	b := &ethpb.BeaconState{Slot: 1, Balances: []uint64{1, 2}}
+	d := diff.Messages(a, b)
+	assert.Equal(t, true, d.Empty())
+	assert.Equal(t, "no differences\n", d.String())
+}
+
+func TestMessages_PerIndexChanges(t *testing.T) {
+	a := &ethpb.BeaconState{
+		Slot:     1,
+		Balances: []uint64{1, 2, 3},
+		Validators: []*ethpb.Validator{
+			{EffectiveBalance: 32, PublicKey: []byte{0x01}},
+			{EffectiveBalance: 32, PublicKey: []byte{0x02}},
+		},
+		Fork: &ethpb.Fork{Epoch: 1},
+	}
+	b := &ethpb.BeaconState{
+		Slot:     2,
+		Balances: []uint64{1, 5},
+		Validators: []*ethpb.Validator{
+			{EffectiveBalance: 32, PublicKey: []byte{0x01}},
+			{EffectiveBalance: 31, PublicKey: []byte{0x02}, Slashed: true},
+			{EffectiveBalance: 32, PublicKey: []byte{0x03}},
+		},
+	}
+	d := diff.Messages(a, b)
+	want := []*diff.Change{
+		{Path: "slot", Type: diff.Modified, Old: "1", New: "2"},
+		{Path: "fork", Type: diff.Removed, Old: "{previous_version: 0x, current_version: 0x, epoch: 1}"},
+		{Path: "validators[1].effective_balance", Type: diff.Modified, Old: "32", New: "31"},
+		{Path: "validators[1].slashed", Type: diff.Modified, Old: "false", New: "true"},
+		{Path: "validators[2]", Type: diff.Added, New: "{public_key: 0x03, withdrawal_credentials: 0x, effective_balance: 32, slashed: false, " +
+			"activation_eligibility_epoch: 0, activation_epoch: 0, exit_epoch: 0, withdrawable_epoch: 0}"},
+		{Path: "balances[1]", Type: diff.Modified, Old: "2", New: "5"},
+		{Path: "balances[2]", Type: diff.Removed, Old: "3"},
+	}
+	require.DeepEqual(t, want, d.Changes)
+
+	counts := d.CountByField()
+	assert.Equal(t, 3, counts["validators"])
+	assert.Equal(t, 2, counts["balances"])
+	assert.Equal(t, 1, counts["slot"])
+	assert.Equal(t, true, strings.Contains(d.String(), "~ balances[1]: 2 -> 5\n"))
+	assert.Equal(t, true, strings.Contains(d.String(), "- balances[2]: 3\n"))
+	assert.Equal(t, true, strings.Contains(d.Summary(), "7 changes\n  balances: 2\n  fork: 1\n"))
+}
+
+func TestMessages_ParticipationPerIndex(t *testing.T) {
+	a := &ethpb.BeaconStateAltair{
+		PreviousEpochParticipation: []byte{0x07, 0x07, 0x07, 0x07},
+		CurrentEpochParticipation:  []byte{0x01},
+		JustificationBits:          []byte{0x01},
+	}
+	b := &ethpb.BeaconStateAltair{
+		PreviousEpochParticipation: []byte{0x07, 0x07, 0x03, 0x07},
+		CurrentEpochParticipation:  []byte{0x01, 0x02},
+		JustificationBits:          []byte{0x03},
+	}
+	d := diff.Messages(a, b)
+	want := []*diff.Change{
+		{Path: "previous_epoch_participation[2]", Type: diff.Modified, Old: "0x07", New: "0x03"},
+		{Path: "current_epoch_participation[1]", Type: diff.Added, New: "0x02"},
+		{Path: "justification_bits", Type: diff.Modified, Old: "0x01", New: "0x03"},
+	}
+	require.DeepEqual(t, want, d.Changes)
+
+	// Bitlists are compared as a whole.
+	d = diff.Messages(
+		&ethpb.PendingAttestation{AggregationBits: []byte{0x01, 0x03}},
+		&ethpb.PendingAttestation{AggregationBits: []byte{0x03, 0x03}},
+	)
+	require.Equal(t, 1, len(d.Changes))
+	assert.Equal(t, "aggregation_bits", d.Changes[0].Path)
+	assert.Equal(t, "0x0103", d.Changes[0].Old)
+}
+
+func TestStates_DifferentForks(t *testing.T) {
+	phase0, err := v1.InitializeFromProto(&ethpb.BeaconState{
+		Slot:                      1,
+		PreviousEpochAttestations: []*ethpb.PendingAttestation{{InclusionDelay: 1}},
+	})
+	require.NoError(t, err)
+	altair, err := v2.InitializeFromProto(&ethpb.BeaconStateAltair{
+		Slot:                       1,
+		InactivityScores:           []uint64{1},
+		PreviousEpochParticipation: []byte{0x07},
+	})
+	require.NoError(t, err)
+
+	d, err := diff.States(phase0, altair)
+	require.NoError(t, err)
+	changes := make(map[string]*diff.Change)
+	for _, c := range d.Changes {
+		changes[c.Path] = c
+	}
+	assert.Equal(t, diff.Removed, changes["previous_epoch_attestations"].Type)
+	assert.Equal(t, diff.Added, changes["inactivity_scores"].Type)
+	assert.Equal(t, "[1]", changes["inactivity_scores"].New)
+	assert.Equal(t, diff.Added, changes["previous_epoch_participation"].Type)
+	assert.Equal(t, "0x07", changes["previous_epoch_participation"].New)
+	_, ok := changes["slot"]
+	assert.Equal(t, false, ok)
+}
@@ -95,3 +95,23 @@ func (mr *MockSlasherClientMockRecorder) IsSlashableBlock(arg0, arg1 interface{}
 	varargs := append([]interface{}{arg0, arg1}, arg2...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSlashableBlock", reflect.TypeOf((*MockSlasherClient)(nil).IsSlashableBlock), varargs...)
 }
+
+// StreamSlasherData mocks base method.
+func (m *MockSlasherClient) StreamSlasherData(arg0 context.Context, arg1 ...grpc.CallOption) (eth.Slasher_StreamSlasherDataClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "StreamSlasherData", varargs...)
+	ret0, _ := ret[0].(eth.Slasher_StreamSlasherDataClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StreamSlasherData indicates an expected call of StreamSlasherData.
+func (mr *MockSlasherClientMockRecorder) StreamSlasherData(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamSlasherData", reflect.TypeOf((*MockSlasherClient)(nil).StreamSlasherData), varargs...)
+}
@@ -8,12 +8,12 @@ import (
 	"github.com/golang/snappy"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/transition"
 	v3 "github.com/prysmaticlabs/prysm/beacon-chain/state/v3"
+	"github.com/prysmaticlabs/prysm/encoding/ssz/diff"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/testing/require"
 	"github.com/prysmaticlabs/prysm/testing/spectest/utils"
 	"github.com/prysmaticlabs/prysm/testing/util"
 	"google.golang.org/protobuf/proto"
-	"gopkg.in/d4l3k/messagediff.v1"
 )
 
 func init() {
@@ -55,8 +55,7 @@ func RunSlotProcessingTests(t *testing.T, config string) {
 			pbState, err := v3.ProtobufBeaconState(postState.CloneInnerState())
 			require.NoError(t, err)
 			if !proto.Equal(pbState, postBeaconState) {
-				diff, _ := messagediff.PrettyDiff(beaconState, postBeaconState)
-				t.Fatalf("Post state does not match expected. Diff between states %s", diff)
+				t.Fatalf("Post state does not match expected. Diff between states %s", diff.Messages(pbState, postBeaconState))
 			}
 		})
 	}
@@ -0,0 +1,550 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: validator/client/iface/validator_client.go
+
+// Package validator_mock is a generated GoMock package.
+package validator_mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	eth "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	iface "github.com/prysmaticlabs/prysm/validator/client/iface"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// MockValidatorClient is a mock of ValidatorClient interface.
+type MockValidatorClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockValidatorClientMockRecorder
+}
+
+// MockValidatorClientMockRecorder is the mock recorder for MockValidatorClient.
+type MockValidatorClientMockRecorder struct {
+	mock *MockValidatorClient
+}
+
+// NewMockValidatorClient creates a new mock instance.
+func NewMockValidatorClient(ctrl *gomock.Controller) *MockValidatorClient {
+	mock := &MockValidatorClient{ctrl: ctrl}
+	mock.recorder = &MockValidatorClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockValidatorClient) EXPECT() *MockValidatorClientMockRecorder {
+	return m.recorder
+}
+
+// CheckDoppelGanger mocks base method.
+func (m *MockValidatorClient) CheckDoppelGanger(ctx context.Context, in *eth.DoppelGangerRequest) (*eth.DoppelGangerResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckDoppelGanger", ctx, in)
+	ret0, _ := ret[0].(*eth.DoppelGangerResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckDoppelGanger indicates an expected call of CheckDoppelGanger.
+func (mr *MockValidatorClientMockRecorder) CheckDoppelGanger(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckDoppelGanger", reflect.TypeOf((*MockValidatorClient)(nil).CheckDoppelGanger), ctx, in)
+}
+
+// DomainData mocks base method.
+func (m *MockValidatorClient) DomainData(ctx context.Context, in *eth.DomainRequest) (*eth.DomainResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DomainData", ctx, in)
+	ret0, _ := ret[0].(*eth.DomainResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DomainData indicates an expected call of DomainData.
+func (mr *MockValidatorClientMockRecorder) DomainData(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DomainData", reflect.TypeOf((*MockValidatorClient)(nil).DomainData), ctx, in)
+}
+
+// GetAggregatedSelections mocks base method.
+func (m *MockValidatorClient) GetAggregatedSelections(ctx context.Context, selections []iface.BeaconCommitteeSelection) ([]iface.BeaconCommitteeSelection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAggregatedSelections", ctx, selections)
+	ret0, _ := ret[0].([]iface.BeaconCommitteeSelection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAggregatedSelections indicates an expected call of GetAggregatedSelections.
+func (mr *MockValidatorClientMockRecorder) GetAggregatedSelections(ctx, selections interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAggregatedSelections", reflect.TypeOf((*MockValidatorClient)(nil).GetAggregatedSelections), ctx, selections)
+}
+
+// GetAggregatedSyncSelections mocks base method.
+func (m *MockValidatorClient) GetAggregatedSyncSelections(ctx context.Context, selections []iface.SyncCommitteeSelection) ([]iface.SyncCommitteeSelection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAggregatedSyncSelections", ctx, selections)
+	ret0, _ := ret[0].([]iface.SyncCommitteeSelection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAggregatedSyncSelections indicates an expected call of GetAggregatedSyncSelections.
+func (mr *MockValidatorClientMockRecorder) GetAggregatedSyncSelections(ctx, selections interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAggregatedSyncSelections", reflect.TypeOf((*MockValidatorClient)(nil).GetAggregatedSyncSelections), ctx, selections)
+}
+
+// GetAttestationData mocks base method.
+func (m *MockValidatorClient) GetAttestationData(ctx context.Context, in *eth.AttestationDataRequest) (*eth.AttestationData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttestationData", ctx, in)
+	ret0, _ := ret[0].(*eth.AttestationData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttestationData indicates an expected call of GetAttestationData.
+func (mr *MockValidatorClientMockRecorder) GetAttestationData(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttestationData", reflect.TypeOf((*MockValidatorClient)(nil).GetAttestationData), ctx, in)
+}
+
+// GetBeaconBlock mocks base method.
+func (m *MockValidatorClient) GetBeaconBlock(ctx context.Context, in *eth.BlockRequest) (*eth.GenericBeaconBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBeaconBlock", ctx, in)
+	ret0, _ := ret[0].(*eth.GenericBeaconBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBeaconBlock indicates an expected call of GetBeaconBlock.
+func (mr *MockValidatorClientMockRecorder) GetBeaconBlock(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBeaconBlock", reflect.TypeOf((*MockValidatorClient)(nil).GetBeaconBlock), ctx, in)
+}
+
+// GetDuties mocks base method.
+func (m *MockValidatorClient) GetDuties(ctx context.Context, in *eth.DutiesRequest) (*eth.DutiesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDuties", ctx, in)
+	ret0, _ := ret[0].(*eth.DutiesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDuties indicates an expected call of GetDuties.
+func (mr *MockValidatorClientMockRecorder) GetDuties(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDuties", reflect.TypeOf((*MockValidatorClient)(nil).GetDuties), ctx, in)
+}
+
+// GetSyncCommitteeContribution mocks base method.
+func (m *MockValidatorClient) GetSyncCommitteeContribution(ctx context.Context, in *eth.SyncCommitteeContributionRequest) (*eth.SyncCommitteeContribution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSyncCommitteeContribution", ctx, in)
+	ret0, _ := ret[0].(*eth.SyncCommitteeContribution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSyncCommitteeContribution indicates an expected call of GetSyncCommitteeContribution.
+func (mr *MockValidatorClientMockRecorder) GetSyncCommitteeContribution(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSyncCommitteeContribution", reflect.TypeOf((*MockValidatorClient)(nil).GetSyncCommitteeContribution), ctx, in)
+}
+
+// GetSyncMessageBlockRoot mocks base method.
+func (m *MockValidatorClient) GetSyncMessageBlockRoot(ctx context.Context, in *emptypb.Empty) (*eth.SyncMessageBlockRootResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSyncMessageBlockRoot", ctx, in)
+	ret0, _ := ret[0].(*eth.SyncMessageBlockRootResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSyncMessageBlockRoot indicates an expected call of GetSyncMessageBlockRoot.
+func (mr *MockValidatorClientMockRecorder) GetSyncMessageBlockRoot(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSyncMessageBlockRoot", reflect.TypeOf((*MockValidatorClient)(nil).GetSyncMessageBlockRoot), ctx, in)
+}
+
+// GetSyncSubcommitteeIndex mocks base method.
+func (m *MockValidatorClient) GetSyncSubcommitteeIndex(ctx context.Context, in *eth.SyncSubcommitteeIndexRequest) (*eth.SyncSubcommitteeIndexResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSyncSubcommitteeIndex", ctx, in)
+	ret0, _ := ret[0].(*eth.SyncSubcommitteeIndexResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSyncSubcommitteeIndex indicates an expected call of GetSyncSubcommitteeIndex.
+func (mr *MockValidatorClientMockRecorder) GetSyncSubcommitteeIndex(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSyncSubcommitteeIndex", reflect.TypeOf((*MockValidatorClient)(nil).GetSyncSubcommitteeIndex), ctx, in)
+}
+
+// GetValidatorsLiveness mocks base method.
+func (m *MockValidatorClient) GetValidatorsLiveness(ctx context.Context, epoch types.Epoch, indices []types.ValidatorIndex) ([]iface.ValidatorLiveness, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValidatorsLiveness", ctx, epoch, indices)
+	ret0, _ := ret[0].([]iface.ValidatorLiveness)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetValidatorsLiveness indicates an expected call of GetValidatorsLiveness.
+func (mr *MockValidatorClientMockRecorder) GetValidatorsLiveness(ctx, epoch, indices interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidatorsLiveness", reflect.TypeOf((*MockValidatorClient)(nil).GetValidatorsLiveness), ctx, epoch, indices)
+}
+
+// MultipleValidatorStatus mocks base method.
+func (m *MockValidatorClient) MultipleValidatorStatus(ctx context.Context, in *eth.MultipleValidatorStatusRequest) (*eth.MultipleValidatorStatusResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MultipleValidatorStatus", ctx, in)
+	ret0, _ := ret[0].(*eth.MultipleValidatorStatusResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MultipleValidatorStatus indicates an expected call of MultipleValidatorStatus.
+func (mr *MockValidatorClientMockRecorder) MultipleValidatorStatus(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MultipleValidatorStatus", reflect.TypeOf((*MockValidatorClient)(nil).MultipleValidatorStatus), ctx, in)
+}
+
+// PrepareBeaconProposer mocks base method.
+func (m *MockValidatorClient) PrepareBeaconProposer(ctx context.Context, in *eth.PrepareBeaconProposerRequest) (*emptypb.Empty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PrepareBeaconProposer", ctx, in)
+	ret0, _ := ret[0].(*emptypb.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PrepareBeaconProposer indicates an expected call of PrepareBeaconProposer.
+func (mr *MockValidatorClientMockRecorder) PrepareBeaconProposer(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrepareBeaconProposer", reflect.TypeOf((*MockValidatorClient)(nil).PrepareBeaconProposer), ctx, in)
+}
+
+// ProposeAttestation mocks base method.
+func (m *MockValidatorClient) ProposeAttestation(ctx context.Context, in *eth.Attestation) (*eth.AttestResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProposeAttestation", ctx, in)
+	ret0, _ := ret[0].(*eth.AttestResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProposeAttestation indicates an expected call of ProposeAttestation.
+func (mr *MockValidatorClientMockRecorder) ProposeAttestation(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProposeAttestation", reflect.TypeOf((*MockValidatorClient)(nil).ProposeAttestation), ctx, in)
+}
+
+// ProposeBeaconBlock mocks base method.
+func (m *MockValidatorClient) ProposeBeaconBlock(ctx context.Context, in *eth.GenericSignedBeaconBlock) (*eth.ProposeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProposeBeaconBlock", ctx, in)
+	ret0, _ := ret[0].(*eth.ProposeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProposeBeaconBlock indicates an expected call of ProposeBeaconBlock.
+func (mr *MockValidatorClientMockRecorder) ProposeBeaconBlock(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProposeBeaconBlock", reflect.TypeOf((*MockValidatorClient)(nil).ProposeBeaconBlock), ctx, in)
+}
+
+// ProposeExit mocks base method.
+func (m *MockValidatorClient) ProposeExit(ctx context.Context, in *eth.SignedVoluntaryExit) (*eth.ProposeExitResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProposeExit", ctx, in)
+	ret0, _ := ret[0].(*eth.ProposeExitResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProposeExit indicates an expected call of ProposeExit.
+func (mr *MockValidatorClientMockRecorder) ProposeExit(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProposeExit", reflect.TypeOf((*MockValidatorClient)(nil).ProposeExit), ctx, in)
+}
+
+// StreamBlocksAltair mocks base method.
+func (m *MockValidatorClient) StreamBlocksAltair(ctx context.Context, in *eth.StreamBlocksRequest) (eth.BeaconNodeValidator_StreamBlocksAltairClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamBlocksAltair", ctx, in)
+	ret0, _ := ret[0].(eth.BeaconNodeValidator_StreamBlocksAltairClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StreamBlocksAltair indicates an expected call of StreamBlocksAltair.
+func (mr *MockValidatorClientMockRecorder) StreamBlocksAltair(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamBlocksAltair", reflect.TypeOf((*MockValidatorClient)(nil).StreamBlocksAltair), ctx, in)
+}
+
+// SubmitAggregateSelectionProof mocks base method.
+func (m *MockValidatorClient) SubmitAggregateSelectionProof(ctx context.Context, in *eth.AggregateSelectionRequest) (*eth.AggregateSelectionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitAggregateSelectionProof", ctx, in)
+	ret0, _ := ret[0].(*eth.AggregateSelectionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitAggregateSelectionProof indicates an expected call of SubmitAggregateSelectionProof.
+func (mr *MockValidatorClientMockRecorder) SubmitAggregateSelectionProof(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitAggregateSelectionProof", reflect.TypeOf((*MockValidatorClient)(nil).SubmitAggregateSelectionProof), ctx, in)
+}
+
+// SubmitSignedAggregateSelectionProof mocks base method.
+func (m *MockValidatorClient) SubmitSignedAggregateSelectionProof(ctx context.Context, in *eth.SignedAggregateSubmitRequest) (*eth.SignedAggregateSubmitResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitSignedAggregateSelectionProof", ctx, in)
+	ret0, _ := ret[0].(*eth.SignedAggregateSubmitResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitSignedAggregateSelectionProof indicates an expected call of SubmitSignedAggregateSelectionProof.
+func (mr *MockValidatorClientMockRecorder) SubmitSignedAggregateSelectionProof(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitSignedAggregateSelectionProof", reflect.TypeOf((*MockValidatorClient)(nil).SubmitSignedAggregateSelectionProof), ctx, in)
+}
+
+// SubmitSignedContributionAndProof mocks base method.
+func (m *MockValidatorClient) SubmitSignedContributionAndProof(ctx context.Context, in *eth.SignedContributionAndProof) (*emptypb.Empty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitSignedContributionAndProof", ctx, in)
+	ret0, _ := ret[0].(*emptypb.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitSignedContributionAndProof indicates an expected call of SubmitSignedContributionAndProof.
+func (mr *MockValidatorClientMockRecorder) SubmitSignedContributionAndProof(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitSignedContributionAndProof", reflect.TypeOf((*MockValidatorClient)(nil).SubmitSignedContributionAndProof), ctx, in)
+}
+
+// SubmitSyncMessage mocks base method.
+func (m *MockValidatorClient) SubmitSyncMessage(ctx context.Context, in *eth.SyncCommitteeMessage) (*emptypb.Empty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitSyncMessage", ctx, in)
+	ret0, _ := ret[0].(*emptypb.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitSyncMessage indicates an expected call of SubmitSyncMessage.
+func (mr *MockValidatorClientMockRecorder) SubmitSyncMessage(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitSyncMessage", reflect.TypeOf((*MockValidatorClient)(nil).SubmitSyncMessage), ctx, in)
+}
+
+// SubmitValidatorRegistration mocks base method.
+func (m *MockValidatorClient) SubmitValidatorRegistration(ctx context.Context, in *eth.SignedValidatorRegistrationsV1) (*emptypb.Empty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitValidatorRegistration", ctx, in)
+	ret0, _ := ret[0].(*emptypb.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitValidatorRegistration indicates an expected call of SubmitValidatorRegistration.
+func (mr *MockValidatorClientMockRecorder) SubmitValidatorRegistration(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitValidatorRegistration", reflect.TypeOf((*MockValidatorClient)(nil).SubmitValidatorRegistration), ctx, in)
+}
+
+// SubscribeCommitteeSubnets mocks base method.
+func (m *MockValidatorClient) SubscribeCommitteeSubnets(ctx context.Context, in *eth.CommitteeSubnetsSubscribeRequest, validatorIndices []types.ValidatorIndex) (*emptypb.Empty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeCommitteeSubnets", ctx, in, validatorIndices)
+	ret0, _ := ret[0].(*emptypb.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribeCommitteeSubnets indicates an expected call of SubscribeCommitteeSubnets.
+func (mr *MockValidatorClientMockRecorder) SubscribeCommitteeSubnets(ctx, in, validatorIndices interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeCommitteeSubnets", reflect.TypeOf((*MockValidatorClient)(nil).SubscribeCommitteeSubnets), ctx, in, validatorIndices)
+}
+
+// ValidatorIndex mocks base method.
+func (m *MockValidatorClient) ValidatorIndex(ctx context.Context, in *eth.ValidatorIndexRequest) (*eth.ValidatorIndexResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidatorIndex", ctx, in)
+	ret0, _ := ret[0].(*eth.ValidatorIndexResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidatorIndex indicates an expected call of ValidatorIndex.
+func (mr *MockValidatorClientMockRecorder) ValidatorIndex(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidatorIndex", reflect.TypeOf((*MockValidatorClient)(nil).ValidatorIndex), ctx, in)
+}
+
+// WaitForActivation mocks base method.
+func (m *MockValidatorClient) WaitForActivation(ctx context.Context, in *eth.ValidatorActivationRequest) (eth.BeaconNodeValidator_WaitForActivationClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitForActivation", ctx, in)
+	ret0, _ := ret[0].(eth.BeaconNodeValidator_WaitForActivationClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WaitForActivation indicates an expected call of WaitForActivation.
+func (mr *MockValidatorClientMockRecorder) WaitForActivation(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForActivation", reflect.TypeOf((*MockValidatorClient)(nil).WaitForActivation), ctx, in)
+}
+
+// WaitForChainStart mocks base method.
+func (m *MockValidatorClient) WaitForChainStart(ctx context.Context, in *emptypb.Empty) (*eth.ChainStartResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitForChainStart", ctx, in)
+	ret0, _ := ret[0].(*eth.ChainStartResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WaitForChainStart indicates an expected call of WaitForChainStart.
+func (mr *MockValidatorClientMockRecorder) WaitForChainStart(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForChainStart", reflect.TypeOf((*MockValidatorClient)(nil).WaitForChainStart), ctx, in)
+}
+
+// MockNodeClient is a mock of NodeClient interface.
+type MockNodeClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockNodeClientMockRecorder
+}
+
+// MockNodeClientMockRecorder is the mock recorder for MockNodeClient.
+type MockNodeClientMockRecorder struct {
+	mock *MockNodeClient
+}
+
+// NewMockNodeClient creates a new mock instance.
+func NewMockNodeClient(ctrl *gomock.Controller) *MockNodeClient {
+	mock := &MockNodeClient{ctrl: ctrl}
+	mock.recorder = &MockNodeClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNodeClient) EXPECT() *MockNodeClientMockRecorder {
+	return m.recorder
+}
+
+// GetGenesis mocks base method.
+func (m *MockNodeClient) GetGenesis(ctx context.Context, in *emptypb.Empty) (*eth.Genesis, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGenesis", ctx, in)
+	ret0, _ := ret[0].(*eth.Genesis)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGenesis indicates an expected call of GetGenesis.
+func (mr *MockNodeClientMockRecorder) GetGenesis(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenesis", reflect.TypeOf((*MockNodeClient)(nil).GetGenesis), ctx, in)
+}
+
+// GetSyncStatus mocks base method.
+func (m *MockNodeClient) GetSyncStatus(ctx context.Context, in *emptypb.Empty) (*eth.SyncStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSyncStatus", ctx, in)
+	ret0, _ := ret[0].(*eth.SyncStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSyncStatus indicates an expected call of GetSyncStatus.
+func (mr *MockNodeClientMockRecorder) GetSyncStatus(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSyncStatus", reflect.TypeOf((*MockNodeClient)(nil).GetSyncStatus), ctx, in)
+}
+
+// ListPeers mocks base method.
+func (m *MockNodeClient) ListPeers(ctx context.Context, in *emptypb.Empty) (*eth.Peers, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPeers", ctx, in)
+	ret0, _ := ret[0].(*eth.Peers)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPeers indicates an expected call of ListPeers.
+func (mr *MockNodeClientMockRecorder) ListPeers(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPeers", reflect.TypeOf((*MockNodeClient)(nil).ListPeers), ctx, in)
+}
+
+// MockBeaconChainClient is a mock of BeaconChainClient interface.
+type MockBeaconChainClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockBeaconChainClientMockRecorder
+}
+
+// MockBeaconChainClientMockRecorder is the mock recorder for MockBeaconChainClient.
+type MockBeaconChainClientMockRecorder struct {
+	mock *MockBeaconChainClient
+}
+
+// NewMockBeaconChainClient creates a new mock instance.
+func NewMockBeaconChainClient(ctrl *gomock.Controller) *MockBeaconChainClient {
+	mock := &MockBeaconChainClient{ctrl: ctrl}
+	mock.recorder = &MockBeaconChainClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBeaconChainClient) EXPECT() *MockBeaconChainClientMockRecorder {
+	return m.recorder
+}
+
+// GetChainHead mocks base method.
+func (m *MockBeaconChainClient) GetChainHead(ctx context.Context, in *emptypb.Empty) (*eth.ChainHead, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChainHead", ctx, in)
+	ret0, _ := ret[0].(*eth.ChainHead)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChainHead indicates an expected call of GetChainHead.
+func (mr *MockBeaconChainClientMockRecorder) GetChainHead(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChainHead", reflect.TypeOf((*MockBeaconChainClient)(nil).GetChainHead), ctx, in)
+}
+
+// GetValidatorPerformance mocks base method.
+func (m *MockBeaconChainClient) GetValidatorPerformance(ctx context.Context, in *eth.ValidatorPerformanceRequest) (*eth.ValidatorPerformanceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValidatorPerformance", ctx, in)
+	ret0, _ := ret[0].(*eth.ValidatorPerformanceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetValidatorPerformance indicates an expected call of GetValidatorPerformance.
+func (mr *MockBeaconChainClientMockRecorder) GetValidatorPerformance(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidatorPerformance", reflect.TypeOf((*MockBeaconChainClient)(nil).GetValidatorPerformance), ctx, in)
+}
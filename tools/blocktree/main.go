@@ -4,7 +4,9 @@
  * Given a DB, start slot and end slot. This tool computes the graphviz data
  * needed to construct the block tree in graphviz data format. Then one can paste
  * the data in a Graph rendering engine (ie. http://www.webgraphviz.com/) to see the visual format.
-
+ *
+ * Alternatively, given the fork choice dump endpoint of a running beacon node
+ * (ie. http://localhost:8080/debug/forkchoice), this tool renders its fork choice store instead.
  */
 package main
 
@@ -13,14 +15,19 @@ import (
 	"encoding/hex"
 	"flag"
 	"fmt"
+	"io"
+	"net/http"
 	"strconv"
 
 	"github.com/emicklei/dot"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice"
 	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"google.golang.org/protobuf/encoding/protojson"
 )
 
 var (
@@ -28,6 +35,8 @@ var (
 	datadir   = flag.String("datadir", "", "Path to data directory.")
 	startSlot = flag.Uint("startSlot", 0, "Start slot of the block tree")
 	endSlot   = flag.Uint("endSlot", 0, "Start slot of the block tree")
+	// Optional fields
+	forkchoiceURL = flag.String("forkchoice", "", "URL of a beacon node fork choice dump endpoint, used instead of a data directory.")
 )
 
 // Used for tree, each node is a representation of a node in the graph
@@ -39,6 +48,10 @@ type node struct {
 
 func main() {
 	flag.Parse()
+	if *forkchoiceURL != "" {
+		fmt.Println(forkchoiceGraph(*forkchoiceURL))
+		return
+	}
 	database, err := db.NewDB(context.Background(), *datadir, &kv.Config{})
 	if err != nil {
 		panic(err)
@@ -102,3 +115,28 @@ func main() {
 
 	fmt.Println(graph.String())
 }
+
+// forkchoiceGraph fetches the fork choice store dump at the given URL and renders it as a graph.
+func forkchoiceGraph(url string) string {
+	resp, err := http.Get(url) // #nosec G107 -- The URL is provided by the user running the tool.
+	if err != nil {
+		panic(err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			panic(err)
+		}
+	}()
+	if resp.StatusCode != http.StatusOK {
+		panic(fmt.Sprintf("unexpected response status %s", resp.Status))
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		panic(err)
+	}
+	dump := &ethpb.ForkChoiceResponse{}
+	if err := protojson.Unmarshal(body, dump); err != nil {
+		panic(err)
+	}
+	return forkchoice.DumpToDot(dump)
+}
@@ -13,13 +13,13 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/transition"
 	v1 "github.com/prysmaticlabs/prysm/beacon-chain/state/v1"
 	"github.com/prysmaticlabs/prysm/consensus-types/wrapper"
+	"github.com/prysmaticlabs/prysm/encoding/ssz/diff"
 	"github.com/prysmaticlabs/prysm/encoding/ssz/equality"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/runtime/version"
 	log "github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v2"
 	prefixed "github.com/x-cray/logrus-prefixed-formatter"
-	"gopkg.in/d4l3k/messagediff.v1"
 )
 
 func main() {
@@ -195,8 +195,11 @@ func main() {
 						log.Fatal(err)
 					}
 					if !equality.DeepEqual(expectedState, postState.InnerStateUnsafe()) {
-						diff, _ := messagediff.PrettyDiff(expectedState, postState.InnerStateUnsafe())
-						log.Errorf("Derived state differs from provided post state: %s", diff)
+						pbState, err := v1.ProtobufBeaconState(postState.InnerStateUnsafe())
+						if err != nil {
+							log.Fatal(err)
+						}
+						log.Errorf("Derived state differs from provided post state:\n%s", diff.Messages(expectedState, pbState))
 					}
 				}
 				return nil
@@ -8,6 +8,8 @@ import (
 	"github.com/prysmaticlabs/prysm/crypto/bls"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/validator/accounts/wallet"
+	grpc_api "github.com/prysmaticlabs/prysm/validator/client/grpc-api"
+	"github.com/prysmaticlabs/prysm/validator/client/iface"
 	"github.com/prysmaticlabs/prysm/validator/keymanager"
 	"google.golang.org/grpc"
 )
@@ -48,7 +50,7 @@ type AccountsCLIManager struct {
 	formattedPubKeys     []string
 }
 
-func (acm *AccountsCLIManager) prepareBeaconClients(ctx context.Context) (*ethpb.BeaconNodeValidatorClient, *ethpb.NodeClient, error) {
+func (acm *AccountsCLIManager) prepareBeaconClients(ctx context.Context) (*iface.ValidatorClient, *ethpb.NodeClient, error) {
 	if acm.dialOpts == nil {
 		return nil, nil, errors.New("failed to construct dial options for beacon clients")
 	}
@@ -58,7 +60,7 @@ func (acm *AccountsCLIManager) prepareBeaconClients(ctx context.Context) (*ethpb
 	if err != nil {
 		return nil, nil, errors.Wrapf(err, "could not dial endpoint %s", acm.beaconRPCProvider)
 	}
-	validatorClient := ethpb.NewBeaconNodeValidatorClient(conn)
+	validatorClient := grpc_api.NewGrpcValidatorClient(conn)
 	nodeClient := ethpb.NewNodeClient(conn)
 	return &validatorClient, &nodeClient, nil
 }
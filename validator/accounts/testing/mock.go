@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	validatorserviceconfig "github.com/prysmaticlabs/prysm/config/validator/service"
 	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/validator/accounts/iface"
 	iface2 "github.com/prysmaticlabs/prysm/validator/client/iface"
@@ -186,6 +187,11 @@ func (_ MockValidator) PushProposerSettings(_ context.Context, _ keymanager.IKey
 	panic("implement me")
 }
 
+// SetProposerSettings for mocking
+func (_ MockValidator) SetProposerSettings(_ context.Context, _ *validatorserviceconfig.ProposerSettings) error {
+	return nil
+}
+
 // SetPubKeyToValidatorIndexMap for mocking
 func (_ MockValidator) SetPubKeyToValidatorIndexMap(_ context.Context, _ keymanager.IKeymanager) error {
 	panic("implement me")
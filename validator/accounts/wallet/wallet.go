@@ -313,7 +313,9 @@ func (w *Wallet) InitializeKeymanager(ctx context.Context, cfg iface.InitKeymana
 		if !bytesutil.IsValidRoot(config.GenesisValidatorsRoot) {
 			return nil, errors.New("web3signer requires a genesis validators root value")
 		}
-		km, err = remoteweb3signer.NewKeymanager(ctx, config)
+		setupConfig := *config
+		setupConfig.ListenForChanges = cfg.ListenForChanges
+		km, err = remoteweb3signer.NewKeymanager(ctx, &setupConfig)
 		if err != nil {
 			return nil, errors.Wrap(err, "could not initialize web3signer keymanager")
 		}
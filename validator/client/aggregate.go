@@ -5,16 +5,19 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/signing"
 	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/config/params"
 	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/crypto/bls"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/monitoring/tracing"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	validatorpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/validator-client"
 	prysmTime "github.com/prysmaticlabs/prysm/time"
 	"github.com/prysmaticlabs/prysm/time/slots"
+	"github.com/prysmaticlabs/prysm/validator/client/iface"
 	"go.opencensus.io/trace"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -50,7 +53,7 @@ func (v *validator) SubmitAggregateAndProof(ctx context.Context, slot types.Slot
 	v.aggregatedSlotCommitteeIDCache.Add(k, true)
 	v.aggregatedSlotCommitteeIDCacheLock.Unlock()
 
-	slotSig, err := v.signSlotWithSelectionProof(ctx, pubKey, slot)
+	slotSig, err := v.attSelectionProof(ctx, pubKey, duty.ValidatorIndex, slot)
 	if err != nil {
 		log.Errorf("Could not sign slot: %v", err)
 		if v.emitAccountMetrics {
@@ -116,6 +119,103 @@ func (v *validator) SubmitAggregateAndProof(ctx context.Context, slot types.Slot
 
 }
 
+// attSelectionKey identifies the selection proof of a validator at a slot.
+type attSelectionKey struct {
+	slot  types.Slot
+	index types.ValidatorIndex
+}
+
+// attSelectionProof returns the proof used to determine whether the validator aggregates at the slot.
+// In a distributed validator cluster each validator client only holds a share of the key, so the
+// slot signature is partial and gets exchanged for the cluster's combined proof.
+func (v *validator) attSelectionProof(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, index types.ValidatorIndex, slot types.Slot) ([]byte, error) {
+	if !v.distributed {
+		return v.signSlotWithSelectionProof(ctx, pubKey, slot)
+	}
+
+	v.attSelectionLock.Lock()
+	defer v.attSelectionLock.Unlock()
+	key := attSelectionKey{slot: slot, index: index}
+	if proof, ok := v.attSelections[key]; ok {
+		return proof, nil
+	}
+	partial, err := v.signSlotWithSelectionProof(ctx, pubKey, slot)
+	if err != nil {
+		return nil, err
+	}
+	if err := v.aggregateAttSelections(ctx, []iface.BeaconCommitteeSelection{{
+		SelectionProof: partial,
+		Slot:           slot,
+		ValidatorIndex: index,
+	}}); err != nil {
+		return nil, err
+	}
+	proof, ok := v.attSelections[key]
+	if !ok {
+		return nil, errors.Errorf("no aggregated selection proof for validator %d at slot %d", index, slot)
+	}
+	return proof, nil
+}
+
+// fetchAttSelections exchanges the partial selection proofs of all active duties for the combined
+// ones in a single request, and forgets the proofs of past epochs.
+func (v *validator) fetchAttSelections(ctx context.Context, res *ethpb.DutiesResponse) error {
+	v.attSelectionLock.Lock()
+	defer v.attSelectionLock.Unlock()
+
+	duties := make([]*ethpb.DutiesResponse_Duty, 0, len(res.CurrentEpochDuties)+len(res.NextEpochDuties))
+	duties = append(duties, res.CurrentEpochDuties...)
+	duties = append(duties, res.NextEpochDuties...)
+	if len(duties) == 0 {
+		return nil
+	}
+	oldestEpoch := slots.ToEpoch(duties[0].AttesterSlot)
+	for k := range v.attSelections {
+		if slots.ToEpoch(k.slot) < oldestEpoch {
+			delete(v.attSelections, k)
+		}
+	}
+
+	selections := make([]iface.BeaconCommitteeSelection, 0, len(duties))
+	for _, duty := range duties {
+		if duty.Status != ethpb.ValidatorStatus_ACTIVE && duty.Status != ethpb.ValidatorStatus_EXITING {
+			continue
+		}
+		if _, ok := v.attSelections[attSelectionKey{slot: duty.AttesterSlot, index: duty.ValidatorIndex}]; ok {
+			continue
+		}
+		partial, err := v.signSlotWithSelectionProof(ctx, bytesutil.ToBytes48(duty.PublicKey), duty.AttesterSlot)
+		if err != nil {
+			return err
+		}
+		selections = append(selections, iface.BeaconCommitteeSelection{
+			SelectionProof: partial,
+			Slot:           duty.AttesterSlot,
+			ValidatorIndex: duty.ValidatorIndex,
+		})
+	}
+	if len(selections) == 0 {
+		return nil
+	}
+	return v.aggregateAttSelections(ctx, selections)
+}
+
+// aggregateAttSelections fetches the combined selection proofs for the partial ones and caches them.
+// The caller must hold the attSelectionLock.
+func (v *validator) aggregateAttSelections(ctx context.Context, selections []iface.BeaconCommitteeSelection) error {
+	aggregated, err := v.validatorClient.GetAggregatedSelections(ctx, selections)
+	if err != nil {
+		return errors.Wrap(err, "could not get aggregated selection proofs")
+	}
+	if v.attSelections == nil {
+		v.attSelections = make(map[attSelectionKey][]byte)
+	}
+	for _, s := range aggregated {
+		v.attSelections[attSelectionKey{slot: s.Slot, index: s.ValidatorIndex}] = s.SelectionProof
+	}
+	return nil
+}
+
 // Signs input slot with domain selection proof. This is used to create the signature for aggregator selection.
 func (v *validator) signSlotWithSelectionProof(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, slot types.Slot) (signature []byte, err error) {
 	domain, err := v.domainData(ctx, slots.ToEpoch(slot), params.BeaconConfig().DomainSelectionProof[:])
@@ -155,6 +155,10 @@ func (v *validator) SubmitAttestation(ctx context.Context, slot types.Slot, pubK
 		return
 	}
 
+	if v.performance != nil {
+		v.performance.recordAttestation(pubKey, data, indexInCommittee)
+	}
+
 	if err := v.saveAttesterIndexToData(data, duty.ValidatorIndex); err != nil {
 		log.WithError(err).Error("Could not save validator index for logging")
 		if v.emitAccountMetrics {
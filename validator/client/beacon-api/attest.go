@@ -0,0 +1,99 @@
+package beacon_api
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+)
+
+// GetAttestationData asks the beacon node for the attestation data to sign for the committee at the slot.
+func (c *beaconApiValidatorClient) GetAttestationData(ctx context.Context, in *ethpb.AttestationDataRequest) (*ethpb.AttestationData, error) {
+	query := url.Values{
+		"slot":            {strconv.FormatUint(uint64(in.Slot), 10)},
+		"committee_index": {strconv.FormatUint(uint64(in.CommitteeIndex), 10)},
+	}
+	resp := &dataResponseJson{}
+	if err := c.handler.get(ctx, "/eth/v1/validator/attestation_data?"+query.Encode(), resp); err != nil {
+		return nil, errors.Wrap(err, "could not get attestation data")
+	}
+	data := &ethpb.AttestationData{}
+	if err := unmarshalSpecJSON(resp.Data, data); err != nil {
+		return nil, errors.Wrap(err, "could not decode attestation data")
+	}
+	return data, nil
+}
+
+// ProposeAttestation submits the signed attestation to the beacon node's pool.
+func (c *beaconApiValidatorClient) ProposeAttestation(ctx context.Context, in *ethpb.Attestation) (*ethpb.AttestResponse, error) {
+	root, err := in.Data.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute attestation data root")
+	}
+	body, err := marshalSpecJSON([]*ethpb.Attestation{in})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode attestation")
+	}
+	if err := c.handler.post(ctx, "/eth/v1/beacon/pool/attestations", body, nil); err != nil {
+		return nil, errors.Wrap(err, "could not submit attestation")
+	}
+	return &ethpb.AttestResponse{AttestationDataRoot: root[:]}, nil
+}
+
+// SubmitAggregateSelectionProof returns the aggregate and proof to sign for an aggregator. The aggregate is
+// the beacon node's best aggregate of the attestations to the data it would have the committee attest to.
+func (c *beaconApiValidatorClient) SubmitAggregateSelectionProof(ctx context.Context, in *ethpb.AggregateSelectionRequest) (*ethpb.AggregateSelectionResponse, error) {
+	index, err := c.ValidatorIndex(ctx, &ethpb.ValidatorIndexRequest{PublicKey: in.PublicKey})
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.GetAttestationData(ctx, &ethpb.AttestationDataRequest{Slot: in.Slot, CommitteeIndex: in.CommitteeIndex})
+	if err != nil {
+		return nil, err
+	}
+	root, err := data.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute attestation data root")
+	}
+	query := url.Values{
+		"attestation_data_root": {hexutil.Encode(root[:])},
+		"slot":                  {strconv.FormatUint(uint64(in.Slot), 10)},
+	}
+	resp := &dataResponseJson{}
+	if err := c.handler.get(ctx, "/eth/v1/validator/aggregate_attestation?"+query.Encode(), resp); err != nil {
+		return nil, errors.Wrap(err, "could not get aggregate attestation")
+	}
+	aggregate := &ethpb.Attestation{}
+	if err := unmarshalSpecJSON(resp.Data, aggregate); err != nil {
+		return nil, errors.Wrap(err, "could not decode aggregate attestation")
+	}
+	return &ethpb.AggregateSelectionResponse{
+		AggregateAndProof: &ethpb.AggregateAttestationAndProof{
+			AggregatorIndex: index.Index,
+			Aggregate:       aggregate,
+			SelectionProof:  in.SlotSignature,
+		},
+	}, nil
+}
+
+// SubmitSignedAggregateSelectionProof publishes the signed aggregate and proof through the beacon node.
+func (c *beaconApiValidatorClient) SubmitSignedAggregateSelectionProof(ctx context.Context, in *ethpb.SignedAggregateSubmitRequest) (*ethpb.SignedAggregateSubmitResponse, error) {
+	if in.SignedAggregateAndProof == nil || in.SignedAggregateAndProof.Message == nil || in.SignedAggregateAndProof.Message.Aggregate == nil {
+		return nil, errors.New("signed aggregate request can't be nil")
+	}
+	root, err := in.SignedAggregateAndProof.Message.Aggregate.Data.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute attestation data root")
+	}
+	body, err := marshalSpecJSON([]*ethpb.SignedAggregateAttestationAndProof{in.SignedAggregateAndProof})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode aggregate and proof")
+	}
+	if err := c.handler.post(ctx, "/eth/v1/validator/aggregate_and_proofs", body, nil); err != nil {
+		return nil, errors.Wrap(err, "could not submit aggregate and proof")
+	}
+	return &ethpb.SignedAggregateSubmitResponse{AttestationDataRoot: root[:]}, nil
+}
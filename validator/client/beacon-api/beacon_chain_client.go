@@ -0,0 +1,106 @@
+package beacon_api
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	"github.com/prysmaticlabs/prysm/validator/client/iface"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type beaconApiBeaconChainClient struct {
+	handler *jsonRestHandler
+}
+
+// NewBeaconApiBeaconChainClient returns a beacon chain client talking to a beacon node over the
+// standard Beacon REST API.
+func NewBeaconApiBeaconChainClient(host string, timeout time.Duration) (iface.BeaconChainClient, error) {
+	handler, err := newJsonRestHandler(host, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return &beaconApiBeaconChainClient{handler: handler}, nil
+}
+
+// GetChainHead returns the head block and the checkpoints of the head state.
+func (c *beaconApiBeaconChainClient) GetChainHead(ctx context.Context, _ *emptypb.Empty) (*ethpb.ChainHead, error) {
+	header, err := getHeadHeader(ctx, c.handler)
+	if err != nil {
+		return nil, err
+	}
+	resp := &finalityCheckpointsResponseJson{}
+	if err := c.handler.get(ctx, "/eth/v1/beacon/states/head/finality_checkpoints", resp); err != nil {
+		return nil, errors.Wrap(err, "could not get finality checkpoints")
+	}
+	if resp.Data == nil {
+		return nil, errors.New("finality checkpoints response has no data")
+	}
+	head := &ethpb.ChainHead{
+		HeadSlot:         header.slot,
+		HeadEpoch:        slots.ToEpoch(header.slot),
+		HeadBlockRoot:    header.root,
+		OptimisticStatus: header.optimistic,
+	}
+	for _, cp := range []struct {
+		json  *checkpointJson
+		epoch *types.Epoch
+		slot  *types.Slot
+		root  *[]byte
+	}{
+		{resp.Data.Finalized, &head.FinalizedEpoch, &head.FinalizedSlot, &head.FinalizedBlockRoot},
+		{resp.Data.CurrentJustified, &head.JustifiedEpoch, &head.JustifiedSlot, &head.JustifiedBlockRoot},
+		{resp.Data.PreviousJustified, &head.PreviousJustifiedEpoch, &head.PreviousJustifiedSlot, &head.PreviousJustifiedBlockRoot},
+	} {
+		if cp.json == nil {
+			return nil, errors.New("finality checkpoints response is missing a checkpoint")
+		}
+		epoch, err := strconv.ParseUint(cp.json.Epoch, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid checkpoint epoch %s", cp.json.Epoch)
+		}
+		if *cp.root, err = hexutil.Decode(cp.json.Root); err != nil {
+			return nil, errors.Wrapf(err, "invalid checkpoint root %s", cp.json.Root)
+		}
+		*cp.epoch = types.Epoch(epoch)
+		if *cp.slot, err = slots.EpochStart(*cp.epoch); err != nil {
+			return nil, err
+		}
+	}
+	return head, nil
+}
+
+// GetValidatorPerformance has no equivalent in the standard API.
+func (c *beaconApiBeaconChainClient) GetValidatorPerformance(_ context.Context, _ *ethpb.ValidatorPerformanceRequest) (*ethpb.ValidatorPerformanceResponse, error) {
+	return nil, iface.ErrNotSupported
+}
+
+type headHeader struct {
+	slot       types.Slot
+	root       []byte
+	optimistic bool
+}
+
+func getHeadHeader(ctx context.Context, handler *jsonRestHandler) (*headHeader, error) {
+	resp := &blockHeaderResponseJson{}
+	if err := handler.get(ctx, "/eth/v1/beacon/headers/head", resp); err != nil {
+		return nil, errors.Wrap(err, "could not get head block header")
+	}
+	if resp.Data == nil || resp.Data.Header == nil || resp.Data.Header.Message == nil {
+		return nil, errors.New("block header response has no data")
+	}
+	slot, err := strconv.ParseUint(resp.Data.Header.Message.Slot, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid head slot %s", resp.Data.Header.Message.Slot)
+	}
+	root, err := hexutil.Decode(resp.Data.Root)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid head root %s", resp.Data.Root)
+	}
+	return &headHeader{slot: types.Slot(slot), root: root, optimistic: resp.ExecutionOptimistic}, nil
+}
@@ -0,0 +1,107 @@
+package beacon_api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	"github.com/prysmaticlabs/prysm/validator/client/iface"
+)
+
+// CheckDoppelGanger reports the validators which were live in either of the two previous epochs,
+// according to the liveness endpoint of the beacon node. As with Prysm's beacon node, validators
+// which signed something within the last two epochs themselves can't be checked and are reported
+// as having no duplicate, while validators missing from the state are left out.
+func (c *beaconApiValidatorClient) CheckDoppelGanger(ctx context.Context, in *ethpb.DoppelGangerRequest) (*ethpb.DoppelGangerResponse, error) {
+	resp := &ethpb.DoppelGangerResponse{Responses: []*ethpb.DoppelGangerResponse_ValidatorResponse{}}
+	if len(in.ValidatorRequests) == 0 {
+		return resp, nil
+	}
+	header, err := getHeadHeader(ctx, c.handler)
+	if err != nil {
+		return nil, err
+	}
+	currentEpoch := slots.ToEpoch(header.slot)
+	if currentEpoch < params.BeaconConfig().AltairForkEpoch || currentEpoch < 2 {
+		log.Info("Skipping doppelganger check for Phase 0")
+		for _, v := range in.ValidatorRequests {
+			resp.Responses = append(resp.Responses, &ethpb.DoppelGangerResponse_ValidatorResponse{PublicKey: v.PublicKey})
+		}
+		return resp, nil
+	}
+
+	var toCheck [][]byte
+	for _, v := range in.ValidatorRequests {
+		if v.Epoch+2 < currentEpoch {
+			toCheck = append(toCheck, v.PublicKey)
+		}
+	}
+	validators, err := getValidators(ctx, c.handler, toCheck, nil)
+	if err != nil {
+		return nil, err
+	}
+	byPubkey := make(map[[fieldparams.BLSPubkeyLength]byte]types.ValidatorIndex, len(validators))
+	indices := make([]types.ValidatorIndex, len(validators))
+	for i, v := range validators {
+		byPubkey[v.publicKey] = v.index
+		indices[i] = v.index
+	}
+	live := make(map[types.ValidatorIndex]bool)
+	if len(indices) > 0 {
+		for _, epoch := range []types.Epoch{currentEpoch - 2, currentEpoch - 1} {
+			liveness, err := c.GetValidatorsLiveness(ctx, epoch, indices)
+			if err != nil {
+				return nil, err
+			}
+			for _, l := range liveness {
+				if l.IsLive {
+					live[l.Index] = true
+				}
+			}
+		}
+	}
+
+	for _, v := range in.ValidatorRequests {
+		if v.Epoch+2 >= currentEpoch {
+			resp.Responses = append(resp.Responses, &ethpb.DoppelGangerResponse_ValidatorResponse{PublicKey: v.PublicKey})
+			continue
+		}
+		idx, ok := byPubkey[bytesutil.ToBytes48(v.PublicKey)]
+		if !ok {
+			continue
+		}
+		if live[idx] {
+			log.WithField("validatorIndex", idx).Info("Validator found live")
+		}
+		resp.Responses = append(resp.Responses, &ethpb.DoppelGangerResponse_ValidatorResponse{
+			PublicKey:       v.PublicKey,
+			DuplicateExists: live[idx],
+		})
+	}
+	return resp, nil
+}
+
+// GetValidatorsLiveness reports whether the validators were seen performing their duties at the epoch,
+// according to the liveness endpoint of the beacon node.
+func (c *beaconApiValidatorClient) GetValidatorsLiveness(ctx context.Context, epoch types.Epoch, indices []types.ValidatorIndex) ([]iface.ValidatorLiveness, error) {
+	resp := &livenessResponseJson{}
+	if err := c.handler.post(ctx, fmt.Sprintf("/eth/v1/validator/liveness/%d", epoch), indicesToStrings(indices), resp); err != nil {
+		return nil, errors.Wrap(err, "could not get validator liveness")
+	}
+	liveness := make([]iface.ValidatorLiveness, len(resp.Data))
+	for i, l := range resp.Data {
+		idx, err := strconv.ParseUint(l.Index, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid validator index %s", l.Index)
+		}
+		liveness[i] = iface.ValidatorLiveness{Index: types.ValidatorIndex(idx), IsLive: l.IsLive}
+	}
+	return liveness, nil
+}
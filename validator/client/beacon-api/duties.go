@@ -0,0 +1,271 @@
+package beacon_api
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type attesterDuty struct {
+	slot             types.Slot
+	committeeIndex   types.CommitteeIndex
+	committeesAtSlot uint64
+}
+
+type committeeKey struct {
+	slot  types.Slot
+	index types.CommitteeIndex
+}
+
+// GetDuties assembles the duties of the requested and the following epoch from the attester, proposer
+// and sync committee duties of the standard API. Proposer duties are only known for the requested epoch.
+func (c *beaconApiValidatorClient) GetDuties(ctx context.Context, in *ethpb.DutiesRequest) (*ethpb.DutiesResponse, error) {
+	validators, err := getValidators(ctx, c.handler, in.PublicKeys, nil)
+	if err != nil {
+		return nil, err
+	}
+	byPubkey := make(map[[fieldparams.BLSPubkeyLength]byte]*validator, len(validators))
+	indices := make([]types.ValidatorIndex, len(validators))
+	for i, v := range validators {
+		byPubkey[v.publicKey] = v
+		indices[i] = v.index
+	}
+
+	current, err := c.epochDuties(ctx, in.Epoch, in.PublicKeys, byPubkey, indices, true /* proposals */)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get duties of epoch %d", in.Epoch)
+	}
+	next, err := c.epochDuties(ctx, in.Epoch+1, in.PublicKeys, byPubkey, indices, false /* proposals */)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get duties of epoch %d", in.Epoch+1)
+	}
+	return &ethpb.DutiesResponse{
+		Duties:             current,
+		CurrentEpochDuties: current,
+		NextEpochDuties:    next,
+	}, nil
+}
+
+func (c *beaconApiValidatorClient) epochDuties(
+	ctx context.Context,
+	epoch types.Epoch,
+	pubkeys [][]byte,
+	byPubkey map[[fieldparams.BLSPubkeyLength]byte]*validator,
+	indices []types.ValidatorIndex,
+	withProposals bool,
+) ([]*ethpb.DutiesResponse_Duty, error) {
+	attesterDuties, err := c.attesterDuties(ctx, epoch, indices)
+	if err != nil {
+		return nil, err
+	}
+	var committees map[committeeKey][]types.ValidatorIndex
+	if len(attesterDuties) > 0 {
+		if committees, err = c.committees(ctx, epoch); err != nil {
+			return nil, err
+		}
+	}
+	proposerSlots := make(map[types.ValidatorIndex][]types.Slot)
+	if withProposals {
+		if proposerSlots, err = c.proposerDuties(ctx, epoch); err != nil {
+			return nil, err
+		}
+	}
+	syncCommittee := make(map[types.ValidatorIndex][]uint64)
+	if epoch >= params.BeaconConfig().AltairForkEpoch {
+		if syncCommittee, err = c.syncCommitteeDuties(ctx, epoch, indices); err != nil {
+			return nil, err
+		}
+	}
+
+	duties := make([]*ethpb.DutiesResponse_Duty, 0, len(pubkeys))
+	for _, pubkey := range pubkeys {
+		duty := &ethpb.DutiesResponse_Duty{
+			PublicKey: pubkey,
+			Status:    ethpb.ValidatorStatus_UNKNOWN_STATUS,
+		}
+		if v, ok := byPubkey[bytesutil.ToBytes48(pubkey)]; ok {
+			duty.ValidatorIndex = v.index
+			duty.Status = v.status
+			if attesterDuty, ok := attesterDuties[v.index]; ok {
+				duty.AttesterSlot = attesterDuty.slot
+				duty.CommitteeIndex = attesterDuty.committeeIndex
+				duty.Committee = committees[committeeKey{attesterDuty.slot, attesterDuty.committeeIndex}]
+			}
+			duty.ProposerSlots = proposerSlots[v.index]
+			_, duty.IsSyncCommittee = syncCommittee[v.index]
+		}
+		duties = append(duties, duty)
+	}
+	return duties, nil
+}
+
+// SubscribeCommitteeSubnets subscribes the beacon node to the subnets of the given committees. The
+// number of committees at the slots, which the standard API requires, is looked up in the attester duties.
+func (c *beaconApiValidatorClient) SubscribeCommitteeSubnets(ctx context.Context, in *ethpb.CommitteeSubnetsSubscribeRequest, validatorIndices []types.ValidatorIndex) (*emptypb.Empty, error) {
+	if len(in.CommitteeIds) != len(in.Slots) || len(in.IsAggregator) != len(in.Slots) || len(validatorIndices) != len(in.Slots) {
+		return nil, errors.New("subscription request slots, committee ids, aggregator flags and validator indices must have the same length")
+	}
+	epochIndices := make(map[types.Epoch][]types.ValidatorIndex)
+	for i, slot := range in.Slots {
+		epoch := slots.ToEpoch(slot)
+		epochIndices[epoch] = append(epochIndices[epoch], validatorIndices[i])
+	}
+	committeesAtSlot := make(map[types.Slot]uint64)
+	for epoch, indices := range epochIndices {
+		duties, err := c.attesterDuties(ctx, epoch, indices)
+		if err != nil {
+			return nil, err
+		}
+		for _, duty := range duties {
+			committeesAtSlot[duty.slot] = duty.committeesAtSlot
+		}
+	}
+
+	subscriptions := make([]*beaconCommitteeSubscribeJson, len(in.Slots))
+	for i, slot := range in.Slots {
+		count, ok := committeesAtSlot[slot]
+		if !ok {
+			return nil, errors.Errorf("no attester duty of validator %d at slot %d", validatorIndices[i], slot)
+		}
+		subscriptions[i] = &beaconCommitteeSubscribeJson{
+			ValidatorIndex:   strconv.FormatUint(uint64(validatorIndices[i]), 10),
+			CommitteeIndex:   strconv.FormatUint(uint64(in.CommitteeIds[i]), 10),
+			CommitteesAtSlot: strconv.FormatUint(count, 10),
+			Slot:             strconv.FormatUint(uint64(slot), 10),
+			IsAggregator:     in.IsAggregator[i],
+		}
+	}
+	if err := c.handler.post(ctx, "/eth/v1/validator/beacon_committee_subscriptions", subscriptions, nil); err != nil {
+		return nil, errors.Wrap(err, "could not subscribe to committee subnets")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (c *beaconApiValidatorClient) attesterDuties(ctx context.Context, epoch types.Epoch, indices []types.ValidatorIndex) (map[types.ValidatorIndex]*attesterDuty, error) {
+	duties := make(map[types.ValidatorIndex]*attesterDuty)
+	if len(indices) == 0 {
+		return duties, nil
+	}
+	resp := &attesterDutiesResponseJson{}
+	if err := c.handler.post(ctx, fmt.Sprintf("/eth/v1/validator/duties/attester/%d", epoch), indicesToStrings(indices), resp); err != nil {
+		return nil, errors.Wrap(err, "could not get attester duties")
+	}
+	for _, d := range resp.Data {
+		idx, err := strconv.ParseUint(d.ValidatorIndex, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid validator index %s", d.ValidatorIndex)
+		}
+		slot, err := strconv.ParseUint(d.Slot, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid attester slot %s", d.Slot)
+		}
+		committeeIndex, err := strconv.ParseUint(d.CommitteeIndex, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid committee index %s", d.CommitteeIndex)
+		}
+		committeesAtSlot, err := strconv.ParseUint(d.CommitteesAtSlot, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid committees at slot %s", d.CommitteesAtSlot)
+		}
+		duties[types.ValidatorIndex(idx)] = &attesterDuty{
+			slot:             types.Slot(slot),
+			committeeIndex:   types.CommitteeIndex(committeeIndex),
+			committeesAtSlot: committeesAtSlot,
+		}
+	}
+	return duties, nil
+}
+
+func (c *beaconApiValidatorClient) committees(ctx context.Context, epoch types.Epoch) (map[committeeKey][]types.ValidatorIndex, error) {
+	resp := &stateCommitteesResponseJson{}
+	query := url.Values{"epoch": {strconv.FormatUint(uint64(epoch), 10)}}
+	if err := c.handler.get(ctx, "/eth/v1/beacon/states/head/committees?"+query.Encode(), resp); err != nil {
+		return nil, errors.Wrap(err, "could not get committees")
+	}
+	committees := make(map[committeeKey][]types.ValidatorIndex, len(resp.Data))
+	for _, committee := range resp.Data {
+		slot, err := strconv.ParseUint(committee.Slot, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid committee slot %s", committee.Slot)
+		}
+		index, err := strconv.ParseUint(committee.Index, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid committee index %s", committee.Index)
+		}
+		members := make([]types.ValidatorIndex, len(committee.Validators))
+		for i, member := range committee.Validators {
+			idx, err := strconv.ParseUint(member, 10, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid committee member %s", member)
+			}
+			members[i] = types.ValidatorIndex(idx)
+		}
+		committees[committeeKey{types.Slot(slot), types.CommitteeIndex(index)}] = members
+	}
+	return committees, nil
+}
+
+func (c *beaconApiValidatorClient) proposerDuties(ctx context.Context, epoch types.Epoch) (map[types.ValidatorIndex][]types.Slot, error) {
+	resp := &proposerDutiesResponseJson{}
+	if err := c.handler.get(ctx, fmt.Sprintf("/eth/v1/validator/duties/proposer/%d", epoch), resp); err != nil {
+		return nil, errors.Wrap(err, "could not get proposer duties")
+	}
+	proposerSlots := make(map[types.ValidatorIndex][]types.Slot)
+	for _, d := range resp.Data {
+		idx, err := strconv.ParseUint(d.ValidatorIndex, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid validator index %s", d.ValidatorIndex)
+		}
+		slot, err := strconv.ParseUint(d.Slot, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid proposer slot %s", d.Slot)
+		}
+		proposerSlots[types.ValidatorIndex(idx)] = append(proposerSlots[types.ValidatorIndex(idx)], types.Slot(slot))
+	}
+	return proposerSlots, nil
+}
+
+// syncCommitteeDuties returns the positions in the sync committee of the given validators
+// which are part of it at the epoch.
+func (c *beaconApiValidatorClient) syncCommitteeDuties(ctx context.Context, epoch types.Epoch, indices []types.ValidatorIndex) (map[types.ValidatorIndex][]uint64, error) {
+	positions := make(map[types.ValidatorIndex][]uint64)
+	if len(indices) == 0 {
+		return positions, nil
+	}
+	resp := &syncCommitteeDutiesResponseJson{}
+	if err := c.handler.post(ctx, fmt.Sprintf("/eth/v1/validator/duties/sync/%d", epoch), indicesToStrings(indices), resp); err != nil {
+		return nil, errors.Wrap(err, "could not get sync committee duties")
+	}
+	for _, d := range resp.Data {
+		idx, err := strconv.ParseUint(d.ValidatorIndex, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid validator index %s", d.ValidatorIndex)
+		}
+		for _, p := range d.ValidatorSyncCommitteeIndices {
+			position, err := strconv.ParseUint(p, 10, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid sync committee index %s", p)
+			}
+			positions[types.ValidatorIndex(idx)] = append(positions[types.ValidatorIndex(idx)], position)
+		}
+	}
+	return positions, nil
+}
+
+func indicesToStrings(indices []types.ValidatorIndex) []string {
+	s := make([]string, len(indices))
+	for i, idx := range indices {
+		s[i] = strconv.FormatUint(uint64(idx), 10)
+	}
+	return s
+}
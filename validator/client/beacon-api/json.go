@@ -0,0 +1,202 @@
+package beacon_api
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+)
+
+// specFieldNames maps the proto fields whose names differ from the consensus specs
+// to their spec names, keyed by "<message type>.<field>".
+var specFieldNames = map[string]string{
+	"AttestationData.CommitteeIndex":          "index",
+	"SyncCommitteeMessage.BlockRoot":          "beacon_block_root",
+	"SyncCommitteeContribution.BlockRoot":     "beacon_block_root",
+	"SignedBeaconBlock.Block":                 "message",
+	"SignedBeaconBlockAltair.Block":           "message",
+	"SignedBeaconBlockBellatrix.Block":        "message",
+	"SignedBlindedBeaconBlockBellatrix.Block": "message",
+	"SignedBeaconBlockHeader.Header":          "message",
+	"SignedVoluntaryExit.Exit":                "message",
+}
+
+// uint256LEFields are the fields holding little endian uint256 values, which the
+// standard API represents as decimal strings.
+var uint256LEFields = map[string]bool{
+	"ExecutionPayload.BaseFeePerGas":       true,
+	"ExecutionPayloadHeader.BaseFeePerGas": true,
+}
+
+// marshalSpecJSON encodes a consensus proto message, or a list of them, into the JSON representation
+// of the standard Beacon API: integers as decimal strings, byte arrays and bitfields as 0x-prefixed
+// hex strings and fields named as in the consensus specs.
+func marshalSpecJSON(msg interface{}) (json.RawMessage, error) {
+	v, err := toSpecJSON(reflect.ValueOf(msg), false)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// unmarshalSpecJSON decodes the standard Beacon API JSON representation of a consensus
+// object into the given proto message.
+func unmarshalSpecJSON(data []byte, msg interface{}) error {
+	v := reflect.ValueOf(msg)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return errors.New("can only decode into a non-nil pointer")
+	}
+	return fromSpecJSON(data, v.Elem(), false)
+}
+
+func toSpecJSON(v reflect.Value, uint256LE bool) (interface{}, error) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return toSpecJSON(reflect.New(v.Type().Elem()), false)
+		}
+		return toSpecJSON(v.Elem(), false)
+	case reflect.Struct:
+		obj := make(map[string]interface{})
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			name, ok := specFieldName(v.Type(), field)
+			if !ok {
+				continue
+			}
+			value, err := toSpecJSON(v.Field(i), uint256LEFields[v.Type().Name()+"."+field.Name])
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not encode %s", name)
+			}
+			obj[name] = value
+		}
+		return obj, nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			if uint256LE {
+				return new(big.Int).SetBytes(bytesutil.ReverseByteOrder(v.Bytes())).String(), nil
+			}
+			return hexutil.Encode(v.Bytes()), nil
+		}
+		list := make([]interface{}, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			item, err := toSpecJSON(v.Index(i), false)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = item
+		}
+		return list, nil
+	case reflect.Uint64, reflect.Uint32:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Bool:
+		return v.Bool(), nil
+	default:
+		return nil, errors.Errorf("unsupported type %s", v.Type())
+	}
+}
+
+func fromSpecJSON(data []byte, v reflect.Value, uint256LE bool) error {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return fromSpecJSON(data, v.Elem(), false)
+	case reflect.Struct:
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return err
+		}
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			name, ok := specFieldName(v.Type(), field)
+			if !ok {
+				continue
+			}
+			raw, ok := obj[name]
+			if !ok {
+				return errors.Errorf("missing field %s", name)
+			}
+			if err := fromSpecJSON(raw, v.Field(i), uint256LEFields[v.Type().Name()+"."+field.Name]); err != nil {
+				return errors.Wrapf(err, "could not decode %s", name)
+			}
+		}
+		return nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			var s string
+			if err := json.Unmarshal(data, &s); err != nil {
+				return err
+			}
+			b, err := decodeSpecBytes(s, uint256LE)
+			if err != nil {
+				return err
+			}
+			v.SetBytes(b)
+			return nil
+		}
+		var list []json.RawMessage
+		if err := json.Unmarshal(data, &list); err != nil {
+			return err
+		}
+		v.Set(reflect.MakeSlice(v.Type(), len(list), len(list)))
+		for i, raw := range list {
+			if err := fromSpecJSON(raw, v.Index(i), false); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Uint64, reflect.Uint32:
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+		return nil
+	case reflect.Bool:
+		var b bool
+		if err := json.Unmarshal(data, &b); err != nil {
+			return err
+		}
+		v.SetBool(b)
+		return nil
+	default:
+		return errors.Errorf("unsupported type %s", v.Type())
+	}
+}
+
+func decodeSpecBytes(s string, uint256LE bool) ([]byte, error) {
+	if !uint256LE {
+		return hexutil.Decode(s)
+	}
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok || n.Sign() < 0 || n.BitLen() > 256 {
+		return nil, errors.Errorf("invalid uint256 %q", s)
+	}
+	return bytesutil.PadTo(bytesutil.ReverseByteOrder(n.Bytes()), 32), nil
+}
+
+// specFieldName returns the spec name of a proto message field, skipping the
+// fields which are internal to the protobuf implementation.
+func specFieldName(t reflect.Type, field reflect.StructField) (string, bool) {
+	if field.PkgPath != "" || field.Tag.Get("protobuf") == "" {
+		return "", false
+	}
+	if name, ok := specFieldNames[t.Name()+"."+field.Name]; ok {
+		return name, true
+	}
+	if name := field.Tag.Get("spec-name"); name != "" {
+		return name, true
+	}
+	return strings.Split(field.Tag.Get("json"), ",")[0], true
+}
@@ -0,0 +1,130 @@
+package beacon_api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrNotOK is returned when the beacon node answered a request with a non-2xx response code.
+var ErrNotOK = errors.New("did not receive 2xx response from API")
+
+// ErrNotFound is returned when the beacon node answered a request with a 404 response code.
+var ErrNotFound = errors.Wrap(ErrNotOK, "recv 404 NotFound response from API")
+
+// apiError is the error body the standard API responds with.
+type apiError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// jsonRestHandler performs JSON requests against a beacon node's standard REST API.
+type jsonRestHandler struct {
+	httpClient *http.Client
+	host       string
+}
+
+func newJsonRestHandler(host string, timeout time.Duration) (*jsonRestHandler, error) {
+	u, err := url.ParseRequestURI(host)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid beacon node REST API url")
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("beacon node REST API url must be in the format of http(s)://host:port, got %s", host)
+	}
+	return &jsonRestHandler{
+		httpClient: &http.Client{Timeout: timeout},
+		host:       strings.TrimSuffix(host, "/"),
+	}, nil
+}
+
+// get sends a GET request to the endpoint and decodes the JSON response into resp.
+func (c *jsonRestHandler) get(ctx context.Context, endpoint string, resp interface{}) error {
+	return c.do(ctx, http.MethodGet, endpoint, nil, resp)
+}
+
+// post sends the JSON encoded body to the endpoint and decodes the JSON response into resp, if not nil.
+func (c *jsonRestHandler) post(ctx context.Context, endpoint string, body interface{}, resp interface{}) error {
+	var encoded []byte
+	var err error
+	if raw, ok := body.(json.RawMessage); ok {
+		encoded = raw
+	} else if encoded, err = json.Marshal(body); err != nil {
+		return errors.Wrapf(err, "could not encode request to %s", endpoint)
+	}
+	return c.do(ctx, http.MethodPost, endpoint, bytes.NewReader(encoded), resp)
+}
+
+func (c *jsonRestHandler) do(ctx context.Context, method, endpoint string, body io.Reader, resp interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, c.host+endpoint, body)
+	if err != nil {
+		return errors.Wrapf(err, "could not create request to %s", endpoint)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	httpResp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "could not send request to %s", endpoint)
+	}
+	defer func() {
+		if err := httpResp.Body.Close(); err != nil {
+			log.WithError(err).Error("Could not close response body")
+		}
+	}()
+	if err := checkResponse(httpResp, endpoint); err != nil {
+		return err
+	}
+	if resp == nil {
+		return nil
+	}
+	if err := json.NewDecoder(httpResp.Body).Decode(resp); err != nil {
+		return errors.Wrapf(err, "could not decode response from %s", endpoint)
+	}
+	return nil
+}
+
+// stream opens a GET request to an endpoint which keeps the response open, such as the event stream.
+// The caller is responsible for closing the returned body.
+func (c *jsonRestHandler) stream(ctx context.Context, endpoint string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.host+endpoint, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not create request to %s", endpoint)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	// Streams stay open for as long as the context allows, the client wide timeout must not apply.
+	httpResp, err := (&http.Client{Transport: c.httpClient.Transport}).Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not send request to %s", endpoint)
+	}
+	if err := checkResponse(httpResp, endpoint); err != nil {
+		_ = httpResp.Body.Close()
+		return nil, err
+	}
+	return httpResp.Body, nil
+}
+
+func checkResponse(resp *http.Response, endpoint string) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	apiErr := &apiError{}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil || json.Unmarshal(body, apiErr) != nil || apiErr.Message == "" {
+		apiErr.Message = strings.TrimSpace(string(body))
+	}
+	baseErr := ErrNotOK
+	if resp.StatusCode == http.StatusNotFound {
+		baseErr = ErrNotFound
+	}
+	return errors.Wrapf(baseErr, "%s responded with %d: %s", endpoint, resp.StatusCode, apiErr.Message)
+}
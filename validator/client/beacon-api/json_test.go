@@ -0,0 +1,121 @@
+package beacon_api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	enginev1 "github.com/prysmaticlabs/prysm/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestMarshalSpecJSON_Attestation(t *testing.T) {
+	att := &ethpb.Attestation{
+		AggregationBits: bitfield.Bitlist{0b1101},
+		Data: &ethpb.AttestationData{
+			Slot:            3,
+			CommitteeIndex:  2,
+			BeaconBlockRoot: bytesutil.PadTo([]byte{0xaa}, 32),
+			Source:          &ethpb.Checkpoint{Epoch: 1, Root: bytesutil.PadTo([]byte{0xbb}, 32)},
+			Target:          &ethpb.Checkpoint{Epoch: 18446744073709551615, Root: bytesutil.PadTo([]byte{0xcc}, 32)},
+		},
+		Signature: bytesutil.PadTo([]byte{0xdd}, 96),
+	}
+	encoded, err := marshalSpecJSON(att)
+	require.NoError(t, err)
+
+	decoded := make(map[string]interface{})
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	assert.Equal(t, "0x0d", decoded["aggregation_bits"])
+	data, ok := decoded["data"].(map[string]interface{})
+	require.Equal(t, true, ok)
+	assert.Equal(t, "3", data["slot"])
+	assert.Equal(t, "2", data["index"])
+	_, ok = data["committee_index"]
+	assert.Equal(t, false, ok, "Expected proto field name to be replaced by the spec name")
+	target, ok := data["target"].(map[string]interface{})
+	require.Equal(t, true, ok)
+	assert.Equal(t, "18446744073709551615", target["epoch"])
+
+	roundTrip := &ethpb.Attestation{}
+	require.NoError(t, unmarshalSpecJSON(encoded, roundTrip))
+	assert.DeepEqual(t, att, roundTrip)
+}
+
+func TestMarshalSpecJSON_List(t *testing.T) {
+	exits := []*ethpb.SignedVoluntaryExit{
+		{Exit: &ethpb.VoluntaryExit{Epoch: 1, ValidatorIndex: 2}, Signature: make([]byte, 96)},
+		{Exit: &ethpb.VoluntaryExit{Epoch: 3, ValidatorIndex: 4}, Signature: make([]byte, 96)},
+	}
+	encoded, err := marshalSpecJSON(exits)
+	require.NoError(t, err)
+	var decoded []struct {
+		Message map[string]string `json:"message"`
+	}
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	require.Equal(t, 2, len(decoded))
+	assert.Equal(t, "3", decoded[1].Message["epoch"])
+	assert.Equal(t, "4", decoded[1].Message["validator_index"])
+}
+
+func TestMarshalSpecJSON_SignedBlockMessage(t *testing.T) {
+	blk := &ethpb.SignedBeaconBlock{
+		Block: &ethpb.BeaconBlock{
+			Slot:       5,
+			ParentRoot: make([]byte, 32),
+			StateRoot:  make([]byte, 32),
+			Body: &ethpb.BeaconBlockBody{
+				RandaoReveal: make([]byte, 96),
+				Eth1Data:     &ethpb.Eth1Data{DepositRoot: make([]byte, 32), BlockHash: make([]byte, 32)},
+				Graffiti:     make([]byte, 32),
+			},
+		},
+		Signature: make([]byte, 96),
+	}
+	encoded, err := marshalSpecJSON(blk)
+	require.NoError(t, err)
+	decoded := make(map[string]json.RawMessage)
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	_, ok := decoded["message"]
+	assert.Equal(t, true, ok, "Expected the block to be encoded as message")
+
+	roundTrip := &ethpb.SignedBeaconBlock{}
+	require.NoError(t, unmarshalSpecJSON(encoded, roundTrip))
+	assert.Equal(t, blk.Block.Slot, roundTrip.Block.Slot)
+	assert.DeepEqual(t, blk.Block.Body.Graffiti, roundTrip.Block.Body.Graffiti)
+}
+
+func TestMarshalSpecJSON_BaseFeePerGas(t *testing.T) {
+	// 1000 as a little endian uint256.
+	baseFee := bytesutil.PadTo([]byte{0xe8, 0x03}, 32)
+	payload := &enginev1.ExecutionPayload{
+		ParentHash:    make([]byte, 32),
+		FeeRecipient:  make([]byte, 20),
+		StateRoot:     make([]byte, 32),
+		ReceiptsRoot:  make([]byte, 32),
+		LogsBloom:     make([]byte, 256),
+		PrevRandao:    make([]byte, 32),
+		BaseFeePerGas: baseFee,
+		BlockHash:     make([]byte, 32),
+		Transactions:  [][]byte{{0x01, 0x02}},
+	}
+	encoded, err := marshalSpecJSON(payload)
+	require.NoError(t, err)
+	decoded := make(map[string]interface{})
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	assert.Equal(t, "1000", decoded["base_fee_per_gas"])
+	assert.DeepEqual(t, []interface{}{"0x0102"}, decoded["transactions"])
+
+	roundTrip := &enginev1.ExecutionPayload{}
+	require.NoError(t, unmarshalSpecJSON(encoded, roundTrip))
+	assert.DeepEqual(t, baseFee, roundTrip.BaseFeePerGas)
+}
+
+func TestUnmarshalSpecJSON_Errors(t *testing.T) {
+	assert.ErrorContains(t, "non-nil pointer", unmarshalSpecJSON([]byte("{}"), ethpb.Checkpoint{}))
+	assert.NotNil(t, unmarshalSpecJSON([]byte(`{"epoch":"foo"}`), &ethpb.Checkpoint{}))
+	assert.NotNil(t, unmarshalSpecJSON([]byte(`{"root":"not hex"}`), &ethpb.Checkpoint{}))
+}
@@ -0,0 +1,101 @@
+package beacon_api
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/validator/client/iface"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type beaconApiNodeClient struct {
+	handler *jsonRestHandler
+}
+
+// NewBeaconApiNodeClient returns a node client talking to a beacon node over the standard Beacon REST API.
+func NewBeaconApiNodeClient(host string, timeout time.Duration) (iface.NodeClient, error) {
+	handler, err := newJsonRestHandler(host, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return &beaconApiNodeClient{handler: handler}, nil
+}
+
+func (c *beaconApiNodeClient) GetSyncStatus(ctx context.Context, _ *emptypb.Empty) (*ethpb.SyncStatus, error) {
+	resp := &syncingResponseJson{}
+	if err := c.handler.get(ctx, "/eth/v1/node/syncing", resp); err != nil {
+		return nil, errors.Wrap(err, "could not get sync status")
+	}
+	if resp.Data == nil {
+		return nil, errors.New("sync status response has no data")
+	}
+	return &ethpb.SyncStatus{Syncing: resp.Data.IsSyncing}, nil
+}
+
+func (c *beaconApiNodeClient) GetGenesis(ctx context.Context, _ *emptypb.Empty) (*ethpb.Genesis, error) {
+	genesis := &genesisResponseJson{}
+	if err := c.handler.get(ctx, "/eth/v1/beacon/genesis", genesis); err != nil {
+		return nil, errors.Wrap(err, "could not get genesis")
+	}
+	if genesis.Data == nil {
+		return nil, errors.New("genesis response has no data")
+	}
+	genesisTime, err := strconv.ParseInt(genesis.Data.GenesisTime, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid genesis time %s", genesis.Data.GenesisTime)
+	}
+	root, err := hexutil.Decode(genesis.Data.GenesisValidatorsRoot)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid genesis validators root %s", genesis.Data.GenesisValidatorsRoot)
+	}
+	depositContract := &depositContractResponseJson{}
+	if err := c.handler.get(ctx, "/eth/v1/config/deposit_contract", depositContract); err != nil {
+		return nil, errors.Wrap(err, "could not get deposit contract")
+	}
+	if depositContract.Data == nil {
+		return nil, errors.New("deposit contract response has no data")
+	}
+	address, err := hexutil.Decode(depositContract.Data.Address)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid deposit contract address %s", depositContract.Data.Address)
+	}
+	return &ethpb.Genesis{
+		GenesisTime:            timestamppb.New(time.Unix(genesisTime, 0)),
+		DepositContractAddress: address,
+		GenesisValidatorsRoot:  root,
+	}, nil
+}
+
+// ListPeers returns the peers the beacon node is currently connected to.
+func (c *beaconApiNodeClient) ListPeers(ctx context.Context, _ *emptypb.Empty) (*ethpb.Peers, error) {
+	resp := &peersResponseJson{}
+	if err := c.handler.get(ctx, "/eth/v1/node/peers?state=connected", resp); err != nil {
+		return nil, errors.Wrap(err, "could not get peers")
+	}
+	peers := make([]*ethpb.Peer, 0, len(resp.Data))
+	for _, p := range resp.Data {
+		if p == nil {
+			continue
+		}
+		direction := ethpb.PeerDirection_UNKNOWN
+		switch p.Direction {
+		case "inbound":
+			direction = ethpb.PeerDirection_INBOUND
+		case "outbound":
+			direction = ethpb.PeerDirection_OUTBOUND
+		}
+		peers = append(peers, &ethpb.Peer{
+			Address:         p.LastSeenP2PAddress,
+			Direction:       direction,
+			ConnectionState: ethpb.ConnectionState_CONNECTED,
+			PeerId:          p.PeerId,
+			Enr:             p.Enr,
+		})
+	}
+	return &ethpb.Peers{Peers: peers}, nil
+}
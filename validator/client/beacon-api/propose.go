@@ -0,0 +1,123 @@
+package beacon_api
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// GetBeaconBlock asks the beacon node to produce an unsigned block. Blinded blocks aren't requested
+// over the standard API, builders are left to the beacon node's configuration.
+func (c *beaconApiValidatorClient) GetBeaconBlock(ctx context.Context, in *ethpb.BlockRequest) (*ethpb.GenericBeaconBlock, error) {
+	query := url.Values{
+		"randao_reveal": {hexutil.Encode(in.RandaoReveal)},
+		"graffiti":      {hexutil.Encode(in.Graffiti)},
+	}
+	resp := &versionedDataResponseJson{}
+	if err := c.handler.get(ctx, fmt.Sprintf("/eth/v2/validator/blocks/%d?%s", in.Slot, query.Encode()), resp); err != nil {
+		return nil, errors.Wrap(err, "could not produce block")
+	}
+	switch resp.Version {
+	case "phase0":
+		blk := &ethpb.BeaconBlock{}
+		if err := unmarshalSpecJSON(resp.Data, blk); err != nil {
+			return nil, errors.Wrap(err, "could not decode phase0 block")
+		}
+		return &ethpb.GenericBeaconBlock{Block: &ethpb.GenericBeaconBlock_Phase0{Phase0: blk}}, nil
+	case "altair":
+		blk := &ethpb.BeaconBlockAltair{}
+		if err := unmarshalSpecJSON(resp.Data, blk); err != nil {
+			return nil, errors.Wrap(err, "could not decode altair block")
+		}
+		return &ethpb.GenericBeaconBlock{Block: &ethpb.GenericBeaconBlock_Altair{Altair: blk}}, nil
+	case "bellatrix":
+		blk := &ethpb.BeaconBlockBellatrix{}
+		if err := unmarshalSpecJSON(resp.Data, blk); err != nil {
+			return nil, errors.Wrap(err, "could not decode bellatrix block")
+		}
+		return &ethpb.GenericBeaconBlock{Block: &ethpb.GenericBeaconBlock_Bellatrix{Bellatrix: blk}}, nil
+	default:
+		return nil, errors.Errorf("unsupported block version %s", resp.Version)
+	}
+}
+
+// ProposeBeaconBlock publishes the signed block through the beacon node.
+func (c *beaconApiValidatorClient) ProposeBeaconBlock(ctx context.Context, in *ethpb.GenericSignedBeaconBlock) (*ethpb.ProposeResponse, error) {
+	endpoint := "/eth/v1/beacon/blocks"
+	var signedBlock interface{}
+	var root [32]byte
+	var err error
+	switch b := in.Block.(type) {
+	case *ethpb.GenericSignedBeaconBlock_Phase0:
+		signedBlock = b.Phase0
+		root, err = b.Phase0.Block.HashTreeRoot()
+	case *ethpb.GenericSignedBeaconBlock_Altair:
+		signedBlock = b.Altair
+		root, err = b.Altair.Block.HashTreeRoot()
+	case *ethpb.GenericSignedBeaconBlock_Bellatrix:
+		signedBlock = b.Bellatrix
+		root, err = b.Bellatrix.Block.HashTreeRoot()
+	case *ethpb.GenericSignedBeaconBlock_BlindedBellatrix:
+		endpoint = "/eth/v1/beacon/blinded_blocks"
+		signedBlock = b.BlindedBellatrix
+		root, err = b.BlindedBellatrix.Block.HashTreeRoot()
+	default:
+		return nil, errors.Errorf("unsupported block type %T", in.Block)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute block root")
+	}
+	body, err := marshalSpecJSON(signedBlock)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode block")
+	}
+	if err := c.handler.post(ctx, endpoint, body, nil); err != nil {
+		return nil, errors.Wrap(err, "could not publish block")
+	}
+	return &ethpb.ProposeResponse{BlockRoot: root[:]}, nil
+}
+
+// ProposeExit submits the signed voluntary exit to the beacon node's pool.
+func (c *beaconApiValidatorClient) ProposeExit(ctx context.Context, in *ethpb.SignedVoluntaryExit) (*ethpb.ProposeExitResponse, error) {
+	root, err := in.Exit.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute exit root")
+	}
+	body, err := marshalSpecJSON(in)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode voluntary exit")
+	}
+	if err := c.handler.post(ctx, "/eth/v1/beacon/pool/voluntary_exits", body, nil); err != nil {
+		return nil, errors.Wrap(err, "could not submit voluntary exit")
+	}
+	return &ethpb.ProposeExitResponse{ExitRoot: root[:]}, nil
+}
+
+// SubmitValidatorRegistration forwards the signed builder registrations to the beacon node.
+func (c *beaconApiValidatorClient) SubmitValidatorRegistration(ctx context.Context, in *ethpb.SignedValidatorRegistrationsV1) (*emptypb.Empty, error) {
+	body, err := marshalSpecJSON(in.Messages)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode validator registrations")
+	}
+	if err := c.handler.post(ctx, "/eth/v1/validator/register_validator", body, nil); err != nil {
+		return nil, errors.Wrap(err, "could not submit validator registrations")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// PrepareBeaconProposer tells the beacon node the fee recipients of the validators.
+func (c *beaconApiValidatorClient) PrepareBeaconProposer(ctx context.Context, in *ethpb.PrepareBeaconProposerRequest) (*emptypb.Empty, error) {
+	body, err := marshalSpecJSON(in.Recipients)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode fee recipients")
+	}
+	if err := c.handler.post(ctx, "/eth/v1/validator/prepare_beacon_proposer", body, nil); err != nil {
+		return nil, errors.Wrap(err, "could not prepare beacon proposer")
+	}
+	return &emptypb.Empty{}, nil
+}
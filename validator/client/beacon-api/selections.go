@@ -0,0 +1,107 @@
+package beacon_api
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/validator/client/iface"
+)
+
+// GetAggregatedSelections posts the validator's partial beacon committee selection proofs and
+// returns the combined ones. Beacon nodes do not serve this endpoint, distributed validator
+// middlewares intercept it to combine the partial proofs of the cluster's validator clients.
+func (c *beaconApiValidatorClient) GetAggregatedSelections(ctx context.Context, selections []iface.BeaconCommitteeSelection) ([]iface.BeaconCommitteeSelection, error) {
+	body := make([]*beaconCommitteeSelectionJson, len(selections))
+	for i, s := range selections {
+		body[i] = &beaconCommitteeSelectionJson{
+			ValidatorIndex: strconv.FormatUint(uint64(s.ValidatorIndex), 10),
+			Slot:           strconv.FormatUint(uint64(s.Slot), 10),
+			SelectionProof: hexutil.Encode(s.SelectionProof),
+		}
+	}
+	resp := &beaconCommitteeSelectionsResponseJson{}
+	if err := c.handler.post(ctx, "/eth/v1/validator/beacon_committee_selections", body, resp); err != nil {
+		return nil, errors.Wrap(err, "could not get aggregated beacon committee selections")
+	}
+	if len(resp.Data) != len(selections) {
+		return nil, errors.Errorf("expected %d aggregated beacon committee selections, got %d", len(selections), len(resp.Data))
+	}
+	aggregated := make([]iface.BeaconCommitteeSelection, len(resp.Data))
+	for i, s := range resp.Data {
+		if s == nil {
+			return nil, errors.New("aggregated beacon committee selection is nil")
+		}
+		index, err := strconv.ParseUint(s.ValidatorIndex, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid validator index %s", s.ValidatorIndex)
+		}
+		slot, err := strconv.ParseUint(s.Slot, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid slot %s", s.Slot)
+		}
+		proof, err := hexutil.Decode(s.SelectionProof)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid selection proof %s", s.SelectionProof)
+		}
+		aggregated[i] = iface.BeaconCommitteeSelection{
+			SelectionProof: proof,
+			Slot:           types.Slot(slot),
+			ValidatorIndex: types.ValidatorIndex(index),
+		}
+	}
+	return aggregated, nil
+}
+
+// GetAggregatedSyncSelections posts the validator's partial sync committee selection proofs and
+// returns the combined ones. Beacon nodes do not serve this endpoint, distributed validator
+// middlewares intercept it to combine the partial proofs of the cluster's validator clients.
+func (c *beaconApiValidatorClient) GetAggregatedSyncSelections(ctx context.Context, selections []iface.SyncCommitteeSelection) ([]iface.SyncCommitteeSelection, error) {
+	body := make([]*syncCommitteeSelectionJson, len(selections))
+	for i, s := range selections {
+		body[i] = &syncCommitteeSelectionJson{
+			ValidatorIndex:    strconv.FormatUint(uint64(s.ValidatorIndex), 10),
+			Slot:              strconv.FormatUint(uint64(s.Slot), 10),
+			SubcommitteeIndex: strconv.FormatUint(s.SubcommitteeIndex, 10),
+			SelectionProof:    hexutil.Encode(s.SelectionProof),
+		}
+	}
+	resp := &syncCommitteeSelectionsResponseJson{}
+	if err := c.handler.post(ctx, "/eth/v1/validator/sync_committee_selections", body, resp); err != nil {
+		return nil, errors.Wrap(err, "could not get aggregated sync committee selections")
+	}
+	if len(resp.Data) != len(selections) {
+		return nil, errors.Errorf("expected %d aggregated sync committee selections, got %d", len(selections), len(resp.Data))
+	}
+	aggregated := make([]iface.SyncCommitteeSelection, len(resp.Data))
+	for i, s := range resp.Data {
+		if s == nil {
+			return nil, errors.New("aggregated sync committee selection is nil")
+		}
+		index, err := strconv.ParseUint(s.ValidatorIndex, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid validator index %s", s.ValidatorIndex)
+		}
+		slot, err := strconv.ParseUint(s.Slot, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid slot %s", s.Slot)
+		}
+		subcommittee, err := strconv.ParseUint(s.SubcommitteeIndex, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid subcommittee index %s", s.SubcommitteeIndex)
+		}
+		proof, err := hexutil.Decode(s.SelectionProof)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid selection proof %s", s.SelectionProof)
+		}
+		aggregated[i] = iface.SyncCommitteeSelection{
+			SelectionProof:    proof,
+			Slot:              types.Slot(slot),
+			SubcommitteeIndex: subcommittee,
+			ValidatorIndex:    types.ValidatorIndex(index),
+		}
+	}
+	return aggregated, nil
+}
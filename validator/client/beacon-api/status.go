@@ -0,0 +1,229 @@
+package beacon_api
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+)
+
+// maxValidatorIdsPerRequest bounds the number of validators queried at once to keep urls short.
+const maxValidatorIdsPerRequest = 64
+
+// nonExistentIndex is the index reported for validators which aren't in the beacon state,
+// as done by Prysm's beacon node.
+const nonExistentIndex = types.ValidatorIndex(^uint64(0))
+
+// validatorStatuses maps the validator statuses of the standard API to Prysm's.
+var validatorStatuses = map[string]ethpb.ValidatorStatus{
+	"pending_initialized": ethpb.ValidatorStatus_DEPOSITED,
+	"pending_queued":      ethpb.ValidatorStatus_PENDING,
+	"active_ongoing":      ethpb.ValidatorStatus_ACTIVE,
+	"active_exiting":      ethpb.ValidatorStatus_EXITING,
+	"active_slashed":      ethpb.ValidatorStatus_SLASHING,
+	"exited_unslashed":    ethpb.ValidatorStatus_EXITED,
+	"exited_slashed":      ethpb.ValidatorStatus_EXITED,
+	"withdrawal_possible": ethpb.ValidatorStatus_EXITED,
+	"withdrawal_done":     ethpb.ValidatorStatus_EXITED,
+}
+
+// validator is a validator of the head state, as reported by the standard API.
+type validator struct {
+	index           types.ValidatorIndex
+	publicKey       [fieldparams.BLSPubkeyLength]byte
+	status          ethpb.ValidatorStatus
+	activationEpoch types.Epoch
+	balance         uint64
+	effective       uint64
+}
+
+// ValidatorIndex looks the validator's index up in the head state.
+func (c *beaconApiValidatorClient) ValidatorIndex(ctx context.Context, in *ethpb.ValidatorIndexRequest) (*ethpb.ValidatorIndexResponse, error) {
+	validators, err := getValidators(ctx, c.handler, [][]byte{in.PublicKey}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(validators) == 0 {
+		return nil, errors.Errorf("could not find validator index for public key %#x", in.PublicKey)
+	}
+	return &ethpb.ValidatorIndexResponse{Index: validators[0].index}, nil
+}
+
+// MultipleValidatorStatus returns the statuses of the given validators in the head state. Validators
+// which aren't in the state are reported with an unknown status.
+func (c *beaconApiValidatorClient) MultipleValidatorStatus(ctx context.Context, in *ethpb.MultipleValidatorStatusRequest) (*ethpb.MultipleValidatorStatusResponse, error) {
+	indices := make([]types.ValidatorIndex, len(in.Indices))
+	for i, idx := range in.Indices {
+		indices[i] = types.ValidatorIndex(idx)
+	}
+	validators, err := getValidators(ctx, c.handler, in.PublicKeys, indices)
+	if err != nil {
+		return nil, err
+	}
+	byPubkey := make(map[[fieldparams.BLSPubkeyLength]byte]*validator, len(validators))
+	byIndex := make(map[types.ValidatorIndex]*validator, len(validators))
+	for _, v := range validators {
+		byPubkey[v.publicKey] = v
+		byIndex[v.index] = v
+	}
+
+	resp := &ethpb.MultipleValidatorStatusResponse{}
+	seen := make(map[[fieldparams.BLSPubkeyLength]byte]bool)
+	seen[[fieldparams.BLSPubkeyLength]byte{}] = true // Filter out keys with all zeros.
+	add := func(pubkey [fieldparams.BLSPubkeyLength]byte, v *validator) {
+		if seen[pubkey] {
+			return
+		}
+		seen[pubkey] = true
+		status := &ethpb.ValidatorStatusResponse{
+			Status:          ethpb.ValidatorStatus_UNKNOWN_STATUS,
+			ActivationEpoch: params.BeaconConfig().FarFutureEpoch,
+		}
+		index := nonExistentIndex
+		if v != nil {
+			status.Status = v.status
+			status.ActivationEpoch = v.activationEpoch
+			index = v.index
+		}
+		resp.PublicKeys = append(resp.PublicKeys, bytesutil.SafeCopyBytes(pubkey[:]))
+		resp.Statuses = append(resp.Statuses, status)
+		resp.Indices = append(resp.Indices, index)
+	}
+	for _, pubkey := range in.PublicKeys {
+		key := bytesutil.ToBytes48(pubkey)
+		add(key, byPubkey[key])
+	}
+	for _, idx := range indices {
+		if v, ok := byIndex[idx]; ok {
+			add(v.publicKey, v)
+		}
+	}
+	return resp, nil
+}
+
+// WaitForActivation returns a stream delivering the statuses of the given validators once every slot.
+func (c *beaconApiValidatorClient) WaitForActivation(ctx context.Context, in *ethpb.ValidatorActivationRequest) (ethpb.BeaconNodeValidator_WaitForActivationClient, error) {
+	return &waitForActivationStream{
+		restStream: restStream{ctx: ctx},
+		client:     c,
+		publicKeys: in.PublicKeys,
+	}, nil
+}
+
+type waitForActivationStream struct {
+	restStream
+	client     *beaconApiValidatorClient
+	publicKeys [][]byte
+	polled     bool
+}
+
+func (s *waitForActivationStream) Recv() (*ethpb.ValidatorActivationResponse, error) {
+	if s.polled {
+		select {
+		case <-time.After(time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second):
+		case <-s.ctx.Done():
+			return nil, s.ctx.Err()
+		}
+	}
+	s.polled = true
+	statuses, err := s.client.MultipleValidatorStatus(s.ctx, &ethpb.MultipleValidatorStatusRequest{PublicKeys: s.publicKeys})
+	if err != nil {
+		return nil, err
+	}
+	resp := &ethpb.ValidatorActivationResponse{
+		Statuses: make([]*ethpb.ValidatorActivationResponse_Status, len(statuses.Statuses)),
+	}
+	for i, status := range statuses.Statuses {
+		resp.Statuses[i] = &ethpb.ValidatorActivationResponse_Status{
+			PublicKey: statuses.PublicKeys[i],
+			Status:    status,
+			Index:     statuses.Indices[i],
+		}
+	}
+	return resp, nil
+}
+
+// getValidators returns the validators of the head state with the given public keys or indices.
+// Validators the beacon node doesn't know about are left out.
+func getValidators(ctx context.Context, handler *jsonRestHandler, pubkeys [][]byte, indices []types.ValidatorIndex) ([]*validator, error) {
+	ids := make([]string, 0, len(pubkeys)+len(indices))
+	seen := make(map[string]bool, len(pubkeys)+len(indices))
+	addId := func(id string) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	for _, pubkey := range pubkeys {
+		addId(hexutil.Encode(pubkey))
+	}
+	for _, idx := range indices {
+		addId(strconv.FormatUint(uint64(idx), 10))
+	}
+	var validators []*validator
+	for start := 0; start < len(ids); start += maxValidatorIdsPerRequest {
+		end := start + maxValidatorIdsPerRequest
+		if end > len(ids) {
+			end = len(ids)
+		}
+		query := url.Values{"id": ids[start:end]}
+		resp := &stateValidatorsResponseJson{}
+		if err := handler.get(ctx, "/eth/v1/beacon/states/head/validators?"+query.Encode(), resp); err != nil {
+			return nil, errors.Wrap(err, "could not get validators")
+		}
+		for _, container := range resp.Data {
+			v, err := validatorFromJson(container)
+			if err != nil {
+				return nil, err
+			}
+			validators = append(validators, v)
+		}
+	}
+	return validators, nil
+}
+
+func validatorFromJson(container *validatorContainerJson) (*validator, error) {
+	if container == nil || container.Validator == nil {
+		return nil, errors.New("validator response has no data")
+	}
+	index, err := strconv.ParseUint(container.Index, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid validator index %s", container.Index)
+	}
+	pubkey, err := hexutil.Decode(container.Validator.PublicKey)
+	if err != nil || len(pubkey) != fieldparams.BLSPubkeyLength {
+		return nil, errors.Errorf("invalid validator public key %s", container.Validator.PublicKey)
+	}
+	status, ok := validatorStatuses[container.Status]
+	if !ok {
+		return nil, errors.Errorf("unknown validator status %s", container.Status)
+	}
+	activationEpoch, err := strconv.ParseUint(container.Validator.ActivationEpoch, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid activation epoch %s", container.Validator.ActivationEpoch)
+	}
+	balance, err := strconv.ParseUint(container.Balance, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid balance %s", container.Balance)
+	}
+	effective, err := strconv.ParseUint(container.Validator.EffectiveBalance, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid effective balance %s", container.Validator.EffectiveBalance)
+	}
+	return &validator{
+		index:           types.ValidatorIndex(index),
+		publicKey:       bytesutil.ToBytes48(pubkey),
+		status:          status,
+		activationEpoch: types.Epoch(activationEpoch),
+		balance:         balance,
+		effective:       effective,
+	}, nil
+}
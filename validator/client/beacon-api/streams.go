@@ -0,0 +1,120 @@
+package beacon_api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/validator/client/iface"
+	"google.golang.org/grpc/metadata"
+)
+
+// restStream implements the grpc.ClientStream part of the streams which are emulated on top
+// of the REST API. Only the typed Recv methods of the embedding streams are meaningful.
+type restStream struct {
+	ctx context.Context
+}
+
+func (s *restStream) Header() (metadata.MD, error) { return metadata.MD{}, nil }
+func (s *restStream) Trailer() metadata.MD         { return metadata.MD{} }
+func (s *restStream) CloseSend() error             { return nil }
+func (s *restStream) Context() context.Context     { return s.ctx }
+func (s *restStream) SendMsg(_ interface{}) error  { return iface.ErrNotSupported }
+func (s *restStream) RecvMsg(_ interface{}) error  { return iface.ErrNotSupported }
+
+// StreamBlocksAltair subscribes to the block events of the beacon node and delivers every block
+// it imports. Blocks are only announced once verified, so the request's verified only flag is moot.
+func (c *beaconApiValidatorClient) StreamBlocksAltair(ctx context.Context, _ *ethpb.StreamBlocksRequest) (ethpb.BeaconNodeValidator_StreamBlocksAltairClient, error) {
+	// The event stream is closed along with the context it was requested with.
+	body, err := c.handler.stream(ctx, "/eth/v1/events?topics=block")
+	if err != nil {
+		return nil, err
+	}
+	return &blockStream{
+		restStream: restStream{ctx: ctx},
+		client:     c,
+		events:     bufio.NewReader(body),
+	}, nil
+}
+
+type blockStream struct {
+	restStream
+	client *beaconApiValidatorClient
+	events *bufio.Reader
+}
+
+func (s *blockStream) Recv() (*ethpb.StreamBlocksResponse, error) {
+	for {
+		event, data, err := s.nextEvent()
+		if err != nil {
+			return nil, err
+		}
+		if event != "block" {
+			continue
+		}
+		blockEvent := &blockEventJson{}
+		if err := json.Unmarshal(data, blockEvent); err != nil {
+			return nil, errors.Wrap(err, "could not decode block event")
+		}
+		resp := &versionedDataResponseJson{}
+		if err := s.client.handler.get(s.ctx, "/eth/v2/beacon/blocks/"+blockEvent.Block, resp); err != nil {
+			return nil, errors.Wrapf(err, "could not get block %s", blockEvent.Block)
+		}
+		return streamBlocksResponse(resp)
+	}
+}
+
+// nextEvent reads the next server-sent event, returning its name and data.
+func (s *blockStream) nextEvent() (string, []byte, error) {
+	var event string
+	var data []byte
+	for {
+		line, err := s.events.ReadString('\n')
+		if err != nil {
+			if err == io.EOF || s.ctx.Err() != nil {
+				return "", nil, io.EOF
+			}
+			return "", nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case line == "":
+			if data != nil {
+				return event, data, nil
+			}
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimSpace(strings.TrimPrefix(line, "data:"))...)
+		}
+	}
+}
+
+func streamBlocksResponse(resp *versionedDataResponseJson) (*ethpb.StreamBlocksResponse, error) {
+	switch resp.Version {
+	case "phase0":
+		blk := &ethpb.SignedBeaconBlock{}
+		if err := unmarshalSpecJSON(resp.Data, blk); err != nil {
+			return nil, errors.Wrap(err, "could not decode phase0 block")
+		}
+		return &ethpb.StreamBlocksResponse{Block: &ethpb.StreamBlocksResponse_Phase0Block{Phase0Block: blk}}, nil
+	case "altair":
+		blk := &ethpb.SignedBeaconBlockAltair{}
+		if err := unmarshalSpecJSON(resp.Data, blk); err != nil {
+			return nil, errors.Wrap(err, "could not decode altair block")
+		}
+		return &ethpb.StreamBlocksResponse{Block: &ethpb.StreamBlocksResponse_AltairBlock{AltairBlock: blk}}, nil
+	case "bellatrix":
+		blk := &ethpb.SignedBeaconBlockBellatrix{}
+		if err := unmarshalSpecJSON(resp.Data, blk); err != nil {
+			return nil, errors.Wrap(err, "could not decode bellatrix block")
+		}
+		return &ethpb.StreamBlocksResponse{Block: &ethpb.StreamBlocksResponse_BellatrixBlock{BellatrixBlock: blk}}, nil
+	default:
+		return nil, errors.Errorf("unsupported block version %s", resp.Version)
+	}
+}
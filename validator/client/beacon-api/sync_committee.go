@@ -0,0 +1,104 @@
+package beacon_api
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// GetSyncMessageBlockRoot returns the root of the beacon node's head block.
+func (c *beaconApiValidatorClient) GetSyncMessageBlockRoot(ctx context.Context, _ *emptypb.Empty) (*ethpb.SyncMessageBlockRootResponse, error) {
+	root, err := c.headRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ethpb.SyncMessageBlockRootResponse{Root: root}, nil
+}
+
+// SubmitSyncMessage submits the signed sync committee message to the beacon node's pool.
+func (c *beaconApiValidatorClient) SubmitSyncMessage(ctx context.Context, in *ethpb.SyncCommitteeMessage) (*emptypb.Empty, error) {
+	body, err := marshalSpecJSON([]*ethpb.SyncCommitteeMessage{in})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode sync committee message")
+	}
+	if err := c.handler.post(ctx, "/eth/v1/beacon/pool/sync_committees", body, nil); err != nil {
+		return nil, errors.Wrap(err, "could not submit sync committee message")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// GetSyncSubcommitteeIndex returns the positions of the validator in the sync committee
+// it is part of at the slot.
+func (c *beaconApiValidatorClient) GetSyncSubcommitteeIndex(ctx context.Context, in *ethpb.SyncSubcommitteeIndexRequest) (*ethpb.SyncSubcommitteeIndexResponse, error) {
+	index, err := c.ValidatorIndex(ctx, &ethpb.ValidatorIndexRequest{PublicKey: in.PublicKey})
+	if err != nil {
+		return nil, err
+	}
+	// Messages of the last slot of a sync committee period are signed by the next period's committee.
+	positions, err := c.syncCommitteeDuties(ctx, slots.ToEpoch(in.Slot+1), []types.ValidatorIndex{index.Index})
+	if err != nil {
+		return nil, err
+	}
+	resp := &ethpb.SyncSubcommitteeIndexResponse{}
+	for _, position := range positions[index.Index] {
+		resp.Indices = append(resp.Indices, types.CommitteeIndex(position))
+	}
+	return resp, nil
+}
+
+// GetSyncCommitteeContribution asks the beacon node for its aggregate of the subcommittee's
+// messages for the head block at the slot.
+func (c *beaconApiValidatorClient) GetSyncCommitteeContribution(ctx context.Context, in *ethpb.SyncCommitteeContributionRequest) (*ethpb.SyncCommitteeContribution, error) {
+	root, err := c.headRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query := url.Values{
+		"slot":               {strconv.FormatUint(uint64(in.Slot), 10)},
+		"subcommittee_index": {strconv.FormatUint(in.SubnetId, 10)},
+		"beacon_block_root":  {hexutil.Encode(root)},
+	}
+	resp := &dataResponseJson{}
+	if err := c.handler.get(ctx, "/eth/v1/validator/sync_committee_contribution?"+query.Encode(), resp); err != nil {
+		return nil, errors.Wrap(err, "could not get sync committee contribution")
+	}
+	contribution := &ethpb.SyncCommitteeContribution{}
+	if err := unmarshalSpecJSON(resp.Data, contribution); err != nil {
+		return nil, errors.Wrap(err, "could not decode sync committee contribution")
+	}
+	return contribution, nil
+}
+
+// SubmitSignedContributionAndProof publishes the signed contribution and proof through the beacon node.
+func (c *beaconApiValidatorClient) SubmitSignedContributionAndProof(ctx context.Context, in *ethpb.SignedContributionAndProof) (*emptypb.Empty, error) {
+	body, err := marshalSpecJSON([]*ethpb.SignedContributionAndProof{in})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode contribution and proof")
+	}
+	if err := c.handler.post(ctx, "/eth/v1/validator/contribution_and_proofs", body, nil); err != nil {
+		return nil, errors.Wrap(err, "could not submit contribution and proof")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (c *beaconApiValidatorClient) headRoot(ctx context.Context) ([]byte, error) {
+	resp := &blockRootResponseJson{}
+	if err := c.handler.get(ctx, "/eth/v1/beacon/blocks/head/root", resp); err != nil {
+		return nil, errors.Wrap(err, "could not get head block root")
+	}
+	if resp.Data == nil {
+		return nil, errors.New("block root response has no data")
+	}
+	root, err := hexutil.Decode(resp.Data.Root)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid block root %s", resp.Data.Root)
+	}
+	return root, nil
+}
@@ -0,0 +1,181 @@
+package beacon_api
+
+import "encoding/json"
+
+type genesisResponseJson struct {
+	Data *struct {
+		GenesisTime           string `json:"genesis_time"`
+		GenesisValidatorsRoot string `json:"genesis_validators_root"`
+		GenesisForkVersion    string `json:"genesis_fork_version"`
+	} `json:"data"`
+}
+
+type depositContractResponseJson struct {
+	Data *struct {
+		ChainId string `json:"chain_id"`
+		Address string `json:"address"`
+	} `json:"data"`
+}
+
+type syncingResponseJson struct {
+	Data *struct {
+		HeadSlot     string `json:"head_slot"`
+		SyncDistance string `json:"sync_distance"`
+		IsSyncing    bool   `json:"is_syncing"`
+	} `json:"data"`
+}
+
+type peersResponseJson struct {
+	Data []*struct {
+		PeerId             string `json:"peer_id"`
+		Enr                string `json:"enr"`
+		LastSeenP2PAddress string `json:"last_seen_p2p_address"`
+		State              string `json:"state"`
+		Direction          string `json:"direction"`
+	} `json:"data"`
+}
+
+type validatorContainerJson struct {
+	Index     string `json:"index"`
+	Balance   string `json:"balance"`
+	Status    string `json:"status"`
+	Validator *struct {
+		PublicKey        string `json:"pubkey"`
+		EffectiveBalance string `json:"effective_balance"`
+		ActivationEpoch  string `json:"activation_epoch"`
+		ExitEpoch        string `json:"exit_epoch"`
+	} `json:"validator"`
+}
+
+type stateValidatorsResponseJson struct {
+	Data []*validatorContainerJson `json:"data"`
+}
+
+type attesterDutyJson struct {
+	Pubkey                  string `json:"pubkey"`
+	ValidatorIndex          string `json:"validator_index"`
+	CommitteeIndex          string `json:"committee_index"`
+	CommitteeLength         string `json:"committee_length"`
+	CommitteesAtSlot        string `json:"committees_at_slot"`
+	ValidatorCommitteeIndex string `json:"validator_committee_index"`
+	Slot                    string `json:"slot"`
+}
+
+type attesterDutiesResponseJson struct {
+	DependentRoot string              `json:"dependent_root"`
+	Data          []*attesterDutyJson `json:"data"`
+}
+
+type proposerDutyJson struct {
+	Pubkey         string `json:"pubkey"`
+	ValidatorIndex string `json:"validator_index"`
+	Slot           string `json:"slot"`
+}
+
+type proposerDutiesResponseJson struct {
+	DependentRoot string              `json:"dependent_root"`
+	Data          []*proposerDutyJson `json:"data"`
+}
+
+type syncCommitteeDutyJson struct {
+	Pubkey                        string   `json:"pubkey"`
+	ValidatorIndex                string   `json:"validator_index"`
+	ValidatorSyncCommitteeIndices []string `json:"validator_sync_committee_indices"`
+}
+
+type syncCommitteeDutiesResponseJson struct {
+	Data []*syncCommitteeDutyJson `json:"data"`
+}
+
+type committeeJson struct {
+	Index      string   `json:"index"`
+	Slot       string   `json:"slot"`
+	Validators []string `json:"validators"`
+}
+
+type stateCommitteesResponseJson struct {
+	Data []*committeeJson `json:"data"`
+}
+
+type beaconCommitteeSubscribeJson struct {
+	ValidatorIndex   string `json:"validator_index"`
+	CommitteeIndex   string `json:"committee_index"`
+	CommitteesAtSlot string `json:"committees_at_slot"`
+	Slot             string `json:"slot"`
+	IsAggregator     bool   `json:"is_aggregator"`
+}
+
+type beaconCommitteeSelectionJson struct {
+	ValidatorIndex string `json:"validator_index"`
+	Slot           string `json:"slot"`
+	SelectionProof string `json:"selection_proof"`
+}
+
+type beaconCommitteeSelectionsResponseJson struct {
+	Data []*beaconCommitteeSelectionJson `json:"data"`
+}
+
+type syncCommitteeSelectionJson struct {
+	ValidatorIndex    string `json:"validator_index"`
+	Slot              string `json:"slot"`
+	SubcommitteeIndex string `json:"subcommittee_index"`
+	SelectionProof    string `json:"selection_proof"`
+}
+
+type syncCommitteeSelectionsResponseJson struct {
+	Data []*syncCommitteeSelectionJson `json:"data"`
+}
+
+type livenessResponseJson struct {
+	Data []*struct {
+		Index  string `json:"index"`
+		IsLive bool   `json:"is_live"`
+	} `json:"data"`
+}
+
+type blockRootResponseJson struct {
+	Data *struct {
+		Root string `json:"root"`
+	} `json:"data"`
+}
+
+type blockHeaderResponseJson struct {
+	ExecutionOptimistic bool `json:"execution_optimistic"`
+	Data                *struct {
+		Root   string `json:"root"`
+		Header *struct {
+			Message *struct {
+				Slot string `json:"slot"`
+			} `json:"message"`
+		} `json:"header"`
+	} `json:"data"`
+}
+
+type finalityCheckpointsResponseJson struct {
+	Data *struct {
+		PreviousJustified *checkpointJson `json:"previous_justified"`
+		CurrentJustified  *checkpointJson `json:"current_justified"`
+		Finalized         *checkpointJson `json:"finalized"`
+	} `json:"data"`
+}
+
+type checkpointJson struct {
+	Epoch string `json:"epoch"`
+	Root  string `json:"root"`
+}
+
+// dataResponseJson is the envelope of responses whose data is decoded with unmarshalSpecJSON.
+type dataResponseJson struct {
+	Data json.RawMessage `json:"data"`
+}
+
+// versionedDataResponseJson is the envelope of responses whose data depends on the fork.
+type versionedDataResponseJson struct {
+	Version string          `json:"version"`
+	Data    json.RawMessage `json:"data"`
+}
+
+type blockEventJson struct {
+	Slot  string `json:"slot"`
+	Block string `json:"block"`
+}
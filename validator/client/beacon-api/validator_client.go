@@ -0,0 +1,104 @@
+package beacon_api
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/network/forks"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/validator/client/iface"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// genesisPollInterval is how often the beacon node is asked for the genesis while waiting for chain start.
+var genesisPollInterval = 10 * time.Second
+
+type beaconApiValidatorClient struct {
+	handler     *jsonRestHandler
+	genesisLock sync.Mutex
+	genesis     *ethpb.ChainStartResponse
+}
+
+// NewBeaconApiValidatorClient returns a validator client talking to a beacon node over the standard
+// Beacon REST API served at host, ex: http://127.0.0.1:3500.
+func NewBeaconApiValidatorClient(host string, timeout time.Duration) (iface.ValidatorClient, error) {
+	handler, err := newJsonRestHandler(host, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return &beaconApiValidatorClient{handler: handler}, nil
+}
+
+// WaitForChainStart polls the beacon node until it knows about the genesis of the chain.
+func (c *beaconApiValidatorClient) WaitForChainStart(ctx context.Context, _ *emptypb.Empty) (*ethpb.ChainStartResponse, error) {
+	for {
+		genesis, err := c.getGenesis(ctx)
+		if err == nil {
+			return genesis, nil
+		}
+		if !errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
+		log.Info("Beacon chain genesis is not known yet, waiting")
+		select {
+		case <-time.After(genesisPollInterval):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// DomainData computes the signature domain from the fork schedule of the configured network,
+// the same way Prysm's beacon node does.
+func (c *beaconApiValidatorClient) DomainData(ctx context.Context, in *ethpb.DomainRequest) (*ethpb.DomainResponse, error) {
+	genesis, err := c.getGenesis(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get genesis")
+	}
+	fork, err := forks.Fork(in.Epoch)
+	if err != nil {
+		return nil, err
+	}
+	domain, err := signing.Domain(fork, in.Epoch, bytesutil.ToBytes4(in.Domain), genesis.GenesisValidatorsRoot)
+	if err != nil {
+		return nil, err
+	}
+	return &ethpb.DomainResponse{SignatureDomain: domain}, nil
+}
+
+// getGenesis returns the genesis of the chain, which is only requested from the beacon node
+// until it is known.
+func (c *beaconApiValidatorClient) getGenesis(ctx context.Context) (*ethpb.ChainStartResponse, error) {
+	c.genesisLock.Lock()
+	defer c.genesisLock.Unlock()
+	if c.genesis != nil {
+		return c.genesis, nil
+	}
+	resp := &genesisResponseJson{}
+	if err := c.handler.get(ctx, "/eth/v1/beacon/genesis", resp); err != nil {
+		return nil, err
+	}
+	if resp.Data == nil {
+		return nil, errors.New("genesis response has no data")
+	}
+	genesisTime, err := strconv.ParseUint(resp.Data.GenesisTime, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid genesis time %s", resp.Data.GenesisTime)
+	}
+	root, err := hexutil.Decode(resp.Data.GenesisValidatorsRoot)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid genesis validators root %s", resp.Data.GenesisValidatorsRoot)
+	}
+	c.genesis = &ethpb.ChainStartResponse{
+		Started:               true,
+		GenesisTime:           genesisTime,
+		GenesisValidatorsRoot: root,
+	}
+	return c.genesis, nil
+}
@@ -0,0 +1,170 @@
+package beacon_api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/validator/client/iface"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *beaconApiValidatorClient {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	client, err := NewBeaconApiValidatorClient(srv.URL, time.Second)
+	require.NoError(t, err)
+	return client.(*beaconApiValidatorClient)
+}
+
+func TestNewBeaconApiValidatorClient_InvalidHost(t *testing.T) {
+	_, err := NewBeaconApiValidatorClient("127.0.0.1:3500", time.Second)
+	assert.ErrorContains(t, "invalid beacon node REST API url", err)
+	_, err = NewBeaconApiValidatorClient("/eth/v1", time.Second)
+	assert.ErrorContains(t, "http(s)://host:port", err)
+}
+
+func TestWaitForChainStart(t *testing.T) {
+	genesisPollInterval = time.Millisecond
+	defer func() {
+		genesisPollInterval = 10 * time.Second
+	}()
+	calls := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/eth/v1/beacon/genesis", r.URL.Path)
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusNotFound)
+			_, err := w.Write([]byte(`{"code":404,"message":"Chain genesis info is not yet known"}`))
+			require.NoError(t, err)
+			return
+		}
+		_, err := w.Write([]byte(`{"data":{"genesis_time":"1606824023","genesis_validators_root":"0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95","genesis_fork_version":"0x00000000"}}`))
+		require.NoError(t, err)
+	})
+
+	resp, err := client.WaitForChainStart(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, true, resp.Started)
+	assert.Equal(t, uint64(1606824023), resp.GenesisTime)
+	assert.Equal(t, "0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95", hexutil.Encode(resp.GenesisValidatorsRoot))
+}
+
+func TestWaitForChainStart_ServerError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, err := w.Write([]byte(`{"code":500,"message":"Internal error"}`))
+		require.NoError(t, err)
+	})
+	_, err := client.WaitForChainStart(context.Background(), &emptypb.Empty{})
+	assert.ErrorContains(t, "responded with 500: Internal error", err)
+}
+
+func TestMultipleValidatorStatus(t *testing.T) {
+	known := bytesutil.PadTo([]byte{0x01}, 48)
+	unknown := bytesutil.PadTo([]byte{0x02}, 48)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/eth/v1/beacon/states/head/validators", r.URL.Path)
+		assert.DeepEqual(t, []string{hexutil.Encode(known), hexutil.Encode(unknown)}, r.URL.Query()["id"])
+		_, err := w.Write([]byte(`{"data":[{"index":"7","balance":"32000000000","status":"active_ongoing","validator":{"pubkey":"` +
+			hexutil.Encode(known) + `","effective_balance":"32000000000","activation_epoch":"4","exit_epoch":"18446744073709551615"}}]}`))
+		require.NoError(t, err)
+	})
+
+	resp, err := client.MultipleValidatorStatus(context.Background(), &ethpb.MultipleValidatorStatusRequest{
+		PublicKeys: [][]byte{known, unknown, known},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(resp.PublicKeys))
+	assert.DeepEqual(t, known, resp.PublicKeys[0])
+	assert.Equal(t, ethpb.ValidatorStatus_ACTIVE, resp.Statuses[0].Status)
+	assert.Equal(t, params.BeaconConfig().GenesisEpoch+4, resp.Statuses[0].ActivationEpoch)
+	assert.Equal(t, uint64(7), uint64(resp.Indices[0]))
+	assert.DeepEqual(t, unknown, resp.PublicKeys[1])
+	assert.Equal(t, ethpb.ValidatorStatus_UNKNOWN_STATUS, resp.Statuses[1].Status)
+	assert.Equal(t, params.BeaconConfig().FarFutureEpoch, resp.Statuses[1].ActivationEpoch)
+	assert.Equal(t, nonExistentIndex, resp.Indices[1])
+}
+
+func TestGetAttestationData(t *testing.T) {
+	root := hexutil.Encode(bytesutil.PadTo([]byte{0xaa}, 32))
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/eth/v1/validator/attestation_data", r.URL.Path)
+		assert.Equal(t, "5", r.URL.Query().Get("slot"))
+		assert.Equal(t, "1", r.URL.Query().Get("committee_index"))
+		_, err := w.Write([]byte(`{"data":{"slot":"5","index":"1","beacon_block_root":"` + root +
+			`","source":{"epoch":"0","root":"` + root + `"},"target":{"epoch":"1","root":"` + root + `"}}}`))
+		require.NoError(t, err)
+	})
+
+	data, err := client.GetAttestationData(context.Background(), &ethpb.AttestationDataRequest{Slot: 5, CommitteeIndex: 1})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), uint64(data.Slot))
+	assert.Equal(t, uint64(1), uint64(data.CommitteeIndex))
+	assert.Equal(t, uint64(1), uint64(data.Target.Epoch))
+	assert.Equal(t, root, hexutil.Encode(data.BeaconBlockRoot))
+}
+
+func TestProposeExit(t *testing.T) {
+	exit := &ethpb.SignedVoluntaryExit{
+		Exit:      &ethpb.VoluntaryExit{Epoch: 10, ValidatorIndex: 3},
+		Signature: bytesutil.PadTo([]byte{0x01}, 96),
+	}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/eth/v1/beacon/pool/voluntary_exits", r.URL.Path)
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		decoded := &struct {
+			Message   map[string]string `json:"message"`
+			Signature string            `json:"signature"`
+		}{}
+		require.NoError(t, json.Unmarshal(body, decoded))
+		assert.Equal(t, "10", decoded.Message["epoch"])
+		assert.Equal(t, "3", decoded.Message["validator_index"])
+		assert.Equal(t, hexutil.Encode(exit.Signature), decoded.Signature)
+	})
+
+	resp, err := client.ProposeExit(context.Background(), exit)
+	require.NoError(t, err)
+	root, err := exit.Exit.HashTreeRoot()
+	require.NoError(t, err)
+	assert.DeepEqual(t, root[:], resp.ExitRoot)
+}
+
+func TestGetValidatorPerformance_NotSupported(t *testing.T) {
+	client, err := NewBeaconApiBeaconChainClient("http://127.0.0.1:3500", time.Second)
+	require.NoError(t, err)
+	_, err = client.GetValidatorPerformance(context.Background(), &ethpb.ValidatorPerformanceRequest{})
+	assert.ErrorContains(t, iface.ErrNotSupported.Error(), err)
+}
+
+func TestListPeers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/eth/v1/node/peers", r.URL.Path)
+		assert.Equal(t, "connected", r.URL.Query().Get("state"))
+		_, err := w.Write([]byte(`{"data":[{"peer_id":"16Uiu2HAm","enr":"","last_seen_p2p_address":"/ip4/1.2.3.4/tcp/13000","state":"connected","direction":"outbound"}]}`))
+		require.NoError(t, err)
+	}))
+	defer srv.Close()
+	client, err := NewBeaconApiNodeClient(srv.URL, time.Second)
+	require.NoError(t, err)
+
+	peers, err := client.ListPeers(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(peers.Peers))
+	assert.Equal(t, "16Uiu2HAm", peers.Peers[0].PeerId)
+	assert.Equal(t, ethpb.PeerDirection_OUTBOUND, peers.Peers[0].Direction)
+	assert.Equal(t, ethpb.ConnectionState_CONNECTED, peers.Peers[0].ConnectionState)
+}
@@ -0,0 +1,480 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/validator/client/iface"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	// maxHealthyHeadLag is how many slots a node's head may trail the best known head
+	// before the node is no longer considered healthy.
+	maxHealthyHeadLag = types.Slot(2)
+	// minHealthyPeers is the peer count below which a node cannot be relied upon to see,
+	// nor to publish to, the rest of the network.
+	minHealthyPeers = 1
+)
+
+var (
+	healthProbeInterval = 4 * time.Second
+	healthProbeTimeout  = 2 * time.Second
+)
+
+var (
+	beaconNodeHealthyGaugeVec = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "validator",
+			Name:      "beacon_node_healthy",
+			Help:      "1 if the beacon node is synced, not optimistic, close to the best head and has peers, 0 otherwise",
+		},
+		[]string{"endpoint"},
+	)
+	beaconNodeHeadSlotGaugeVec = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "validator",
+			Name:      "beacon_node_head_slot",
+			Help:      "The head slot last reported by the beacon node",
+		},
+		[]string{"endpoint"},
+	)
+	beaconNodePeersGaugeVec = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "validator",
+			Name:      "beacon_node_peers",
+			Help:      "The number of peers last reported by the beacon node",
+		},
+		[]string{"endpoint"},
+	)
+	beaconNodePrimaryGaugeVec = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "validator",
+			Name:      "beacon_node_primary",
+			Help:      "1 for the beacon node duties are currently routed to, 0 otherwise",
+		},
+		[]string{"endpoint"},
+	)
+	beaconNodeFailoverCount = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "validator",
+			Name:      "beacon_node_failovers_total",
+			Help:      "The number of times duties were routed to a different beacon node",
+		},
+	)
+)
+
+// beaconNode is one of the beacon nodes the validator client is configured with.
+type beaconNode struct {
+	endpoint        string
+	validatorClient iface.ValidatorClient
+	nodeClient      iface.NodeClient
+	beaconClient    iface.BeaconChainClient
+	// slasherClient is nil when the node is not talked to over gRPC.
+	slasherClient ethpb.SlasherClient
+	health        beaconNodeHealth
+}
+
+// subscriptionKind is a kind of call which leaves state on the beacon node it is made against.
+type subscriptionKind int
+
+const (
+	committeeSubnetsSubscription subscriptionKind = iota
+	// Prysm beacon nodes subscribe to the sync committee subnets of the validators they
+	// return duties for.
+	syncCommitteeSubnetsSubscription
+	proposerPreparationSubscription
+	validatorRegistrationSubscription
+)
+
+var subscriptionKindNames = map[subscriptionKind]string{
+	committeeSubnetsSubscription:      "committee subnets",
+	syncCommitteeSubnetsSubscription:  "sync committee subnets",
+	proposerPreparationSubscription:   "proposer preparation",
+	validatorRegistrationSubscription: "validator registration",
+}
+
+// subscription is the last call of a kind, replayed on a new primary as the new primary would
+// otherwise miss the state left by the call.
+type subscription struct {
+	// node is the node the call was last made against. It is nil for calls broadcast to
+	// several nodes, which are replayed on every new primary.
+	node   *beaconNode
+	replay func(ctx context.Context, n *beaconNode) error
+}
+
+// beaconNodeHealth is the result of the last probe of a beacon node.
+type beaconNodeHealth struct {
+	probed     bool
+	reachable  bool
+	syncing    bool
+	optimistic bool
+	headSlot   types.Slot
+	// peers is -1 when the node could not tell its peer count.
+	peers int
+}
+
+// usable reports whether the node can serve requests at all. Nodes which were not probed yet
+// get the benefit of the doubt.
+func (h beaconNodeHealth) usable() bool {
+	return !h.probed || (h.reachable && !h.syncing)
+}
+
+// healthy reports whether the node can be trusted to perform duties against, given the best
+// head slot known across all nodes.
+func (h beaconNodeHealth) healthy(bestHead types.Slot) bool {
+	if !h.probed {
+		return true
+	}
+	if !h.usable() || h.optimistic {
+		return false
+	}
+	if h.headSlot+maxHealthyHeadLag < bestHead {
+		return false
+	}
+	return h.peers < 0 || h.peers >= minHealthyPeers
+}
+
+// healthier reports whether a should be preferred over b. Nodes which compare equal keep
+// their configured order.
+func healthier(a, b beaconNodeHealth, bestHead types.Slot) bool {
+	if a.usable() != b.usable() {
+		return a.usable()
+	}
+	if ha, hb := a.healthy(bestHead), b.healthy(bestHead); ha != hb {
+		return ha
+	}
+	if a.headSlot != b.headSlot {
+		return a.headSlot > b.headSlot
+	}
+	return a.peers > b.peers
+}
+
+// beaconNodeFailover routes the validator client's calls across several beacon nodes. It probes
+// every node in the background, sends duties to the healthiest one, falls through to the next
+// node as soon as a node stops answering, and publishes signed messages to all healthy nodes.
+type beaconNodeFailover struct {
+	ctx   context.Context
+	nodes []*beaconNode
+
+	lock     sync.RWMutex
+	ordered  []*beaconNode
+	primary  *beaconNode
+	bestHead types.Slot
+	// streamsCtx is canceled when the primary changes, which closes the streams opened on the
+	// previous primary so they get reopened on the new one.
+	streamsCtx    context.Context
+	streamsCancel context.CancelFunc
+
+	subscriptionLock sync.Mutex
+	subscriptions    map[subscriptionKind]*subscription
+}
+
+func newBeaconNodeFailover(ctx context.Context, nodes []*beaconNode) *beaconNodeFailover {
+	f := &beaconNodeFailover{
+		ctx:           ctx,
+		nodes:         nodes,
+		ordered:       append([]*beaconNode{}, nodes...),
+		primary:       nodes[0],
+		subscriptions: make(map[subscriptionKind]*subscription),
+	}
+	f.streamsCtx, f.streamsCancel = context.WithCancel(ctx)
+	for _, n := range nodes {
+		beaconNodePrimaryGaugeVec.WithLabelValues(n.endpoint).Set(0)
+	}
+	beaconNodePrimaryGaugeVec.WithLabelValues(f.primary.endpoint).Set(1)
+	return f
+}
+
+// run probes the beacon nodes on an interval until the context is canceled.
+func (f *beaconNodeFailover) run() {
+	ticker := time.NewTicker(healthProbeInterval)
+	defer ticker.Stop()
+	for {
+		f.probeAll()
+		select {
+		case <-f.ctx.Done():
+			f.streamsCancel()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (f *beaconNodeFailover) probeAll() {
+	results := make([]beaconNodeHealth, len(f.nodes))
+	var wg sync.WaitGroup
+	for i, n := range f.nodes {
+		wg.Add(1)
+		go func(i int, n *beaconNode) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(f.ctx, healthProbeTimeout)
+			defer cancel()
+			results[i] = probe(ctx, n)
+		}(i, n)
+	}
+	wg.Wait()
+	if f.ctx.Err() != nil {
+		return
+	}
+
+	f.lock.Lock()
+	for i, n := range f.nodes {
+		n.health = results[i]
+	}
+	switched := f.reorder()
+	f.lock.Unlock()
+	if switched {
+		go f.resubscribe()
+	}
+}
+
+// probe queries the sync status, head and peer count of a beacon node.
+func probe(ctx context.Context, n *beaconNode) beaconNodeHealth {
+	h := beaconNodeHealth{probed: true, peers: -1}
+	syncStatus, err := n.nodeClient.GetSyncStatus(ctx, &emptypb.Empty{})
+	if err != nil {
+		log.WithError(err).WithField("endpoint", n.endpoint).Debug("Could not get beacon node sync status")
+		return h
+	}
+	h.reachable = true
+	h.syncing = syncStatus.Syncing
+	head, err := n.beaconClient.GetChainHead(ctx, &emptypb.Empty{})
+	if err != nil {
+		log.WithError(err).WithField("endpoint", n.endpoint).Debug("Could not get beacon node chain head")
+		h.reachable = false
+		return h
+	}
+	h.headSlot = head.HeadSlot
+	h.optimistic = head.OptimisticStatus
+	peers, err := n.nodeClient.ListPeers(ctx, &emptypb.Empty{})
+	if err != nil {
+		log.WithError(err).WithField("endpoint", n.endpoint).Debug("Could not get beacon node peers")
+		return h
+	}
+	h.peers = len(peers.Peers)
+	return h
+}
+
+// reorder sorts the nodes by health and picks the primary. The current primary is kept for as
+// long as it is healthy, so that duties do not bounce between nodes of similar health.
+// It reports whether the primary changed. The caller must hold the lock.
+func (f *beaconNodeFailover) reorder() bool {
+	f.bestHead = 0
+	for _, n := range f.nodes {
+		if n.health.usable() && n.health.headSlot > f.bestHead {
+			f.bestHead = n.health.headSlot
+		}
+	}
+	sort.SliceStable(f.ordered, func(i, j int) bool {
+		return healthier(f.ordered[i].health, f.ordered[j].health, f.bestHead)
+	})
+	for _, n := range f.nodes {
+		healthy := 0.0
+		if n.health.healthy(f.bestHead) {
+			healthy = 1
+		}
+		beaconNodeHealthyGaugeVec.WithLabelValues(n.endpoint).Set(healthy)
+		beaconNodeHeadSlotGaugeVec.WithLabelValues(n.endpoint).Set(float64(n.health.headSlot))
+		beaconNodePeersGaugeVec.WithLabelValues(n.endpoint).Set(float64(n.health.peers))
+	}
+
+	best := f.ordered[0]
+	if best == f.primary || f.primary.health.healthy(f.bestHead) || !healthier(best.health, f.primary.health, f.bestHead) {
+		return false
+	}
+	log.WithFields(logrus.Fields{
+		"previous": f.primary.endpoint,
+		"endpoint": best.endpoint,
+	}).Warn("Switching to a healthier beacon node")
+	beaconNodePrimaryGaugeVec.WithLabelValues(f.primary.endpoint).Set(0)
+	beaconNodePrimaryGaugeVec.WithLabelValues(best.endpoint).Set(1)
+	beaconNodeFailoverCount.Inc()
+	f.primary = best
+	f.streamsCancel()
+	f.streamsCtx, f.streamsCancel = context.WithCancel(f.ctx)
+	return true
+}
+
+// candidates returns the primary followed by the other nodes, healthiest first.
+func (f *beaconNodeFailover) candidates() []*beaconNode {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	nodes := make([]*beaconNode, 0, len(f.ordered))
+	nodes = append(nodes, f.primary)
+	for _, n := range f.ordered {
+		if n != f.primary {
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes
+}
+
+// healthyNodes returns the primary, if it is still usable, followed by every other healthy node.
+func (f *beaconNodeFailover) healthyNodes() []*beaconNode {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	var nodes []*beaconNode
+	if f.primary.health.usable() {
+		nodes = append(nodes, f.primary)
+	}
+	for _, n := range f.ordered {
+		if n != f.primary && n.health.healthy(f.bestHead) {
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes
+}
+
+// markUnreachable records that a call to the node failed for connectivity reasons, without
+// waiting for the next probe, and fails over if the node was the primary.
+func (f *beaconNodeFailover) markUnreachable(n *beaconNode) {
+	f.lock.Lock()
+	n.health.probed = true
+	n.health.reachable = false
+	switched := f.reorder()
+	f.lock.Unlock()
+	if switched {
+		go f.resubscribe()
+	}
+}
+
+// call performs fn against the primary. If the primary cannot be reached, the next healthiest
+// node is tried right away so that no duty is missed while waiting for the next probe.
+func (f *beaconNodeFailover) call(ctx context.Context, fn func(n *beaconNode) (interface{}, error)) (interface{}, error) {
+	var err error
+	for _, n := range f.candidates() {
+		var resp interface{}
+		resp, err = fn(n)
+		if err == nil || !isBeaconNodeUnreachable(ctx, err) {
+			return resp, err
+		}
+		log.WithError(err).WithField("endpoint", n.endpoint).Warn("Beacon node is unreachable, trying the next one")
+		f.markUnreachable(n)
+	}
+	return nil, err
+}
+
+// broadcast performs fn against every healthy node at once, so that signed messages reach the
+// network even if one of the nodes is poorly connected. It returns as soon as one node
+// accepted the message, or with the primary's error if none did.
+func (f *beaconNodeFailover) broadcast(ctx context.Context, fn func(n *beaconNode) (interface{}, error)) (interface{}, error) {
+	nodes := f.healthyNodes()
+	if len(nodes) == 0 {
+		return f.call(ctx, fn)
+	}
+	type result struct {
+		node *beaconNode
+		resp interface{}
+		err  error
+	}
+	results := make(chan result, len(nodes))
+	for _, n := range nodes {
+		go func(n *beaconNode) {
+			resp, err := fn(n)
+			results <- result{node: n, resp: resp, err: err}
+		}(n)
+	}
+	var firstErr error
+	for i := 0; i < len(nodes); i++ {
+		r := <-results
+		if r.err == nil {
+			return r.resp, nil
+		}
+		log.WithError(r.err).WithField("endpoint", r.node.endpoint).Debug("Beacon node did not accept the message")
+		if isBeaconNodeUnreachable(ctx, r.err) {
+			f.markUnreachable(r.node)
+		}
+		if firstErr == nil || r.node == nodes[0] {
+			firstErr = r.err
+		}
+	}
+	return nil, firstErr
+}
+
+// streamContext returns a context for a stream opened on the primary, which gets canceled when
+// the primary changes.
+func (f *beaconNodeFailover) streamContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	f.lock.RLock()
+	switched := f.streamsCtx.Done()
+	f.lock.RUnlock()
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		select {
+		case <-switched:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
+
+// subscribed records the last call of a kind made against n, to be replayed on a new primary.
+func (f *beaconNodeFailover) subscribed(kind subscriptionKind, n *beaconNode, replay func(ctx context.Context, n *beaconNode) error) {
+	f.subscriptionLock.Lock()
+	defer f.subscriptionLock.Unlock()
+	f.subscriptions[kind] = &subscription{node: n, replay: replay}
+}
+
+// resubscribe replays the last call of every subscription kind on the primary, as the new
+// primary would otherwise not be subscribed to the subnets of the current duties, nor know
+// about the proposers of the validator client.
+func (f *beaconNodeFailover) resubscribe() {
+	f.subscriptionLock.Lock()
+	defer f.subscriptionLock.Unlock()
+	f.lock.RLock()
+	primary := f.primary
+	f.lock.RUnlock()
+	kinds := make([]subscriptionKind, 0, len(f.subscriptions))
+	for kind := range f.subscriptions {
+		kinds = append(kinds, kind)
+	}
+	sort.Slice(kinds, func(i, j int) bool { return kinds[i] < kinds[j] })
+	for _, kind := range kinds {
+		sub := f.subscriptions[kind]
+		if sub.node == primary {
+			continue
+		}
+		if err := sub.replay(f.ctx, primary); err != nil {
+			log.WithError(err).WithFields(logrus.Fields{
+				"endpoint":     primary.endpoint,
+				"subscription": subscriptionKindNames[kind],
+			}).Error("Could not replay subscription on the new beacon node")
+			continue
+		}
+		if sub.node != nil {
+			sub.node = primary
+		}
+	}
+}
+
+// isBeaconNodeUnreachable reports whether the error means the node could not be talked to, as
+// opposed to the node rejecting the request.
+func isBeaconNodeUnreachable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		// The caller gave up, the node is not to blame.
+		return false
+	}
+	if errors.Is(err, iface.ErrConnectionIssue) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	if s, ok := status.FromError(errors.Cause(err)); ok {
+		switch s.Code() {
+		case codes.Unavailable, codes.DeadlineExceeded:
+			return true
+		}
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
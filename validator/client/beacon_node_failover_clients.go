@@ -0,0 +1,468 @@
+package client
+
+import (
+	"context"
+
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/validator/client/iface"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// failoverValidatorClient performs the validator client's calls through a beaconNodeFailover.
+// Signed messages are broadcast to every healthy node, everything else goes to the primary.
+type failoverValidatorClient struct {
+	*beaconNodeFailover
+}
+
+var _ iface.ValidatorClient = (*failoverValidatorClient)(nil)
+
+// GetDuties gets the duties from the primary and remembers the request, as the beacon node
+// subscribes to the sync committee subnets of the validators it returns duties for.
+func (c *failoverValidatorClient) GetDuties(ctx context.Context, in *ethpb.DutiesRequest) (*ethpb.DutiesResponse, error) {
+	var requested *beaconNode
+	resp, err := c.call(ctx, func(n *beaconNode) (interface{}, error) {
+		requested = n
+		return n.validatorClient.GetDuties(ctx, in)
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.subscribed(syncCommitteeSubnetsSubscription, requested, func(ctx context.Context, n *beaconNode) error {
+		_, err := n.validatorClient.GetDuties(ctx, in)
+		return err
+	})
+	return resp.(*ethpb.DutiesResponse), nil
+}
+
+func (c *failoverValidatorClient) DomainData(ctx context.Context, in *ethpb.DomainRequest) (*ethpb.DomainResponse, error) {
+	resp, err := c.call(ctx, func(n *beaconNode) (interface{}, error) {
+		return n.validatorClient.DomainData(ctx, in)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*ethpb.DomainResponse), nil
+}
+
+func (c *failoverValidatorClient) WaitForChainStart(ctx context.Context, in *emptypb.Empty) (*ethpb.ChainStartResponse, error) {
+	resp, err := c.call(ctx, func(n *beaconNode) (interface{}, error) {
+		return n.validatorClient.WaitForChainStart(ctx, in)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*ethpb.ChainStartResponse), nil
+}
+
+func (c *failoverValidatorClient) ValidatorIndex(ctx context.Context, in *ethpb.ValidatorIndexRequest) (*ethpb.ValidatorIndexResponse, error) {
+	resp, err := c.call(ctx, func(n *beaconNode) (interface{}, error) {
+		return n.validatorClient.ValidatorIndex(ctx, in)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*ethpb.ValidatorIndexResponse), nil
+}
+
+func (c *failoverValidatorClient) MultipleValidatorStatus(ctx context.Context, in *ethpb.MultipleValidatorStatusRequest) (*ethpb.MultipleValidatorStatusResponse, error) {
+	resp, err := c.call(ctx, func(n *beaconNode) (interface{}, error) {
+		return n.validatorClient.MultipleValidatorStatus(ctx, in)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*ethpb.MultipleValidatorStatusResponse), nil
+}
+
+func (c *failoverValidatorClient) GetBeaconBlock(ctx context.Context, in *ethpb.BlockRequest) (*ethpb.GenericBeaconBlock, error) {
+	resp, err := c.call(ctx, func(n *beaconNode) (interface{}, error) {
+		return n.validatorClient.GetBeaconBlock(ctx, in)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*ethpb.GenericBeaconBlock), nil
+}
+
+func (c *failoverValidatorClient) ProposeBeaconBlock(ctx context.Context, in *ethpb.GenericSignedBeaconBlock) (*ethpb.ProposeResponse, error) {
+	resp, err := c.broadcast(ctx, func(n *beaconNode) (interface{}, error) {
+		return n.validatorClient.ProposeBeaconBlock(ctx, in)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*ethpb.ProposeResponse), nil
+}
+
+func (c *failoverValidatorClient) GetAttestationData(ctx context.Context, in *ethpb.AttestationDataRequest) (*ethpb.AttestationData, error) {
+	resp, err := c.call(ctx, func(n *beaconNode) (interface{}, error) {
+		return n.validatorClient.GetAttestationData(ctx, in)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*ethpb.AttestationData), nil
+}
+
+func (c *failoverValidatorClient) ProposeAttestation(ctx context.Context, in *ethpb.Attestation) (*ethpb.AttestResponse, error) {
+	resp, err := c.broadcast(ctx, func(n *beaconNode) (interface{}, error) {
+		return n.validatorClient.ProposeAttestation(ctx, in)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*ethpb.AttestResponse), nil
+}
+
+func (c *failoverValidatorClient) SubmitAggregateSelectionProof(ctx context.Context, in *ethpb.AggregateSelectionRequest) (*ethpb.AggregateSelectionResponse, error) {
+	resp, err := c.call(ctx, func(n *beaconNode) (interface{}, error) {
+		return n.validatorClient.SubmitAggregateSelectionProof(ctx, in)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*ethpb.AggregateSelectionResponse), nil
+}
+
+func (c *failoverValidatorClient) SubmitSignedAggregateSelectionProof(ctx context.Context, in *ethpb.SignedAggregateSubmitRequest) (*ethpb.SignedAggregateSubmitResponse, error) {
+	resp, err := c.broadcast(ctx, func(n *beaconNode) (interface{}, error) {
+		return n.validatorClient.SubmitSignedAggregateSelectionProof(ctx, in)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*ethpb.SignedAggregateSubmitResponse), nil
+}
+
+func (c *failoverValidatorClient) ProposeExit(ctx context.Context, in *ethpb.SignedVoluntaryExit) (*ethpb.ProposeExitResponse, error) {
+	resp, err := c.broadcast(ctx, func(n *beaconNode) (interface{}, error) {
+		return n.validatorClient.ProposeExit(ctx, in)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*ethpb.ProposeExitResponse), nil
+}
+
+func (c *failoverValidatorClient) CheckDoppelGanger(ctx context.Context, in *ethpb.DoppelGangerRequest) (*ethpb.DoppelGangerResponse, error) {
+	resp, err := c.call(ctx, func(n *beaconNode) (interface{}, error) {
+		return n.validatorClient.CheckDoppelGanger(ctx, in)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*ethpb.DoppelGangerResponse), nil
+}
+
+func (c *failoverValidatorClient) GetSyncMessageBlockRoot(ctx context.Context, in *emptypb.Empty) (*ethpb.SyncMessageBlockRootResponse, error) {
+	resp, err := c.call(ctx, func(n *beaconNode) (interface{}, error) {
+		return n.validatorClient.GetSyncMessageBlockRoot(ctx, in)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*ethpb.SyncMessageBlockRootResponse), nil
+}
+
+func (c *failoverValidatorClient) SubmitSyncMessage(ctx context.Context, in *ethpb.SyncCommitteeMessage) (*emptypb.Empty, error) {
+	resp, err := c.broadcast(ctx, func(n *beaconNode) (interface{}, error) {
+		return n.validatorClient.SubmitSyncMessage(ctx, in)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*emptypb.Empty), nil
+}
+
+func (c *failoverValidatorClient) GetSyncSubcommitteeIndex(ctx context.Context, in *ethpb.SyncSubcommitteeIndexRequest) (*ethpb.SyncSubcommitteeIndexResponse, error) {
+	resp, err := c.call(ctx, func(n *beaconNode) (interface{}, error) {
+		return n.validatorClient.GetSyncSubcommitteeIndex(ctx, in)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*ethpb.SyncSubcommitteeIndexResponse), nil
+}
+
+func (c *failoverValidatorClient) GetSyncCommitteeContribution(ctx context.Context, in *ethpb.SyncCommitteeContributionRequest) (*ethpb.SyncCommitteeContribution, error) {
+	resp, err := c.call(ctx, func(n *beaconNode) (interface{}, error) {
+		return n.validatorClient.GetSyncCommitteeContribution(ctx, in)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*ethpb.SyncCommitteeContribution), nil
+}
+
+func (c *failoverValidatorClient) SubmitSignedContributionAndProof(ctx context.Context, in *ethpb.SignedContributionAndProof) (*emptypb.Empty, error) {
+	resp, err := c.broadcast(ctx, func(n *beaconNode) (interface{}, error) {
+		return n.validatorClient.SubmitSignedContributionAndProof(ctx, in)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*emptypb.Empty), nil
+}
+
+func (c *failoverValidatorClient) SubmitValidatorRegistration(ctx context.Context, in *ethpb.SignedValidatorRegistrationsV1) (*emptypb.Empty, error) {
+	resp, err := c.broadcast(ctx, func(n *beaconNode) (interface{}, error) {
+		return n.validatorClient.SubmitValidatorRegistration(ctx, in)
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.subscribed(validatorRegistrationSubscription, nil, func(ctx context.Context, n *beaconNode) error {
+		_, err := n.validatorClient.SubmitValidatorRegistration(ctx, in)
+		return err
+	})
+	return resp.(*emptypb.Empty), nil
+}
+
+func (c *failoverValidatorClient) PrepareBeaconProposer(ctx context.Context, in *ethpb.PrepareBeaconProposerRequest) (*emptypb.Empty, error) {
+	resp, err := c.broadcast(ctx, func(n *beaconNode) (interface{}, error) {
+		return n.validatorClient.PrepareBeaconProposer(ctx, in)
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.subscribed(proposerPreparationSubscription, nil, func(ctx context.Context, n *beaconNode) error {
+		_, err := n.validatorClient.PrepareBeaconProposer(ctx, in)
+		return err
+	})
+	return resp.(*emptypb.Empty), nil
+}
+
+func (c *failoverValidatorClient) GetAggregatedSelections(ctx context.Context, selections []iface.BeaconCommitteeSelection) ([]iface.BeaconCommitteeSelection, error) {
+	resp, err := c.call(ctx, func(n *beaconNode) (interface{}, error) {
+		return n.validatorClient.GetAggregatedSelections(ctx, selections)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.([]iface.BeaconCommitteeSelection), nil
+}
+
+func (c *failoverValidatorClient) GetAggregatedSyncSelections(ctx context.Context, selections []iface.SyncCommitteeSelection) ([]iface.SyncCommitteeSelection, error) {
+	resp, err := c.call(ctx, func(n *beaconNode) (interface{}, error) {
+		return n.validatorClient.GetAggregatedSyncSelections(ctx, selections)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.([]iface.SyncCommitteeSelection), nil
+}
+
+func (c *failoverValidatorClient) GetValidatorsLiveness(ctx context.Context, epoch types.Epoch, indices []types.ValidatorIndex) ([]iface.ValidatorLiveness, error) {
+	resp, err := c.call(ctx, func(n *beaconNode) (interface{}, error) {
+		return n.validatorClient.GetValidatorsLiveness(ctx, epoch, indices)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.([]iface.ValidatorLiveness), nil
+}
+
+// SubscribeCommitteeSubnets subscribes on the primary and remembers the subscription, so it can
+// be replayed should the primary change before the duties are performed.
+func (c *failoverValidatorClient) SubscribeCommitteeSubnets(ctx context.Context, in *ethpb.CommitteeSubnetsSubscribeRequest, validatorIndices []types.ValidatorIndex) (*emptypb.Empty, error) {
+	var subscribed *beaconNode
+	resp, err := c.call(ctx, func(n *beaconNode) (interface{}, error) {
+		subscribed = n
+		return n.validatorClient.SubscribeCommitteeSubnets(ctx, in, validatorIndices)
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.subscribed(committeeSubnetsSubscription, subscribed, func(ctx context.Context, n *beaconNode) error {
+		_, err := n.validatorClient.SubscribeCommitteeSubnets(ctx, in, validatorIndices)
+		return err
+	})
+	return resp.(*emptypb.Empty), nil
+}
+
+// WaitForActivation opens the activation stream on the primary. The stream is closed when the
+// primary changes, upon which the caller reopens it.
+func (c *failoverValidatorClient) WaitForActivation(ctx context.Context, in *ethpb.ValidatorActivationRequest) (ethpb.BeaconNodeValidator_WaitForActivationClient, error) {
+	streamCtx, cancel := c.streamContext(ctx)
+	resp, err := c.call(ctx, func(n *beaconNode) (interface{}, error) {
+		return n.validatorClient.WaitForActivation(streamCtx, in)
+	})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &failoverActivationStream{resp.(ethpb.BeaconNodeValidator_WaitForActivationClient), cancel}, nil
+}
+
+// StreamBlocksAltair opens the blocks stream on the primary. The stream is closed when the
+// primary changes, upon which the caller reopens it.
+func (c *failoverValidatorClient) StreamBlocksAltair(ctx context.Context, in *ethpb.StreamBlocksRequest) (ethpb.BeaconNodeValidator_StreamBlocksAltairClient, error) {
+	streamCtx, cancel := c.streamContext(ctx)
+	resp, err := c.call(ctx, func(n *beaconNode) (interface{}, error) {
+		return n.validatorClient.StreamBlocksAltair(streamCtx, in)
+	})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &failoverBlocksStream{resp.(ethpb.BeaconNodeValidator_StreamBlocksAltairClient), cancel}, nil
+}
+
+// failoverActivationStream releases the stream's context once the stream ended.
+type failoverActivationStream struct {
+	ethpb.BeaconNodeValidator_WaitForActivationClient
+	cancel context.CancelFunc
+}
+
+func (s *failoverActivationStream) Recv() (*ethpb.ValidatorActivationResponse, error) {
+	resp, err := s.BeaconNodeValidator_WaitForActivationClient.Recv()
+	if err != nil {
+		s.cancel()
+	}
+	return resp, err
+}
+
+// failoverBlocksStream releases the stream's context once the stream ended.
+type failoverBlocksStream struct {
+	ethpb.BeaconNodeValidator_StreamBlocksAltairClient
+	cancel context.CancelFunc
+}
+
+func (s *failoverBlocksStream) Recv() (*ethpb.StreamBlocksResponse, error) {
+	resp, err := s.BeaconNodeValidator_StreamBlocksAltairClient.Recv()
+	if err != nil {
+		s.cancel()
+	}
+	return resp, err
+}
+
+// failoverNodeClient performs node queries through a beaconNodeFailover.
+type failoverNodeClient struct {
+	*beaconNodeFailover
+}
+
+var _ iface.NodeClient = (*failoverNodeClient)(nil)
+
+func (c *failoverNodeClient) GetSyncStatus(ctx context.Context, in *emptypb.Empty) (*ethpb.SyncStatus, error) {
+	resp, err := c.call(ctx, func(n *beaconNode) (interface{}, error) {
+		return n.nodeClient.GetSyncStatus(ctx, in)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*ethpb.SyncStatus), nil
+}
+
+func (c *failoverNodeClient) GetGenesis(ctx context.Context, in *emptypb.Empty) (*ethpb.Genesis, error) {
+	resp, err := c.call(ctx, func(n *beaconNode) (interface{}, error) {
+		return n.nodeClient.GetGenesis(ctx, in)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*ethpb.Genesis), nil
+}
+
+func (c *failoverNodeClient) ListPeers(ctx context.Context, in *emptypb.Empty) (*ethpb.Peers, error) {
+	resp, err := c.call(ctx, func(n *beaconNode) (interface{}, error) {
+		return n.nodeClient.ListPeers(ctx, in)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*ethpb.Peers), nil
+}
+
+// failoverBeaconChainClient performs beacon chain queries through a beaconNodeFailover.
+type failoverBeaconChainClient struct {
+	*beaconNodeFailover
+}
+
+var _ iface.BeaconChainClient = (*failoverBeaconChainClient)(nil)
+
+func (c *failoverBeaconChainClient) GetChainHead(ctx context.Context, in *emptypb.Empty) (*ethpb.ChainHead, error) {
+	resp, err := c.call(ctx, func(n *beaconNode) (interface{}, error) {
+		return n.beaconClient.GetChainHead(ctx, in)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*ethpb.ChainHead), nil
+}
+
+func (c *failoverBeaconChainClient) GetValidatorPerformance(ctx context.Context, in *ethpb.ValidatorPerformanceRequest) (*ethpb.ValidatorPerformanceResponse, error) {
+	resp, err := c.call(ctx, func(n *beaconNode) (interface{}, error) {
+		return n.beaconClient.GetValidatorPerformance(ctx, in)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*ethpb.ValidatorPerformanceResponse), nil
+}
+
+// failoverSlasherClient performs slashing protection queries through a beaconNodeFailover.
+type failoverSlasherClient struct {
+	*beaconNodeFailover
+}
+
+var _ ethpb.SlasherClient = (*failoverSlasherClient)(nil)
+
+func (c *failoverSlasherClient) IsSlashableAttestation(ctx context.Context, in *ethpb.IndexedAttestation, opts ...grpc.CallOption) (*ethpb.AttesterSlashingResponse, error) {
+	resp, err := c.call(ctx, func(n *beaconNode) (interface{}, error) {
+		return n.slasherClient.IsSlashableAttestation(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*ethpb.AttesterSlashingResponse), nil
+}
+
+func (c *failoverSlasherClient) IsSlashableBlock(ctx context.Context, in *ethpb.SignedBeaconBlockHeader, opts ...grpc.CallOption) (*ethpb.ProposerSlashingResponse, error) {
+	resp, err := c.call(ctx, func(n *beaconNode) (interface{}, error) {
+		return n.slasherClient.IsSlashableBlock(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*ethpb.ProposerSlashingResponse), nil
+}
+
+func (c *failoverSlasherClient) HighestAttestations(ctx context.Context, in *ethpb.HighestAttestationRequest, opts ...grpc.CallOption) (*ethpb.HighestAttestationResponse, error) {
+	resp, err := c.call(ctx, func(n *beaconNode) (interface{}, error) {
+		return n.slasherClient.HighestAttestations(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*ethpb.HighestAttestationResponse), nil
+}
+
+// StreamSlasherData opens the slasher data stream on the primary. The stream is closed when the
+// primary changes, upon which the caller reopens it.
+func (c *failoverSlasherClient) StreamSlasherData(ctx context.Context, opts ...grpc.CallOption) (ethpb.Slasher_StreamSlasherDataClient, error) {
+	streamCtx, cancel := c.streamContext(ctx)
+	resp, err := c.call(ctx, func(n *beaconNode) (interface{}, error) {
+		return n.slasherClient.StreamSlasherData(streamCtx, opts...)
+	})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &failoverSlasherStream{resp.(ethpb.Slasher_StreamSlasherDataClient), cancel}, nil
+}
+
+// failoverSlasherStream releases the stream's context once the stream ended.
+type failoverSlasherStream struct {
+	ethpb.Slasher_StreamSlasherDataClient
+	cancel context.CancelFunc
+}
+
+func (s *failoverSlasherStream) Recv() (*ethpb.DetectedSlashings, error) {
+	resp, err := s.Slasher_StreamSlasherDataClient.Recv()
+	if err != nil {
+		s.cancel()
+	}
+	return resp, err
+}
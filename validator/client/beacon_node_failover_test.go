@@ -0,0 +1,273 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/mock"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	validatormock "github.com/prysmaticlabs/prysm/testing/validator-mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type mockBeaconNode struct {
+	*beaconNode
+	validatorClient *validatormock.MockValidatorClient
+	nodeClient      *validatormock.MockNodeClient
+	beaconClient    *validatormock.MockBeaconChainClient
+	slasherClient   *mock.MockSlasherClient
+}
+
+func newMockBeaconNode(ctrl *gomock.Controller, endpoint string) *mockBeaconNode {
+	m := &mockBeaconNode{
+		validatorClient: validatormock.NewMockValidatorClient(ctrl),
+		nodeClient:      validatormock.NewMockNodeClient(ctrl),
+		beaconClient:    validatormock.NewMockBeaconChainClient(ctrl),
+		slasherClient:   mock.NewMockSlasherClient(ctrl),
+	}
+	m.beaconNode = &beaconNode{
+		endpoint:        endpoint,
+		validatorClient: m.validatorClient,
+		nodeClient:      m.nodeClient,
+		beaconClient:    m.beaconClient,
+		slasherClient:   m.slasherClient,
+	}
+	return m
+}
+
+func (m *mockBeaconNode) expectProbe(syncing bool, headSlot types.Slot, peers int) {
+	m.nodeClient.EXPECT().GetSyncStatus(gomock.Any(), gomock.Any()).Return(&ethpb.SyncStatus{Syncing: syncing}, nil)
+	m.beaconClient.EXPECT().GetChainHead(gomock.Any(), gomock.Any()).Return(&ethpb.ChainHead{HeadSlot: headSlot}, nil)
+	m.nodeClient.EXPECT().ListPeers(gomock.Any(), gomock.Any()).Return(&ethpb.Peers{Peers: make([]*ethpb.Peer, peers)}, nil)
+}
+
+func TestHealthier(t *testing.T) {
+	healthy := beaconNodeHealth{probed: true, reachable: true, headSlot: 100, peers: 50}
+	tests := []struct {
+		name string
+		a, b beaconNodeHealth
+	}{
+		{
+			name: "reachable over unreachable",
+			a:    beaconNodeHealth{probed: true, reachable: true, syncing: false, headSlot: 10, peers: 0},
+			b:    beaconNodeHealth{probed: true, reachable: false},
+		},
+		{
+			name: "synced over syncing",
+			a:    beaconNodeHealth{probed: true, reachable: true, headSlot: 90, peers: 0},
+			b:    beaconNodeHealth{probed: true, reachable: true, syncing: true, headSlot: 100, peers: 50},
+		},
+		{
+			name: "not optimistic over optimistic",
+			a:    beaconNodeHealth{probed: true, reachable: true, headSlot: 99, peers: 10},
+			b:    beaconNodeHealth{probed: true, reachable: true, optimistic: true, headSlot: 100, peers: 50},
+		},
+		{
+			name: "close to the head over lagging",
+			a:    beaconNodeHealth{probed: true, reachable: true, headSlot: 98, peers: 1},
+			b:    beaconNodeHealth{probed: true, reachable: true, headSlot: 90, peers: 50},
+		},
+		{
+			name: "peers over no peers",
+			a:    beaconNodeHealth{probed: true, reachable: true, headSlot: 99, peers: 1},
+			b:    beaconNodeHealth{probed: true, reachable: true, headSlot: 100, peers: 0},
+		},
+		{
+			name: "higher head",
+			a:    healthy,
+			b:    beaconNodeHealth{probed: true, reachable: true, headSlot: 99, peers: 50},
+		},
+		{
+			name: "more peers",
+			a:    healthy,
+			b:    beaconNodeHealth{probed: true, reachable: true, headSlot: 100, peers: 10},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, true, healthier(tt.a, tt.b, 100))
+			assert.Equal(t, false, healthier(tt.b, tt.a, 100))
+		})
+	}
+	assert.Equal(t, false, healthier(healthy, healthy, 100), "Equal nodes must keep their configured order")
+	assert.Equal(t, true, beaconNodeHealth{}.healthy(100), "Nodes not probed yet must be given the benefit of the doubt")
+}
+
+func TestBeaconNodeFailover_ProbeSwitchesPrimaryAndResubscribes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	first := newMockBeaconNode(ctrl, "first")
+	second := newMockBeaconNode(ctrl, "second")
+	f := newBeaconNodeFailover(context.Background(), []*beaconNode{first.beaconNode, second.beaconNode})
+	client := &failoverValidatorClient{f}
+
+	req := &ethpb.CommitteeSubnetsSubscribeRequest{Slots: []types.Slot{1}, CommitteeIds: []types.CommitteeIndex{2}, IsAggregator: []bool{true}}
+	indices := []types.ValidatorIndex{3}
+	first.validatorClient.EXPECT().SubscribeCommitteeSubnets(gomock.Any(), req, indices).Return(nil, nil)
+	_, err := client.SubscribeCommitteeSubnets(context.Background(), req, indices)
+	require.NoError(t, err)
+
+	first.expectProbe(true /* syncing */, 100, 50)
+	second.expectProbe(false, 100, 50)
+	resubscribed := make(chan struct{})
+	second.validatorClient.EXPECT().SubscribeCommitteeSubnets(gomock.Any(), req, indices).DoAndReturn(
+		func(context.Context, *ethpb.CommitteeSubnetsSubscribeRequest, []types.ValidatorIndex) (*emptypb.Empty, error) {
+			close(resubscribed)
+			return &emptypb.Empty{}, nil
+		})
+	f.probeAll()
+	assert.Equal(t, second.beaconNode, f.primary)
+
+	select {
+	case <-resubscribed:
+	case <-time.After(time.Second):
+		t.Fatal("Committee subnets were not subscribed to on the new primary")
+	}
+}
+
+func TestBeaconNodeFailover_ProbeReplaysDutiesAndProposerPreparation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	first := newMockBeaconNode(ctrl, "first")
+	second := newMockBeaconNode(ctrl, "second")
+	f := newBeaconNodeFailover(context.Background(), []*beaconNode{first.beaconNode, second.beaconNode})
+	client := &failoverValidatorClient{f}
+
+	dutiesReq := &ethpb.DutiesRequest{Epoch: 1, PublicKeys: [][]byte{{'a'}}}
+	first.validatorClient.EXPECT().GetDuties(gomock.Any(), dutiesReq).Return(&ethpb.DutiesResponse{}, nil)
+	_, err := client.GetDuties(context.Background(), dutiesReq)
+	require.NoError(t, err)
+	prepareReq := &ethpb.PrepareBeaconProposerRequest{
+		Recipients: []*ethpb.PrepareBeaconProposerRequest_FeeRecipientContainer{{ValidatorIndex: 1, FeeRecipient: make([]byte, 20)}},
+	}
+	first.validatorClient.EXPECT().PrepareBeaconProposer(gomock.Any(), prepareReq).Return(&emptypb.Empty{}, nil)
+	second.validatorClient.EXPECT().PrepareBeaconProposer(gomock.Any(), prepareReq).Return(&emptypb.Empty{}, nil)
+	_, err = client.PrepareBeaconProposer(context.Background(), prepareReq)
+	require.NoError(t, err)
+
+	first.expectProbe(true /* syncing */, 100, 50)
+	second.expectProbe(false, 100, 50)
+	replayed := make(chan string, 2)
+	second.validatorClient.EXPECT().GetDuties(gomock.Any(), dutiesReq).DoAndReturn(
+		func(context.Context, *ethpb.DutiesRequest) (*ethpb.DutiesResponse, error) {
+			replayed <- "duties"
+			return &ethpb.DutiesResponse{}, nil
+		})
+	second.validatorClient.EXPECT().PrepareBeaconProposer(gomock.Any(), prepareReq).DoAndReturn(
+		func(context.Context, *ethpb.PrepareBeaconProposerRequest) (*emptypb.Empty, error) {
+			replayed <- "proposer preparation"
+			return &emptypb.Empty{}, nil
+		})
+	f.probeAll()
+	assert.Equal(t, second.beaconNode, f.primary)
+
+	for _, want := range []string{"duties", "proposer preparation"} {
+		select {
+		case got := <-replayed:
+			assert.Equal(t, want, got)
+		case <-time.After(time.Second):
+			t.Fatalf("The %s was not replayed on the new primary", want)
+		}
+	}
+}
+
+func TestFailoverSlasherClient_UsesPrimary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	first := newMockBeaconNode(ctrl, "first")
+	second := newMockBeaconNode(ctrl, "second")
+	f := newBeaconNodeFailover(context.Background(), []*beaconNode{first.beaconNode, second.beaconNode})
+	client := &failoverSlasherClient{f}
+
+	first.expectProbe(true /* syncing */, 100, 50)
+	second.expectProbe(false, 100, 50)
+	f.probeAll()
+
+	header := &ethpb.SignedBeaconBlockHeader{Header: &ethpb.BeaconBlockHeader{Slot: 1}}
+	second.slasherClient.EXPECT().IsSlashableBlock(gomock.Any(), header).Return(&ethpb.ProposerSlashingResponse{}, nil)
+	_, err := client.IsSlashableBlock(context.Background(), header)
+	require.NoError(t, err)
+}
+
+func TestBeaconNodeFailover_KeepsHealthyPrimary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	first := newMockBeaconNode(ctrl, "first")
+	second := newMockBeaconNode(ctrl, "second")
+	f := newBeaconNodeFailover(context.Background(), []*beaconNode{first.beaconNode, second.beaconNode})
+
+	first.expectProbe(false, 99, 10)
+	second.expectProbe(false, 100, 50)
+	f.probeAll()
+	assert.Equal(t, first.beaconNode, f.primary)
+	assert.DeepEqual(t, []*beaconNode{first.beaconNode, second.beaconNode}, f.candidates())
+}
+
+func TestBeaconNodeFailover_CallFallsThroughUnreachableNode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	first := newMockBeaconNode(ctrl, "first")
+	second := newMockBeaconNode(ctrl, "second")
+	f := newBeaconNodeFailover(context.Background(), []*beaconNode{first.beaconNode, second.beaconNode})
+	client := &failoverValidatorClient{f}
+
+	first.validatorClient.EXPECT().GetDuties(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.Unavailable, "connection refused"))
+	want := &ethpb.DutiesResponse{CurrentEpochDuties: []*ethpb.DutiesResponse_Duty{{ValidatorIndex: 1}}}
+	second.validatorClient.EXPECT().GetDuties(gomock.Any(), gomock.Any()).Return(want, nil)
+
+	resp, err := client.GetDuties(context.Background(), &ethpb.DutiesRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, want, resp)
+	assert.Equal(t, second.beaconNode, f.primary, "Expected to fail over without waiting for the next probe")
+}
+
+func TestBeaconNodeFailover_CallReturnsRejection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	first := newMockBeaconNode(ctrl, "first")
+	second := newMockBeaconNode(ctrl, "second")
+	f := newBeaconNodeFailover(context.Background(), []*beaconNode{first.beaconNode, second.beaconNode})
+	client := &failoverValidatorClient{f}
+
+	first.validatorClient.EXPECT().GetDuties(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.InvalidArgument, "bad request"))
+
+	_, err := client.GetDuties(context.Background(), &ethpb.DutiesRequest{})
+	assert.ErrorContains(t, "bad request", err)
+	assert.Equal(t, first.beaconNode, f.primary)
+}
+
+func TestBeaconNodeFailover_BroadcastsToHealthyNodes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	first := newMockBeaconNode(ctrl, "first")
+	second := newMockBeaconNode(ctrl, "second")
+	third := newMockBeaconNode(ctrl, "third")
+	f := newBeaconNodeFailover(context.Background(), []*beaconNode{first.beaconNode, second.beaconNode, third.beaconNode})
+	client := &failoverValidatorClient{f}
+
+	first.expectProbe(false, 100, 50)
+	second.expectProbe(false, 100, 50)
+	third.expectProbe(true /* syncing */, 10, 50)
+	f.probeAll()
+
+	att := &ethpb.Attestation{}
+	rejected := make(chan struct{})
+	first.validatorClient.EXPECT().ProposeAttestation(gomock.Any(), att).DoAndReturn(
+		func(context.Context, *ethpb.Attestation) (*ethpb.AttestResponse, error) {
+			close(rejected)
+			return nil, status.Error(codes.Internal, "could not broadcast")
+		})
+	second.validatorClient.EXPECT().ProposeAttestation(gomock.Any(), att).Return(&ethpb.AttestResponse{AttestationDataRoot: []byte{1}}, nil)
+
+	resp, err := client.ProposeAttestation(context.Background(), att)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []byte{1}, resp.AttestationDataRoot)
+	<-rejected
+}
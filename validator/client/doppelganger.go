@@ -0,0 +1,374 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	"github.com/prysmaticlabs/prysm/validator/client/iface"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// DoppelgangerStatus is the state of the doppelganger protection of a validating key.
+type DoppelgangerStatus int
+
+const (
+	// DoppelgangerPending keys have their duties withheld while their liveness is being monitored.
+	DoppelgangerPending DoppelgangerStatus = iota + 1
+	// DoppelgangerSafe keys were not seen live for the monitored epochs and perform their duties.
+	DoppelgangerSafe
+	// DoppelgangerDetected keys were seen live elsewhere and have their duties withheld until restart.
+	DoppelgangerDetected
+)
+
+func (s DoppelgangerStatus) String() string {
+	switch s {
+	case DoppelgangerPending:
+		return "PENDING"
+	case DoppelgangerSafe:
+		return "SAFE"
+	case DoppelgangerDetected:
+		return "DETECTED"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// DoppelgangerKeyStatus is the doppelganger protection status of a validating key.
+type DoppelgangerKeyStatus struct {
+	PublicKey       [fieldparams.BLSPubkeyLength]byte
+	Status          DoppelgangerStatus
+	RemainingEpochs uint64
+}
+
+type doppelgangerKey struct {
+	status          DoppelgangerStatus
+	nextEpoch       types.Epoch
+	remainingEpochs uint64
+}
+
+// doppelgangerProtection withholds the duties of newly added validating keys until the beacon node
+// did not see them live for a number of epochs, as another validator client may still be running them.
+type doppelgangerProtection struct {
+	lock        sync.RWMutex
+	epochs      uint64
+	disableKeys bool
+	keys        map[[fieldparams.BLSPubkeyLength]byte]*doppelgangerKey
+}
+
+func newDoppelgangerProtection(epochs uint64, disableKeys bool) *doppelgangerProtection {
+	return &doppelgangerProtection{
+		epochs:      epochs,
+		disableKeys: disableKeys,
+		keys:        make(map[[fieldparams.BLSPubkeyLength]byte]*doppelgangerKey),
+	}
+}
+
+// addKeys starts monitoring the liveness of the keys not known yet from the epoch on,
+// and returns how many keys were added.
+func (d *doppelgangerProtection) addKeys(pubKeys [][fieldparams.BLSPubkeyLength]byte, epoch types.Epoch) int {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	added := 0
+	for _, pubKey := range pubKeys {
+		if _, ok := d.keys[pubKey]; ok {
+			continue
+		}
+		d.keys[pubKey] = &doppelgangerKey{
+			status:          DoppelgangerPending,
+			nextEpoch:       epoch,
+			remainingEpochs: d.epochs,
+		}
+		updateDoppelgangerMetric(pubKey, DoppelgangerPending)
+		added++
+	}
+	return added
+}
+
+// retainKeys forgets the keys which are not validating anymore, so that they are
+// monitored again if they get imported back.
+func (d *doppelgangerProtection) retainKeys(pubKeys [][fieldparams.BLSPubkeyLength]byte) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	validating := make(map[[fieldparams.BLSPubkeyLength]byte]bool, len(pubKeys))
+	for _, pubKey := range pubKeys {
+		validating[pubKey] = true
+	}
+	for pubKey := range d.keys {
+		if !validating[pubKey] {
+			delete(d.keys, pubKey)
+			ValidatorDoppelgangerStatusGaugeVec.DeleteLabelValues(fmt.Sprintf("%#x", pubKey))
+		}
+	}
+}
+
+// isSafe returns whether the key is allowed to perform its duties.
+func (d *doppelgangerProtection) isSafe(pubKey [fieldparams.BLSPubkeyLength]byte) bool {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	k, ok := d.keys[pubKey]
+	return ok && k.status == DoppelgangerSafe
+}
+
+// pendingKeys returns the keys whose liveness at the epoch is yet to be checked, ordered by public key.
+func (d *doppelgangerProtection) pendingKeys(epoch types.Epoch) [][fieldparams.BLSPubkeyLength]byte {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	var pending [][fieldparams.BLSPubkeyLength]byte
+	for pubKey, k := range d.keys {
+		if k.status == DoppelgangerPending && k.nextEpoch <= epoch {
+			pending = append(pending, pubKey)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		return string(pending[i][:]) < string(pending[j][:])
+	})
+	return pending
+}
+
+// recordLiveness updates the keys with their liveness at the epoch, and returns the keys which
+// were detected live as well as the keys which completed their protection. Keys missing from the
+// liveness could not be checked for the epoch.
+func (d *doppelgangerProtection) recordLiveness(epoch types.Epoch, live map[[fieldparams.BLSPubkeyLength]byte]bool) (detected, safe [][fieldparams.BLSPubkeyLength]byte) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	for pubKey, isLive := range live {
+		k, ok := d.keys[pubKey]
+		if !ok || k.status != DoppelgangerPending || k.nextEpoch > epoch {
+			continue
+		}
+		k.nextEpoch = epoch + 1
+		if isLive {
+			k.status = DoppelgangerDetected
+			detected = append(detected, pubKey)
+		} else if k.remainingEpochs--; k.remainingEpochs == 0 {
+			k.status = DoppelgangerSafe
+			safe = append(safe, pubKey)
+		}
+		updateDoppelgangerMetric(pubKey, k.status)
+	}
+	return detected, safe
+}
+
+// skipEpoch moves the keys past an epoch whose liveness could not be checked, without
+// counting it towards the monitored epochs.
+func (d *doppelgangerProtection) skipEpoch(epoch types.Epoch, pubKeys [][fieldparams.BLSPubkeyLength]byte) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	for _, pubKey := range pubKeys {
+		if k, ok := d.keys[pubKey]; ok && k.status == DoppelgangerPending && k.nextEpoch <= epoch {
+			k.nextEpoch = epoch + 1
+		}
+	}
+}
+
+// statuses returns the protection status of all the known keys, ordered by public key.
+func (d *doppelgangerProtection) statuses() []DoppelgangerKeyStatus {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	statuses := make([]DoppelgangerKeyStatus, 0, len(d.keys))
+	for pubKey, k := range d.keys {
+		statuses = append(statuses, DoppelgangerKeyStatus{
+			PublicKey:       pubKey,
+			Status:          k.status,
+			RemainingEpochs: k.remainingEpochs,
+		})
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return string(statuses[i].PublicKey[:]) < string(statuses[j].PublicKey[:])
+	})
+	return statuses
+}
+
+func updateDoppelgangerMetric(pubKey [fieldparams.BLSPubkeyLength]byte, status DoppelgangerStatus) {
+	if status == DoppelgangerDetected {
+		ValidatorDoppelgangersDetectedCount.Inc()
+	}
+	ValidatorDoppelgangerStatusGaugeVec.WithLabelValues(fmt.Sprintf("%#x", pubKey)).Set(float64(status))
+}
+
+// CheckDoppelGanger starts doppelganger protection for the validating keys which are not protected
+// yet, and checks the liveness of the protected keys during the previous epoch. An error is returned
+// when any of the keys was seen live elsewhere, unless only the affected keys are to be disabled.
+func (v *validator) CheckDoppelGanger(ctx context.Context) error {
+	if v.doppelganger == nil {
+		return nil
+	}
+	ctx, span := trace.StartSpan(ctx, "validator.CheckDoppelGanger")
+	defer span.End()
+
+	pubKeys, err := v.keyManager.FetchValidatingPublicKeys(ctx)
+	if err != nil {
+		return err
+	}
+	currentEpoch := slots.ToEpoch(slots.CurrentSlot(v.genesisTime))
+	v.doppelganger.retainKeys(pubKeys)
+	v.startDoppelgangerProtection(pubKeys, currentEpoch)
+	if currentEpoch == 0 {
+		return nil
+	}
+
+	epoch := currentEpoch - 1
+	pending := v.doppelganger.pendingKeys(epoch)
+	if len(pending) == 0 {
+		return nil
+	}
+	live, err := v.doppelgangerLiveness(ctx, epoch, pending)
+	if err != nil {
+		// The keys stay protected until enough epochs could be checked.
+		log.WithError(err).WithField("epoch", epoch).Warn("Could not check validator liveness for doppelganger protection")
+		v.doppelganger.skipEpoch(epoch, pending)
+		return nil
+	}
+	detected, safe := v.doppelganger.recordLiveness(epoch, live)
+	v.doppelganger.skipEpoch(epoch, pending)
+	for _, pubKey := range safe {
+		log.WithField("pubKey", fmt.Sprintf("%#x", bytesutil.Trunc(pubKey[:]))).Info("Doppelganger protection complete, starting to perform duties")
+	}
+	if len(detected) == 0 {
+		return nil
+	}
+	if v.doppelganger.disableKeys {
+		for _, pubKey := range detected {
+			log.WithField("pubKey", fmt.Sprintf("%#x", pubKey)).Error("Duplicate instance exists in the network for validator key, its duties are disabled until restart")
+		}
+		return nil
+	}
+	return errors.Errorf("Duplicate instances exists in the network for validator keys: %#x", bytesutil.FromBytes48Array(detected))
+}
+
+// startDoppelgangerProtection withholds the duties of the keys not protected yet until their
+// liveness was monitored from the epoch on.
+func (v *validator) startDoppelgangerProtection(pubKeys [][fieldparams.BLSPubkeyLength]byte, epoch types.Epoch) {
+	if v.doppelganger == nil {
+		return
+	}
+	if added := v.doppelganger.addKeys(pubKeys, epoch); added > 0 {
+		log.WithFields(logrus.Fields{
+			"keys":   added,
+			"epochs": v.doppelganger.epochs,
+		}).Info("Withholding duties of new validator keys while checking for doppelgangers")
+	}
+}
+
+// doppelgangerLiveness returns whether the keys were live at the epoch. Keys which signed an
+// attestation for the epoch or later through this validator client are left out, as their
+// liveness can't tell this validator client apart from a doppelganger.
+func (v *validator) doppelgangerLiveness(ctx context.Context, epoch types.Epoch, pubKeys [][fieldparams.BLSPubkeyLength]byte) (map[[fieldparams.BLSPubkeyLength]byte]bool, error) {
+	var toCheck [][fieldparams.BLSPubkeyLength]byte
+	for _, pubKey := range pubKeys {
+		history, err := v.db.AttestationHistoryForPubKey(ctx, pubKey)
+		if err != nil {
+			return nil, err
+		}
+		if r := retrieveLatestRecord(history); r != nil && r.Target >= epoch {
+			continue
+		}
+		toCheck = append(toCheck, pubKey)
+	}
+	live := make(map[[fieldparams.BLSPubkeyLength]byte]bool, len(toCheck))
+	if len(toCheck) == 0 {
+		return live, nil
+	}
+
+	resp, err := v.validatorClient.MultipleValidatorStatus(ctx, &ethpb.MultipleValidatorStatusRequest{
+		PublicKeys: bytesutil.FromBytes48Array(toCheck),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get validator statuses")
+	}
+	byIndex := make(map[types.ValidatorIndex][fieldparams.BLSPubkeyLength]byte, len(resp.PublicKeys))
+	indices := make([]types.ValidatorIndex, 0, len(resp.PublicKeys))
+	for i, pk := range resp.PublicKeys {
+		pubKey := bytesutil.ToBytes48(pk)
+		// Keys unknown to the beacon chain can't have been live.
+		live[pubKey] = false
+		if resp.Statuses[i].Status == ethpb.ValidatorStatus_UNKNOWN_STATUS {
+			continue
+		}
+		byIndex[resp.Indices[i]] = pubKey
+		indices = append(indices, resp.Indices[i])
+	}
+	if len(indices) == 0 {
+		return live, nil
+	}
+
+	liveness, err := v.validatorClient.GetValidatorsLiveness(ctx, epoch, indices)
+	if errors.Is(err, iface.ErrNotSupported) {
+		return v.doppelgangerLivenessFromNode(ctx, toCheck)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range liveness {
+		if pubKey, ok := byIndex[l.Index]; ok && l.IsLive {
+			live[pubKey] = true
+		}
+	}
+	return live, nil
+}
+
+// doppelgangerLivenessFromNode falls back to the doppelganger check of Prysm's beacon node,
+// which looks at the liveness of the keys during the two previous epochs.
+func (v *validator) doppelgangerLivenessFromNode(ctx context.Context, pubKeys [][fieldparams.BLSPubkeyLength]byte) (map[[fieldparams.BLSPubkeyLength]byte]bool, error) {
+	req := &ethpb.DoppelGangerRequest{ValidatorRequests: []*ethpb.DoppelGangerRequest_ValidatorRequest{}}
+	for _, pubKey := range pubKeys {
+		copiedKey := pubKey
+		attRec, err := v.db.AttestationHistoryForPubKey(ctx, copiedKey)
+		if err != nil {
+			return nil, err
+		}
+		if len(attRec) == 0 {
+			// If no history exists we simply send in a zero
+			// value for the request epoch and root.
+			req.ValidatorRequests = append(req.ValidatorRequests,
+				&ethpb.DoppelGangerRequest_ValidatorRequest{
+					PublicKey:  copiedKey[:],
+					Epoch:      0,
+					SignedRoot: make([]byte, fieldparams.RootLength),
+				})
+			continue
+		}
+		r := retrieveLatestRecord(attRec)
+		if copiedKey != r.PubKey {
+			return nil, errors.New("attestation record mismatched public key")
+		}
+		req.ValidatorRequests = append(req.ValidatorRequests,
+			&ethpb.DoppelGangerRequest_ValidatorRequest{
+				PublicKey:  r.PubKey[:],
+				Epoch:      r.Target,
+				SignedRoot: r.SigningRoot[:],
+			})
+	}
+	resp, err := v.validatorClient.CheckDoppelGanger(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	// If nothing is returned by the beacon node, we return an
+	// error as it is unsafe for us to proceed.
+	if resp == nil || len(resp.Responses) == 0 {
+		return nil, errors.New("beacon node returned 0 responses for doppelganger check")
+	}
+	live := make(map[[fieldparams.BLSPubkeyLength]byte]bool, len(resp.Responses))
+	for _, r := range resp.Responses {
+		live[bytesutil.ToBytes48(r.PublicKey)] = r.DuplicateExists
+	}
+	return live, nil
+}
+
+// DoppelgangerStatuses returns the doppelganger protection status of the validating keys,
+// or nil when doppelganger protection is disabled.
+func (v *ValidatorService) DoppelgangerStatuses() []DoppelgangerKeyStatus {
+	if v.doppelganger == nil {
+		return nil
+	}
+	return v.doppelganger.statuses()
+}
@@ -0,0 +1,294 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/crypto/bls"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	validatormock "github.com/prysmaticlabs/prysm/testing/validator-mock"
+	"github.com/prysmaticlabs/prysm/validator/client/iface"
+	dbTest "github.com/prysmaticlabs/prysm/validator/db/testing"
+)
+
+type doppelGangerRequestMatcher struct {
+	req *ethpb.DoppelGangerRequest
+}
+
+var _ gomock.Matcher = (*doppelGangerRequestMatcher)(nil)
+
+func (m *doppelGangerRequestMatcher) Matches(x interface{}) bool {
+	r, ok := x.(*ethpb.DoppelGangerRequest)
+	if !ok {
+		panic("Invalid match type")
+	}
+	return gomock.InAnyOrder(m.req.ValidatorRequests).Matches(r.ValidatorRequests)
+}
+
+func (m *doppelGangerRequestMatcher) String() string {
+	return fmt.Sprintf("%#v", m.req.ValidatorRequests)
+}
+
+func doppelgangerTestKeys(n int) [][fieldparams.BLSPubkeyLength]byte {
+	keys := make([][fieldparams.BLSPubkeyLength]byte, n)
+	for i := range keys {
+		keys[i][0] = byte(i + 1)
+	}
+	return keys
+}
+
+// genesisTimeAtEpoch returns a genesis time making the epoch the current one.
+func genesisTimeAtEpoch(epoch types.Epoch) uint64 {
+	secondsPerEpoch := uint64(params.BeaconConfig().SlotsPerEpoch) * params.BeaconConfig().SecondsPerSlot
+	return uint64(time.Now().Unix()) - uint64(epoch)*secondsPerEpoch - 1
+}
+
+func TestDoppelgangerProtection_RecordLiveness(t *testing.T) {
+	keys := doppelgangerTestKeys(3)
+	d := newDoppelgangerProtection(2, false)
+	assert.Equal(t, 3, d.addKeys(keys, 5))
+	assert.Equal(t, 0, d.addKeys(keys[:1], 6), "Known keys must not be protected again")
+	assert.Equal(t, 0, len(d.pendingKeys(4)))
+	assert.Equal(t, 3, len(d.pendingKeys(5)))
+
+	detected, safe := d.recordLiveness(5, map[[fieldparams.BLSPubkeyLength]byte]bool{keys[0]: false, keys[1]: true})
+	assert.DeepEqual(t, [][fieldparams.BLSPubkeyLength]byte{keys[1]}, detected)
+	assert.Equal(t, 0, len(safe))
+	d.skipEpoch(5, keys)
+
+	// Keys can't be checked twice for the same epoch.
+	detected, safe = d.recordLiveness(5, map[[fieldparams.BLSPubkeyLength]byte]bool{keys[0]: false})
+	assert.Equal(t, 0, len(detected))
+	assert.Equal(t, 0, len(safe))
+
+	detected, safe = d.recordLiveness(6, map[[fieldparams.BLSPubkeyLength]byte]bool{keys[0]: false, keys[2]: false})
+	assert.Equal(t, 0, len(detected))
+	assert.DeepEqual(t, [][fieldparams.BLSPubkeyLength]byte{keys[0]}, safe)
+
+	assert.Equal(t, true, d.isSafe(keys[0]))
+	assert.Equal(t, false, d.isSafe(keys[1]))
+	assert.Equal(t, false, d.isSafe(keys[2]))
+	assert.DeepEqual(t, []DoppelgangerKeyStatus{
+		{PublicKey: keys[0], Status: DoppelgangerSafe, RemainingEpochs: 0},
+		{PublicKey: keys[1], Status: DoppelgangerDetected, RemainingEpochs: 2},
+		{PublicKey: keys[2], Status: DoppelgangerPending, RemainingEpochs: 1},
+	}, d.statuses())
+
+	d.retainKeys(keys[1:])
+	assert.Equal(t, false, d.isSafe(keys[0]))
+	assert.Equal(t, 1, d.addKeys(keys, 7), "Removed keys must be protected again")
+}
+
+func TestValidator_CheckDoppelGanger(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	const currentEpoch = types.Epoch(20)
+	keys := doppelgangerTestKeys(3)
+	indices := []types.ValidatorIndex{10, 11, 12}
+	activeStatuses := &ethpb.MultipleValidatorStatusResponse{
+		PublicKeys: [][]byte{keys[0][:], keys[1][:], keys[2][:]},
+		Statuses: []*ethpb.ValidatorStatusResponse{
+			{Status: ethpb.ValidatorStatus_ACTIVE},
+			{Status: ethpb.ValidatorStatus_ACTIVE},
+			{Status: ethpb.ValidatorStatus_ACTIVE},
+		},
+		Indices: indices,
+	}
+
+	tests := []struct {
+		name         string
+		disableKeys  bool
+		expectations func(t *testing.T, client *validatormock.MockValidatorClient, v *validator)
+		err          string
+		statuses     []DoppelgangerStatus
+	}{
+		{
+			name: "no doppelganger",
+			expectations: func(t *testing.T, client *validatormock.MockValidatorClient, _ *validator) {
+				client.EXPECT().MultipleValidatorStatus(gomock.Any(), gomock.Any()).Return(activeStatuses, nil)
+				client.EXPECT().GetValidatorsLiveness(gomock.Any(), currentEpoch-1, gomock.Any()).Return([]iface.ValidatorLiveness{
+					{Index: 10, IsLive: false},
+					{Index: 11, IsLive: false},
+					{Index: 12, IsLive: false},
+				}, nil)
+			},
+			statuses: []DoppelgangerStatus{DoppelgangerSafe, DoppelgangerSafe, DoppelgangerSafe},
+		},
+		{
+			name: "doppelganger exists",
+			expectations: func(t *testing.T, client *validatormock.MockValidatorClient, _ *validator) {
+				client.EXPECT().MultipleValidatorStatus(gomock.Any(), gomock.Any()).Return(activeStatuses, nil)
+				client.EXPECT().GetValidatorsLiveness(gomock.Any(), currentEpoch-1, gomock.Any()).Return([]iface.ValidatorLiveness{
+					{Index: 10, IsLive: false},
+					{Index: 11, IsLive: true},
+					{Index: 12, IsLive: false},
+				}, nil)
+			},
+			err:      "Duplicate instances exists in the network for validator keys",
+			statuses: []DoppelgangerStatus{DoppelgangerSafe, DoppelgangerDetected, DoppelgangerSafe},
+		},
+		{
+			name:        "doppelganger exists, disabling keys",
+			disableKeys: true,
+			expectations: func(t *testing.T, client *validatormock.MockValidatorClient, _ *validator) {
+				client.EXPECT().MultipleValidatorStatus(gomock.Any(), gomock.Any()).Return(activeStatuses, nil)
+				client.EXPECT().GetValidatorsLiveness(gomock.Any(), currentEpoch-1, gomock.Any()).Return([]iface.ValidatorLiveness{
+					{Index: 10, IsLive: false},
+					{Index: 11, IsLive: true},
+					{Index: 12, IsLive: false},
+				}, nil)
+			},
+			statuses: []DoppelgangerStatus{DoppelgangerSafe, DoppelgangerDetected, DoppelgangerSafe},
+		},
+		{
+			name: "keys unknown to the beacon chain",
+			expectations: func(t *testing.T, client *validatormock.MockValidatorClient, _ *validator) {
+				client.EXPECT().MultipleValidatorStatus(gomock.Any(), gomock.Any()).Return(&ethpb.MultipleValidatorStatusResponse{
+					PublicKeys: [][]byte{keys[0][:], keys[1][:], keys[2][:]},
+					Statuses: []*ethpb.ValidatorStatusResponse{
+						{Status: ethpb.ValidatorStatus_UNKNOWN_STATUS},
+						{Status: ethpb.ValidatorStatus_UNKNOWN_STATUS},
+						{Status: ethpb.ValidatorStatus_UNKNOWN_STATUS},
+					},
+					Indices: []types.ValidatorIndex{0, 0, 0},
+				}, nil)
+			},
+			statuses: []DoppelgangerStatus{DoppelgangerSafe, DoppelgangerSafe, DoppelgangerSafe},
+		},
+		{
+			name: "keys which attested through this validator client are not checked",
+			expectations: func(t *testing.T, client *validatormock.MockValidatorClient, v *validator) {
+				att := createAttestation(currentEpoch-2, currentEpoch-1)
+				rt, err := att.Data.HashTreeRoot()
+				require.NoError(t, err)
+				require.NoError(t, v.db.SaveAttestationForPubKey(context.Background(), keys[0], rt, att))
+				client.EXPECT().MultipleValidatorStatus(gomock.Any(), &ethpb.MultipleValidatorStatusRequest{
+					PublicKeys: [][]byte{keys[1][:], keys[2][:]},
+				}).Return(&ethpb.MultipleValidatorStatusResponse{
+					PublicKeys: [][]byte{keys[1][:], keys[2][:]},
+					Statuses:   []*ethpb.ValidatorStatusResponse{{Status: ethpb.ValidatorStatus_ACTIVE}, {Status: ethpb.ValidatorStatus_ACTIVE}},
+					Indices:    indices[1:],
+				}, nil)
+				client.EXPECT().GetValidatorsLiveness(gomock.Any(), currentEpoch-1, indices[1:]).Return([]iface.ValidatorLiveness{
+					{Index: 11, IsLive: false},
+					{Index: 12, IsLive: false},
+				}, nil)
+			},
+			statuses: []DoppelgangerStatus{DoppelgangerPending, DoppelgangerSafe, DoppelgangerSafe},
+		},
+		{
+			name: "liveness not available keeps keys pending",
+			expectations: func(t *testing.T, client *validatormock.MockValidatorClient, _ *validator) {
+				client.EXPECT().MultipleValidatorStatus(gomock.Any(), gomock.Any()).Return(activeStatuses, nil)
+				client.EXPECT().GetValidatorsLiveness(gomock.Any(), currentEpoch-1, gomock.Any()).Return(nil, errors.New("bad"))
+			},
+			statuses: []DoppelgangerStatus{DoppelgangerPending, DoppelgangerPending, DoppelgangerPending},
+		},
+		{
+			name: "falls back to the doppelganger check of the beacon node",
+			expectations: func(t *testing.T, client *validatormock.MockValidatorClient, _ *validator) {
+				client.EXPECT().MultipleValidatorStatus(gomock.Any(), gomock.Any()).Return(activeStatuses, nil)
+				client.EXPECT().GetValidatorsLiveness(gomock.Any(), currentEpoch-1, gomock.Any()).Return(nil, iface.ErrNotSupported)
+				req := &ethpb.DoppelGangerRequest{ValidatorRequests: []*ethpb.DoppelGangerRequest_ValidatorRequest{}}
+				resp := &ethpb.DoppelGangerResponse{Responses: []*ethpb.DoppelGangerResponse_ValidatorResponse{}}
+				for i, k := range keys {
+					pubKey := k
+					req.ValidatorRequests = append(req.ValidatorRequests, &ethpb.DoppelGangerRequest_ValidatorRequest{
+						PublicKey:  pubKey[:],
+						Epoch:      0,
+						SignedRoot: make([]byte, fieldparams.RootLength),
+					})
+					resp.Responses = append(resp.Responses, &ethpb.DoppelGangerResponse_ValidatorResponse{PublicKey: pubKey[:], DuplicateExists: i == 2})
+				}
+				client.EXPECT().CheckDoppelGanger(gomock.Any(), &doppelGangerRequestMatcher{req}).Return(resp, nil)
+			},
+			err:      "Duplicate instances exists in the network for validator keys",
+			statuses: []DoppelgangerStatus{DoppelgangerSafe, DoppelgangerSafe, DoppelgangerDetected},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := validatormock.NewMockValidatorClient(ctrl)
+			km := &mockKeymanager{keysMap: make(map[[fieldparams.BLSPubkeyLength]byte]bls.SecretKey)}
+			for _, k := range keys {
+				km.keysMap[k] = nil
+			}
+			v := &validator{
+				validatorClient: client,
+				keyManager:      km,
+				db:              dbTest.SetupDB(t, keys),
+				genesisTime:     genesisTimeAtEpoch(currentEpoch),
+				doppelganger:    newDoppelgangerProtection(1, tt.disableKeys),
+			}
+			v.doppelganger.addKeys(keys, currentEpoch-1)
+			tt.expectations(t, client, v)
+
+			err := v.CheckDoppelGanger(context.Background())
+			if tt.err != "" {
+				assert.ErrorContains(t, tt.err, err)
+			} else {
+				require.NoError(t, err)
+			}
+			statuses := v.doppelganger.statuses()
+			require.Equal(t, len(tt.statuses), len(statuses))
+			for i, s := range statuses {
+				assert.Equal(t, tt.statuses[i], s.Status, "Unexpected status for key %d", i)
+			}
+		})
+	}
+}
+
+func TestValidator_CheckDoppelGanger_ProtectsNewKeys(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	keys := doppelgangerTestKeys(2)
+	km := &mockKeymanager{keysMap: map[[fieldparams.BLSPubkeyLength]byte]bls.SecretKey{keys[0]: nil}}
+	v := &validator{
+		validatorClient: validatormock.NewMockValidatorClient(ctrl),
+		keyManager:      km,
+		db:              dbTest.SetupDB(t, keys),
+		genesisTime:     genesisTimeAtEpoch(10),
+		doppelganger:    newDoppelgangerProtection(2, false),
+	}
+	require.NoError(t, v.CheckDoppelGanger(context.Background()))
+	assert.DeepEqual(t, []DoppelgangerKeyStatus{
+		{PublicKey: keys[0], Status: DoppelgangerPending, RemainingEpochs: 2},
+	}, v.doppelganger.statuses())
+
+	// Keys imported while running are protected on their own, removed keys are forgotten.
+	km.keysMap = map[[fieldparams.BLSPubkeyLength]byte]bls.SecretKey{keys[1]: nil}
+	require.NoError(t, v.CheckDoppelGanger(context.Background()))
+	assert.DeepEqual(t, []DoppelgangerKeyStatus{
+		{PublicKey: keys[1], Status: DoppelgangerPending, RemainingEpochs: 2},
+	}, v.doppelganger.statuses())
+}
+
+func TestValidator_RolesAt_WithholdsDoppelgangerProtectedKeys(t *testing.T) {
+	keys := doppelgangerTestKeys(2)
+	v := &validator{
+		duties: &ethpb.DutiesResponse{
+			Duties: []*ethpb.DutiesResponse_Duty{
+				{PublicKey: keys[0][:], ProposerSlots: []types.Slot{1}},
+				{PublicKey: keys[1][:], ProposerSlots: []types.Slot{1}},
+			},
+		},
+		doppelganger: newDoppelgangerProtection(1, false),
+	}
+	v.doppelganger.addKeys(keys, 0)
+	v.doppelganger.recordLiveness(0, map[[fieldparams.BLSPubkeyLength]byte]bool{keys[0]: false})
+
+	roles, err := v.RolesAt(context.Background(), 1)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []iface.ValidatorRole{iface.RoleProposer}, roles[keys[0]])
+	_, ok := roles[keys[1]]
+	assert.Equal(t, false, ok, "Duties of a key under doppelganger protection must be withheld")
+}
@@ -0,0 +1,27 @@
+package grpc_api
+
+import (
+	"context"
+
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/validator/client/iface"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type grpcBeaconChainClient struct {
+	beaconChainClient ethpb.BeaconChainClient
+}
+
+// NewGrpcBeaconChainClient returns a beacon chain client talking to a beacon node over Prysm's gRPC API.
+func NewGrpcBeaconChainClient(cc grpc.ClientConnInterface) iface.BeaconChainClient {
+	return &grpcBeaconChainClient{ethpb.NewBeaconChainClient(cc)}
+}
+
+func (c *grpcBeaconChainClient) GetChainHead(ctx context.Context, in *emptypb.Empty) (*ethpb.ChainHead, error) {
+	return c.beaconChainClient.GetChainHead(ctx, in)
+}
+
+func (c *grpcBeaconChainClient) GetValidatorPerformance(ctx context.Context, in *ethpb.ValidatorPerformanceRequest) (*ethpb.ValidatorPerformanceResponse, error) {
+	return c.beaconChainClient.GetValidatorPerformance(ctx, in)
+}
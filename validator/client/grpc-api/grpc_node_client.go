@@ -0,0 +1,31 @@
+package grpc_api
+
+import (
+	"context"
+
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/validator/client/iface"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type grpcNodeClient struct {
+	nodeClient ethpb.NodeClient
+}
+
+// NewGrpcNodeClient returns a node client talking to a beacon node over Prysm's gRPC API.
+func NewGrpcNodeClient(cc grpc.ClientConnInterface) iface.NodeClient {
+	return &grpcNodeClient{ethpb.NewNodeClient(cc)}
+}
+
+func (c *grpcNodeClient) GetSyncStatus(ctx context.Context, in *emptypb.Empty) (*ethpb.SyncStatus, error) {
+	return c.nodeClient.GetSyncStatus(ctx, in)
+}
+
+func (c *grpcNodeClient) GetGenesis(ctx context.Context, in *emptypb.Empty) (*ethpb.Genesis, error) {
+	return c.nodeClient.GetGenesis(ctx, in)
+}
+
+func (c *grpcNodeClient) ListPeers(ctx context.Context, in *emptypb.Empty) (*ethpb.Peers, error) {
+	return c.nodeClient.ListPeers(ctx, in)
+}
@@ -0,0 +1,136 @@
+package grpc_api
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/validator/client/iface"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type grpcValidatorClient struct {
+	beaconNodeValidatorClient ethpb.BeaconNodeValidatorClient
+}
+
+// NewGrpcValidatorClient returns a validator client talking to a beacon node over Prysm's gRPC API.
+func NewGrpcValidatorClient(cc grpc.ClientConnInterface) iface.ValidatorClient {
+	return &grpcValidatorClient{ethpb.NewBeaconNodeValidatorClient(cc)}
+}
+
+func (c *grpcValidatorClient) GetDuties(ctx context.Context, in *ethpb.DutiesRequest) (*ethpb.DutiesResponse, error) {
+	return c.beaconNodeValidatorClient.GetDuties(ctx, in)
+}
+
+func (c *grpcValidatorClient) DomainData(ctx context.Context, in *ethpb.DomainRequest) (*ethpb.DomainResponse, error) {
+	return c.beaconNodeValidatorClient.DomainData(ctx, in)
+}
+
+// WaitForChainStart blocks until the beacon node's chain start stream delivers its response.
+func (c *grpcValidatorClient) WaitForChainStart(ctx context.Context, in *emptypb.Empty) (*ethpb.ChainStartResponse, error) {
+	stream, err := c.beaconNodeValidatorClient.WaitForChainStart(ctx, in)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not setup beacon chain ChainStart streaming client")
+	}
+	return stream.Recv()
+}
+
+func (c *grpcValidatorClient) WaitForActivation(ctx context.Context, in *ethpb.ValidatorActivationRequest) (ethpb.BeaconNodeValidator_WaitForActivationClient, error) {
+	return c.beaconNodeValidatorClient.WaitForActivation(ctx, in)
+}
+
+func (c *grpcValidatorClient) ValidatorIndex(ctx context.Context, in *ethpb.ValidatorIndexRequest) (*ethpb.ValidatorIndexResponse, error) {
+	return c.beaconNodeValidatorClient.ValidatorIndex(ctx, in)
+}
+
+func (c *grpcValidatorClient) MultipleValidatorStatus(ctx context.Context, in *ethpb.MultipleValidatorStatusRequest) (*ethpb.MultipleValidatorStatusResponse, error) {
+	return c.beaconNodeValidatorClient.MultipleValidatorStatus(ctx, in)
+}
+
+func (c *grpcValidatorClient) GetBeaconBlock(ctx context.Context, in *ethpb.BlockRequest) (*ethpb.GenericBeaconBlock, error) {
+	return c.beaconNodeValidatorClient.GetBeaconBlock(ctx, in)
+}
+
+func (c *grpcValidatorClient) ProposeBeaconBlock(ctx context.Context, in *ethpb.GenericSignedBeaconBlock) (*ethpb.ProposeResponse, error) {
+	return c.beaconNodeValidatorClient.ProposeBeaconBlock(ctx, in)
+}
+
+func (c *grpcValidatorClient) GetAttestationData(ctx context.Context, in *ethpb.AttestationDataRequest) (*ethpb.AttestationData, error) {
+	return c.beaconNodeValidatorClient.GetAttestationData(ctx, in)
+}
+
+func (c *grpcValidatorClient) ProposeAttestation(ctx context.Context, in *ethpb.Attestation) (*ethpb.AttestResponse, error) {
+	return c.beaconNodeValidatorClient.ProposeAttestation(ctx, in)
+}
+
+func (c *grpcValidatorClient) SubmitAggregateSelectionProof(ctx context.Context, in *ethpb.AggregateSelectionRequest) (*ethpb.AggregateSelectionResponse, error) {
+	return c.beaconNodeValidatorClient.SubmitAggregateSelectionProof(ctx, in)
+}
+
+func (c *grpcValidatorClient) SubmitSignedAggregateSelectionProof(ctx context.Context, in *ethpb.SignedAggregateSubmitRequest) (*ethpb.SignedAggregateSubmitResponse, error) {
+	return c.beaconNodeValidatorClient.SubmitSignedAggregateSelectionProof(ctx, in)
+}
+
+func (c *grpcValidatorClient) ProposeExit(ctx context.Context, in *ethpb.SignedVoluntaryExit) (*ethpb.ProposeExitResponse, error) {
+	return c.beaconNodeValidatorClient.ProposeExit(ctx, in)
+}
+
+// SubscribeCommitteeSubnets ignores the validator indices, Prysm's beacon node doesn't need them.
+func (c *grpcValidatorClient) SubscribeCommitteeSubnets(ctx context.Context, in *ethpb.CommitteeSubnetsSubscribeRequest, _ []types.ValidatorIndex) (*emptypb.Empty, error) {
+	return c.beaconNodeValidatorClient.SubscribeCommitteeSubnets(ctx, in)
+}
+
+func (c *grpcValidatorClient) CheckDoppelGanger(ctx context.Context, in *ethpb.DoppelGangerRequest) (*ethpb.DoppelGangerResponse, error) {
+	return c.beaconNodeValidatorClient.CheckDoppelGanger(ctx, in)
+}
+
+func (c *grpcValidatorClient) GetSyncMessageBlockRoot(ctx context.Context, in *emptypb.Empty) (*ethpb.SyncMessageBlockRootResponse, error) {
+	return c.beaconNodeValidatorClient.GetSyncMessageBlockRoot(ctx, in)
+}
+
+func (c *grpcValidatorClient) SubmitSyncMessage(ctx context.Context, in *ethpb.SyncCommitteeMessage) (*emptypb.Empty, error) {
+	return c.beaconNodeValidatorClient.SubmitSyncMessage(ctx, in)
+}
+
+func (c *grpcValidatorClient) GetSyncSubcommitteeIndex(ctx context.Context, in *ethpb.SyncSubcommitteeIndexRequest) (*ethpb.SyncSubcommitteeIndexResponse, error) {
+	return c.beaconNodeValidatorClient.GetSyncSubcommitteeIndex(ctx, in)
+}
+
+func (c *grpcValidatorClient) GetSyncCommitteeContribution(ctx context.Context, in *ethpb.SyncCommitteeContributionRequest) (*ethpb.SyncCommitteeContribution, error) {
+	return c.beaconNodeValidatorClient.GetSyncCommitteeContribution(ctx, in)
+}
+
+func (c *grpcValidatorClient) SubmitSignedContributionAndProof(ctx context.Context, in *ethpb.SignedContributionAndProof) (*emptypb.Empty, error) {
+	return c.beaconNodeValidatorClient.SubmitSignedContributionAndProof(ctx, in)
+}
+
+func (c *grpcValidatorClient) StreamBlocksAltair(ctx context.Context, in *ethpb.StreamBlocksRequest) (ethpb.BeaconNodeValidator_StreamBlocksAltairClient, error) {
+	return c.beaconNodeValidatorClient.StreamBlocksAltair(ctx, in)
+}
+
+func (c *grpcValidatorClient) SubmitValidatorRegistration(ctx context.Context, in *ethpb.SignedValidatorRegistrationsV1) (*emptypb.Empty, error) {
+	return c.beaconNodeValidatorClient.SubmitValidatorRegistration(ctx, in)
+}
+
+func (c *grpcValidatorClient) PrepareBeaconProposer(ctx context.Context, in *ethpb.PrepareBeaconProposerRequest) (*emptypb.Empty, error) {
+	return c.beaconNodeValidatorClient.PrepareBeaconProposer(ctx, in)
+}
+
+// GetAggregatedSelections is not part of Prysm's gRPC API, distributed validator middlewares only
+// speak the standard Beacon REST API.
+func (c *grpcValidatorClient) GetAggregatedSelections(_ context.Context, _ []iface.BeaconCommitteeSelection) ([]iface.BeaconCommitteeSelection, error) {
+	return nil, iface.ErrNotSupported
+}
+
+// GetAggregatedSyncSelections is not part of Prysm's gRPC API, distributed validator middlewares only
+// speak the standard Beacon REST API.
+func (c *grpcValidatorClient) GetAggregatedSyncSelections(_ context.Context, _ []iface.SyncCommitteeSelection) ([]iface.SyncCommitteeSelection, error) {
+	return nil, iface.ErrNotSupported
+}
+
+// GetValidatorsLiveness is not part of Prysm's gRPC API, CheckDoppelGanger is its equivalent.
+func (c *grpcValidatorClient) GetValidatorsLiveness(_ context.Context, _ types.Epoch, _ []types.ValidatorIndex) ([]iface.ValidatorLiveness, error) {
+	return nil, iface.ErrNotSupported
+}
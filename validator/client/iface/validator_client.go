@@ -0,0 +1,88 @@
+package iface
+
+import (
+	"context"
+	"errors"
+
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// ErrNotSupported is returned by clients for calls which the underlying beacon node API
+// has no equivalent for.
+var ErrNotSupported = errors.New("not supported by the beacon node API in use")
+
+// ValidatorClient is the set of beacon node calls the validator client performs its duties with.
+// It is implemented on top of Prysm's gRPC API as well as on top of the standard Beacon REST API.
+type ValidatorClient interface {
+	GetDuties(ctx context.Context, in *ethpb.DutiesRequest) (*ethpb.DutiesResponse, error)
+	DomainData(ctx context.Context, in *ethpb.DomainRequest) (*ethpb.DomainResponse, error)
+	WaitForChainStart(ctx context.Context, in *emptypb.Empty) (*ethpb.ChainStartResponse, error)
+	WaitForActivation(ctx context.Context, in *ethpb.ValidatorActivationRequest) (ethpb.BeaconNodeValidator_WaitForActivationClient, error)
+	ValidatorIndex(ctx context.Context, in *ethpb.ValidatorIndexRequest) (*ethpb.ValidatorIndexResponse, error)
+	MultipleValidatorStatus(ctx context.Context, in *ethpb.MultipleValidatorStatusRequest) (*ethpb.MultipleValidatorStatusResponse, error)
+	GetBeaconBlock(ctx context.Context, in *ethpb.BlockRequest) (*ethpb.GenericBeaconBlock, error)
+	ProposeBeaconBlock(ctx context.Context, in *ethpb.GenericSignedBeaconBlock) (*ethpb.ProposeResponse, error)
+	GetAttestationData(ctx context.Context, in *ethpb.AttestationDataRequest) (*ethpb.AttestationData, error)
+	ProposeAttestation(ctx context.Context, in *ethpb.Attestation) (*ethpb.AttestResponse, error)
+	SubmitAggregateSelectionProof(ctx context.Context, in *ethpb.AggregateSelectionRequest) (*ethpb.AggregateSelectionResponse, error)
+	SubmitSignedAggregateSelectionProof(ctx context.Context, in *ethpb.SignedAggregateSubmitRequest) (*ethpb.SignedAggregateSubmitResponse, error)
+	ProposeExit(ctx context.Context, in *ethpb.SignedVoluntaryExit) (*ethpb.ProposeExitResponse, error)
+	// SubscribeCommitteeSubnets takes the indices of the subscribing validators in the same order
+	// as the request's slots, as the standard API identifies subscriptions by validator.
+	SubscribeCommitteeSubnets(ctx context.Context, in *ethpb.CommitteeSubnetsSubscribeRequest, validatorIndices []types.ValidatorIndex) (*emptypb.Empty, error)
+	CheckDoppelGanger(ctx context.Context, in *ethpb.DoppelGangerRequest) (*ethpb.DoppelGangerResponse, error)
+	GetSyncMessageBlockRoot(ctx context.Context, in *emptypb.Empty) (*ethpb.SyncMessageBlockRootResponse, error)
+	SubmitSyncMessage(ctx context.Context, in *ethpb.SyncCommitteeMessage) (*emptypb.Empty, error)
+	GetSyncSubcommitteeIndex(ctx context.Context, in *ethpb.SyncSubcommitteeIndexRequest) (*ethpb.SyncSubcommitteeIndexResponse, error)
+	GetSyncCommitteeContribution(ctx context.Context, in *ethpb.SyncCommitteeContributionRequest) (*ethpb.SyncCommitteeContribution, error)
+	SubmitSignedContributionAndProof(ctx context.Context, in *ethpb.SignedContributionAndProof) (*emptypb.Empty, error)
+	StreamBlocksAltair(ctx context.Context, in *ethpb.StreamBlocksRequest) (ethpb.BeaconNodeValidator_StreamBlocksAltairClient, error)
+	SubmitValidatorRegistration(ctx context.Context, in *ethpb.SignedValidatorRegistrationsV1) (*emptypb.Empty, error)
+	PrepareBeaconProposer(ctx context.Context, in *ethpb.PrepareBeaconProposerRequest) (*emptypb.Empty, error)
+	// GetAggregatedSelections exchanges partial beacon committee selection proofs for the combined
+	// ones. It is only served by distributed validator middlewares sitting in front of the beacon node.
+	GetAggregatedSelections(ctx context.Context, selections []BeaconCommitteeSelection) ([]BeaconCommitteeSelection, error)
+	// GetAggregatedSyncSelections exchanges partial sync committee selection proofs for the combined
+	// ones. It is only served by distributed validator middlewares sitting in front of the beacon node.
+	GetAggregatedSyncSelections(ctx context.Context, selections []SyncCommitteeSelection) ([]SyncCommitteeSelection, error)
+	// GetValidatorsLiveness reports whether the validators were seen performing their duties at the epoch.
+	GetValidatorsLiveness(ctx context.Context, epoch types.Epoch, indices []types.ValidatorIndex) ([]ValidatorLiveness, error)
+}
+
+// BeaconCommitteeSelection is the selection proof of a validator for aggregating the attestations
+// of its committee at a slot.
+type BeaconCommitteeSelection struct {
+	SelectionProof []byte
+	Slot           types.Slot
+	ValidatorIndex types.ValidatorIndex
+}
+
+// SyncCommitteeSelection is the selection proof of a validator for aggregating the sync committee
+// messages of a subcommittee at a slot.
+type SyncCommitteeSelection struct {
+	SelectionProof    []byte
+	Slot              types.Slot
+	SubcommitteeIndex uint64
+	ValidatorIndex    types.ValidatorIndex
+}
+
+// ValidatorLiveness tells whether a validator was seen performing its duties at an epoch.
+type ValidatorLiveness struct {
+	Index  types.ValidatorIndex
+	IsLive bool
+}
+
+// NodeClient is the set of calls the validator client uses to query the beacon node's own state.
+type NodeClient interface {
+	GetSyncStatus(ctx context.Context, in *emptypb.Empty) (*ethpb.SyncStatus, error)
+	GetGenesis(ctx context.Context, in *emptypb.Empty) (*ethpb.Genesis, error)
+	ListPeers(ctx context.Context, in *emptypb.Empty) (*ethpb.Peers, error)
+}
+
+// BeaconChainClient is the set of calls the validator client uses to query the beacon chain.
+type BeaconChainClient interface {
+	GetChainHead(ctx context.Context, in *emptypb.Empty) (*ethpb.ChainHead, error)
+	GetValidatorPerformance(ctx context.Context, in *ethpb.ValidatorPerformanceRequest) (*ethpb.ValidatorPerformanceResponse, error)
+}
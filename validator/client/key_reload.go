@@ -5,6 +5,7 @@ import (
 
 	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
 	eth "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/time/slots"
 	"go.opencensus.io/trace"
 )
 
@@ -14,6 +15,10 @@ func (v *validator) HandleKeyReload(ctx context.Context, newKeys [][fieldparams.
 	ctx, span := trace.StartSpan(ctx, "validator.HandleKeyReload")
 	defer span.End()
 
+	// Imported keys may still be running in another validator client, their duties are withheld
+	// until doppelganger protection completes.
+	v.startDoppelgangerProtection(newKeys, slots.ToEpoch(slots.CurrentSlot(v.genesisTime)))
+
 	statusRequestKeys := make([][]byte, len(newKeys))
 	for i := range newKeys {
 		statusRequestKeys[i] = newKeys[i][:]
@@ -10,8 +10,8 @@ import (
 	"github.com/prysmaticlabs/prysm/crypto/bls"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/testing/assert"
-	"github.com/prysmaticlabs/prysm/testing/mock"
 	"github.com/prysmaticlabs/prysm/testing/require"
+	validatormock "github.com/prysmaticlabs/prysm/testing/validator-mock"
 	"github.com/prysmaticlabs/prysm/validator/client/testutil"
 	logTest "github.com/sirupsen/logrus/hooks/test"
 )
@@ -36,7 +36,7 @@ func TestValidator_HandleKeyReload(t *testing.T) {
 				inactivePubKey: inactivePrivKey,
 			},
 		}
-		client := mock.NewMockBeaconNodeValidatorClient(ctrl)
+		client := validatormock.NewMockValidatorClient(ctrl)
 		v := validator{
 			validatorClient: client,
 			keyManager:      km,
@@ -72,7 +72,7 @@ func TestValidator_HandleKeyReload(t *testing.T) {
 				inactivePubKey: inactivePrivKey,
 			},
 		}
-		client := mock.NewMockBeaconNodeValidatorClient(ctrl)
+		client := validatormock.NewMockValidatorClient(ctrl)
 		v := validator{
 			validatorClient: client,
 			keyManager:      km,
@@ -105,7 +105,7 @@ func TestValidator_HandleKeyReload(t *testing.T) {
 				inactivePubKey: inactivePrivKey,
 			},
 		}
-		client := mock.NewMockBeaconNodeValidatorClient(ctrl)
+		client := validatormock.NewMockValidatorClient(ctrl)
 		v := validator{
 			validatorClient: client,
 			keyManager:      km,
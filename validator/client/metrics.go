@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
@@ -12,6 +13,7 @@ import (
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/time/slots"
+	"github.com/prysmaticlabs/prysm/validator/client/iface"
 	"github.com/sirupsen/logrus"
 )
 
@@ -212,6 +214,25 @@ var (
 			"pubkey",
 		},
 	)
+	// ValidatorDoppelgangerStatusGaugeVec used to track the doppelganger protection status by public key.
+	ValidatorDoppelgangerStatusGaugeVec = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "validator",
+			Name:      "doppelganger_status",
+			Help:      "doppelganger protection status: 1 PENDING, 2 SAFE, 3 DETECTED",
+		},
+		[]string{
+			"pubkey",
+		},
+	)
+	// ValidatorDoppelgangersDetectedCount used to count the keys found live elsewhere by doppelganger protection.
+	ValidatorDoppelgangersDetectedCount = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "validator",
+			Name:      "doppelgangers_detected_total",
+			Help:      "number of validating keys found live elsewhere by doppelganger protection",
+		},
+	)
 )
 
 // LogValidatorGainsAndLosses logs important metrics related to this validator client's
@@ -223,9 +244,12 @@ func (v *validator) LogValidatorGainsAndLosses(ctx context.Context, slot types.S
 		// Do nothing unless we are at the end of the epoch, and not in the first epoch.
 		return nil
 	}
-	if !v.logValidatorBalances {
+	if !v.logValidatorBalances && v.performance == nil {
 		return nil
 	}
+	// The attestations of the previous epoch can be included until the end of this epoch, so the
+	// performance is sampled once the block of the last slot of the epoch is in.
+	v.waitToSlotTwoThirds(ctx, slot)
 
 	var pks [][fieldparams.BLSPubkeyLength]byte
 	var err error
@@ -239,10 +263,22 @@ func (v *validator) LogValidatorGainsAndLosses(ctx context.Context, slot types.S
 		PublicKeys: pubKeys,
 	}
 	resp, err := v.beaconClient.GetValidatorPerformance(ctx, req)
-	if err != nil {
+	if errors.Is(err, iface.ErrNotSupported) {
+		// Performance data is only served by Prysm's own API.
+		resp = nil
+	} else if err != nil {
 		return err
 	}
 
+	if v.performance != nil {
+		if err := v.savePerformanceRecords(ctx, resp, slots.ToEpoch(slot)-1); err != nil {
+			log.WithError(err).Error("Could not record validator performance")
+		}
+	}
+	if resp == nil || !v.logValidatorBalances {
+		return nil
+	}
+
 	if v.emitAccountMetrics {
 		for _, missingPubKey := range resp.MissingValidators {
 			fmtKey := fmt.Sprintf("%#x", missingPubKey)
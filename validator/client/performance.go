@@ -0,0 +1,195 @@
+package client
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/config/params"
+	"github.com/prysmaticlabs/prysm/consensus-types/interfaces"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/time/slots"
+	"github.com/prysmaticlabs/prysm/validator/db/kv"
+)
+
+// performanceLedger keeps the outcome of the duties performed by the validating keys during
+// recent epochs, until the beacon node reports on their attestations and they are recorded
+// in the validator database.
+type performanceLedger struct {
+	lock         sync.Mutex
+	retention    types.Epoch
+	records      map[types.Epoch]map[[fieldparams.BLSPubkeyLength]byte]*kv.PerformanceRecord
+	attestations map[attestationCommittee][]submittedAttestation
+}
+
+// attestationCommittee identifies the committee of an attestation.
+type attestationCommittee struct {
+	slot           types.Slot
+	committeeIndex types.CommitteeIndex
+}
+
+// submittedAttestation is an attestation submitted by a validating key, looked for in the
+// blocks to come to find its inclusion distance.
+type submittedAttestation struct {
+	pubKey           [fieldparams.BLSPubkeyLength]byte
+	indexInCommittee uint64
+}
+
+// newPerformanceLedger creates a ledger keeping the records of the last retention epochs
+// in the validator database, or all of them when retention is 0.
+func newPerformanceLedger(retention types.Epoch) *performanceLedger {
+	return &performanceLedger{
+		retention:    retention,
+		records:      make(map[types.Epoch]map[[fieldparams.BLSPubkeyLength]byte]*kv.PerformanceRecord),
+		attestations: make(map[attestationCommittee][]submittedAttestation),
+	}
+}
+
+// record returns the record of a key at an epoch, the lock must be held.
+func (p *performanceLedger) record(pubKey [fieldparams.BLSPubkeyLength]byte, epoch types.Epoch) *kv.PerformanceRecord {
+	epochRecords, ok := p.records[epoch]
+	if !ok {
+		epochRecords = make(map[[fieldparams.BLSPubkeyLength]byte]*kv.PerformanceRecord)
+		p.records[epoch] = epochRecords
+	}
+	r, ok := epochRecords[pubKey]
+	if !ok {
+		r = &kv.PerformanceRecord{Epoch: epoch}
+		epochRecords[pubKey] = r
+	}
+	return r
+}
+
+func (p *performanceLedger) recordProposal(pubKey [fieldparams.BLSPubkeyLength]byte, slot types.Slot, proposed bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	r := p.record(pubKey, slots.ToEpoch(slot))
+	if proposed {
+		r.ProposedSlots = append(r.ProposedSlots, slot)
+	} else {
+		r.FailedProposalSlots = append(r.FailedProposalSlots, slot)
+	}
+}
+
+func (p *performanceLedger) recordSyncMessage(pubKey [fieldparams.BLSPubkeyLength]byte, slot types.Slot, submitted bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	r := p.record(pubKey, slots.ToEpoch(slot))
+	if submitted {
+		r.SyncMessagesSubmitted++
+	} else {
+		r.SyncMessagesFailed++
+	}
+}
+
+func (p *performanceLedger) recordAttestation(pubKey [fieldparams.BLSPubkeyLength]byte, data *ethpb.AttestationData, indexInCommittee uint64) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	committee := attestationCommittee{slot: data.Slot, committeeIndex: data.CommitteeIndex}
+	p.attestations[committee] = append(p.attestations[committee], submittedAttestation{pubKey: pubKey, indexInCommittee: indexInCommittee})
+}
+
+// recordIncludedAttestations records the inclusion distance of the submitted attestations the
+// block includes. The beacon node only reports inclusion distances before Altair, so they are
+// found in the blocks streamed by the beacon node instead. The shortest distance is kept, as
+// blocks of forks are streamed as well.
+func (p *performanceLedger) recordIncludedAttestations(blk interfaces.SignedBeaconBlock) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	slot := blk.Block().Slot()
+	for _, att := range blk.Block().Body().Attestations() {
+		if att == nil || att.Data == nil || att.Data.Slot >= slot {
+			continue
+		}
+		submitted := p.attestations[attestationCommittee{slot: att.Data.Slot, committeeIndex: att.Data.CommitteeIndex}]
+		for _, s := range submitted {
+			if s.indexInCommittee >= att.AggregationBits.Len() || !att.AggregationBits.BitAt(s.indexInCommittee) {
+				continue
+			}
+			distance := slot - att.Data.Slot
+			r := p.record(s.pubKey, slots.ToEpoch(att.Data.Slot))
+			if r.InclusionDistance == 0 || distance < r.InclusionDistance {
+				r.InclusionDistance = distance
+			}
+		}
+	}
+}
+
+// takeEpoch returns the records of an epoch, and forgets about the records of this epoch
+// and of the ones before.
+func (p *performanceLedger) takeEpoch(epoch types.Epoch) map[[fieldparams.BLSPubkeyLength]byte]*kv.PerformanceRecord {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	records, ok := p.records[epoch]
+	if !ok {
+		records = make(map[[fieldparams.BLSPubkeyLength]byte]*kv.PerformanceRecord)
+	}
+	for e := range p.records {
+		if e <= epoch {
+			delete(p.records, e)
+		}
+	}
+	for committee := range p.attestations {
+		if slots.ToEpoch(committee.slot) <= epoch {
+			delete(p.attestations, committee)
+		}
+	}
+	return records
+}
+
+// savePerformanceRecords completes the records of the duties of an epoch with the performance
+// reported by the beacon node, and saves them in the validator database. The records are dropped
+// when the beacon node does not report the performance, as the outcome of the attestations of
+// the epoch is then unknown.
+func (v *validator) savePerformanceRecords(ctx context.Context, resp *ethpb.ValidatorPerformanceResponse, epoch types.Epoch) error {
+	records := v.performance.takeEpoch(epoch)
+	if resp == nil {
+		return nil
+	}
+	for i, pubKey := range resp.PublicKeys {
+		r, ok := records[bytesutil.ToBytes48(pubKey)]
+		if !ok {
+			r = &kv.PerformanceRecord{Epoch: epoch}
+			records[bytesutil.ToBytes48(pubKey)] = r
+		}
+		if i < len(resp.CorrectlyVotedSource) {
+			r.CorrectlyVotedSource = resp.CorrectlyVotedSource[i]
+		}
+		if i < len(resp.CorrectlyVotedTarget) {
+			r.CorrectlyVotedTarget = resp.CorrectlyVotedTarget[i]
+		}
+		if i < len(resp.CorrectlyVotedHead) {
+			r.CorrectlyVotedHead = resp.CorrectlyVotedHead[i]
+		}
+		// From Altair on, the inclusion distance is found in the blocks rather than reported, a late
+		// attestation can then be included without any timely flag.
+		r.AttestationIncluded = r.CorrectlyVotedSource || r.CorrectlyVotedTarget || r.InclusionDistance > 0
+		// Inclusion distances are only reported before Altair.
+		if epoch < params.BeaconConfig().AltairForkEpoch && i < len(resp.InclusionDistances) && i < len(resp.InclusionSlots) {
+			if uint64(resp.InclusionSlots[i]) != ^uint64(0) {
+				r.AttestationIncluded = true
+				r.InclusionDistance = resp.InclusionDistances[i]
+			}
+		}
+		if i < len(resp.BalancesBeforeEpochTransition) && i < len(resp.BalancesAfterEpochTransition) {
+			r.BalanceBefore = resp.BalancesBeforeEpochTransition[i]
+			r.BalanceAfter = resp.BalancesAfterEpochTransition[i]
+			r.EstimatedReward = int64(r.BalanceAfter) - int64(r.BalanceBefore)
+		}
+	}
+	if len(records) == 0 {
+		return nil
+	}
+	if err := v.db.SavePerformanceRecords(ctx, records); err != nil {
+		return errors.Wrap(err, "could not save performance records")
+	}
+	if v.performance.retention > 0 && epoch > v.performance.retention {
+		if err := v.db.PrunePerformanceRecords(ctx, epoch-v.performance.retention); err != nil {
+			return errors.Wrap(err, "could not prune performance records")
+		}
+	}
+	return nil
+}
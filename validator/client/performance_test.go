@@ -0,0 +1,190 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/config/params"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/consensus-types/wrapper"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/testing/util"
+	"github.com/prysmaticlabs/prysm/validator/db/kv"
+	dbTest "github.com/prysmaticlabs/prysm/validator/db/testing"
+)
+
+func TestPerformanceLedger_TakeEpoch(t *testing.T) {
+	pubKey := [fieldparams.BLSPubkeyLength]byte{1}
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	p := newPerformanceLedger(0)
+	p.recordProposal(pubKey, slotsPerEpoch+1, true)
+	p.recordProposal(pubKey, slotsPerEpoch+2, false)
+	p.recordSyncMessage(pubKey, slotsPerEpoch+1, true)
+	p.recordSyncMessage(pubKey, slotsPerEpoch+2, true)
+	p.recordSyncMessage(pubKey, slotsPerEpoch+3, false)
+	p.recordSyncMessage(pubKey, 2*slotsPerEpoch, true)
+
+	require.DeepEqual(t, map[[fieldparams.BLSPubkeyLength]byte]*kv.PerformanceRecord{
+		pubKey: {
+			Epoch:                 1,
+			ProposedSlots:         []types.Slot{slotsPerEpoch + 1},
+			FailedProposalSlots:   []types.Slot{slotsPerEpoch + 2},
+			SyncMessagesSubmitted: 2,
+			SyncMessagesFailed:    1,
+		},
+	}, p.takeEpoch(1))
+	require.Equal(t, 0, len(p.takeEpoch(1)))
+	require.Equal(t, 1, len(p.takeEpoch(2)))
+}
+
+func TestPerformanceLedger_RecordIncludedAttestations(t *testing.T) {
+	pubKey := [fieldparams.BLSPubkeyLength]byte{1}
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	p := newPerformanceLedger(0)
+	data := &ethpb.AttestationData{Slot: slotsPerEpoch + 1, CommitteeIndex: 1}
+	p.recordAttestation(pubKey, data, 2)
+
+	includeAt := func(slot types.Slot, committeeIndex types.CommitteeIndex, bit uint64) {
+		b := util.NewBeaconBlockAltair()
+		b.Block.Slot = slot
+		bits := bitfield.NewBitlist(4)
+		bits.SetBitAt(bit, true)
+		b.Block.Body.Attestations = []*ethpb.Attestation{{
+			Data:            &ethpb.AttestationData{Slot: data.Slot, CommitteeIndex: committeeIndex},
+			AggregationBits: bits,
+		}}
+		blk, err := wrapper.WrappedSignedBeaconBlock(b)
+		require.NoError(t, err)
+		p.recordIncludedAttestations(blk)
+	}
+	includeAt(data.Slot+1, 1, 3 /* another member of the committee */)
+	includeAt(data.Slot+1, 0, 2 /* another committee */)
+	includeAt(data.Slot+3, 1, 2)
+	// A block of another fork includes the attestation earlier.
+	includeAt(data.Slot+2, 1, 2)
+	includeAt(data.Slot+4, 1, 2)
+
+	records := p.takeEpoch(1)
+	require.Equal(t, 1, len(records))
+	require.Equal(t, types.Slot(2), records[pubKey].InclusionDistance)
+	require.Equal(t, 0, len(p.attestations))
+}
+
+func TestValidator_SavePerformanceRecords(t *testing.T) {
+	ctx := context.Background()
+	pubKeys := [][fieldparams.BLSPubkeyLength]byte{{1}, {2}}
+	v := &validator{
+		db:          dbTest.SetupDB(t, pubKeys),
+		performance: newPerformanceLedger(2),
+	}
+	altairEpoch := params.BeaconConfig().AltairForkEpoch
+	resp := &ethpb.ValidatorPerformanceResponse{
+		PublicKeys:                    [][]byte{pubKeys[0][:], pubKeys[1][:]},
+		CorrectlyVotedSource:          []bool{true, false},
+		CorrectlyVotedTarget:          []bool{true, false},
+		CorrectlyVotedHead:            []bool{false, false},
+		BalancesBeforeEpochTransition: []uint64{32000000000, 32000000000},
+		BalancesAfterEpochTransition:  []uint64{32000001000, 31999999000},
+	}
+	for epoch := altairEpoch; epoch < altairEpoch+4; epoch++ {
+		v.performance.recordProposal(pubKeys[1], params.BeaconConfig().SlotsPerEpoch.Mul(uint64(epoch)), true)
+		require.NoError(t, v.savePerformanceRecords(ctx, resp, epoch))
+	}
+
+	records, err := v.db.PerformanceRecordsForPubKey(ctx, pubKeys[0], 0, altairEpoch+4)
+	require.NoError(t, err)
+	require.DeepEqual(t, []*kv.PerformanceRecord{
+		{
+			Epoch:                altairEpoch + 1,
+			AttestationIncluded:  true,
+			CorrectlyVotedSource: true,
+			CorrectlyVotedTarget: true,
+			BalanceBefore:        32000000000,
+			BalanceAfter:         32000001000,
+			EstimatedReward:      1000,
+		},
+		{
+			Epoch:                altairEpoch + 2,
+			AttestationIncluded:  true,
+			CorrectlyVotedSource: true,
+			CorrectlyVotedTarget: true,
+			BalanceBefore:        32000000000,
+			BalanceAfter:         32000001000,
+			EstimatedReward:      1000,
+		},
+		{
+			Epoch:                altairEpoch + 3,
+			AttestationIncluded:  true,
+			CorrectlyVotedSource: true,
+			CorrectlyVotedTarget: true,
+			BalanceBefore:        32000000000,
+			BalanceAfter:         32000001000,
+			EstimatedReward:      1000,
+		},
+	}, records)
+
+	records, err = v.db.PerformanceRecordsForPubKey(ctx, pubKeys[1], altairEpoch+3, altairEpoch+3)
+	require.NoError(t, err)
+	require.DeepEqual(t, []*kv.PerformanceRecord{{
+		Epoch:           altairEpoch + 3,
+		ProposedSlots:   []types.Slot{params.BeaconConfig().SlotsPerEpoch.Mul(uint64(altairEpoch + 3))},
+		BalanceBefore:   32000000000,
+		BalanceAfter:    31999999000,
+		EstimatedReward: -1000,
+	}}, records)
+}
+
+func TestValidator_SavePerformanceRecords_InclusionDistance(t *testing.T) {
+	ctx := context.Background()
+	pubKey := [fieldparams.BLSPubkeyLength]byte{1}
+	v := &validator{
+		db:          dbTest.SetupDB(t, [][fieldparams.BLSPubkeyLength]byte{pubKey}),
+		performance: newPerformanceLedger(0),
+	}
+	epoch := params.BeaconConfig().AltairForkEpoch + 1
+	slot := params.BeaconConfig().SlotsPerEpoch.Mul(uint64(epoch))
+	v.performance.recordAttestation(pubKey, &ethpb.AttestationData{Slot: slot}, 0)
+	b := util.NewBeaconBlockAltair()
+	b.Block.Slot = slot + 10
+	bits := bitfield.NewBitlist(1)
+	bits.SetBitAt(0, true)
+	b.Block.Body.Attestations = []*ethpb.Attestation{{Data: &ethpb.AttestationData{Slot: slot}, AggregationBits: bits}}
+	blk, err := wrapper.WrappedSignedBeaconBlock(b)
+	require.NoError(t, err)
+	v.performance.recordIncludedAttestations(blk)
+
+	// The attestation was included too late to get any timely flag.
+	resp := &ethpb.ValidatorPerformanceResponse{
+		PublicKeys:           [][]byte{pubKey[:]},
+		CorrectlyVotedSource: []bool{false},
+		CorrectlyVotedTarget: []bool{false},
+		CorrectlyVotedHead:   []bool{false},
+	}
+	require.NoError(t, v.savePerformanceRecords(ctx, resp, epoch))
+	records, err := v.db.PerformanceRecordsForPubKey(ctx, pubKey, epoch, epoch)
+	require.NoError(t, err)
+	require.DeepEqual(t, []*kv.PerformanceRecord{{
+		Epoch:               epoch,
+		AttestationIncluded: true,
+		InclusionDistance:   10,
+	}}, records)
+}
+
+func TestValidator_SavePerformanceRecords_PerformanceNotReported(t *testing.T) {
+	ctx := context.Background()
+	pubKey := [fieldparams.BLSPubkeyLength]byte{1}
+	v := &validator{
+		db:          dbTest.SetupDB(t, [][fieldparams.BLSPubkeyLength]byte{pubKey}),
+		performance: newPerformanceLedger(0),
+	}
+	v.performance.recordProposal(pubKey, params.BeaconConfig().SlotsPerEpoch, true)
+	require.NoError(t, v.savePerformanceRecords(ctx, nil, 1))
+
+	records, err := v.db.PerformanceRecordsForPubKey(ctx, pubKey, 0, 2)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(records))
+	require.Equal(t, 0, len(v.performance.takeEpoch(1)))
+}
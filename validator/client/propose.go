@@ -11,6 +11,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/signing"
 	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/config/params"
+	validatorserviceconfig "github.com/prysmaticlabs/prysm/config/validator/service"
 	"github.com/prysmaticlabs/prysm/consensus-types/interfaces"
 	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/consensus-types/wrapper"
@@ -54,6 +55,13 @@ func (v *validator) ProposeBlock(ctx context.Context, slot types.Slot, pubKey [f
 	span.AddAttributes(trace.StringAttribute("validator", fmtKey))
 	log := log.WithField("pubKey", fmt.Sprintf("%#x", bytesutil.Trunc(pubKey[:])))
 
+	var proposed bool
+	if v.performance != nil {
+		defer func() {
+			v.performance.recordProposal(pubKey, slot, proposed)
+		}()
+	}
+
 	// Sign randao reveal, it's used to request block from beacon node
 	epoch := types.Epoch(slot / params.BeaconConfig().SlotsPerEpoch)
 	randaoReveal, err := v.signRandaoReveal(ctx, pubKey, epoch, slot)
@@ -139,6 +147,7 @@ func (v *validator) ProposeBlock(ctx context.Context, slot types.Slot, pubKey [f
 		}
 		return
 	}
+	proposed = true
 
 	span.AddAttributes(
 		trace.StringAttribute("blockRoot", fmt.Sprintf("%#x", blkResp.BlockRoot)),
@@ -182,7 +191,7 @@ func (v *validator) ProposeBlock(ctx context.Context, slot types.Slot, pubKey [f
 // The exit is signed by the validator before being sent to the beacon node for broadcasting.
 func ProposeExit(
 	ctx context.Context,
-	validatorClient ethpb.BeaconNodeValidatorClient,
+	validatorClient iface.ValidatorClient,
 	nodeClient ethpb.NodeClient,
 	signer signingFunc,
 	pubKey []byte,
@@ -194,20 +203,16 @@ func ProposeExit(
 	if err != nil {
 		return errors.Wrap(err, "gRPC call to get validator index failed")
 	}
-	genesisResponse, err := nodeClient.GetGenesis(ctx, &emptypb.Empty{})
+	currentEpoch, err := CurrentEpoch(ctx, nodeClient)
 	if err != nil {
-		return errors.Wrap(err, "gRPC call to get genesis time failed")
+		return err
 	}
-	totalSecondsPassed := prysmTime.Now().Unix() - genesisResponse.GenesisTime.Seconds
-	currentEpoch := types.Epoch(uint64(totalSecondsPassed) / uint64(params.BeaconConfig().SlotsPerEpoch.Mul(params.BeaconConfig().SecondsPerSlot)))
 
 	exit := &ethpb.VoluntaryExit{Epoch: currentEpoch, ValidatorIndex: indexResponse.Index}
-	sig, err := signVoluntaryExit(ctx, validatorClient, signer, pubKey, exit)
+	signedExit, err := CreateSignedVoluntaryExit(ctx, validatorClient, signer, pubKey, exit)
 	if err != nil {
-		return errors.Wrap(err, "failed to sign voluntary exit")
+		return err
 	}
-
-	signedExit := &ethpb.SignedVoluntaryExit{Exit: exit, Signature: sig}
 	exitResp, err := validatorClient.ProposeExit(ctx, signedExit)
 	if err != nil {
 		return errors.Wrap(err, "failed to propose voluntary exit")
@@ -220,6 +225,32 @@ func ProposeExit(
 	return nil
 }
 
+// CreateSignedVoluntaryExit signs the voluntary exit with the fork domain of its epoch, without
+// sending it to the beacon node.
+func CreateSignedVoluntaryExit(
+	ctx context.Context,
+	validatorClient iface.ValidatorClient,
+	signer signingFunc,
+	pubKey []byte,
+	exit *ethpb.VoluntaryExit,
+) (*ethpb.SignedVoluntaryExit, error) {
+	sig, err := signVoluntaryExit(ctx, validatorClient, signer, pubKey, exit)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign voluntary exit")
+	}
+	return &ethpb.SignedVoluntaryExit{Exit: exit, Signature: sig}, nil
+}
+
+// CurrentEpoch returns the current epoch according to the genesis time of the beacon node.
+func CurrentEpoch(ctx context.Context, nodeClient ethpb.NodeClient) (types.Epoch, error) {
+	genesisResponse, err := nodeClient.GetGenesis(ctx, &emptypb.Empty{})
+	if err != nil {
+		return 0, errors.Wrap(err, "gRPC call to get genesis time failed")
+	}
+	totalSecondsPassed := prysmTime.Now().Unix() - genesisResponse.GenesisTime.Seconds
+	return types.Epoch(uint64(totalSecondsPassed) / uint64(params.BeaconConfig().SlotsPerEpoch.Mul(params.BeaconConfig().SecondsPerSlot))), nil
+}
+
 // Sign randao reveal with randao domain and private key.
 func (v *validator) signRandaoReveal(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, epoch types.Epoch, slot types.Slot) ([]byte, error) {
 	domain, err := v.domainData(ctx, epoch, params.BeaconConfig().DomainRandao[:])
@@ -280,7 +311,7 @@ func (v *validator) signBlock(ctx context.Context, pubKey [fieldparams.BLSPubkey
 // Sign voluntary exit with proposer domain and private key.
 func signVoluntaryExit(
 	ctx context.Context,
-	validatorClient ethpb.BeaconNodeValidatorClient,
+	validatorClient iface.ValidatorClient,
 	signer signingFunc,
 	pubKey []byte,
 	exit *ethpb.VoluntaryExit,
@@ -315,28 +346,42 @@ func signVoluntaryExit(
 	return sig.Marshal(), nil
 }
 
-// Gets the graffiti from cli or file for the validator public key.
+// Gets the graffiti from the proposer settings, cli or file for the validator public key.
 func (v *validator) getGraffiti(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte) ([]byte, error) {
-	// When specified, default graffiti from the command line takes the first priority.
+	settings := v.proposerSettings()
+	// When specified, graffiti of the validator in the proposer settings takes the first priority, as it
+	// can be set for each validator through the keymanager API.
+	if g := proposerSettingsGraffiti(settings, pubKey); g != "" {
+		return []byte(g), nil
+	}
+
+	// When specified, default graffiti from the command line takes the second priority.
 	if len(v.graffiti) != 0 {
 		return v.graffiti, nil
 	}
 
-	if v.graffitiStruct == nil {
-		return nil, errors.New("graffitiStruct can't be nil")
+	// When specified, individual validator specified graffiti takes the third priority.
+	if v.graffitiStruct != nil {
+		idx, err := v.validatorClient.ValidatorIndex(ctx, &ethpb.ValidatorIndexRequest{PublicKey: pubKey[:]})
+		if err != nil {
+			return []byte{}, err
+		}
+		g, ok := v.graffitiStruct.Specific[idx.Index]
+		if ok {
+			return []byte(g), nil
+		}
 	}
 
-	// When specified, individual validator specified graffiti takes the second priority.
-	idx, err := v.validatorClient.ValidatorIndex(ctx, &ethpb.ValidatorIndexRequest{PublicKey: pubKey[:]})
-	if err != nil {
-		return []byte{}, err
+	// When specified, default graffiti from the proposer settings takes the fourth priority.
+	if settings != nil && settings.DefaultConfig != nil && settings.DefaultConfig.Graffiti != "" {
+		return []byte(settings.DefaultConfig.Graffiti), nil
 	}
-	g, ok := v.graffitiStruct.Specific[idx.Index]
-	if ok {
-		return []byte(g), nil
+
+	if v.graffitiStruct == nil {
+		return nil, errors.New("graffitiStruct can't be nil")
 	}
 
-	// When specified, a graffiti from the ordered list in the file take third priority.
+	// When specified, a graffiti from the ordered list in the file take fifth priority.
 	if v.graffitiOrderedIndex < uint64(len(v.graffitiStruct.Ordered)) {
 		graffiti := v.graffitiStruct.Ordered[v.graffitiOrderedIndex]
 		v.graffitiOrderedIndex = v.graffitiOrderedIndex + 1
@@ -347,7 +392,7 @@ func (v *validator) getGraffiti(ctx context.Context, pubKey [fieldparams.BLSPubk
 		return []byte(graffiti), nil
 	}
 
-	// When specified, a graffiti from the random list in the file take fourth priority.
+	// When specified, a graffiti from the random list in the file take sixth priority.
 	if len(v.graffitiStruct.Random) != 0 {
 		r := rand.NewGenerator()
 		r.Seed(time.Now().Unix())
@@ -362,3 +407,16 @@ func (v *validator) getGraffiti(ctx context.Context, pubKey [fieldparams.BLSPubk
 
 	return []byte{}, nil
 }
+
+// proposerSettingsGraffiti returns the graffiti of the public key in its own proposer config, leaving out
+// the default config which comes after the graffiti file entry of the validator.
+func proposerSettingsGraffiti(settings *validatorserviceconfig.ProposerSettings, pubKey [fieldparams.BLSPubkeyLength]byte) string {
+	if settings == nil || settings.ProposeConfig == nil {
+		return ""
+	}
+	option, ok := settings.ProposeConfig[pubKey]
+	if !ok || option == nil {
+		return ""
+	}
+	return option.Graffiti
+}
@@ -13,6 +13,7 @@ import (
 	lruwrpr "github.com/prysmaticlabs/prysm/cache/lru"
 	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/config/params"
+	validatorserviceconfig "github.com/prysmaticlabs/prysm/config/validator/service"
 	"github.com/prysmaticlabs/prysm/consensus-types/interfaces"
 	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/consensus-types/wrapper"
@@ -24,15 +25,15 @@ import (
 	"github.com/prysmaticlabs/prysm/testing/mock"
 	"github.com/prysmaticlabs/prysm/testing/require"
 	"github.com/prysmaticlabs/prysm/testing/util"
+	validatormock "github.com/prysmaticlabs/prysm/testing/validator-mock"
 	testing2 "github.com/prysmaticlabs/prysm/validator/db/testing"
 	"github.com/prysmaticlabs/prysm/validator/graffiti"
 	logTest "github.com/sirupsen/logrus/hooks/test"
-	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type mocks struct {
-	validatorClient *mock.MockBeaconNodeValidatorClient
+	validatorClient *validatormock.MockValidatorClient
 	nodeClient      *mock.MockNodeClient
 	slasherClient   *mock.MockSlasherClient
 	signfunc        func(context.Context, *validatorpb.SignRequest) (bls.Signature, error)
@@ -71,7 +72,7 @@ func setupWithKey(t *testing.T, validatorKey bls.SecretKey) (*validator, *mocks,
 	valDB := testing2.SetupDB(t, [][fieldparams.BLSPubkeyLength]byte{pubKey})
 	ctrl := gomock.NewController(t)
 	m := &mocks{
-		validatorClient: mock.NewMockBeaconNodeValidatorClient(ctrl),
+		validatorClient: validatormock.NewMockValidatorClient(ctrl),
 		nodeClient:      mock.NewMockNodeClient(ctrl),
 		slasherClient:   mock.NewMockSlasherClient(ctrl),
 		signfunc: func(ctx context.Context, req *validatorpb.SignRequest) (bls.Signature, error) {
@@ -568,7 +569,7 @@ func testProposeBlock(t *testing.T, graffiti []byte) {
 			m.validatorClient.EXPECT().GetBeaconBlock(
 				gomock.Any(), // ctx
 				gomock.AssignableToTypeOf(&ethpb.BlockRequest{}),
-			).DoAndReturn(func(ctx context.Context, req *ethpb.BlockRequest, arg2 ...grpc.CallOption) (*ethpb.GenericBeaconBlock, error) {
+			).DoAndReturn(func(ctx context.Context, req *ethpb.BlockRequest) (*ethpb.GenericBeaconBlock, error) {
 				assert.DeepEqual(t, graffiti, req.Graffiti, "Unexpected graffiti in request")
 
 				return tt.block, nil
@@ -585,7 +586,7 @@ func testProposeBlock(t *testing.T, graffiti []byte) {
 			m.validatorClient.EXPECT().ProposeBeaconBlock(
 				gomock.Any(), // ctx
 				gomock.AssignableToTypeOf(&ethpb.GenericSignedBeaconBlock{}),
-			).DoAndReturn(func(ctx context.Context, block *ethpb.GenericSignedBeaconBlock, opts ...grpc.CallOption) (*ethpb.ProposeResponse, error) {
+			).DoAndReturn(func(ctx context.Context, block *ethpb.GenericSignedBeaconBlock) (*ethpb.ProposeResponse, error) {
 				sentBlock, err = wrapper.UnwrapGenericSignedBeaconBlock(block)
 				assert.NoError(t, err, "Unexpected error unwrapping block")
 				return &ethpb.ProposeResponse{BlockRoot: make([]byte, 32)}, nil
@@ -896,7 +897,7 @@ func TestSignBellatrixBlock(t *testing.T) {
 func TestGetGraffiti_Ok(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	m := &mocks{
-		validatorClient: mock.NewMockBeaconNodeValidatorClient(ctrl),
+		validatorClient: validatormock.NewMockValidatorClient(ctrl),
 	}
 	pubKey := [fieldparams.BLSPubkeyLength]byte{'a'}
 	tests := []struct {
@@ -904,6 +905,63 @@ func TestGetGraffiti_Ok(t *testing.T) {
 		v    *validator
 		want []byte
 	}{
+		{name: "use proposer settings graffiti",
+			v: &validator{
+				graffiti: []byte{'b'},
+				ProposerSettings: &validatorserviceconfig.ProposerSettings{
+					ProposeConfig: map[[fieldparams.BLSPubkeyLength]byte]*validatorserviceconfig.ProposerOption{
+						pubKey: {Graffiti: "h"},
+					},
+					DefaultConfig: &validatorserviceconfig.ProposerOption{Graffiti: "i"},
+				},
+			},
+			want: []byte{'h'},
+		},
+		{name: "use default proposer settings graffiti",
+			v: &validator{
+				ProposerSettings: &validatorserviceconfig.ProposerSettings{
+					DefaultConfig: &validatorserviceconfig.ProposerOption{Graffiti: "i"},
+				},
+			},
+			want: []byte{'i'},
+		},
+		{name: "use default cli graffiti over default proposer settings",
+			v: &validator{
+				graffiti: []byte{'b'},
+				ProposerSettings: &validatorserviceconfig.ProposerSettings{
+					DefaultConfig: &validatorserviceconfig.ProposerOption{Graffiti: "i"},
+				},
+			},
+			want: []byte{'b'},
+		},
+		{name: "use validator file graffiti over default proposer settings",
+			v: &validator{
+				validatorClient: m.validatorClient,
+				ProposerSettings: &validatorserviceconfig.ProposerSettings{
+					DefaultConfig: &validatorserviceconfig.ProposerOption{Graffiti: "i"},
+				},
+				graffitiStruct: &graffiti.Graffiti{
+					Default: "c",
+					Specific: map[types.ValidatorIndex]string{
+						2: "g",
+					},
+				},
+			},
+			want: []byte{'g'},
+		},
+		{name: "use default proposer settings over ordered file graffiti",
+			v: &validator{
+				validatorClient: m.validatorClient,
+				ProposerSettings: &validatorserviceconfig.ProposerSettings{
+					DefaultConfig: &validatorserviceconfig.ProposerOption{Graffiti: "i"},
+				},
+				graffitiStruct: &graffiti.Graffiti{
+					Ordered: []string{"o"},
+					Default: "c",
+				},
+			},
+			want: []byte{'i'},
+		},
 		{name: "use default cli graffiti",
 			v: &validator{
 				graffiti: []byte{'b'},
@@ -962,7 +1020,7 @@ func TestGetGraffiti_Ok(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if !strings.Contains(tt.name, "use default cli graffiti") {
+			if !strings.Contains(tt.name, "use default cli graffiti") && !strings.Contains(tt.name, "proposer settings graffiti") {
 				m.validatorClient.EXPECT().
 					ValidatorIndex(gomock.Any(), &ethpb.ValidatorIndexRequest{PublicKey: pubKey[:]}).
 					Return(&ethpb.ValidatorIndexResponse{Index: 2}, nil)
@@ -979,7 +1037,7 @@ func TestGetGraffitiOrdered_Ok(t *testing.T) {
 	valDB := testing2.SetupDB(t, [][fieldparams.BLSPubkeyLength]byte{pubKey})
 	ctrl := gomock.NewController(t)
 	m := &mocks{
-		validatorClient: mock.NewMockBeaconNodeValidatorClient(ctrl),
+		validatorClient: validatormock.NewMockValidatorClient(ctrl),
 	}
 	m.validatorClient.EXPECT().
 		ValidatorIndex(gomock.Any(), &ethpb.ValidatorIndexRequest{PublicKey: pubKey[:]}).
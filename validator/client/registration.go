@@ -8,13 +8,14 @@ import (
 	"github.com/prysmaticlabs/prysm/config/params"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	validatorpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/validator-client"
+	"github.com/prysmaticlabs/prysm/validator/client/iface"
 	"go.opencensus.io/trace"
 )
 
 // SubmitValidatorRegistration signs validator registration object and submits it to the beacon node.
 func SubmitValidatorRegistration(
 	ctx context.Context,
-	validatorClient ethpb.BeaconNodeValidatorClient,
+	validatorClient iface.ValidatorClient,
 	signer signingFunc,
 	regs []*ethpb.ValidatorRegistrationV1,
 ) error {
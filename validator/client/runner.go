@@ -105,6 +105,14 @@ func run(ctx context.Context, v iface.Validator) {
 			log := log.WithField("slot", slot)
 			log.WithField("deadline", deadline).Debug("Set deadline for proposals and attestations")
 
+			// Check the liveness of the keys under doppelganger protection before their duties are
+			// updated, so that keys which completed their protection perform duties from this epoch on.
+			if slots.IsEpochStart(slot) {
+				if err := v.CheckDoppelGanger(ctx); err != nil {
+					log.Fatalf("Could not succeed with doppelganger check: %v", err) // allow fatal. skipcq
+				}
+			}
+
 			// Keep trying to update assignments if they are nil or if we are past an
 			// epoch transition in the beacon node's state.
 			if err := v.UpdateDuties(ctx, slot); err != nil {
@@ -2,7 +2,9 @@ package client
 
 import (
 	"context"
+	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/dgraph-io/ristretto"
@@ -14,13 +16,17 @@ import (
 	grpcutil "github.com/prysmaticlabs/prysm/api/grpc"
 	"github.com/prysmaticlabs/prysm/async/event"
 	lruwrpr "github.com/prysmaticlabs/prysm/cache/lru"
+	"github.com/prysmaticlabs/prysm/config/features"
 	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/config/params"
 	validatorserviceconfig "github.com/prysmaticlabs/prysm/config/validator/service"
 	"github.com/prysmaticlabs/prysm/consensus-types/interfaces"
 	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/validator/accounts/wallet"
+	beacon_api "github.com/prysmaticlabs/prysm/validator/client/beacon-api"
+	grpc_api "github.com/prysmaticlabs/prysm/validator/client/grpc-api"
 	"github.com/prysmaticlabs/prysm/validator/client/iface"
 	"github.com/prysmaticlabs/prysm/validator/db"
 	"github.com/prysmaticlabs/prysm/validator/graffiti"
@@ -52,8 +58,14 @@ type ValidatorService struct {
 	emitAccountMetrics    bool
 	logValidatorBalances  bool
 	logDutyCountDown      bool
+	distributed           bool
 	interopKeysConfig     *local.InteropKeymanagerConfig
-	conn                  *grpc.ClientConn
+	conns                 []*grpc.ClientConn
+	failover              *beaconNodeFailover
+	validatorClient       iface.ValidatorClient
+	beaconClient          iface.BeaconChainClient
+	nodeClient            iface.NodeClient
+	slasherClient         ethpb.SlasherClient
 	grpcRetryDelay        time.Duration
 	grpcRetries           uint
 	maxCallRecvMsgSize    int
@@ -70,7 +82,16 @@ type ValidatorService struct {
 	grpcHeaders           []string
 	graffiti              []byte
 	Web3SignerConfig      *remoteweb3signer.SetupConfig
-	ProposerSettings      *validatorserviceconfig.ProposerSettings
+	doppelganger          *doppelgangerProtection
+	performance           *performanceLedger
+	// proposerSettingsLock guards the proposer settings, the settings they were loaded from, the changes
+	// made to them through the keymanager API and the validator they are pushed to.
+	proposerSettingsLock    sync.RWMutex
+	proposerSettings        *validatorserviceconfig.ProposerSettings
+	fileProposerSettings    *validatorserviceconfig.ProposerSettings
+	proposerSettingsUpdates map[proposerSettingsUpdateKey]ProposerSettingsUpdate
+	// proposerSettingsPushLock orders the pushes of changes made through the keymanager API.
+	proposerSettingsPushLock sync.Mutex
 }
 
 // Config for the validator service.
@@ -79,6 +100,7 @@ type Config struct {
 	LogValidatorBalances       bool
 	EmitAccountMetrics         bool
 	LogDutyCountDown           bool
+	Distributed                bool
 	InteropKeysConfig          *local.InteropKeymanagerConfig
 	Wallet                     *wallet.Wallet
 	WalletInitializedFeed      *event.Feed
@@ -93,8 +115,14 @@ type Config struct {
 	GrpcHeadersFlag            string
 	GraffitiFlag               string
 	Endpoint                   string
+	BeaconApiEndpoint          string
+	BeaconApiTimeout           time.Duration
 	Web3SignerConfig           *remoteweb3signer.SetupConfig
 	ProposerSettings           *validatorserviceconfig.ProposerSettings
+	DoppelgangerEpochs         uint64
+	DoppelgangerDisableKeys    bool
+	PerformanceLedger          bool
+	PerformanceLedgerRetention types.Epoch
 }
 
 // NewValidatorService creates a new validator service for the service
@@ -122,8 +150,34 @@ func NewValidatorService(ctx context.Context, cfg *Config) (*ValidatorService, e
 		interopKeysConfig:     cfg.InteropKeysConfig,
 		graffitiStruct:        cfg.GraffitiStruct,
 		logDutyCountDown:      cfg.LogDutyCountDown,
+		distributed:           cfg.Distributed,
 		Web3SignerConfig:      cfg.Web3SignerConfig,
-		ProposerSettings:      cfg.ProposerSettings,
+		proposerSettings:      cfg.ProposerSettings,
+		fileProposerSettings:  cfg.ProposerSettings,
+	}
+	if features.Get().EnableDoppelGanger {
+		s.doppelganger = newDoppelgangerProtection(cfg.DoppelgangerEpochs, cfg.DoppelgangerDisableKeys)
+	}
+	if cfg.PerformanceLedger {
+		if features.Get().EnableBeaconRESTApi {
+			// Attestation performance is only served by Prysm's own API, records without it would
+			// report every attestation as missed.
+			log.Warn("The validator performance ledger is not supported with the Beacon REST API and is disabled")
+		} else {
+			s.performance = newPerformanceLedger(cfg.PerformanceLedgerRetention)
+		}
+	}
+
+	if s.distributed && !features.Get().EnableBeaconRESTApi {
+		// Distributed validator middlewares only speak the standard Beacon REST API.
+		return s, errors.New("running as part of a distributed validator cluster requires the Beacon REST API to be enabled")
+	}
+	if features.Get().EnableBeaconRESTApi && features.Get().RemoteSlasherProtection {
+		// Remote slashing protection is only served over gRPC.
+		return s, errors.New("remote slashing protection is not supported with the Beacon REST API")
+	}
+	if features.Get().EnableBeaconRESTApi {
+		return s, s.useBeaconApi(cfg.BeaconApiEndpoint, cfg.BeaconApiTimeout)
 	}
 
 	dialOpts := ConstructDialOptions(
@@ -138,18 +192,87 @@ func NewValidatorService(ctx context.Context, cfg *Config) (*ValidatorService, e
 
 	s.ctx = grpcutil.AppendHeaders(ctx, s.grpcHeaders)
 
-	conn, err := grpc.DialContext(ctx, s.endpoint, dialOpts...)
-	if err != nil {
-		return s, err
+	nodes := make([]*beaconNode, 0)
+	for _, endpoint := range splitEndpoints(s.endpoint) {
+		conn, err := grpc.DialContext(ctx, endpoint, dialOpts...)
+		if err != nil {
+			return s, err
+		}
+		s.conns = append(s.conns, conn)
+		nodes = append(nodes, &beaconNode{
+			endpoint:        endpoint,
+			validatorClient: grpc_api.NewGrpcValidatorClient(conn),
+			nodeClient:      grpc_api.NewGrpcNodeClient(conn),
+			beaconClient:    grpc_api.NewGrpcBeaconChainClient(conn),
+			slasherClient:   ethpb.NewSlasherClient(conn),
+		})
 	}
 	if s.withCert != "" {
 		log.Info("Established secure gRPC connection")
 	}
-	s.conn = conn
+	s.useBeaconNodes(nodes)
 
 	return s, nil
 }
 
+// useBeaconApi makes the service talk to the beacon nodes at the comma-separated endpoints over
+// the standard Beacon REST API.
+func (v *ValidatorService) useBeaconApi(endpoints string, timeout time.Duration) error {
+	nodes := make([]*beaconNode, 0)
+	for _, endpoint := range splitEndpoints(endpoints) {
+		validatorClient, err := beacon_api.NewBeaconApiValidatorClient(endpoint, timeout)
+		if err != nil {
+			return errors.Wrap(err, "could not create beacon API validator client")
+		}
+		beaconClient, err := beacon_api.NewBeaconApiBeaconChainClient(endpoint, timeout)
+		if err != nil {
+			return errors.Wrap(err, "could not create beacon API beacon chain client")
+		}
+		nodeClient, err := beacon_api.NewBeaconApiNodeClient(endpoint, timeout)
+		if err != nil {
+			return errors.Wrap(err, "could not create beacon API node client")
+		}
+		log.WithField("endpoint", endpoint).Info("Using the Beacon REST API to talk to the beacon node")
+		nodes = append(nodes, &beaconNode{
+			endpoint:        endpoint,
+			validatorClient: validatorClient,
+			nodeClient:      nodeClient,
+			beaconClient:    beaconClient,
+		})
+	}
+	v.useBeaconNodes(nodes)
+	return nil
+}
+
+// useBeaconNodes makes the service perform its duties against the given beacon nodes. With more
+// than one node, calls are routed by a beaconNodeFailover which keeps track of their health.
+func (v *ValidatorService) useBeaconNodes(nodes []*beaconNode) {
+	if len(nodes) == 1 {
+		v.validatorClient = nodes[0].validatorClient
+		v.beaconClient = nodes[0].beaconClient
+		v.nodeClient = nodes[0].nodeClient
+		v.slasherClient = nodes[0].slasherClient
+		return
+	}
+	v.failover = newBeaconNodeFailover(v.ctx, nodes)
+	v.validatorClient = &failoverValidatorClient{v.failover}
+	v.beaconClient = &failoverBeaconChainClient{v.failover}
+	v.nodeClient = &failoverNodeClient{v.failover}
+	if nodes[0].slasherClient != nil {
+		v.slasherClient = &failoverSlasherClient{v.failover}
+	}
+	log.WithField("beaconNodes", len(nodes)).Info("Routing duties to the healthiest of the configured beacon nodes")
+}
+
+// splitEndpoints splits a comma-separated list of beacon node endpoints.
+func splitEndpoints(endpoints string) []string {
+	split := strings.Split(endpoints, ",")
+	for i := range split {
+		split[i] = strings.TrimSpace(split[i])
+	}
+	return split
+}
+
 // Start the validator service. Launches the main go routine for the validator
 // client.
 func (v *ValidatorService) Start() {
@@ -162,6 +285,10 @@ func (v *ValidatorService) Start() {
 		panic(err)
 	}
 
+	if v.failover != nil {
+		go v.failover.run()
+	}
+
 	aggregatedSlotCommitteeIDCache := lruwrpr.New(int(params.BeaconConfig().MaxCommitteesPerSlot))
 
 	sPubKeys, err := v.db.EIPImportBlacklistedPublicKeys(v.ctx)
@@ -180,12 +307,17 @@ func (v *ValidatorService) Start() {
 		return
 	}
 
+	// Remote slashing protection is only served over gRPC.
+	slasherClient := v.slasherClient
+	if slasherClient == nil {
+		slasherClient = ethpb.NewSlasherClient(nil)
+	}
 	valStruct := &validator{
 		db:                             v.db,
-		validatorClient:                ethpb.NewBeaconNodeValidatorClient(v.conn),
-		beaconClient:                   ethpb.NewBeaconChainClient(v.conn),
-		slashingProtectionClient:       ethpb.NewSlasherClient(v.conn),
-		node:                           ethpb.NewNodeClient(v.conn),
+		validatorClient:                v.validatorClient,
+		beaconClient:                   v.beaconClient,
+		slashingProtectionClient:       slasherClient,
+		node:                           v.nodeClient,
 		graffiti:                       v.graffiti,
 		logValidatorBalances:           v.logValidatorBalances,
 		emitAccountMetrics:             v.emitAccountMetrics,
@@ -206,9 +338,11 @@ func (v *ValidatorService) Start() {
 		graffitiOrderedIndex:           graffitiOrderedIndex,
 		eipImportBlacklistedPublicKeys: slashablePublicKeys,
 		logDutyCountDown:               v.logDutyCountDown,
+		distributed:                    v.distributed,
 		Web3SignerConfig:               v.Web3SignerConfig,
-		ProposerSettings:               v.ProposerSettings,
 		walletIntializedChannel:        make(chan *wallet.Wallet, 1),
+		doppelganger:                   v.doppelganger,
+		performance:                    v.performance,
 	}
 	// To resolve a race condition at startup due to the interface
 	// nature of the abstracted block type. We initialize
@@ -220,24 +354,30 @@ func (v *ValidatorService) Start() {
 	sub.Unsubscribe()
 	close(tempChan)
 
+	v.proposerSettingsLock.Lock()
+	valStruct.ProposerSettings = v.proposerSettings
 	v.validator = valStruct
-	go run(v.ctx, v.validator)
+	v.proposerSettingsLock.Unlock()
+	go run(v.ctx, valStruct)
 }
 
 // Stop the validator service.
 func (v *ValidatorService) Stop() error {
 	v.cancel()
 	log.Info("Stopping service")
-	if v.conn != nil {
-		return v.conn.Close()
+	var err error
+	for _, conn := range v.conns {
+		if closeErr := conn.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
 	}
-	return nil
+	return err
 }
 
 // Status of the validator service.
 func (v *ValidatorService) Status() error {
-	if v.conn == nil {
-		return errors.New("no connection to beacon RPC")
+	if v.nodeClient == nil {
+		return errors.New("no connection to beacon node")
 	}
 	return nil
 }
@@ -251,6 +391,160 @@ func (v *ValidatorService) Keymanager() (keymanager.IKeymanager, error) {
 	return v.validator.Keymanager()
 }
 
+// ProposerSettingsField is a proposer setting of a public key that can be changed through the keymanager API.
+type ProposerSettingsField int
+
+const (
+	// FeeRecipientSetting is the fee recipient of a public key.
+	FeeRecipientSetting ProposerSettingsField = iota
+	// GasLimitSetting is the gas limit in the builder registration of a public key.
+	GasLimitSetting
+	// GraffitiSetting is the graffiti of a public key.
+	GraffitiSetting
+)
+
+// ProposerSettingsUpdate applies a change made through the keymanager API to the proposer settings.
+// The settings it is given always have a default config and a propose config.
+type ProposerSettingsUpdate func(settings *validatorserviceconfig.ProposerSettings)
+
+// minGasLimit is the lowest gas limit execution clients accept for a block.
+const minGasLimit = 5000
+
+// ValidateGasLimit rejects gas limits no execution client would build blocks with,
+// and warns about ones far above the default.
+func ValidateGasLimit(gasLimit uint64) error {
+	if gasLimit < minGasLimit {
+		return fmt.Errorf("gas limit %d is below the minimum gas limit of %d", gasLimit, minGasLimit)
+	}
+	if defaultGasLimit := params.BeaconConfig().DefaultBuilderGasLimit; gasLimit > 2*defaultGasLimit {
+		log.Warnf("Gas limit %d is more than twice the default gas limit of %d", gasLimit, defaultGasLimit)
+	}
+	return nil
+}
+
+// proposerSettingsUpdateKey identifies a proposer setting of a public key.
+type proposerSettingsUpdateKey struct {
+	pubkey [fieldparams.BLSPubkeyLength]byte
+	field  ProposerSettingsField
+}
+
+// ProposerSettings returns the proposer settings in use. The returned settings must not be modified,
+// changes go through SetProposerSettings or UpdateProposerSettings.
+func (v *ValidatorService) ProposerSettings() *validatorserviceconfig.ProposerSettings {
+	v.proposerSettingsLock.RLock()
+	defer v.proposerSettingsLock.RUnlock()
+	return v.proposerSettings
+}
+
+// SetProposerSettings replaces the proposer settings of the service, as read from the proposer settings
+// file or URL, pushing them to the beacon node if the validator is already running. Changes made through
+// the keymanager API take precedence, and are applied again on top of the new settings, unless the new
+// settings change the same setting of the public key themselves.
+func (v *ValidatorService) SetProposerSettings(ctx context.Context, settings *validatorserviceconfig.ProposerSettings) error {
+	v.proposerSettingsLock.Lock()
+	defer v.proposerSettingsLock.Unlock()
+	for key := range v.proposerSettingsUpdates {
+		if proposeConfigValue(v.fileProposerSettings, key) != proposeConfigValue(settings, key) {
+			log.WithField("pubKey", fmt.Sprintf("%#x", bytesutil.Trunc(key.pubkey[:]))).Info(
+				"Reloaded proposer settings change a setting previously changed through the keymanager API, using the reloaded setting")
+			delete(v.proposerSettingsUpdates, key)
+		}
+	}
+	v.fileProposerSettings = settings
+	if settings != nil && len(v.proposerSettingsUpdates) > 0 {
+		settings = withProposerSettingsConfigs(settings.Clone())
+		for _, update := range v.proposerSettingsUpdates {
+			update(settings)
+		}
+		log.WithField("apiChanges", len(v.proposerSettingsUpdates)).Warn(
+			"Proposer settings changed through the keymanager API take precedence over the reloaded proposer settings")
+	}
+	return v.setProposerSettings(ctx, settings)
+}
+
+// UpdateProposerSettings changes a proposer setting of the public key on behalf of the keymanager API. The
+// change is kept, replacing any previous change of the same setting of the public key, so that it survives
+// reloads of the proposer settings. The change is pushed to the beacon node in the background; should that
+// fail, it is pushed again along with all proposer settings at the start of the next epoch.
+func (v *ValidatorService) UpdateProposerSettings(
+	pubkey [fieldparams.BLSPubkeyLength]byte,
+	field ProposerSettingsField,
+	update ProposerSettingsUpdate,
+) {
+	v.proposerSettingsLock.Lock()
+	defer v.proposerSettingsLock.Unlock()
+	settings := withProposerSettingsConfigs(v.proposerSettings.Clone())
+	update(settings)
+	if v.proposerSettingsUpdates == nil {
+		v.proposerSettingsUpdates = make(map[proposerSettingsUpdateKey]ProposerSettingsUpdate)
+	}
+	v.proposerSettingsUpdates[proposerSettingsUpdateKey{pubkey: pubkey, field: field}] = update
+	v.proposerSettings = settings
+	if v.validator != nil {
+		go v.pushProposerSettings(v.validator)
+	}
+}
+
+// pushProposerSettings pushes the proposer settings in use to the beacon node. Pushes are ordered, and
+// each pushes the latest settings, so that a slow push never overrides a later change.
+func (v *ValidatorService) pushProposerSettings(validator iface.Validator) {
+	v.proposerSettingsPushLock.Lock()
+	defer v.proposerSettingsPushLock.Unlock()
+	if err := validator.SetProposerSettings(v.ctx, v.ProposerSettings()); err != nil {
+		log.WithError(err).Warn("Could not push proposer settings changed through the keymanager API to the beacon node, " +
+			"they are pushed again at the start of the next epoch")
+	}
+}
+
+// setProposerSettings replaces the proposer settings in use. The caller must hold the proposerSettingsLock.
+func (v *ValidatorService) setProposerSettings(ctx context.Context, settings *validatorserviceconfig.ProposerSettings) error {
+	v.proposerSettings = settings
+	if v.validator == nil {
+		return nil
+	}
+	return v.validator.SetProposerSettings(ctx, settings)
+}
+
+// proposeConfigValue returns the value of the setting in the propose config of the public key, or nil if
+// the public key has none.
+func proposeConfigValue(settings *validatorserviceconfig.ProposerSettings, key proposerSettingsUpdateKey) interface{} {
+	if settings == nil {
+		return nil
+	}
+	option, ok := settings.ProposeConfig[key.pubkey]
+	if !ok || option == nil {
+		return nil
+	}
+	switch key.field {
+	case FeeRecipientSetting:
+		return option.FeeRecipient
+	case GasLimitSetting:
+		if option.ValidatorRegistration == nil {
+			return nil
+		}
+		return option.ValidatorRegistration.GasLimit
+	case GraffitiSetting:
+		return option.Graffiti
+	default:
+		return nil
+	}
+}
+
+// withProposerSettingsConfigs fills in the default config and the propose config of the settings if absent.
+func withProposerSettingsConfigs(settings *validatorserviceconfig.ProposerSettings) *validatorserviceconfig.ProposerSettings {
+	if settings == nil {
+		settings = &validatorserviceconfig.ProposerSettings{}
+	}
+	if settings.DefaultConfig == nil {
+		defaultOption := validatorserviceconfig.DefaultProposerOption()
+		settings.DefaultConfig = &defaultOption
+	}
+	if settings.ProposeConfig == nil {
+		settings.ProposeConfig = make(map[[fieldparams.BLSPubkeyLength]byte]*validatorserviceconfig.ProposerOption)
+	}
+	return settings
+}
+
 // ConstructDialOptions constructs a list of grpc dial options
 func ConstructDialOptions(
 	maxCallRecvMsgSize int,
@@ -307,8 +601,7 @@ func ConstructDialOptions(
 
 // Syncing returns whether or not the beacon node is currently synchronizing the chain.
 func (v *ValidatorService) Syncing(ctx context.Context) (bool, error) {
-	nc := ethpb.NewNodeClient(v.conn)
-	resp, err := nc.GetSyncStatus(ctx, &emptypb.Empty{})
+	resp, err := v.nodeClient.GetSyncStatus(ctx, &emptypb.Empty{})
 	if err != nil {
 		return false, err
 	}
@@ -318,6 +611,5 @@ func (v *ValidatorService) Syncing(ctx context.Context) (bool, error) {
 // GenesisInfo queries the beacon node for the chain genesis info containing
 // the genesis time along with the validator deposit contract address.
 func (v *ValidatorService) GenesisInfo(ctx context.Context) (*ethpb.Genesis, error) {
-	nc := ethpb.NewNodeClient(v.conn)
-	return nc.GetGenesis(ctx, &emptypb.Empty{})
+	return v.nodeClient.GetGenesis(ctx, &emptypb.Empty{})
 }
@@ -5,9 +5,14 @@ import (
 	"testing"
 	"time"
 
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/config/features"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	validatorserviceconfig "github.com/prysmaticlabs/prysm/config/validator/service"
 	"github.com/prysmaticlabs/prysm/runtime"
 	"github.com/prysmaticlabs/prysm/testing/assert"
 	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/validator/client/iface"
 	logTest "github.com/sirupsen/logrus/hooks/test"
 	"google.golang.org/grpc/metadata"
 )
@@ -39,6 +44,16 @@ func TestNew_Insecure(t *testing.T) {
 	require.LogsContain(t, hook, "You are using an insecure gRPC connection")
 }
 
+func TestNew_RemoteSlasherProtectionWithBeaconApi(t *testing.T) {
+	reset := features.InitWithReset(&features.Flags{
+		EnableBeaconRESTApi:     true,
+		RemoteSlasherProtection: true,
+	})
+	defer reset()
+	_, err := NewValidatorService(context.Background(), &Config{})
+	require.ErrorContains(t, "remote slashing protection is not supported with the Beacon REST API", err)
+}
+
 func TestStatus_NoConnectionError(t *testing.T) {
 	validatorService := &ValidatorService{}
 	assert.ErrorContains(t, "no connection", validatorService.Status())
@@ -72,3 +87,96 @@ func TestStart_GrpcHeaders(t *testing.T) {
 		}
 	}
 }
+
+func TestSetProposerSettings_KeepsAPIUpdates(t *testing.T) {
+	hook := logTest.NewGlobal()
+	ctx := context.Background()
+	pubkey := [fieldparams.BLSPubkeyLength]byte{1}
+	vs := &ValidatorService{}
+	require.NoError(t, vs.SetProposerSettings(ctx, &validatorserviceconfig.ProposerSettings{
+		DefaultConfig: &validatorserviceconfig.ProposerOption{Graffiti: "default"},
+	}))
+	require.LogsDoNotContain(t, hook, "take precedence")
+
+	vs.UpdateProposerSettings(pubkey, GraffitiSetting, func(settings *validatorserviceconfig.ProposerSettings) {
+		settings.ProposeConfig[pubkey] = &validatorserviceconfig.ProposerOption{Graffiti: "first"}
+	})
+	vs.UpdateProposerSettings(pubkey, GraffitiSetting, func(settings *validatorserviceconfig.ProposerSettings) {
+		settings.ProposeConfig[pubkey] = &validatorserviceconfig.ProposerOption{Graffiti: "api"}
+	})
+	assert.Equal(t, "api", vs.ProposerSettings().ProposeConfig[pubkey].Graffiti)
+
+	reloaded := &validatorserviceconfig.ProposerSettings{
+		DefaultConfig: &validatorserviceconfig.ProposerOption{Graffiti: "new default"},
+	}
+	require.NoError(t, vs.SetProposerSettings(ctx, reloaded))
+	settings := vs.ProposerSettings()
+	assert.Equal(t, "api", settings.ProposeConfig[pubkey].Graffiti)
+	assert.Equal(t, "new default", settings.DefaultConfig.Graffiti)
+	// The reloaded settings are not modified.
+	assert.Equal(t, 0, len(reloaded.ProposeConfig))
+	require.LogsContain(t, hook, "take precedence")
+}
+
+func TestSetProposerSettings_DropsSupersededAPIUpdates(t *testing.T) {
+	ctx := context.Background()
+	pubkey := [fieldparams.BLSPubkeyLength]byte{1}
+	vs := &ValidatorService{}
+	require.NoError(t, vs.SetProposerSettings(ctx, &validatorserviceconfig.ProposerSettings{
+		DefaultConfig: &validatorserviceconfig.ProposerOption{Graffiti: "default"},
+	}))
+	vs.UpdateProposerSettings(pubkey, GraffitiSetting, func(settings *validatorserviceconfig.ProposerSettings) {
+		settings.ProposeConfig[pubkey] = &validatorserviceconfig.ProposerOption{Graffiti: "api"}
+	})
+
+	// The reloaded settings change the graffiti of the public key themselves.
+	require.NoError(t, vs.SetProposerSettings(ctx, &validatorserviceconfig.ProposerSettings{
+		ProposeConfig: map[[fieldparams.BLSPubkeyLength]byte]*validatorserviceconfig.ProposerOption{
+			pubkey: {Graffiti: "file"},
+		},
+		DefaultConfig: &validatorserviceconfig.ProposerOption{Graffiti: "default"},
+	}))
+	assert.Equal(t, "file", vs.ProposerSettings().ProposeConfig[pubkey].Graffiti)
+	assert.Equal(t, 0, len(vs.proposerSettingsUpdates))
+
+	// The change made through the API is gone for good.
+	require.NoError(t, vs.SetProposerSettings(ctx, &validatorserviceconfig.ProposerSettings{
+		DefaultConfig: &validatorserviceconfig.ProposerOption{Graffiti: "default"},
+	}))
+	assert.Equal(t, 0, len(vs.ProposerSettings().ProposeConfig))
+}
+
+// failingPushValidator fails to push the proposer settings to the beacon node.
+type failingPushValidator struct {
+	iface.Validator
+	pushed chan *validatorserviceconfig.ProposerSettings
+}
+
+func (v *failingPushValidator) SetProposerSettings(_ context.Context, settings *validatorserviceconfig.ProposerSettings) error {
+	v.pushed <- settings
+	return errors.New("beacon node unavailable")
+}
+
+func TestUpdateProposerSettings_KeepsChangeWhenPushFails(t *testing.T) {
+	hook := logTest.NewGlobal()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pubkey := [fieldparams.BLSPubkeyLength]byte{1}
+	validator := &failingPushValidator{pushed: make(chan *validatorserviceconfig.ProposerSettings, 1)}
+	vs := &ValidatorService{ctx: ctx, validator: validator}
+
+	vs.UpdateProposerSettings(pubkey, GraffitiSetting, func(settings *validatorserviceconfig.ProposerSettings) {
+		settings.ProposeConfig[pubkey] = &validatorserviceconfig.ProposerOption{Graffiti: "api"}
+	})
+	assert.Equal(t, "api", vs.ProposerSettings().ProposeConfig[pubkey].Graffiti)
+	select {
+	case pushed := <-validator.pushed:
+		assert.Equal(t, "api", pushed.ProposeConfig[pubkey].Graffiti)
+	case <-time.After(time.Second):
+		t.Fatal("The proposer settings were not pushed")
+	}
+	vs.proposerSettingsPushLock.Lock()
+	defer vs.proposerSettingsPushLock.Unlock()
+	require.LogsContain(t, hook, "pushed again at the start of the next epoch")
+	assert.Equal(t, 1, len(vs.proposerSettingsUpdates))
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	emptypb "github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/altair"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/signing"
 	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
@@ -17,6 +18,7 @@ import (
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	validatorpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/validator-client"
 	"github.com/prysmaticlabs/prysm/time/slots"
+	"github.com/prysmaticlabs/prysm/validator/client/iface"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
 )
@@ -27,6 +29,13 @@ func (v *validator) SubmitSyncCommitteeMessage(ctx context.Context, slot types.S
 	defer span.End()
 	span.AddAttributes(trace.StringAttribute("validator", fmt.Sprintf("%#x", pubKey)))
 
+	var submitted bool
+	if v.performance != nil {
+		defer func() {
+			v.performance.recordSyncMessage(pubKey, slot, submitted)
+		}()
+	}
+
 	v.waitOneThirdOrValidBlock(ctx, slot)
 
 	res, err := v.validatorClient.GetSyncMessageBlockRoot(ctx, &emptypb.Empty{})
@@ -78,6 +87,7 @@ func (v *validator) SubmitSyncCommitteeMessage(ctx context.Context, slot types.S
 		log.WithError(err).Error("Could not submit sync committee message")
 		return
 	}
+	submitted = true
 
 	msgSlot := msg.Slot
 	slotTime := time.Unix(int64(v.genesisTime+uint64(msgSlot)*params.BeaconConfig().SecondsPerSlot), 0)
@@ -116,7 +126,7 @@ func (v *validator) SubmitSignedContributionAndProof(ctx context.Context, slot t
 		return
 	}
 
-	selectionProofs, err := v.selectionProofs(ctx, slot, pubKey, indexRes)
+	selectionProofs, err := v.selectionProofs(ctx, slot, pubKey, indexRes, duty.ValidatorIndex)
 	if err != nil {
 		log.Errorf("Could not get selection proofs: %v", err)
 		return
@@ -186,9 +196,44 @@ func (v *validator) SubmitSignedContributionAndProof(ctx context.Context, slot t
 	}
 }
 
-// Signs and returns selection proofs per validator for slot and pub key.
-func (v *validator) selectionProofs(ctx context.Context, slot types.Slot, pubKey [fieldparams.BLSPubkeyLength]byte, indexRes *ethpb.SyncSubcommitteeIndexResponse) ([][]byte, error) {
+// syncSelectionKey identifies the sync committee selection proofs of a validator at a slot.
+type syncSelectionKey struct {
+	slot  types.Slot
+	index types.ValidatorIndex
+}
+
+// Returns selection proofs per validator for slot and pub key. Proofs are computed once per slot, as
+// both aggregator selection and contribution submission need them, and forgotten past the epoch.
+func (v *validator) selectionProofs(ctx context.Context, slot types.Slot, pubKey [fieldparams.BLSPubkeyLength]byte, indexRes *ethpb.SyncSubcommitteeIndexResponse, validatorIndex types.ValidatorIndex) ([][]byte, error) {
+	v.syncSelectionLock.Lock()
+	defer v.syncSelectionLock.Unlock()
+
+	key := syncSelectionKey{slot: slot, index: validatorIndex}
+	if proofs, ok := v.syncSelections[key]; ok && len(proofs) == len(indexRes.Indices) {
+		return proofs, nil
+	}
+	proofs, err := v.computeSelectionProofs(ctx, slot, pubKey, indexRes, validatorIndex)
+	if err != nil {
+		return nil, err
+	}
+	if v.syncSelections == nil {
+		v.syncSelections = make(map[syncSelectionKey][][]byte)
+	}
+	epoch := slots.ToEpoch(slot)
+	for k := range v.syncSelections {
+		if slots.ToEpoch(k.slot) < epoch {
+			delete(v.syncSelections, k)
+		}
+	}
+	v.syncSelections[key] = proofs
+	return proofs, nil
+}
+
+// Signs and returns selection proofs per validator for slot and pub key. In a distributed validator
+// cluster, the signatures are partial and get exchanged for the cluster's combined proofs.
+func (v *validator) computeSelectionProofs(ctx context.Context, slot types.Slot, pubKey [fieldparams.BLSPubkeyLength]byte, indexRes *ethpb.SyncSubcommitteeIndexResponse, validatorIndex types.ValidatorIndex) ([][]byte, error) {
 	selectionProofs := make([][]byte, len(indexRes.Indices))
+	selections := make([]iface.SyncCommitteeSelection, len(indexRes.Indices))
 	cfg := params.BeaconConfig()
 	size := cfg.SyncCommitteeSize
 	subCount := cfg.SyncCommitteeSubnetCount
@@ -200,6 +245,33 @@ func (v *validator) selectionProofs(ctx context.Context, slot types.Slot, pubKey
 			return nil, err
 		}
 		selectionProofs[i] = selectionProof
+		selections[i] = iface.SyncCommitteeSelection{
+			SelectionProof:    selectionProof,
+			Slot:              slot,
+			SubcommitteeIndex: subnet,
+			ValidatorIndex:    validatorIndex,
+		}
+	}
+	if !v.distributed || len(selections) == 0 {
+		return selectionProofs, nil
+	}
+
+	aggregated, err := v.validatorClient.GetAggregatedSyncSelections(ctx, selections)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get aggregated sync selection proofs")
+	}
+	for i, s := range selections {
+		found := false
+		for _, a := range aggregated {
+			if a.Slot == s.Slot && a.SubcommitteeIndex == s.SubcommitteeIndex && a.ValidatorIndex == s.ValidatorIndex {
+				selectionProofs[i] = a.SelectionProof
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, errors.Errorf("no aggregated sync selection proof for subcommittee %d", s.SubcommitteeIndex)
+		}
 	}
 	return selectionProofs, nil
 }
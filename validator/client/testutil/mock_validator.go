@@ -6,6 +6,7 @@ import (
 	"time"
 
 	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	validatorserviceconfig "github.com/prysmaticlabs/prysm/config/validator/service"
 	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	prysmTime "github.com/prysmaticlabs/prysm/time"
@@ -257,6 +258,12 @@ func (_ *FakeValidator) PushProposerSettings(_ context.Context, _ keymanager.IKe
 	return nil
 }
 
+// SetProposerSettings for mocking
+func (_ *FakeValidator) SetProposerSettings(_ context.Context, _ *validatorserviceconfig.ProposerSettings) error {
+	log.Infoln("Mock set proposer settings")
+	return nil
+}
+
 // SetPubKeyToValidatorIndexMap for mocking
 func (_ *FakeValidator) SetPubKeyToValidatorIndexMap(_ context.Context, _ keymanager.IKeymanager) error {
 	return nil
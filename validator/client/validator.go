@@ -22,7 +22,6 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/async/event"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/altair"
-	"github.com/prysmaticlabs/prysm/config/features"
 	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/config/params"
 	validatorserviceconfig "github.com/prysmaticlabs/prysm/config/validator/service"
@@ -64,15 +63,22 @@ type validator struct {
 	useWeb                             bool
 	emitAccountMetrics                 bool
 	logDutyCountDown                   bool
+	distributed                        bool
 	domainDataLock                     sync.Mutex
 	attLogsLock                        sync.Mutex
 	aggregatedSlotCommitteeIDCacheLock sync.Mutex
 	highestValidSlotLock               sync.Mutex
+	attSelectionLock                   sync.Mutex
+	syncSelectionLock                  sync.Mutex
+	proposerSettingsLock               sync.Mutex
+	proposerSettingsValueLock          sync.RWMutex
 	prevBalanceLock                    sync.RWMutex
 	slashableKeysLock                  sync.RWMutex
 	eipImportBlacklistedPublicKeys     map[[fieldparams.BLSPubkeyLength]byte]bool
 	walletInitializedFeed              *event.Feed
 	attLogs                            map[[32]byte]*attSubmitted
+	attSelections                      map[attSelectionKey][]byte
+	syncSelections                     map[syncSelectionKey][][]byte
 	startBalances                      map[[fieldparams.BLSPubkeyLength]byte]uint64
 	duties                             *ethpb.DutiesResponse
 	prevBalance                        map[[fieldparams.BLSPubkeyLength]byte]uint64
@@ -86,19 +92,21 @@ type validator struct {
 	interopKeysConfig                  *local.InteropKeymanagerConfig
 	wallet                             *wallet.Wallet
 	graffitiStruct                     *graffiti.Graffiti
-	node                               ethpb.NodeClient
+	node                               iface.NodeClient
 	slashingProtectionClient           ethpb.SlasherClient
 	db                                 vdb.Database
-	beaconClient                       ethpb.BeaconChainClient
+	beaconClient                       iface.BeaconChainClient
 	keyManager                         keymanager.IKeymanager
 	ticker                             slots.Ticker
-	validatorClient                    ethpb.BeaconNodeValidatorClient
+	validatorClient                    iface.ValidatorClient
 	graffiti                           []byte
 	voteStats                          voteStats
 	syncCommitteeStats                 syncCommitteeStats
 	Web3SignerConfig                   *remoteweb3signer.SetupConfig
 	ProposerSettings                   *validatorserviceconfig.ProposerSettings
 	walletIntializedChannel            chan *wallet.Wallet
+	doppelganger                       *doppelgangerProtection
+	performance                        *performanceLedger
 }
 
 type validatorStatus struct {
@@ -233,16 +241,8 @@ func (v *validator) WaitForChainStart(ctx context.Context) error {
 	ctx, span := trace.StartSpan(ctx, "validator.WaitForChainStart")
 	defer span.End()
 	// First, check if the beacon chain has started.
-	stream, err := v.validatorClient.WaitForChainStart(ctx, &emptypb.Empty{})
-	if err != nil {
-		return errors.Wrap(
-			iface.ErrConnectionIssue,
-			errors.Wrap(err, "could not setup beacon chain ChainStart streaming client").Error(),
-		)
-	}
-
 	log.Info("Waiting for beacon chain start log from the ETH 1.0 deposit contract")
-	chainStartRes, err := stream.Recv()
+	chainStartRes, err := v.validatorClient.WaitForChainStart(ctx, &emptypb.Empty{})
 	if err != io.EOF {
 		if ctx.Err() == context.Canceled {
 			return errors.Wrap(ctx.Err(), "context has been canceled so shutting down the loop")
@@ -364,6 +364,9 @@ func (v *validator) ReceiveBlocks(ctx context.Context, connectionErrorChannel ch
 			v.highestValidSlot = blk.Block().Slot()
 		}
 		v.highestValidSlotLock.Unlock()
+		if v.performance != nil {
+			v.performance.recordIncludedAttestations(blk)
+		}
 		v.blockFeed.Send(blk)
 	}
 }
@@ -453,77 +456,6 @@ func (v *validator) SlotDeadline(slot types.Slot) time.Time {
 	return time.Unix(int64(v.genesisTime), 0 /*ns*/).Add(secs * time.Second)
 }
 
-// CheckDoppelGanger checks if the current actively provided keys have
-// any duplicates active in the network.
-func (v *validator) CheckDoppelGanger(ctx context.Context) error {
-	if !features.Get().EnableDoppelGanger {
-		return nil
-	}
-	pubkeys, err := v.keyManager.FetchValidatingPublicKeys(ctx)
-	if err != nil {
-		return err
-	}
-	log.WithField("keys", len(pubkeys)).Info("Running doppelganger check")
-	// Exit early if no validating pub keys are found.
-	if len(pubkeys) == 0 {
-		return nil
-	}
-	req := &ethpb.DoppelGangerRequest{ValidatorRequests: []*ethpb.DoppelGangerRequest_ValidatorRequest{}}
-	for _, pkey := range pubkeys {
-		copiedKey := pkey
-		attRec, err := v.db.AttestationHistoryForPubKey(ctx, copiedKey)
-		if err != nil {
-			return err
-		}
-		if len(attRec) == 0 {
-			// If no history exists we simply send in a zero
-			// value for the request epoch and root.
-			req.ValidatorRequests = append(req.ValidatorRequests,
-				&ethpb.DoppelGangerRequest_ValidatorRequest{
-					PublicKey:  copiedKey[:],
-					Epoch:      0,
-					SignedRoot: make([]byte, fieldparams.RootLength),
-				})
-			continue
-		}
-		r := retrieveLatestRecord(attRec)
-		if copiedKey != r.PubKey {
-			return errors.New("attestation record mismatched public key")
-		}
-		req.ValidatorRequests = append(req.ValidatorRequests,
-			&ethpb.DoppelGangerRequest_ValidatorRequest{
-				PublicKey:  r.PubKey[:],
-				Epoch:      r.Target,
-				SignedRoot: r.SigningRoot[:],
-			})
-	}
-	resp, err := v.validatorClient.CheckDoppelGanger(ctx, req)
-	if err != nil {
-		return err
-	}
-	// If nothing is returned by the beacon node, we return an
-	// error as it is unsafe for us to proceed.
-	if resp == nil || resp.Responses == nil || len(resp.Responses) == 0 {
-		return errors.New("beacon node returned 0 responses for doppelganger check")
-	}
-	return buildDuplicateError(resp.Responses)
-}
-
-func buildDuplicateError(response []*ethpb.DoppelGangerResponse_ValidatorResponse) error {
-	duplicates := make([][]byte, 0)
-	for _, valRes := range response {
-		if valRes.DuplicateExists {
-			copiedKey := [fieldparams.BLSPubkeyLength]byte{}
-			copy(copiedKey[:], valRes.PublicKey)
-			duplicates = append(duplicates, copiedKey[:])
-		}
-	}
-	if len(duplicates) == 0 {
-		return nil
-	}
-	return errors.Errorf("Duplicate instances exists in the network for validator keys: %#x", duplicates)
-}
-
 // Ensures that the latest attestation history is retrieved.
 func retrieveLatestRecord(recs []*kv.AttestationRecord) *kv.AttestationRecord {
 	if len(recs) == 0 {
@@ -617,8 +549,16 @@ func (v *validator) subscribeToSubnets(ctx context.Context, res *ethpb.DutiesRes
 	subscribeSlots := make([]types.Slot, 0, len(res.CurrentEpochDuties)+len(res.NextEpochDuties))
 	subscribeCommitteeIndices := make([]types.CommitteeIndex, 0, len(res.CurrentEpochDuties)+len(res.NextEpochDuties))
 	subscribeIsAggregator := make([]bool, 0, len(res.CurrentEpochDuties)+len(res.NextEpochDuties))
+	subscribeValidatorIndices := make([]types.ValidatorIndex, 0, len(res.CurrentEpochDuties)+len(res.NextEpochDuties))
 	alreadySubscribed := make(map[[64]byte]bool)
 
+	if v.distributed {
+		// Fetch the combined selection proofs of all duties at once, rather than one by one below.
+		if err := v.fetchAttSelections(ctx, res); err != nil {
+			return errors.Wrap(err, "could not get aggregated selection proofs")
+		}
+	}
+
 	for _, duty := range res.CurrentEpochDuties {
 		pk := bytesutil.ToBytes48(duty.PublicKey)
 		if duty.Status == ethpb.ValidatorStatus_ACTIVE || duty.Status == ethpb.ValidatorStatus_EXITING {
@@ -630,7 +570,7 @@ func (v *validator) subscribeToSubnets(ctx context.Context, res *ethpb.DutiesRes
 				continue
 			}
 
-			aggregator, err := v.isAggregator(ctx, duty.Committee, attesterSlot, pk)
+			aggregator, err := v.isAggregator(ctx, duty.Committee, attesterSlot, pk, duty.ValidatorIndex)
 			if err != nil {
 				return errors.Wrap(err, "could not check if a validator is an aggregator")
 			}
@@ -641,6 +581,7 @@ func (v *validator) subscribeToSubnets(ctx context.Context, res *ethpb.DutiesRes
 			subscribeSlots = append(subscribeSlots, attesterSlot)
 			subscribeCommitteeIndices = append(subscribeCommitteeIndices, committeeIndex)
 			subscribeIsAggregator = append(subscribeIsAggregator, aggregator)
+			subscribeValidatorIndices = append(subscribeValidatorIndices, duty.ValidatorIndex)
 		}
 	}
 
@@ -654,7 +595,7 @@ func (v *validator) subscribeToSubnets(ctx context.Context, res *ethpb.DutiesRes
 				continue
 			}
 
-			aggregator, err := v.isAggregator(ctx, duty.Committee, attesterSlot, bytesutil.ToBytes48(duty.PublicKey))
+			aggregator, err := v.isAggregator(ctx, duty.Committee, attesterSlot, bytesutil.ToBytes48(duty.PublicKey), duty.ValidatorIndex)
 			if err != nil {
 				return errors.Wrap(err, "could not check if a validator is an aggregator")
 			}
@@ -665,6 +606,7 @@ func (v *validator) subscribeToSubnets(ctx context.Context, res *ethpb.DutiesRes
 			subscribeSlots = append(subscribeSlots, attesterSlot)
 			subscribeCommitteeIndices = append(subscribeCommitteeIndices, committeeIndex)
 			subscribeIsAggregator = append(subscribeIsAggregator, aggregator)
+			subscribeValidatorIndices = append(subscribeValidatorIndices, duty.ValidatorIndex)
 		}
 	}
 
@@ -672,7 +614,7 @@ func (v *validator) subscribeToSubnets(ctx context.Context, res *ethpb.DutiesRes
 		Slots:        subscribeSlots,
 		CommitteeIds: subscribeCommitteeIndices,
 		IsAggregator: subscribeIsAggregator,
-	})
+	}, subscribeValidatorIndices)
 
 	return err
 }
@@ -688,6 +630,9 @@ func (v *validator) RolesAt(ctx context.Context, slot types.Slot) (map[[fieldpar
 		if duty == nil {
 			continue
 		}
+		if v.doppelganger != nil && !v.doppelganger.isSafe(bytesutil.ToBytes48(duty.PublicKey)) {
+			continue
+		}
 		if len(duty.ProposerSlots) > 0 {
 			for _, proposerSlot := range duty.ProposerSlots {
 				if proposerSlot != 0 && proposerSlot == slot {
@@ -699,7 +644,7 @@ func (v *validator) RolesAt(ctx context.Context, slot types.Slot) (map[[fieldpar
 		if duty.AttesterSlot == slot {
 			roles = append(roles, iface.RoleAttester)
 
-			aggregator, err := v.isAggregator(ctx, duty.Committee, slot, bytesutil.ToBytes48(duty.PublicKey))
+			aggregator, err := v.isAggregator(ctx, duty.Committee, slot, bytesutil.ToBytes48(duty.PublicKey), duty.ValidatorIndex)
 			if err != nil {
 				return nil, errors.Wrap(err, "could not check if a validator is an aggregator")
 			}
@@ -725,7 +670,7 @@ func (v *validator) RolesAt(ctx context.Context, slot types.Slot) (map[[fieldpar
 			}
 		}
 		if inSyncCommittee {
-			aggregator, err := v.isSyncCommitteeAggregator(ctx, slot, bytesutil.ToBytes48(duty.PublicKey))
+			aggregator, err := v.isSyncCommitteeAggregator(ctx, slot, bytesutil.ToBytes48(duty.PublicKey), duty.ValidatorIndex)
 			if err != nil {
 				return nil, errors.Wrap(err, "could not check if a validator is a sync committee aggregator")
 			}
@@ -755,13 +700,13 @@ func (v *validator) Keymanager() (keymanager.IKeymanager, error) {
 
 // isAggregator checks if a validator is an aggregator of a given slot and committee,
 // it uses a modulo calculated by validator count in committee and samples randomness around it.
-func (v *validator) isAggregator(ctx context.Context, committee []types.ValidatorIndex, slot types.Slot, pubKey [fieldparams.BLSPubkeyLength]byte) (bool, error) {
+func (v *validator) isAggregator(ctx context.Context, committee []types.ValidatorIndex, slot types.Slot, pubKey [fieldparams.BLSPubkeyLength]byte, validatorIndex types.ValidatorIndex) (bool, error) {
 	modulo := uint64(1)
 	if len(committee)/int(params.BeaconConfig().TargetAggregatorsPerCommittee) > 1 {
 		modulo = uint64(len(committee)) / params.BeaconConfig().TargetAggregatorsPerCommittee
 	}
 
-	slotSig, err := v.signSlotWithSelectionProof(ctx, pubKey, slot)
+	slotSig, err := v.attSelectionProof(ctx, pubKey, validatorIndex, slot)
 	if err != nil {
 		return false, err
 	}
@@ -778,7 +723,7 @@ func (v *validator) isAggregator(ctx context.Context, committee []types.Validato
 // def is_sync_committee_aggregator(signature: BLSSignature) -> bool:
 //    modulo = max(1, SYNC_COMMITTEE_SIZE // SYNC_COMMITTEE_SUBNET_COUNT // TARGET_AGGREGATORS_PER_SYNC_SUBCOMMITTEE)
 //    return bytes_to_uint64(hash(signature)[0:8]) % modulo == 0
-func (v *validator) isSyncCommitteeAggregator(ctx context.Context, slot types.Slot, pubKey [fieldparams.BLSPubkeyLength]byte) (bool, error) {
+func (v *validator) isSyncCommitteeAggregator(ctx context.Context, slot types.Slot, pubKey [fieldparams.BLSPubkeyLength]byte, validatorIndex types.ValidatorIndex) (bool, error) {
 	res, err := v.validatorClient.GetSyncSubcommitteeIndex(ctx, &ethpb.SyncSubcommitteeIndexRequest{
 		PublicKey: pubKey[:],
 		Slot:      slot,
@@ -786,14 +731,15 @@ func (v *validator) isSyncCommitteeAggregator(ctx context.Context, slot types.Sl
 	if err != nil {
 		return false, err
 	}
+	if len(res.Indices) == 0 {
+		return false, nil
+	}
 
-	for _, index := range res.Indices {
-		subCommitteeSize := params.BeaconConfig().SyncCommitteeSize / params.BeaconConfig().SyncCommitteeSubnetCount
-		subnet := uint64(index) / subCommitteeSize
-		sig, err := v.signSyncSelectionData(ctx, pubKey, subnet, slot)
-		if err != nil {
-			return false, err
-		}
+	selectionProofs, err := v.selectionProofs(ctx, slot, pubKey, res, validatorIndex)
+	if err != nil {
+		return false, err
+	}
+	for _, sig := range selectionProofs {
 		isAggregator, err := altair.IsSyncCommitteeAggregator(sig)
 		if err != nil {
 			return false, err
@@ -944,6 +890,8 @@ func (v *validator) logDuties(slot types.Slot, duties []*ethpb.DutiesResponse_Du
 
 // PushProposerSettings calls the prepareBeaconProposer RPC to set the fee recipient and also the register validator API if using a custom builder.
 func (v *validator) PushProposerSettings(ctx context.Context, km keymanager.IKeymanager) error {
+	v.proposerSettingsLock.Lock()
+	defer v.proposerSettingsLock.Unlock()
 	// only used after Bellatrix
 	if v.ProposerSettings == nil {
 		e := params.BeaconConfig().BellatrixForkEpoch
@@ -957,6 +905,55 @@ func (v *validator) PushProposerSettings(ctx context.Context, km keymanager.IKey
 		}
 		return nil
 	}
+	return v.pushProposerSettings(ctx, km, nil /* register all keys */)
+}
+
+// SetProposerSettings replaces the proposer settings in use. The fee recipients are pushed to the beacon node
+// again, while builder registrations are only signed again for the keys whose settings changed.
+func (v *validator) SetProposerSettings(ctx context.Context, settings *validatorserviceconfig.ProposerSettings) error {
+	if settings == nil {
+		return errors.New("proposer settings are nil")
+	}
+	v.proposerSettingsLock.Lock()
+	defer v.proposerSettingsLock.Unlock()
+	previous := v.ProposerSettings
+	// The proposerSettingsLock is held while pushing the settings to the beacon node, readers outside
+	// of pushes use the proposerSettingsValueLock instead so that they are not held up by the pushes.
+	v.proposerSettingsValueLock.Lock()
+	v.ProposerSettings = settings
+	v.proposerSettingsValueLock.Unlock()
+	if v.keyManager == nil {
+		// The settings get pushed once the keymanager is initialized and the validator starts running.
+		return nil
+	}
+	pubkeys, err := v.keyManager.FetchValidatingPublicKeys(ctx)
+	if err != nil {
+		return err
+	}
+	changedKeys := make(map[[fieldparams.BLSPubkeyLength]byte]bool)
+	for _, key := range pubkeys {
+		if previous == nil || effectiveProposerOption(previous, key) != effectiveProposerOption(settings, key) {
+			changedKeys[key] = true
+		}
+	}
+	if len(changedKeys) == 0 {
+		log.Info("Reloaded proposer settings did not change for any validating public key")
+		return nil
+	}
+	log.WithField("changedKeys", len(changedKeys)).Info("Reloaded proposer settings, pushing changes to the beacon node")
+	return v.pushProposerSettings(ctx, v.keyManager, changedKeys)
+}
+
+// proposerSettings returns the proposer settings in use.
+func (v *validator) proposerSettings() *validatorserviceconfig.ProposerSettings {
+	v.proposerSettingsValueLock.RLock()
+	defer v.proposerSettingsValueLock.RUnlock()
+	return v.ProposerSettings
+}
+
+// pushProposerSettings prepares the beacon proposers of all validating keys, and submits builder registrations
+// of the keys in registrationKeys, or all keys if nil. The caller must hold the proposerSettingsLock.
+func (v *validator) pushProposerSettings(ctx context.Context, km keymanager.IKeymanager, registrationKeys map[[fieldparams.BLSPubkeyLength]byte]bool) error {
 	deadline := v.SlotDeadline(slots.RoundUpToNearestEpoch(slots.CurrentSlot(v.genesisTime)))
 	ctx, cancel := context.WithDeadline(ctx, deadline)
 	defer cancel()
@@ -985,10 +982,18 @@ func (v *validator) PushProposerSettings(ctx context.Context, km keymanager.IKey
 	}
 	log.Infoln("Prepared beacon proposer with fee recipient to validator index mapping")
 
-	if len(registerValidatorRequests) > 0 {
-		if len(registerValidatorRequests) != len(pubkeys) {
-			log.Warnf("%d public key(s) will not be included in validator registration until a validator index is assigned", len(pubkeys)-len(registerValidatorRequests))
+	if registrationKeys != nil {
+		changedRequests := make([]*ethpb.ValidatorRegistrationV1, 0, len(registrationKeys))
+		for _, req := range registerValidatorRequests {
+			if registrationKeys[bytesutil.ToBytes48(req.Pubkey)] {
+				changedRequests = append(changedRequests, req)
+			}
 		}
+		registerValidatorRequests = changedRequests
+	} else if len(registerValidatorRequests) > 0 && len(registerValidatorRequests) != len(pubkeys) {
+		log.Warnf("%d public key(s) will not be included in validator registration until a validator index is assigned", len(pubkeys)-len(registerValidatorRequests))
+	}
+	if len(registerValidatorRequests) > 0 {
 		if err := SubmitValidatorRegistration(ctx, v.validatorClient, km.Sign, registerValidatorRequests); err != nil {
 			return err
 		}
@@ -1002,10 +1007,7 @@ func (v *validator) buildProposerSettingsRequests(ctx context.Context, pubkeys [
 	var registerValidatorRequests []*ethpb.ValidatorRegistrationV1
 	// need to check for pubkey to validator index mappings
 	for i, key := range pubkeys {
-		var enableValidatorRegistration bool
 		skipAppendToFeeRecipientArray := false
-		feeRecipient := common.HexToAddress(params.BeaconConfig().EthBurnAddressHex)
-		gasLimit := params.BeaconConfig().DefaultBuilderGasLimit
 		validatorIndex, found := v.pubkeyToValidatorIndex[key]
 		// ignore updating fee recipient if validator index is not found
 		if !found {
@@ -1020,29 +1022,8 @@ func (v *validator) buildProposerSettingsRequests(ctx context.Context, pubkeys [
 				v.pubkeyToValidatorIndex[key] = validatorIndex
 			}
 		}
-		if v.ProposerSettings.DefaultConfig != nil {
-			feeRecipient = v.ProposerSettings.DefaultConfig.FeeRecipient
-			vr := v.ProposerSettings.DefaultConfig.ValidatorRegistration
-			if vr != nil && vr.Enable {
-				gasLimit = vr.GasLimit
-				enableValidatorRegistration = true
-			}
-
-		}
-		if v.ProposerSettings.ProposeConfig != nil {
-			option, ok := v.ProposerSettings.ProposeConfig[key]
-			if ok && option != nil {
-				// override the default if a proposeconfig is set
-				feeRecipient = option.FeeRecipient
-				vr := option.ValidatorRegistration
-				if vr != nil && vr.Enable {
-					gasLimit = vr.GasLimit
-					enableValidatorRegistration = true
-				} else {
-					enableValidatorRegistration = false
-				}
-			}
-		}
+		option := effectiveProposerOption(v.ProposerSettings, key)
+		feeRecipient := option.feeRecipient
 		if hexutil.Encode(feeRecipient.Bytes()) == params.BeaconConfig().EthBurnAddressHex {
 			log.Warnln("Fee recipient is set to the burn address. You will not be rewarded transaction fees on this setting. Please set a different fee recipient.")
 		}
@@ -1054,10 +1035,10 @@ func (v *validator) buildProposerSettingsRequests(ctx context.Context, pubkeys [
 				FeeRecipient:   feeRecipient[:],
 			})
 		}
-		if !skipAppendToFeeRecipientArray && enableValidatorRegistration {
+		if !skipAppendToFeeRecipientArray && option.enableValidatorRegistration {
 			registerValidatorRequests = append(registerValidatorRequests, &ethpb.ValidatorRegistrationV1{
 				FeeRecipient: feeRecipient[:],
-				GasLimit:     gasLimit,
+				GasLimit:     option.gasLimit,
 				Timestamp:    uint64(time.Now().UTC().Unix()),
 				Pubkey:       pubkeys[i][:],
 			})
@@ -1066,6 +1047,48 @@ func (v *validator) buildProposerSettingsRequests(ctx context.Context, pubkeys [
 	return validatorToFeeRecipients, registerValidatorRequests, nil
 }
 
+// proposerOption is the outcome of the proposer settings for a single validating public key.
+type proposerOption struct {
+	feeRecipient                common.Address
+	enableValidatorRegistration bool
+	gasLimit                    uint64
+}
+
+// effectiveProposerOption resolves the proposer settings of a public key, where its own
+// proposer config overrides the default config.
+func effectiveProposerOption(settings *validatorserviceconfig.ProposerSettings, key [fieldparams.BLSPubkeyLength]byte) proposerOption {
+	option := proposerOption{
+		feeRecipient: common.HexToAddress(params.BeaconConfig().EthBurnAddressHex),
+		gasLimit:     params.BeaconConfig().DefaultBuilderGasLimit,
+	}
+	if settings == nil {
+		return option
+	}
+	if settings.DefaultConfig != nil {
+		option.feeRecipient = settings.DefaultConfig.FeeRecipient
+		vr := settings.DefaultConfig.ValidatorRegistration
+		if vr != nil && vr.Enable {
+			option.gasLimit = vr.GasLimit
+			option.enableValidatorRegistration = true
+		}
+	}
+	if settings.ProposeConfig != nil {
+		keyOption, ok := settings.ProposeConfig[key]
+		if ok && keyOption != nil {
+			// override the default if a proposeconfig is set
+			option.feeRecipient = keyOption.FeeRecipient
+			vr := keyOption.ValidatorRegistration
+			if vr != nil && vr.Enable {
+				option.gasLimit = vr.GasLimit
+				option.enableValidatorRegistration = true
+			} else {
+				option.enableValidatorRegistration = false
+			}
+		}
+	}
+	return option
+}
+
 func (v *validator) cacheValidatorPubkeyHexToValidatorIndex(ctx context.Context, pubkey [fieldparams.BLSPubkeyLength]byte) (types.ValidatorIndex, bool, error) {
 	resp, err := v.validatorClient.ValidatorIndex(ctx, &ethpb.ValidatorIndexRequest{PublicKey: pubkey[:]})
 	if err != nil {
@@ -2,8 +2,8 @@ package client
 
 import (
 	"context"
+	"encoding/binary"
 	"errors"
-	"fmt"
 	"io"
 	"math"
 	"strings"
@@ -16,12 +16,13 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/prysmaticlabs/prysm/async/event"
-	"github.com/prysmaticlabs/prysm/config/features"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/altair"
 	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/config/params"
 	validatorserviceconfig "github.com/prysmaticlabs/prysm/config/validator/service"
 	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/crypto/bls"
+	"github.com/prysmaticlabs/prysm/crypto/hash"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	ethpbservice "github.com/prysmaticlabs/prysm/proto/eth/service"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
@@ -30,6 +31,7 @@ import (
 	mock2 "github.com/prysmaticlabs/prysm/testing/mock"
 	"github.com/prysmaticlabs/prysm/testing/require"
 	"github.com/prysmaticlabs/prysm/testing/util"
+	validatormock "github.com/prysmaticlabs/prysm/testing/validator-mock"
 	"github.com/prysmaticlabs/prysm/validator/accounts/wallet"
 	"github.com/prysmaticlabs/prysm/validator/client/iface"
 	dbTest "github.com/prysmaticlabs/prysm/validator/db/testing"
@@ -38,7 +40,6 @@ import (
 	remoteweb3signer "github.com/prysmaticlabs/prysm/validator/keymanager/remote-web3signer"
 	"github.com/sirupsen/logrus"
 	logTest "github.com/sirupsen/logrus/hooks/test"
-	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -139,7 +140,7 @@ func generateMockStatusResponse(pubkeys [][]byte) *ethpb.ValidatorActivationResp
 func TestWaitForChainStart_SetsGenesisInfo(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	client := mock2.NewMockBeaconNodeValidatorClient(ctrl)
+	client := validatormock.NewMockValidatorClient(ctrl)
 
 	db := dbTest.SetupDB(t, [][fieldparams.BLSPubkeyLength]byte{})
 	v := validator{
@@ -154,12 +155,10 @@ func TestWaitForChainStart_SetsGenesisInfo(t *testing.T) {
 
 	genesis := uint64(time.Unix(1, 0).Unix())
 	genesisValidatorsRoot := bytesutil.ToBytes32([]byte("validators"))
-	clientStream := mock2.NewMockBeaconNodeValidator_WaitForChainStartClient(ctrl)
 	client.EXPECT().WaitForChainStart(
 		gomock.Any(),
 		&emptypb.Empty{},
-	).Return(clientStream, nil)
-	clientStream.EXPECT().Recv().Return(
+	).Return(
 		&ethpb.ChainStartResponse{
 			Started:               true,
 			GenesisTime:           genesis,
@@ -179,8 +178,7 @@ func TestWaitForChainStart_SetsGenesisInfo(t *testing.T) {
 	client.EXPECT().WaitForChainStart(
 		gomock.Any(),
 		&emptypb.Empty{},
-	).Return(clientStream, nil)
-	clientStream.EXPECT().Recv().Return(
+	).Return(
 		&ethpb.ChainStartResponse{
 			Started:               true,
 			GenesisTime:           genesis,
@@ -194,7 +192,7 @@ func TestWaitForChainStart_SetsGenesisInfo(t *testing.T) {
 func TestWaitForChainStart_SetsGenesisInfo_IncorrectSecondTry(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	client := mock2.NewMockBeaconNodeValidatorClient(ctrl)
+	client := validatormock.NewMockValidatorClient(ctrl)
 
 	db := dbTest.SetupDB(t, [][fieldparams.BLSPubkeyLength]byte{})
 	v := validator{
@@ -203,12 +201,10 @@ func TestWaitForChainStart_SetsGenesisInfo_IncorrectSecondTry(t *testing.T) {
 	}
 	genesis := uint64(time.Unix(1, 0).Unix())
 	genesisValidatorsRoot := bytesutil.ToBytes32([]byte("validators"))
-	clientStream := mock2.NewMockBeaconNodeValidator_WaitForChainStartClient(ctrl)
 	client.EXPECT().WaitForChainStart(
 		gomock.Any(),
 		&emptypb.Empty{},
-	).Return(clientStream, nil)
-	clientStream.EXPECT().Recv().Return(
+	).Return(
 		&ethpb.ChainStartResponse{
 			Started:               true,
 			GenesisTime:           genesis,
@@ -230,8 +226,7 @@ func TestWaitForChainStart_SetsGenesisInfo_IncorrectSecondTry(t *testing.T) {
 	client.EXPECT().WaitForChainStart(
 		gomock.Any(),
 		&emptypb.Empty{},
-	).Return(clientStream, nil)
-	clientStream.EXPECT().Recv().Return(
+	).Return(
 		&ethpb.ChainStartResponse{
 			Started:               true,
 			GenesisTime:           genesis,
@@ -246,7 +241,7 @@ func TestWaitForChainStart_SetsGenesisInfo_IncorrectSecondTry(t *testing.T) {
 func TestWaitForChainStart_ContextCanceled(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	client := mock2.NewMockBeaconNodeValidatorClient(ctrl)
+	client := validatormock.NewMockValidatorClient(ctrl)
 
 	v := validator{
 		//keyManager:      testKeyManager,
@@ -254,12 +249,10 @@ func TestWaitForChainStart_ContextCanceled(t *testing.T) {
 	}
 	genesis := uint64(time.Unix(0, 0).Unix())
 	genesisValidatorsRoot := bytesutil.PadTo([]byte("validators"), 32)
-	clientStream := mock2.NewMockBeaconNodeValidator_WaitForChainStartClient(ctrl)
 	client.EXPECT().WaitForChainStart(
 		gomock.Any(),
 		&emptypb.Empty{},
-	).Return(clientStream, nil)
-	clientStream.EXPECT().Recv().Return(
+	).Return(
 		&ethpb.ChainStartResponse{
 			Started:               true,
 			GenesisTime:           genesis,
@@ -275,7 +268,7 @@ func TestWaitForChainStart_ContextCanceled(t *testing.T) {
 func TestWaitForChainStart_StreamSetupFails(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	client := mock2.NewMockBeaconNodeValidatorClient(ctrl)
+	client := validatormock.NewMockValidatorClient(ctrl)
 
 	privKey, err := bls.RandKey()
 	require.NoError(t, err)
@@ -288,30 +281,26 @@ func TestWaitForChainStart_StreamSetupFails(t *testing.T) {
 		validatorClient: client,
 		keyManager:      km,
 	}
-	clientStream := mock2.NewMockBeaconNodeValidator_WaitForChainStartClient(ctrl)
 	client.EXPECT().WaitForChainStart(
 		gomock.Any(),
 		&emptypb.Empty{},
-	).Return(clientStream, errors.New("failed stream"))
+	).Return(nil, errors.New("failed stream"))
 	err = v.WaitForChainStart(context.Background())
-	want := "could not setup beacon chain ChainStart streaming client"
-	assert.ErrorContains(t, want, err)
+	assert.ErrorContains(t, iface.ErrConnectionIssue.Error(), err)
 }
 
 func TestWaitForChainStart_ReceiveErrorFromStream(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	client := mock2.NewMockBeaconNodeValidatorClient(ctrl)
+	client := validatormock.NewMockValidatorClient(ctrl)
 
 	v := validator{
 		validatorClient: client,
 	}
-	clientStream := mock2.NewMockBeaconNodeValidator_WaitForChainStartClient(ctrl)
 	client.EXPECT().WaitForChainStart(
 		gomock.Any(),
 		&emptypb.Empty{},
-	).Return(clientStream, nil)
-	clientStream.EXPECT().Recv().Return(
+	).Return(
 		nil,
 		errors.New("fails"),
 	)
@@ -323,7 +312,7 @@ func TestWaitForChainStart_ReceiveErrorFromStream(t *testing.T) {
 func TestCanonicalHeadSlot_FailedRPC(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	client := mock2.NewMockBeaconChainClient(ctrl)
+	client := validatormock.NewMockBeaconChainClient(ctrl)
 	v := validator{
 		beaconClient: client,
 		genesisTime:  1,
@@ -339,7 +328,7 @@ func TestCanonicalHeadSlot_FailedRPC(t *testing.T) {
 func TestCanonicalHeadSlot_OK(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	client := mock2.NewMockBeaconChainClient(ctrl)
+	client := validatormock.NewMockBeaconChainClient(ctrl)
 	v := validator{
 		beaconClient: client,
 	}
@@ -357,7 +346,7 @@ func TestWaitMultipleActivation_LogsActivationEpochOK(t *testing.T) {
 	hook := logTest.NewGlobal()
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	client := mock2.NewMockBeaconNodeValidatorClient(ctrl)
+	client := validatormock.NewMockValidatorClient(ctrl)
 	privKey, err := bls.RandKey()
 	require.NoError(t, err)
 	pubKey := [fieldparams.BLSPubkeyLength]byte{}
@@ -392,7 +381,7 @@ func TestWaitMultipleActivation_LogsActivationEpochOK(t *testing.T) {
 func TestWaitActivation_NotAllValidatorsActivatedOK(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	client := mock2.NewMockBeaconNodeValidatorClient(ctrl)
+	client := validatormock.NewMockValidatorClient(ctrl)
 	privKey, err := bls.RandKey()
 	require.NoError(t, err)
 	pubKey := [fieldparams.BLSPubkeyLength]byte{}
@@ -427,7 +416,7 @@ func TestWaitActivation_NotAllValidatorsActivatedOK(t *testing.T) {
 func TestWaitSync_ContextCanceled(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	n := mock2.NewMockNodeClient(ctrl)
+	n := validatormock.NewMockNodeClient(ctrl)
 
 	v := validator{
 		node: n,
@@ -447,7 +436,7 @@ func TestWaitSync_ContextCanceled(t *testing.T) {
 func TestWaitSync_NotSyncing(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	n := mock2.NewMockNodeClient(ctrl)
+	n := validatormock.NewMockNodeClient(ctrl)
 
 	v := validator{
 		node: n,
@@ -464,7 +453,7 @@ func TestWaitSync_NotSyncing(t *testing.T) {
 func TestWaitSync_Syncing(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	n := mock2.NewMockNodeClient(ctrl)
+	n := validatormock.NewMockNodeClient(ctrl)
 
 	v := validator{
 		node: n,
@@ -486,7 +475,7 @@ func TestWaitSync_Syncing(t *testing.T) {
 func TestUpdateDuties_DoesNothingWhenNotEpochStart_AlreadyExistingAssignments(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	client := mock2.NewMockBeaconNodeValidatorClient(ctrl)
+	client := validatormock.NewMockValidatorClient(ctrl)
 
 	slot := types.Slot(1)
 	v := validator{
@@ -512,7 +501,7 @@ func TestUpdateDuties_DoesNothingWhenNotEpochStart_AlreadyExistingAssignments(t
 func TestUpdateDuties_ReturnsError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	client := mock2.NewMockBeaconNodeValidatorClient(ctrl)
+	client := validatormock.NewMockValidatorClient(ctrl)
 
 	privKey, err := bls.RandKey()
 	require.NoError(t, err)
@@ -549,7 +538,7 @@ func TestUpdateDuties_ReturnsError(t *testing.T) {
 func TestUpdateDuties_OK(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	client := mock2.NewMockBeaconNodeValidatorClient(ctrl)
+	client := validatormock.NewMockValidatorClient(ctrl)
 
 	slot := params.BeaconConfig().SlotsPerEpoch
 	privKey, err := bls.RandKey()
@@ -588,7 +577,8 @@ func TestUpdateDuties_OK(t *testing.T) {
 	client.EXPECT().SubscribeCommitteeSubnets(
 		gomock.Any(),
 		gomock.Any(),
-	).DoAndReturn(func(_ context.Context, _ *ethpb.CommitteeSubnetsSubscribeRequest, arg2 ...grpc.CallOption) (*emptypb.Empty, error) {
+		gomock.Any(),
+	).DoAndReturn(func(_ context.Context, _ *ethpb.CommitteeSubnetsSubscribeRequest, _ []types.ValidatorIndex) (*emptypb.Empty, error) {
 		wg.Done()
 		return nil, nil
 	})
@@ -607,7 +597,7 @@ func TestUpdateDuties_OK_FilterBlacklistedPublicKeys(t *testing.T) {
 	hook := logTest.NewGlobal()
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	client := mock2.NewMockBeaconNodeValidatorClient(ctrl)
+	client := validatormock.NewMockValidatorClient(ctrl)
 	slot := params.BeaconConfig().SlotsPerEpoch
 
 	numValidators := 10
@@ -643,7 +633,8 @@ func TestUpdateDuties_OK_FilterBlacklistedPublicKeys(t *testing.T) {
 	client.EXPECT().SubscribeCommitteeSubnets(
 		gomock.Any(),
 		gomock.Any(),
-	).DoAndReturn(func(_ context.Context, _ *ethpb.CommitteeSubnetsSubscribeRequest, arg2 ...grpc.CallOption) (*emptypb.Empty, error) {
+		gomock.Any(),
+	).DoAndReturn(func(_ context.Context, _ *ethpb.CommitteeSubnetsSubscribeRequest, _ []types.ValidatorIndex) (*emptypb.Empty, error) {
 		wg.Done()
 		return nil, nil
 	})
@@ -861,7 +852,7 @@ func TestCheckAndLogValidatorStatus_OK(t *testing.T) {
 			hook := logTest.NewGlobal()
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
-			client := mock2.NewMockBeaconNodeValidatorClient(ctrl)
+			client := validatormock.NewMockValidatorClient(ctrl)
 			v := validator{
 				validatorClient: client,
 				duties: &ethpb.DutiesResponse{
@@ -885,7 +876,7 @@ func TestCheckAndLogValidatorStatus_OK(t *testing.T) {
 func TestAllValidatorsAreExited_AllExited(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	client := mock2.NewMockBeaconNodeValidatorClient(ctrl)
+	client := validatormock.NewMockValidatorClient(ctrl)
 
 	statuses := []*ethpb.ValidatorStatusResponse{
 		{Status: ethpb.ValidatorStatus_EXITED},
@@ -906,7 +897,7 @@ func TestAllValidatorsAreExited_AllExited(t *testing.T) {
 func TestAllValidatorsAreExited_NotAllExited(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	client := mock2.NewMockBeaconNodeValidatorClient(ctrl)
+	client := validatormock.NewMockValidatorClient(ctrl)
 
 	statuses := []*ethpb.ValidatorStatusResponse{
 		{Status: ethpb.ValidatorStatus_ACTIVE},
@@ -927,7 +918,7 @@ func TestAllValidatorsAreExited_NotAllExited(t *testing.T) {
 func TestAllValidatorsAreExited_PartialResult(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	client := mock2.NewMockBeaconNodeValidatorClient(ctrl)
+	client := validatormock.NewMockValidatorClient(ctrl)
 
 	statuses := []*ethpb.ValidatorStatusResponse{
 		{Status: ethpb.ValidatorStatus_EXITED},
@@ -947,7 +938,7 @@ func TestAllValidatorsAreExited_PartialResult(t *testing.T) {
 func TestAllValidatorsAreExited_NoKeys(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	client := mock2.NewMockBeaconNodeValidatorClient(ctrl)
+	client := validatormock.NewMockValidatorClient(ctrl)
 	v := validator{keyManager: genMockKeymanager(0), validatorClient: client}
 	exited, err := v.AllValidatorsAreExited(context.Background())
 	require.NoError(t, err)
@@ -958,7 +949,7 @@ func TestAllValidatorsAreExited_NoKeys(t *testing.T) {
 func TestAllValidatorsAreExited_CorrectRequest(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	client := mock2.NewMockBeaconNodeValidatorClient(ctrl)
+	client := validatormock.NewMockValidatorClient(ctrl)
 
 	// Create two different public keys
 	pubKey0 := [fieldparams.BLSPubkeyLength]byte{1, 2, 3, 4}
@@ -1001,7 +992,7 @@ func TestAllValidatorsAreExited_CorrectRequest(t *testing.T) {
 func TestService_ReceiveBlocks_NilBlock(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	valClient := mock2.NewMockBeaconNodeValidatorClient(ctrl)
+	valClient := validatormock.NewMockValidatorClient(ctrl)
 	v := validator{
 		blockFeed:       new(event.Feed),
 		validatorClient: valClient,
@@ -1028,7 +1019,7 @@ func TestService_ReceiveBlocks_NilBlock(t *testing.T) {
 func TestService_ReceiveBlocks_SetHighest(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	client := mock2.NewMockBeaconNodeValidatorClient(ctrl)
+	client := validatormock.NewMockValidatorClient(ctrl)
 
 	v := validator{
 		validatorClient: client,
@@ -1056,214 +1047,6 @@ func TestService_ReceiveBlocks_SetHighest(t *testing.T) {
 	require.Equal(t, slot, v.highestValidSlot)
 }
 
-type doppelGangerRequestMatcher struct {
-	req *ethpb.DoppelGangerRequest
-}
-
-var _ gomock.Matcher = (*doppelGangerRequestMatcher)(nil)
-
-func (m *doppelGangerRequestMatcher) Matches(x interface{}) bool {
-	r, ok := x.(*ethpb.DoppelGangerRequest)
-	if !ok {
-		panic("Invalid match type")
-	}
-	return gomock.InAnyOrder(m.req.ValidatorRequests).Matches(r.ValidatorRequests)
-}
-
-func (m *doppelGangerRequestMatcher) String() string {
-	return fmt.Sprintf("%#v", m.req.ValidatorRequests)
-}
-
-func TestValidator_CheckDoppelGanger(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-	flgs := features.Get()
-	flgs.EnableDoppelGanger = true
-	reset := features.InitWithReset(flgs)
-	defer reset()
-	tests := []struct {
-		name            string
-		validatorSetter func(t *testing.T) *validator
-		err             string
-	}{
-		{
-			name: "no doppelganger",
-			validatorSetter: func(t *testing.T) *validator {
-				client := mock2.NewMockBeaconNodeValidatorClient(ctrl)
-				km := genMockKeymanager(10)
-				keys, err := km.FetchValidatingPublicKeys(context.Background())
-				assert.NoError(t, err)
-				db := dbTest.SetupDB(t, keys)
-				req := &ethpb.DoppelGangerRequest{ValidatorRequests: []*ethpb.DoppelGangerRequest_ValidatorRequest{}}
-				resp := &ethpb.DoppelGangerRequest{ValidatorRequests: []*ethpb.DoppelGangerRequest_ValidatorRequest{}}
-				for _, k := range keys {
-					pkey := k
-					att := createAttestation(10, 12)
-					rt, err := att.Data.HashTreeRoot()
-					assert.NoError(t, err)
-					assert.NoError(t, db.SaveAttestationForPubKey(context.Background(), pkey, rt, att))
-					resp.ValidatorRequests = append(resp.ValidatorRequests, &ethpb.DoppelGangerRequest_ValidatorRequest{PublicKey: pkey[:], Epoch: att.Data.Target.Epoch, SignedRoot: rt[:]})
-					req.ValidatorRequests = append(req.ValidatorRequests, &ethpb.DoppelGangerRequest_ValidatorRequest{PublicKey: pkey[:], Epoch: att.Data.Target.Epoch, SignedRoot: rt[:]})
-				}
-				v := &validator{
-					validatorClient: client,
-					keyManager:      km,
-					db:              db,
-				}
-				client.EXPECT().CheckDoppelGanger(
-					gomock.Any(),                     // ctx
-					&doppelGangerRequestMatcher{req}, // request
-				).Return(nil, nil /*err*/)
-
-				return v
-			},
-		},
-		{
-			name: "multiple doppelganger exists",
-			validatorSetter: func(t *testing.T) *validator {
-				client := mock2.NewMockBeaconNodeValidatorClient(ctrl)
-				km := genMockKeymanager(10)
-				keys, err := km.FetchValidatingPublicKeys(context.Background())
-				assert.NoError(t, err)
-				db := dbTest.SetupDB(t, keys)
-				req := &ethpb.DoppelGangerRequest{ValidatorRequests: []*ethpb.DoppelGangerRequest_ValidatorRequest{}}
-				resp := &ethpb.DoppelGangerResponse{Responses: []*ethpb.DoppelGangerResponse_ValidatorResponse{}}
-				for i, k := range keys {
-					pkey := k
-					att := createAttestation(10, 12)
-					rt, err := att.Data.HashTreeRoot()
-					assert.NoError(t, err)
-					assert.NoError(t, db.SaveAttestationForPubKey(context.Background(), pkey, rt, att))
-					if i%3 == 0 {
-						resp.Responses = append(resp.Responses, &ethpb.DoppelGangerResponse_ValidatorResponse{PublicKey: pkey[:], DuplicateExists: true})
-					}
-					req.ValidatorRequests = append(req.ValidatorRequests, &ethpb.DoppelGangerRequest_ValidatorRequest{PublicKey: pkey[:], Epoch: att.Data.Target.Epoch, SignedRoot: rt[:]})
-				}
-				v := &validator{
-					validatorClient: client,
-					keyManager:      km,
-					db:              db,
-				}
-				client.EXPECT().CheckDoppelGanger(
-					gomock.Any(),                     // ctx
-					&doppelGangerRequestMatcher{req}, // request
-				).Return(resp, nil /*err*/)
-				return v
-			},
-			err: "Duplicate instances exists in the network for validator keys",
-		},
-		{
-			name: "single doppelganger exists",
-			validatorSetter: func(t *testing.T) *validator {
-				client := mock2.NewMockBeaconNodeValidatorClient(ctrl)
-				km := genMockKeymanager(10)
-				keys, err := km.FetchValidatingPublicKeys(context.Background())
-				assert.NoError(t, err)
-				db := dbTest.SetupDB(t, keys)
-				req := &ethpb.DoppelGangerRequest{ValidatorRequests: []*ethpb.DoppelGangerRequest_ValidatorRequest{}}
-				resp := &ethpb.DoppelGangerResponse{Responses: []*ethpb.DoppelGangerResponse_ValidatorResponse{}}
-				for i, k := range keys {
-					pkey := k
-					att := createAttestation(10, 12)
-					rt, err := att.Data.HashTreeRoot()
-					assert.NoError(t, err)
-					assert.NoError(t, db.SaveAttestationForPubKey(context.Background(), pkey, rt, att))
-					if i%9 == 0 {
-						resp.Responses = append(resp.Responses, &ethpb.DoppelGangerResponse_ValidatorResponse{PublicKey: pkey[:], DuplicateExists: true})
-					}
-					req.ValidatorRequests = append(req.ValidatorRequests, &ethpb.DoppelGangerRequest_ValidatorRequest{PublicKey: pkey[:], Epoch: att.Data.Target.Epoch, SignedRoot: rt[:]})
-				}
-				v := &validator{
-					validatorClient: client,
-					keyManager:      km,
-					db:              db,
-				}
-				client.EXPECT().CheckDoppelGanger(
-					gomock.Any(),                     // ctx
-					&doppelGangerRequestMatcher{req}, // request
-				).Return(resp, nil /*err*/)
-				return v
-			},
-			err: "Duplicate instances exists in the network for validator keys",
-		},
-		{
-			name: "multiple attestations saved",
-			validatorSetter: func(t *testing.T) *validator {
-				client := mock2.NewMockBeaconNodeValidatorClient(ctrl)
-				km := genMockKeymanager(10)
-				keys, err := km.FetchValidatingPublicKeys(context.Background())
-				assert.NoError(t, err)
-				db := dbTest.SetupDB(t, keys)
-				req := &ethpb.DoppelGangerRequest{ValidatorRequests: []*ethpb.DoppelGangerRequest_ValidatorRequest{}}
-				resp := &ethpb.DoppelGangerResponse{Responses: []*ethpb.DoppelGangerResponse_ValidatorResponse{}}
-				attLimit := 5
-				for i, k := range keys {
-					pkey := k
-					for j := 0; j < attLimit; j++ {
-						att := createAttestation(10+types.Epoch(j), 12+types.Epoch(j))
-						rt, err := att.Data.HashTreeRoot()
-						assert.NoError(t, err)
-						assert.NoError(t, db.SaveAttestationForPubKey(context.Background(), pkey, rt, att))
-						if j == attLimit-1 {
-							req.ValidatorRequests = append(req.ValidatorRequests, &ethpb.DoppelGangerRequest_ValidatorRequest{PublicKey: pkey[:], Epoch: att.Data.Target.Epoch, SignedRoot: rt[:]})
-						}
-					}
-					if i%3 == 0 {
-						resp.Responses = append(resp.Responses, &ethpb.DoppelGangerResponse_ValidatorResponse{PublicKey: pkey[:], DuplicateExists: true})
-					}
-				}
-				v := &validator{
-					validatorClient: client,
-					keyManager:      km,
-					db:              db,
-				}
-				client.EXPECT().CheckDoppelGanger(
-					gomock.Any(),                     // ctx
-					&doppelGangerRequestMatcher{req}, // request
-				).Return(resp, nil /*err*/)
-				return v
-			},
-			err: "Duplicate instances exists in the network for validator keys",
-		},
-		{
-			name: "no history exists",
-			validatorSetter: func(t *testing.T) *validator {
-				client := mock2.NewMockBeaconNodeValidatorClient(ctrl)
-				// Use only 1 key for deterministic order.
-				km := genMockKeymanager(1)
-				keys, err := km.FetchValidatingPublicKeys(context.Background())
-				assert.NoError(t, err)
-				db := dbTest.SetupDB(t, keys)
-				resp := &ethpb.DoppelGangerResponse{Responses: []*ethpb.DoppelGangerResponse_ValidatorResponse{}}
-				req := &ethpb.DoppelGangerRequest{ValidatorRequests: []*ethpb.DoppelGangerRequest_ValidatorRequest{}}
-				for _, k := range keys {
-					resp.Responses = append(resp.Responses, &ethpb.DoppelGangerResponse_ValidatorResponse{PublicKey: k[:], DuplicateExists: false})
-					req.ValidatorRequests = append(req.ValidatorRequests, &ethpb.DoppelGangerRequest_ValidatorRequest{PublicKey: k[:], SignedRoot: make([]byte, 32), Epoch: 0})
-				}
-				v := &validator{
-					validatorClient: client,
-					keyManager:      km,
-					db:              db,
-				}
-				client.EXPECT().CheckDoppelGanger(
-					gomock.Any(), // ctx
-					req,          // request
-				).Return(resp, nil /*err*/)
-				return v
-			},
-			err: "",
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			v := tt.validatorSetter(t)
-			if err := v.CheckDoppelGanger(context.Background()); tt.err != "" {
-				assert.ErrorContains(t, tt.err, err)
-			}
-		})
-	}
-}
-
 func TestValidatorAttestationsAreOrdered(t *testing.T) {
 	km := genMockKeymanager(10)
 	keys, err := km.FetchValidatingPublicKeys(context.Background())
@@ -1330,7 +1113,7 @@ func TestIsSyncCommitteeAggregator_OK(t *testing.T) {
 		},
 	).Return(&ethpb.SyncSubcommitteeIndexResponse{}, nil /*err*/)
 
-	aggregator, err := v.isSyncCommitteeAggregator(context.Background(), slot, bytesutil.ToBytes48(pubKey))
+	aggregator, err := v.isSyncCommitteeAggregator(context.Background(), slot, bytesutil.ToBytes48(pubKey), 0)
 	require.NoError(t, err)
 	require.Equal(t, false, aggregator)
 
@@ -1351,11 +1134,123 @@ func TestIsSyncCommitteeAggregator_OK(t *testing.T) {
 		},
 	).Return(&ethpb.SyncSubcommitteeIndexResponse{Indices: []types.CommitteeIndex{0}}, nil /*err*/)
 
-	aggregator, err = v.isSyncCommitteeAggregator(context.Background(), slot, bytesutil.ToBytes48(pubKey))
+	aggregator, err = v.isSyncCommitteeAggregator(context.Background(), slot, bytesutil.ToBytes48(pubKey), 0)
 	require.NoError(t, err)
 	require.Equal(t, true, aggregator)
 }
 
+func TestIsSyncCommitteeAggregator_Distributed(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	v, m, validatorKey, finish := setup(t)
+	defer finish()
+	v.distributed = true
+
+	slot := types.Slot(1)
+	pubKey := validatorKey.PublicKey().Marshal()
+	m.validatorClient.EXPECT().DomainData(
+		gomock.Any(), // ctx
+		gomock.Any(), // epoch
+	).Return(&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}, nil /*err*/)
+	m.validatorClient.EXPECT().GetSyncSubcommitteeIndex(
+		gomock.Any(), // ctx
+		&ethpb.SyncSubcommitteeIndexRequest{
+			PublicKey: pubKey,
+			Slot:      1,
+		},
+	).Return(&ethpb.SyncSubcommitteeIndexResponse{Indices: []types.CommitteeIndex{0}}, nil /*err*/)
+
+	aggregatedProof := bytesutil.PadTo([]byte{0x01}, 96)
+	m.validatorClient.EXPECT().GetAggregatedSyncSelections(
+		gomock.Any(), // ctx
+		gomock.Any(), // selections
+	).DoAndReturn(func(_ context.Context, selections []iface.SyncCommitteeSelection) ([]iface.SyncCommitteeSelection, error) {
+		require.Equal(t, 1, len(selections))
+		assert.Equal(t, slot, selections[0].Slot)
+		assert.Equal(t, uint64(0), selections[0].SubcommitteeIndex)
+		assert.Equal(t, types.ValidatorIndex(123), selections[0].ValidatorIndex)
+		return []iface.SyncCommitteeSelection{{
+			SelectionProof:    aggregatedProof,
+			Slot:              slot,
+			SubcommitteeIndex: 0,
+			ValidatorIndex:    123,
+		}}, nil
+	})
+
+	aggregator, err := v.isSyncCommitteeAggregator(context.Background(), slot, bytesutil.ToBytes48(pubKey), 123)
+	require.NoError(t, err)
+	expected, err := altair.IsSyncCommitteeAggregator(aggregatedProof)
+	require.NoError(t, err)
+	assert.Equal(t, expected, aggregator, "Expected the aggregated selection proof to decide")
+
+	// Submitting the contribution reuses the proofs of the slot, without asking the cluster again.
+	proofs, err := v.selectionProofs(context.Background(), slot, bytesutil.ToBytes48(pubKey), &ethpb.SyncSubcommitteeIndexResponse{Indices: []types.CommitteeIndex{0}}, 123)
+	require.NoError(t, err)
+	assert.DeepEqual(t, [][]byte{aggregatedProof}, proofs)
+}
+
+func TestIsAggregator_DistributedUsesAggregatedSelection(t *testing.T) {
+	committee := make([]types.ValidatorIndex, 16*params.BeaconConfig().TargetAggregatorsPerCommittee)
+	for i := byte(0); i < 8; i++ {
+		proof := bytesutil.PadTo([]byte{i}, 96)
+		v := &validator{
+			distributed: true,
+			attSelections: map[attSelectionKey][]byte{
+				{slot: 5, index: 7}: proof,
+			},
+		}
+		aggregator, err := v.isAggregator(context.Background(), committee, 5, [fieldparams.BLSPubkeyLength]byte{}, 7)
+		require.NoError(t, err)
+		h := hash.Hash(proof)
+		assert.Equal(t, binary.LittleEndian.Uint64(h[:8])%16 == 0, aggregator)
+	}
+}
+
+func TestSubscribeToSubnets_DistributedFetchesSelectionsAtOnce(t *testing.T) {
+	v, m, validatorKey, finish := setup(t)
+	defer finish()
+	v.distributed = true
+	v.attSelections = map[attSelectionKey][]byte{
+		{slot: 1, index: 9}: make([]byte, 96), // Of a past epoch, to be pruned.
+	}
+	pubKey := validatorKey.PublicKey().Marshal()
+	epochStart := params.BeaconConfig().SlotsPerEpoch
+	res := &ethpb.DutiesResponse{
+		CurrentEpochDuties: []*ethpb.DutiesResponse_Duty{
+			{AttesterSlot: epochStart + 1, ValidatorIndex: 1, PublicKey: pubKey, Status: ethpb.ValidatorStatus_ACTIVE},
+		},
+		NextEpochDuties: []*ethpb.DutiesResponse_Duty{
+			{AttesterSlot: 2*epochStart + 2, ValidatorIndex: 1, PublicKey: pubKey, Status: ethpb.ValidatorStatus_ACTIVE},
+		},
+	}
+
+	m.validatorClient.EXPECT().DomainData(
+		gomock.Any(), // ctx
+		gomock.Any(), // epoch
+	).Return(&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}, nil /*err*/).Times(2)
+	m.validatorClient.EXPECT().GetAggregatedSelections(
+		gomock.Any(), // ctx
+		gomock.Any(), // selections
+	).DoAndReturn(func(_ context.Context, selections []iface.BeaconCommitteeSelection) ([]iface.BeaconCommitteeSelection, error) {
+		require.Equal(t, 2, len(selections))
+		aggregated := make([]iface.BeaconCommitteeSelection, len(selections))
+		for i, s := range selections {
+			aggregated[i] = iface.BeaconCommitteeSelection{SelectionProof: []byte{byte(i)}, Slot: s.Slot, ValidatorIndex: s.ValidatorIndex}
+		}
+		return aggregated, nil
+	})
+	m.validatorClient.EXPECT().SubscribeCommitteeSubnets(
+		gomock.Any(), // ctx
+		gomock.Any(), // request
+		[]types.ValidatorIndex{1, 1},
+	).Return(&emptypb.Empty{}, nil)
+
+	require.NoError(t, v.subscribeToSubnets(context.Background(), res))
+	assert.DeepEqual(t, map[attSelectionKey][]byte{
+		{slot: epochStart + 1, index: 1}:   {0},
+		{slot: 2*epochStart + 2, index: 1}: {1},
+	}, v.attSelections)
+}
+
 func TestValidator_WaitForKeymanagerInitialization_web3Signer(t *testing.T) {
 	ctx := context.Background()
 	db := dbTest.SetupDB(t, [][fieldparams.BLSPubkeyLength]byte{})
@@ -1441,8 +1336,8 @@ func TestValidator_PushProposerSettings(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	ctx := context.Background()
 	db := dbTest.SetupDB(t, [][fieldparams.BLSPubkeyLength]byte{})
-	client := mock2.NewMockBeaconNodeValidatorClient(ctrl)
-	nodeClient := mock2.NewMockNodeClient(ctrl)
+	client := validatormock.NewMockValidatorClient(ctrl)
+	nodeClient := validatormock.NewMockNodeClient(ctrl)
 	defaultFeeHex := "0x046Fb65722E7b2455043BFEBf6177F1D2e9738D9"
 	byteValueAddress, err := hexutil.Decode("0x046Fb65722E7b2455043BFEBf6177F1D2e9738D9")
 	require.NoError(t, err)
@@ -2025,3 +1920,66 @@ func TestValidator_PushProposerSettings(t *testing.T) {
 		})
 	}
 }
+
+func TestValidator_SetProposerSettings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	db := dbTest.SetupDB(t, [][fieldparams.BLSPubkeyLength]byte{})
+	client := validatormock.NewMockValidatorClient(ctrl)
+	defaultFeeHex := "0x046Fb65722E7b2455043BFEBf6177F1D2e9738D9"
+	changedFeeHex := "0x055Fb65722E7b2455043BFEBf6177F1D2e9738D9"
+
+	v := validator{
+		validatorClient:        client,
+		db:                     db,
+		pubkeyToValidatorIndex: make(map[[fieldparams.BLSPubkeyLength]byte]types.ValidatorIndex),
+		interopKeysConfig: &local.InteropKeymanagerConfig{
+			NumValidatorKeys: 2,
+			Offset:           1,
+		},
+	}
+	require.NoError(t, v.WaitForKeymanagerInitialization(ctx))
+	km, err := v.Keymanager()
+	require.NoError(t, err)
+	keys, err := km.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	v.pubkeyToValidatorIndex[keys[0]] = 1
+	v.pubkeyToValidatorIndex[keys[1]] = 2
+	defaultConfig := &validatorserviceconfig.ProposerOption{
+		FeeRecipient: common.HexToAddress(defaultFeeHex),
+		ValidatorRegistration: &validatorserviceconfig.ValidatorRegistration{
+			Enable:   true,
+			GasLimit: uint64(30000000),
+		},
+	}
+	v.ProposerSettings = &validatorserviceconfig.ProposerSettings{DefaultConfig: defaultConfig}
+
+	// Unchanged settings are not pushed again.
+	require.NoError(t, v.SetProposerSettings(ctx, &validatorserviceconfig.ProposerSettings{DefaultConfig: defaultConfig}))
+
+	client.EXPECT().PrepareBeaconProposer(gomock.Any(), &ethpb.PrepareBeaconProposerRequest{
+		Recipients: []*ethpb.PrepareBeaconProposerRequest_FeeRecipientContainer{
+			{FeeRecipient: common.HexToAddress(changedFeeHex).Bytes(), ValidatorIndex: 1},
+			{FeeRecipient: common.HexToAddress(defaultFeeHex).Bytes(), ValidatorIndex: 2},
+		},
+	}).Return(nil, nil)
+	client.EXPECT().SubmitValidatorRegistration(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, in *ethpb.SignedValidatorRegistrationsV1) (*empty.Empty, error) {
+			// Only the key whose fee recipient changed is registered again.
+			require.Equal(t, 1, len(in.Messages))
+			assert.DeepEqual(t, keys[0][:], in.Messages[0].Message.Pubkey)
+			assert.DeepEqual(t, common.HexToAddress(changedFeeHex).Bytes(), in.Messages[0].Message.FeeRecipient)
+			return &empty.Empty{}, nil
+		})
+	changed := &validatorserviceconfig.ProposerSettings{
+		ProposeConfig: map[[fieldparams.BLSPubkeyLength]byte]*validatorserviceconfig.ProposerOption{
+			keys[0]: {
+				FeeRecipient:          common.HexToAddress(changedFeeHex),
+				ValidatorRegistration: defaultConfig.ValidatorRegistration,
+			},
+		},
+		DefaultConfig: defaultConfig,
+	}
+	require.NoError(t, v.SetProposerSettings(ctx, changed))
+	assert.Equal(t, changed, v.ProposerSettings)
+}
@@ -62,4 +62,12 @@ type ValidatorDB interface {
 	// Graffiti ordered index related methods
 	SaveGraffitiOrderedIndex(ctx context.Context, index uint64) error
 	GraffitiOrderedIndex(ctx context.Context, fileHash [32]byte) (uint64, error)
+
+	// Performance ledger related methods.
+	SavePerformanceRecords(ctx context.Context, records map[[fieldparams.BLSPubkeyLength]byte]*kv.PerformanceRecord) error
+	PerformanceRecordsForPubKey(
+		ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, startEpoch, endEpoch types.Epoch,
+	) ([]*kv.PerformanceRecord, error)
+	PerformancePublicKeys(ctx context.Context) ([][fieldparams.BLSPubkeyLength]byte, error)
+	PrunePerformanceRecords(ctx context.Context, beforeEpoch types.Epoch) error
 }
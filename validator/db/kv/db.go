@@ -151,6 +151,7 @@ func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, er
 			pubKeysBucket,
 			migrationsBucket,
 			graffitiBucket,
+			performanceBucket,
 		)
 	}); err != nil {
 		return nil, err
@@ -0,0 +1,125 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// PerformanceRecord is the outcome of the duties of a validator public key during an epoch.
+type PerformanceRecord struct {
+	Epoch                 types.Epoch  `json:"epoch"`
+	AttestationIncluded   bool         `json:"attestation_included"`
+	InclusionDistance     types.Slot   `json:"inclusion_distance"`
+	CorrectlyVotedSource  bool         `json:"correctly_voted_source"`
+	CorrectlyVotedTarget  bool         `json:"correctly_voted_target"`
+	CorrectlyVotedHead    bool         `json:"correctly_voted_head"`
+	ProposedSlots         []types.Slot `json:"proposed_slots"`
+	FailedProposalSlots   []types.Slot `json:"failed_proposal_slots"`
+	SyncMessagesSubmitted uint64       `json:"sync_messages_submitted"`
+	SyncMessagesFailed    uint64       `json:"sync_messages_failed"`
+	BalanceBefore         uint64       `json:"balance_before"`
+	BalanceAfter          uint64       `json:"balance_after"`
+	EstimatedReward       int64        `json:"estimated_reward"`
+}
+
+// SavePerformanceRecords saves the performance records of an epoch by validator public key.
+func (s *Store) SavePerformanceRecords(
+	ctx context.Context, records map[[fieldparams.BLSPubkeyLength]byte]*PerformanceRecord,
+) error {
+	_, span := trace.StartSpan(ctx, "Validator.SavePerformanceRecords")
+	defer span.End()
+	return s.update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(performanceBucket)
+		for pubKey, record := range records {
+			pkBucket, err := bucket.CreateBucketIfNotExists(pubKey[:])
+			if err != nil {
+				return errors.Wrapf(err, "could not create performance bucket for public key %#x", pubKey)
+			}
+			enc, err := json.Marshal(record)
+			if err != nil {
+				return errors.Wrap(err, "could not encode performance record")
+			}
+			if err := pkBucket.Put(bytesutil.EpochToBytesBigEndian(record.Epoch), enc); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// PerformanceRecordsForPubKey returns the performance records of a validator public key
+// from a start epoch to an end epoch, both included, ordered by epoch.
+func (s *Store) PerformanceRecordsForPubKey(
+	ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, startEpoch, endEpoch types.Epoch,
+) ([]*PerformanceRecord, error) {
+	_, span := trace.StartSpan(ctx, "Validator.PerformanceRecordsForPubKey")
+	defer span.End()
+	records := make([]*PerformanceRecord, 0)
+	err := s.view(func(tx *bolt.Tx) error {
+		pkBucket := tx.Bucket(performanceBucket).Bucket(pubKey[:])
+		if pkBucket == nil {
+			return nil
+		}
+		c := pkBucket.Cursor()
+		endKey := bytesutil.EpochToBytesBigEndian(endEpoch)
+		for k, v := c.Seek(bytesutil.EpochToBytesBigEndian(startEpoch)); k != nil && bytes.Compare(k, endKey) <= 0; k, v = c.Next() {
+			record := &PerformanceRecord{}
+			if err := json.Unmarshal(v, record); err != nil {
+				return errors.Wrap(err, "could not decode performance record")
+			}
+			records = append(records, record)
+		}
+		return nil
+	})
+	return records, err
+}
+
+// PerformancePublicKeys retrieves all public keys with performance records.
+func (s *Store) PerformancePublicKeys(ctx context.Context) ([][fieldparams.BLSPubkeyLength]byte, error) {
+	_, span := trace.StartSpan(ctx, "Validator.PerformancePublicKeys")
+	defer span.End()
+	pubKeys := make([][fieldparams.BLSPubkeyLength]byte, 0)
+	err := s.view(func(tx *bolt.Tx) error {
+		return tx.Bucket(performanceBucket).ForEach(func(key []byte, _ []byte) error {
+			pubKeys = append(pubKeys, bytesutil.ToBytes48(key))
+			return nil
+		})
+	})
+	return pubKeys, err
+}
+
+// PrunePerformanceRecords deletes the performance records of all public keys for epochs
+// lower than the given one.
+func (s *Store) PrunePerformanceRecords(ctx context.Context, beforeEpoch types.Epoch) error {
+	_, span := trace.StartSpan(ctx, "Validator.PrunePerformanceRecords")
+	defer span.End()
+	beforeKey := bytesutil.EpochToBytesBigEndian(beforeEpoch)
+	return s.update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(performanceBucket)
+		return bucket.ForEach(func(pubKey []byte, _ []byte) error {
+			pkBucket := bucket.Bucket(pubKey)
+			if pkBucket == nil {
+				return nil
+			}
+			var epochKeys [][]byte
+			c := pkBucket.Cursor()
+			for k, _ := c.First(); k != nil && bytes.Compare(k, beforeKey) < 0; k, _ = c.Next() {
+				epochKeys = append(epochKeys, k)
+			}
+			for _, k := range epochKeys {
+				if err := pkBucket.Delete(k); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+}
@@ -0,0 +1,53 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestStore_PerformanceRecords(t *testing.T) {
+	ctx := context.Background()
+	pubKeys := [][fieldparams.BLSPubkeyLength]byte{{1}, {2}}
+	db := setupDB(t, pubKeys)
+
+	for epoch := types.Epoch(1); epoch <= 5; epoch++ {
+		require.NoError(t, db.SavePerformanceRecords(ctx, map[[fieldparams.BLSPubkeyLength]byte]*PerformanceRecord{
+			pubKeys[0]: {
+				Epoch:               epoch,
+				AttestationIncluded: true,
+				InclusionDistance:   1,
+				ProposedSlots:       []types.Slot{types.Slot(epoch) * 32},
+				EstimatedReward:     -10,
+			},
+			pubKeys[1]: {Epoch: epoch, SyncMessagesSubmitted: 32},
+		}))
+	}
+
+	records, err := db.PerformanceRecordsForPubKey(ctx, pubKeys[0], 2, 3)
+	require.NoError(t, err)
+	require.DeepEqual(t, []*PerformanceRecord{
+		{Epoch: 2, AttestationIncluded: true, InclusionDistance: 1, ProposedSlots: []types.Slot{64}, EstimatedReward: -10},
+		{Epoch: 3, AttestationIncluded: true, InclusionDistance: 1, ProposedSlots: []types.Slot{96}, EstimatedReward: -10},
+	}, records)
+
+	records, err = db.PerformanceRecordsForPubKey(ctx, [fieldparams.BLSPubkeyLength]byte{3}, 0, 5)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(records))
+
+	got, err := db.PerformancePublicKeys(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, pubKeys, got)
+
+	require.NoError(t, db.PrunePerformanceRecords(ctx, 4))
+	for _, pubKey := range pubKeys {
+		records, err = db.PerformanceRecordsForPubKey(ctx, pubKey, 0, 10)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(records))
+		require.Equal(t, types.Epoch(4), records[0].Epoch)
+		require.Equal(t, types.Epoch(5), records[1].Epoch)
+	}
+}
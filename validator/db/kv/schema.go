@@ -37,4 +37,7 @@ var (
 	// Graffiti ordered index and hash keys
 	graffitiOrderedIndexKey = []byte("graffiti-ordered-index")
 	graffitiFileHashKey     = []byte("graffiti-file-hash")
+
+	// Performance records of validator duties by public key and epoch.
+	performanceBucket = []byte("performance-bucket")
 )
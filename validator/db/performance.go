@@ -0,0 +1,125 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/cmd"
+	"github.com/prysmaticlabs/prysm/cmd/validator/flags"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/io/file"
+	"github.com/prysmaticlabs/prysm/validator/db/iface"
+	"github.com/prysmaticlabs/prysm/validator/db/kv"
+	"github.com/urfave/cli/v2"
+)
+
+var performanceCSVHeader = []string{
+	"public_key",
+	"epoch",
+	"attestation_included",
+	"inclusion_distance",
+	"correctly_voted_source",
+	"correctly_voted_target",
+	"correctly_voted_head",
+	"proposed_slots",
+	"failed_proposal_slots",
+	"sync_messages_submitted",
+	"sync_messages_failed",
+	"balance_before",
+	"balance_after",
+	"estimated_reward",
+}
+
+// ExportPerformanceCSV exports the performance records of a validator database as a CSV file.
+func ExportPerformanceCSV(cliCtx *cli.Context) error {
+	dataDir := cliCtx.String(cmd.DataDirFlag.Name)
+
+	if !file.FileExists(path.Join(dataDir, kv.ProtectionDbFileName)) {
+		return errors.New("No validator db found at path, nothing to export")
+	}
+	startEpoch := types.Epoch(cliCtx.Uint64(flags.PerformanceStartEpochFlag.Name))
+	endEpoch := types.Epoch(cliCtx.Uint64(flags.PerformanceEndEpochFlag.Name))
+	if endEpoch == 0 {
+		endEpoch = types.Epoch(^uint64(0))
+	}
+
+	ctx := context.Background()
+	log.Info("Opening DB")
+	validatorDB, err := kv.NewKVStore(ctx, dataDir, &kv.Config{})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := validatorDB.Close(); err != nil {
+			log.WithError(err).Error("Could not close validator DB")
+		}
+	}()
+
+	buf := new(bytes.Buffer)
+	if err := writePerformanceCSV(ctx, validatorDB, buf, startEpoch, endEpoch); err != nil {
+		return err
+	}
+	outputFile := cliCtx.String(flags.PerformanceCSVFileFlag.Name)
+	if err := file.WriteFile(outputFile, buf.Bytes()); err != nil {
+		return errors.Wrapf(err, "could not write file to path %s", outputFile)
+	}
+	log.Infof("Exported performance records to %s", outputFile)
+	return nil
+}
+
+// writePerformanceCSV writes the performance records of all the public keys from a start epoch
+// to an end epoch as CSV, one line per public key and epoch.
+func writePerformanceCSV(ctx context.Context, validatorDB iface.ValidatorDB, w io.Writer, startEpoch, endEpoch types.Epoch) error {
+	pubKeys, err := validatorDB.PerformancePublicKeys(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get public keys with performance records")
+	}
+	csvWriter := csv.NewWriter(w)
+	if err := csvWriter.Write(performanceCSVHeader); err != nil {
+		return err
+	}
+	for _, pubKey := range pubKeys {
+		records, err := validatorDB.PerformanceRecordsForPubKey(ctx, pubKey, startEpoch, endEpoch)
+		if err != nil {
+			return errors.Wrapf(err, "could not get performance records of %#x", pubKey)
+		}
+		for _, r := range records {
+			if err := csvWriter.Write([]string{
+				fmt.Sprintf("%#x", pubKey),
+				strconv.FormatUint(uint64(r.Epoch), 10),
+				strconv.FormatBool(r.AttestationIncluded),
+				strconv.FormatUint(uint64(r.InclusionDistance), 10),
+				strconv.FormatBool(r.CorrectlyVotedSource),
+				strconv.FormatBool(r.CorrectlyVotedTarget),
+				strconv.FormatBool(r.CorrectlyVotedHead),
+				joinSlots(r.ProposedSlots),
+				joinSlots(r.FailedProposalSlots),
+				strconv.FormatUint(r.SyncMessagesSubmitted, 10),
+				strconv.FormatUint(r.SyncMessagesFailed, 10),
+				strconv.FormatUint(r.BalanceBefore, 10),
+				strconv.FormatUint(r.BalanceAfter, 10),
+				strconv.FormatInt(r.EstimatedReward, 10),
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// joinSlots formats slots as a space-separated list, to keep a single CSV column.
+func joinSlots(slots []types.Slot) string {
+	strs := make([]string, len(slots))
+	for i, slot := range slots {
+		strs[i] = strconv.FormatUint(uint64(slot), 10)
+	}
+	return strings.Join(strs, " ")
+}
@@ -0,0 +1,70 @@
+package db
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/cmd"
+	"github.com/prysmaticlabs/prysm/cmd/validator/flags"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/io/file"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/validator/db/kv"
+	dbtest "github.com/prysmaticlabs/prysm/validator/db/testing"
+	"github.com/urfave/cli/v2"
+)
+
+func TestExportPerformanceCSV(t *testing.T) {
+	ctx := context.Background()
+	pubKey := [fieldparams.BLSPubkeyLength]byte{1}
+	validatorDB := dbtest.SetupDB(t, nil)
+	for epoch := types.Epoch(1); epoch <= 3; epoch++ {
+		require.NoError(t, validatorDB.SavePerformanceRecords(ctx, map[[fieldparams.BLSPubkeyLength]byte]*kv.PerformanceRecord{
+			pubKey: {
+				Epoch:                epoch,
+				AttestationIncluded:  true,
+				CorrectlyVotedSource: true,
+				ProposedSlots:        []types.Slot{types.Slot(epoch), types.Slot(epoch) + 1},
+				BalanceBefore:        32000000000,
+				BalanceAfter:         31999999000,
+				EstimatedReward:      -1000,
+			},
+		}))
+	}
+	dbPath := validatorDB.DatabasePath()
+	require.NoError(t, validatorDB.Close())
+
+	outputFile := filepath.Join(t.TempDir(), "performance.csv")
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.String(cmd.DataDirFlag.Name, dbPath, "")
+	set.String(flags.PerformanceCSVFileFlag.Name, outputFile, "")
+	set.Uint64(flags.PerformanceStartEpochFlag.Name, 2, "")
+	set.Uint64(flags.PerformanceEndEpochFlag.Name, 0, "")
+	cliCtx := cli.NewContext(&app, set, nil)
+	require.NoError(t, ExportPerformanceCSV(cliCtx))
+
+	enc, err := file.ReadFileAsBytes(outputFile)
+	require.NoError(t, err)
+	pubKeyHex := fmt.Sprintf("%#x", pubKey)
+	assert.Equal(t, strings.Join([]string{
+		strings.Join(performanceCSVHeader, ","),
+		pubKeyHex + ",2,true,0,true,false,false,2 3,,0,0,32000000000,31999999000,-1000",
+		pubKeyHex + ",3,true,0,true,false,false,3 4,,0,0,32000000000,31999999000,-1000",
+		"",
+	}, "\n"), string(enc))
+}
+
+func TestExportPerformanceCSV_NoDBFound(t *testing.T) {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.String(cmd.DataDirFlag.Name, "", "")
+	cliCtx := cli.NewContext(&app, set, nil)
+	assert.ErrorContains(t, "No validator db found at path", ExportPerformanceCSV(cliCtx))
+}
@@ -6,6 +6,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/go-playground/validator/v10"
@@ -24,6 +26,9 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+// PublicKeysPath is web3signer's endpoint listing the public keys of the keys it holds.
+const PublicKeysPath = "/api/v1/eth2/publicKeys"
+
 // SetupConfig includes configuration values for initializing.
 // a keymanager, such as passwords, the wallet, and more.
 // Web3Signer contains one public keys option. Either through a URL or a static key list.
@@ -40,6 +45,13 @@ type SetupConfig struct {
 	// a static list of public keys to be passed by the user to determine what accounts should sign.
 	// This will provide a layer of safety against slashing if the web3signer is shared across validators.
 	ProvidedPublicKeys [][48]byte
+
+	// PublicKeysRefreshInterval is how often the public keys are fetched again from the URL.
+	// Changes to the list are sent to the account changes subscribers. Zero fetches the keys only once.
+	PublicKeysRefreshInterval time.Duration
+
+	// ListenForChanges starts refreshing the public keys from the URL in the background.
+	ListenForChanges bool
 }
 
 // Keymanager defines the web3signer keymanager.
@@ -51,10 +63,14 @@ type Keymanager struct {
 	accountsChangedFeed   *event.Feed
 	validator             *validator.Validate
 	publicKeysUrlCalled   bool
+	publicKeysLock        sync.Mutex
+	// Keys added and deleted through the keymanager API, which are kept on refreshes from the URL.
+	addedPublicKeys   [][fieldparams.BLSPubkeyLength]byte
+	deletedPublicKeys map[[fieldparams.BLSPubkeyLength]byte]bool
 }
 
 // NewKeymanager instantiates a new web3signer key manager.
-func NewKeymanager(_ context.Context, cfg *SetupConfig) (*Keymanager, error) {
+func NewKeymanager(ctx context.Context, cfg *SetupConfig) (*Keymanager, error) {
 	if cfg.BaseEndpoint == "" || !bytesutil.IsValidRoot(cfg.GenesisValidatorsRoot) {
 		return nil, fmt.Errorf("invalid setup config, one or more configs are empty: BaseEndpoint: %v, GenesisValidatorsRoot: %#x", cfg.BaseEndpoint, cfg.GenesisValidatorsRoot)
 	}
@@ -62,7 +78,7 @@ func NewKeymanager(_ context.Context, cfg *SetupConfig) (*Keymanager, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "could not create apiClient")
 	}
-	return &Keymanager{
+	km := &Keymanager{
 		client:                internal.HttpSignerClient(client),
 		genesisValidatorsRoot: cfg.GenesisValidatorsRoot,
 		accountsChangedFeed:   new(event.Feed),
@@ -70,13 +86,20 @@ func NewKeymanager(_ context.Context, cfg *SetupConfig) (*Keymanager, error) {
 		providedPublicKeys:    cfg.ProvidedPublicKeys,
 		validator:             validator.New(),
 		publicKeysUrlCalled:   false,
-	}, nil
+	}
+	if cfg.ListenForChanges && cfg.PublicKeysURL != "" && cfg.PublicKeysRefreshInterval > 0 {
+		// We begin a goroutine to pick up keys added to or removed from the remote signer.
+		go km.refreshPublicKeysPeriodically(ctx, cfg.PublicKeysRefreshInterval)
+	}
+	return km, nil
 }
 
 // FetchValidatingPublicKeys fetches the validating public keys
 // from the remote server or from the provided keys if there are no existing public keys set
 // or provides the existing keys in the keymanager.
 func (km *Keymanager) FetchValidatingPublicKeys(ctx context.Context) ([][fieldparams.BLSPubkeyLength]byte, error) {
+	km.publicKeysLock.Lock()
+	defer km.publicKeysLock.Unlock()
 	if km.publicKeysURL != "" && !km.publicKeysUrlCalled {
 		providedPublicKeys, err := km.client.GetPublicKeys(ctx, km.publicKeysURL)
 		if err != nil {
@@ -85,11 +108,93 @@ func (km *Keymanager) FetchValidatingPublicKeys(ctx context.Context) ([][fieldpa
 		}
 		// makes sure that if the public keys are deleted the validator does not call URL again.
 		km.publicKeysUrlCalled = true
-		km.providedPublicKeys = providedPublicKeys
+		km.providedPublicKeys = km.mergeAPIManagedKeys(providedPublicKeys)
 	}
 	return km.providedPublicKeys, nil
 }
 
+// refreshPublicKeysPeriodically fetches the public keys from the URL on every interval
+// until the context is canceled.
+func (km *Keymanager) refreshPublicKeysPeriodically(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := km.refreshPublicKeys(ctx); err != nil {
+				log.WithError(err).Error("Could not refresh public keys from remote server")
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// refreshPublicKeys fetches the public keys from the URL and, if the list differs from the
+// keys in use, replaces them and notifies the account changes subscribers. Keys added or deleted
+// through the keymanager API are respectively kept and left out.
+func (km *Keymanager) refreshPublicKeys(ctx context.Context) error {
+	remoteKeys, err := km.client.GetPublicKeys(ctx, km.publicKeysURL)
+	if err != nil {
+		erroredResponsesTotal.Inc()
+		return errors.Wrap(err, fmt.Sprintf("could not get public keys from remote server url: %v", km.publicKeysURL))
+	}
+
+	km.publicKeysLock.Lock()
+	fetchedKeys := km.mergeAPIManagedKeys(remoteKeys)
+	fetched := make(map[[fieldparams.BLSPubkeyLength]byte]bool, len(fetchedKeys))
+	for _, key := range fetchedKeys {
+		fetched[key] = true
+	}
+	current := make(map[[fieldparams.BLSPubkeyLength]byte]bool, len(km.providedPublicKeys))
+	removed := 0
+	for _, key := range km.providedPublicKeys {
+		current[key] = true
+		if !fetched[key] {
+			removed++
+		}
+	}
+	added := 0
+	for key := range fetched {
+		if !current[key] {
+			added++
+		}
+	}
+	km.publicKeysUrlCalled = true
+	if added == 0 && removed == 0 {
+		km.publicKeysLock.Unlock()
+		return nil
+	}
+	km.providedPublicKeys = fetchedKeys
+	km.publicKeysLock.Unlock()
+
+	log.WithFields(log.Fields{
+		"added":   added,
+		"removed": removed,
+		"total":   len(fetched),
+	}).Info("Public keys changed on remote server")
+	// Subscribers fetch the keys again on changes, so the lock must not be held while sending.
+	km.accountsChangedFeed.Send(fetchedKeys)
+	return nil
+}
+
+// mergeAPIManagedKeys applies the keys added and deleted through the keymanager API to the keys
+// fetched from the URL. The caller must hold the publicKeysLock.
+func (km *Keymanager) mergeAPIManagedKeys(fetchedKeys [][fieldparams.BLSPubkeyLength]byte) [][fieldparams.BLSPubkeyLength]byte {
+	merged := make([][fieldparams.BLSPubkeyLength]byte, 0, len(fetchedKeys)+len(km.addedPublicKeys))
+	seen := make(map[[fieldparams.BLSPubkeyLength]byte]bool, len(fetchedKeys)+len(km.addedPublicKeys))
+	for _, keys := range [][][fieldparams.BLSPubkeyLength]byte{fetchedKeys, km.addedPublicKeys} {
+		for _, key := range keys {
+			if seen[key] || km.deletedPublicKeys[key] {
+				continue
+			}
+			seen[key] = true
+			merged = append(merged, key)
+		}
+	}
+	return merged
+}
+
 // Sign signs the message by using a remote web3signer server.
 func (km *Keymanager) Sign(ctx context.Context, request *validatorpb.SignRequest) (bls.Signature, error) {
 	signRequest, err := getSignRequestJson(ctx, km.validator, request, km.genesisValidatorsRoot)
@@ -292,6 +397,7 @@ func (km *Keymanager) AddPublicKeys(ctx context.Context, pubKeys [][fieldparams.
 	if ctx == nil {
 		return nil, errors.New("context is nil")
 	}
+	km.publicKeysLock.Lock()
 	importedRemoteKeysStatuses := make([]*ethpbservice.ImportedRemoteKeysStatus, len(pubKeys))
 	for i, pubKey := range pubKeys {
 		found := false
@@ -309,13 +415,17 @@ func (km *Keymanager) AddPublicKeys(ctx context.Context, pubKeys [][fieldparams.
 			continue
 		}
 		km.providedPublicKeys = append(km.providedPublicKeys, pubKey)
+		km.addedPublicKeys = append(km.addedPublicKeys, pubKey)
+		delete(km.deletedPublicKeys, pubKey)
 		importedRemoteKeysStatuses[i] = &ethpbservice.ImportedRemoteKeysStatus{
 			Status:  ethpbservice.ImportedRemoteKeysStatus_IMPORTED,
 			Message: fmt.Sprintf("Successfully added pubkey: %v", hexutil.Encode(pubKey[:])),
 		}
 		log.Debug("Added pubkey to keymanager for web3signer", "pubkey", hexutil.Encode(pubKey[:]))
 	}
-	km.accountsChangedFeed.Send(km.providedPublicKeys)
+	providedPublicKeys := km.providedPublicKeys
+	km.publicKeysLock.Unlock()
+	km.accountsChangedFeed.Send(providedPublicKeys)
 	return importedRemoteKeysStatuses, nil
 }
 
@@ -324,8 +434,10 @@ func (km *Keymanager) DeletePublicKeys(ctx context.Context, pubKeys [][fieldpara
 	if ctx == nil {
 		return nil, errors.New("context is nil")
 	}
+	km.publicKeysLock.Lock()
 	deletedRemoteKeysStatuses := make([]*ethpbservice.DeletedRemoteKeysStatus, len(pubKeys))
 	if len(km.providedPublicKeys) == 0 {
+		km.publicKeysLock.Unlock()
 		for i := range deletedRemoteKeysStatuses {
 			deletedRemoteKeysStatuses[i] = &ethpbservice.DeletedRemoteKeysStatus{
 				Status:  ethpbservice.DeletedRemoteKeysStatus_NOT_FOUND,
@@ -338,6 +450,11 @@ func (km *Keymanager) DeletePublicKeys(ctx context.Context, pubKeys [][fieldpara
 		for in, key := range km.providedPublicKeys {
 			if bytes.Equal(key[:], pubkey[:]) {
 				km.providedPublicKeys = append(km.providedPublicKeys[:in], km.providedPublicKeys[in+1:]...)
+				km.forgetAddedPublicKey(pubkey)
+				if km.deletedPublicKeys == nil {
+					km.deletedPublicKeys = make(map[[fieldparams.BLSPubkeyLength]byte]bool)
+				}
+				km.deletedPublicKeys[pubkey] = true
 				deletedRemoteKeysStatuses[i] = &ethpbservice.DeletedRemoteKeysStatus{
 					Status:  ethpbservice.DeletedRemoteKeysStatus_DELETED,
 					Message: fmt.Sprintf("Successfully deleted pubkey: %v", hexutil.Encode(pubkey[:])),
@@ -353,6 +470,19 @@ func (km *Keymanager) DeletePublicKeys(ctx context.Context, pubKeys [][fieldpara
 			}
 		}
 	}
-	km.accountsChangedFeed.Send(km.providedPublicKeys)
+	providedPublicKeys := km.providedPublicKeys
+	km.publicKeysLock.Unlock()
+	km.accountsChangedFeed.Send(providedPublicKeys)
 	return deletedRemoteKeysStatuses, nil
 }
+
+// forgetAddedPublicKey removes a key from the keys added through the keymanager API. The caller must
+// hold the publicKeysLock.
+func (km *Keymanager) forgetAddedPublicKey(pubKey [fieldparams.BLSPubkeyLength]byte) {
+	for i, key := range km.addedPublicKeys {
+		if key == pubKey {
+			km.addedPublicKeys = append(km.addedPublicKeys[:i], km.addedPublicKeys[i+1:]...)
+			return
+		}
+	}
+}
@@ -4,8 +4,12 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
@@ -269,6 +273,135 @@ func TestKeymanager_FetchValidatingPublicKeys_WithExternalURL_ThrowsError(t *tes
 	assert.Equal(t, "could not get public keys from remote server url: http://example2.com/api/v1/eth2/publicKeys: mock error", fmt.Sprintf("%v", err))
 }
 
+func TestKeymanager_RefreshPublicKeys(t *testing.T) {
+	ctx := context.Background()
+	root, err := hexutil.Decode("0x270d43e74ce340de4bca2b1936beca0f4f5408d9e78aec4850920baf659d5b69")
+	require.NoError(t, err)
+	key1 := "0xa2b5aaad9c6efefe7bb9b1243a043404f3362937cfb6b31833929833173f476630ea2cfeb0d9ddf15f97ca8685948820"
+	key2 := "0x8000a9a6d3f5e22d783eefaadbcf0298146adb5d95b04db910a0d4e16976b30229d0b1e7b9cda6c7e0bfa11f72efe055"
+	client := &MockClient{PublicKeys: []string{key1}}
+	config := &SetupConfig{
+		BaseEndpoint:          "http://example.com",
+		GenesisValidatorsRoot: root,
+		PublicKeysURL:         "http://example2.com/api/v1/eth2/publicKeys",
+	}
+	km, err := NewKeymanager(ctx, config)
+	require.NoError(t, err)
+	km.client = client
+	keys, err := km.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(keys))
+
+	keysChan := make(chan [][fieldparams.BLSPubkeyLength]byte, 1)
+	sub := km.SubscribeAccountChanges(keysChan)
+	defer sub.Unsubscribe()
+
+	// An unchanged list is not sent to the subscribers.
+	require.NoError(t, km.refreshPublicKeys(ctx))
+	require.Equal(t, 0, len(keysChan))
+
+	client.PublicKeys = []string{key2}
+	require.NoError(t, km.refreshPublicKeys(ctx))
+	changed := <-keysChan
+	require.Equal(t, 1, len(changed))
+	assert.Equal(t, key2, hexutil.Encode(changed[0][:]))
+	keys, err = km.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, key2, hexutil.Encode(keys[0][:]))
+
+	client.isThrowingError = true
+	require.ErrorContains(t, "mock error", km.refreshPublicKeys(ctx))
+}
+
+func TestKeymanager_RefreshPublicKeys_KeepsAPIManagedKeys(t *testing.T) {
+	ctx := context.Background()
+	root, err := hexutil.Decode("0x270d43e74ce340de4bca2b1936beca0f4f5408d9e78aec4850920baf659d5b69")
+	require.NoError(t, err)
+	key1 := "0xa2b5aaad9c6efefe7bb9b1243a043404f3362937cfb6b31833929833173f476630ea2cfeb0d9ddf15f97ca8685948820"
+	key2 := "0x8000a9a6d3f5e22d783eefaadbcf0298146adb5d95b04db910a0d4e16976b30229d0b1e7b9cda6c7e0bfa11f72efe055"
+	key3 := "0x93247f2209abcacf57b75a51dafae777f9dd38bc7053d1af526f220a7489a6d3a2753e5f3e8b1cfe39b56f43611df74a"
+	client := &MockClient{PublicKeys: []string{key1, key2}}
+	config := &SetupConfig{
+		BaseEndpoint:          "http://example.com",
+		GenesisValidatorsRoot: root,
+		PublicKeysURL:         "http://example2.com/api/v1/eth2/publicKeys",
+	}
+	km, err := NewKeymanager(ctx, config)
+	require.NoError(t, err)
+	km.client = client
+	keys, err := km.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(keys))
+
+	added, err := hexutil.Decode(key3)
+	require.NoError(t, err)
+	_, err = km.AddPublicKeys(ctx, [][fieldparams.BLSPubkeyLength]byte{bytesutil.ToBytes48(added)})
+	require.NoError(t, err)
+	deleted, err := hexutil.Decode(key1)
+	require.NoError(t, err)
+	_, err = km.DeletePublicKeys(ctx, [][fieldparams.BLSPubkeyLength]byte{bytesutil.ToBytes48(deleted)})
+	require.NoError(t, err)
+
+	// The remote server still lists the deleted key, and not the added one.
+	require.NoError(t, km.refreshPublicKeys(ctx))
+	keys, err = km.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(keys))
+	assert.Equal(t, key2, hexutil.Encode(keys[0][:]))
+	assert.Equal(t, key3, hexutil.Encode(keys[1][:]))
+}
+
+func TestKeymanager_RefreshPublicKeysPeriodically(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	root, err := hexutil.Decode("0x270d43e74ce340de4bca2b1936beca0f4f5408d9e78aec4850920baf659d5b69")
+	require.NoError(t, err)
+	key1 := "0xa2b5aaad9c6efefe7bb9b1243a043404f3362937cfb6b31833929833173f476630ea2cfeb0d9ddf15f97ca8685948820"
+	key2 := "0x8000a9a6d3f5e22d783eefaadbcf0298146adb5d95b04db910a0d4e16976b30229d0b1e7b9cda6c7e0bfa11f72efe055"
+
+	var lock sync.Mutex
+	served := []string{key1}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, err := fmt.Fprintf(w, `["%s"]`, strings.Join(served, `","`))
+		require.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	config := &SetupConfig{
+		BaseEndpoint:              srv.URL,
+		GenesisValidatorsRoot:     root,
+		PublicKeysURL:             srv.URL + PublicKeysPath,
+		PublicKeysRefreshInterval: 10 * time.Millisecond,
+		ListenForChanges:          true,
+	}
+	km, err := NewKeymanager(ctx, config)
+	require.NoError(t, err)
+	keysChan := make(chan [][fieldparams.BLSPubkeyLength]byte, 1)
+	sub := km.SubscribeAccountChanges(keysChan)
+	defer sub.Unsubscribe()
+
+	select {
+	case keys := <-keysChan:
+		require.Equal(t, 1, len(keys))
+		assert.Equal(t, key1, hexutil.Encode(keys[0][:]))
+	case <-time.After(5 * time.Second):
+		t.Fatal("public keys were not fetched")
+	}
+
+	lock.Lock()
+	served = []string{key1, key2}
+	lock.Unlock()
+	select {
+	case keys := <-keysChan:
+		assert.Equal(t, 2, len(keys))
+	case <-time.After(5 * time.Second):
+		t.Fatal("added public key was not picked up")
+	}
+}
+
 func TestKeymanager_AddPublicKeys(t *testing.T) {
 	ctx := context.Background()
 	root, err := hexutil.Decode("0x270d43e74ce340de4bca2b1936beca0f4f5408d9e78aec4850920baf659d5b69")
@@ -16,6 +16,7 @@ import (
 	"strings"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -31,6 +32,7 @@ import (
 	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/config/params"
 	validatorServiceConfig "github.com/prysmaticlabs/prysm/config/validator/service"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/io/file"
 	"github.com/prysmaticlabs/prysm/monitoring/backup"
@@ -152,6 +154,10 @@ func (c *ValidatorClient) Start() {
 
 	c.services.StartAll()
 
+	if c.cliCtx.IsSet(flags.ProposerSettingsFlag.Name) || c.cliCtx.IsSet(flags.ProposerSettingsURLFlag.Name) {
+		go c.watchProposerSettings()
+	}
+
 	stop := c.stop
 	c.lock.Unlock()
 
@@ -410,8 +416,15 @@ func (c *ValidatorClient) registerValidatorService(cliCtx *cli.Context) error {
 		return err
 	}
 
+	doppelgangerEpochs := c.cliCtx.Uint64(flags.DoppelgangerEpochsFlag.Name)
+	if features.Get().EnableDoppelGanger && doppelgangerEpochs == 0 {
+		return errors.New("--" + flags.DoppelgangerEpochsFlag.Name + " must be at least 1")
+	}
+
 	v, err := client.NewValidatorService(c.cliCtx.Context, &client.Config{
 		Endpoint:                   endpoint,
+		BeaconApiEndpoint:          c.cliCtx.String(flags.BeaconRESTApiProviderFlag.Name),
+		BeaconApiTimeout:           c.cliCtx.Duration(flags.BeaconRESTApiTimeout.Name),
 		DataDir:                    dataDir,
 		LogValidatorBalances:       logValidatorBalances,
 		EmitAccountMetrics:         emitAccountMetrics,
@@ -428,8 +441,13 @@ func (c *ValidatorClient) registerValidatorService(cliCtx *cli.Context) error {
 		WalletInitializedFeed:      c.walletInitialized,
 		GraffitiStruct:             gStruct,
 		LogDutyCountDown:           c.cliCtx.Bool(flags.EnableDutyCountDown.Name),
+		Distributed:                c.cliCtx.Bool(flags.DistributedFlag.Name),
 		Web3SignerConfig:           wsc,
 		ProposerSettings:           bpc,
+		DoppelgangerEpochs:         doppelgangerEpochs,
+		DoppelgangerDisableKeys:    c.cliCtx.Bool(flags.DoppelgangerDisableKeysFlag.Name),
+		PerformanceLedger:          c.cliCtx.Bool(flags.PerformanceLedgerFlag.Name),
+		PerformanceLedgerRetention: types.Epoch(c.cliCtx.Uint64(flags.PerformanceLedgerRetentionFlag.Name)),
 	})
 	if err != nil {
 		return errors.Wrap(err, "could not initialize validator service")
@@ -470,6 +488,12 @@ func web3SignerConfig(cliCtx *cli.Context) (*remoteweb3signer.SetupConfig, error
 				web3signerConfig.ProvidedPublicKeys = validatorKeys
 			}
 		}
+		if cliCtx.IsSet(flags.Web3SignerPublicKeysRefreshIntervalFlag.Name) {
+			web3signerConfig.PublicKeysRefreshInterval = cliCtx.Duration(flags.Web3SignerPublicKeysRefreshIntervalFlag.Name)
+			if web3signerConfig.PublicKeysURL == "" && len(web3signerConfig.ProvidedPublicKeys) == 0 {
+				web3signerConfig.PublicKeysURL = u.String() + remoteweb3signer.PublicKeysPath
+			}
+		}
 	}
 	return web3signerConfig, nil
 }
@@ -514,13 +538,10 @@ func proposerSettings(cliCtx *cli.Context) (*validatorServiceConfig.ProposerSett
 		return nil, errors.New(flags.FeeRecipientConfigURLFlag.Usage)
 	}
 
-	if cliCtx.IsSet(flags.ProposerSettingsFlag.Name) {
-		if err := unmarshalFromFile(cliCtx.Context, cliCtx.String(flags.ProposerSettingsFlag.Name), &fileConfig); err != nil {
-			return nil, err
-		}
-	}
-	if cliCtx.IsSet(flags.ProposerSettingsURLFlag.Name) {
-		if err := unmarshalFromURL(cliCtx.Context, cliCtx.String(flags.ProposerSettingsURLFlag.Name), &fileConfig); err != nil {
+	if cliCtx.IsSet(flags.ProposerSettingsFlag.Name) || cliCtx.IsSet(flags.ProposerSettingsURLFlag.Name) {
+		var err error
+		fileConfig, err = readProposerSettingsPayload(cliCtx.Context, cliCtx)
+		if err != nil {
 			return nil, err
 		}
 	}
@@ -529,6 +550,32 @@ func proposerSettings(cliCtx *cli.Context) (*validatorServiceConfig.ProposerSett
 	if fileConfig == nil {
 		return nil, nil
 	}
+	return proposerSettingsFromPayload(fileConfig)
+}
+
+// readProposerSettingsPayload reads the proposer settings from the file or URL set through the CLI,
+// reporting the keys which are not part of the proposer settings format.
+func readProposerSettingsPayload(ctx context.Context, cliCtx *cli.Context) (*validatorServiceConfig.ProposerSettingsPayload, error) {
+	var fileConfig *validatorServiceConfig.ProposerSettingsPayload
+	var raw []byte
+	var err error
+	if cliCtx.IsSet(flags.ProposerSettingsFlag.Name) {
+		raw, err = unmarshalFromFile(ctx, cliCtx.String(flags.ProposerSettingsFlag.Name), &fileConfig)
+	} else {
+		raw, err = unmarshalFromURL(ctx, cliCtx.String(flags.ProposerSettingsURLFlag.Name), &fileConfig)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// JSON is valid YAML, so the strict YAML decoder reports unknown keys in both formats.
+	if err := yaml.UnmarshalStrict(raw, &validatorServiceConfig.ProposerSettingsPayload{}); err != nil {
+		log.WithError(err).Warn("Proposer settings contain unknown keys, which are ignored")
+	}
+	return fileConfig, nil
+}
+
+// proposerSettingsFromPayload validates the proposer settings payload and converts it for internal use.
+func proposerSettingsFromPayload(fileConfig *validatorServiceConfig.ProposerSettingsPayload) (*validatorServiceConfig.ProposerSettings, error) {
 	//convert file config to proposer config for internal use
 	vpSettings := &validatorServiceConfig.ProposerSettings{}
 
@@ -545,9 +592,16 @@ func proposerSettings(cliCtx *cli.Context) (*validatorServiceConfig.ProposerSett
 	vpSettings.DefaultConfig = &validatorServiceConfig.ProposerOption{
 		FeeRecipient:          common.HexToAddress(fileConfig.DefaultConfig.FeeRecipient),
 		ValidatorRegistration: fileConfig.DefaultConfig.ValidatorRegistration,
+		Graffiti:              fileConfig.DefaultConfig.Graffiti,
+	}
+	if len(vpSettings.DefaultConfig.Graffiti) > fieldparams.RootLength {
+		return nil, fmt.Errorf("default fileConfig graffiti is longer than %d bytes", fieldparams.RootLength)
 	}
 	if vpSettings.DefaultConfig.ValidatorRegistration != nil {
 		vpSettings.DefaultConfig.ValidatorRegistration.GasLimit = reviewGasLimit(vpSettings.DefaultConfig.ValidatorRegistration.GasLimit)
+		if err := client.ValidateGasLimit(vpSettings.DefaultConfig.ValidatorRegistration.GasLimit); err != nil {
+			return nil, errors.Wrap(err, "invalid default fileConfig gas limit")
+		}
 	}
 
 	if fileConfig.ProposerConfig != nil {
@@ -571,10 +625,17 @@ func proposerSettings(cliCtx *cli.Context) (*validatorServiceConfig.ProposerSett
 			}
 			if option.ValidatorRegistration != nil {
 				option.ValidatorRegistration.GasLimit = reviewGasLimit(option.ValidatorRegistration.GasLimit)
+				if err := client.ValidateGasLimit(option.ValidatorRegistration.GasLimit); err != nil {
+					return nil, errors.Wrapf(err, "invalid gas limit for proposer %s", key)
+				}
+			}
+			if len(option.Graffiti) > fieldparams.RootLength {
+				return nil, fmt.Errorf("graffiti for proposer %s is longer than %d bytes", key, fieldparams.RootLength)
 			}
 			vpSettings.ProposeConfig[bytesutil.ToBytes48(decodedKey)] = &validatorServiceConfig.ProposerOption{
 				FeeRecipient:          common.HexToAddress(option.FeeRecipient),
 				ValidatorRegistration: option.ValidatorRegistration,
+				Graffiti:              option.Graffiti,
 			}
 
 		}
@@ -602,10 +663,55 @@ func reviewGasLimit(gasLimit uint64) uint64 {
 	if gasLimit == 0 {
 		return params.BeaconConfig().DefaultBuilderGasLimit
 	}
-	//TODO(10810): add in warning for ranges
 	return gasLimit
 }
 
+// watchProposerSettings reads the proposer settings file or URL again on every refresh
+// interval and on SIGHUP, and pushes the new settings to the validator service.
+func (c *ValidatorClient) watchProposerSettings() {
+	var vs *client.ValidatorService
+	if err := c.services.FetchService(&vs); err != nil {
+		log.WithError(err).Error("Could not fetch validator service to reload proposer settings")
+		return
+	}
+	var tick <-chan time.Time
+	if interval := c.cliCtx.Duration(flags.ProposerSettingsRefreshIntervalFlag.Name); interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	defer signal.Stop(sighup)
+
+	for {
+		select {
+		case <-tick:
+		case <-sighup:
+			log.Info("Got SIGHUP, reloading proposer settings")
+		case <-c.ctx.Done():
+			return
+		}
+		fileConfig, err := readProposerSettingsPayload(c.ctx, c.cliCtx)
+		if err != nil {
+			log.WithError(err).Error("Could not reload proposer settings, keeping the current ones")
+			continue
+		}
+		if fileConfig == nil {
+			log.Error("Reloaded proposer settings are empty, keeping the current ones")
+			continue
+		}
+		settings, err := proposerSettingsFromPayload(fileConfig)
+		if err != nil {
+			log.WithError(err).Error("Reloaded proposer settings are invalid, keeping the current ones")
+			continue
+		}
+		if err := vs.SetProposerSettings(c.ctx, settings); err != nil {
+			log.WithError(err).Error("Could not push reloaded proposer settings")
+		}
+	}
+}
+
 func (c *ValidatorClient) registerRPCService(cliCtx *cli.Context) error {
 	var vs *client.ValidatorService
 	if err := c.services.FetchService(&vs); err != nil {
@@ -795,22 +901,22 @@ func clearDB(ctx context.Context, dataDir string, force bool) error {
 	return nil
 }
 
-func unmarshalFromURL(ctx context.Context, from string, to interface{}) error {
+func unmarshalFromURL(ctx context.Context, from string, to interface{}) ([]byte, error) {
 	u, err := url.ParseRequestURI(from)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if u.Scheme == "" || u.Host == "" {
-		return fmt.Errorf("invalid URL: %s", from)
+		return nil, fmt.Errorf("invalid URL: %s", from)
 	}
 	req, reqerr := http.NewRequestWithContext(ctx, http.MethodGet, from, nil)
 	if reqerr != nil {
-		return errors.Wrap(reqerr, "failed to create http request")
+		return nil, errors.Wrap(reqerr, "failed to create http request")
 	}
 	req.Header.Set("Content-Type", "application/json")
 	resp, resperr := http.DefaultClient.Do(req)
 	if resperr != nil {
-		return errors.Wrap(resperr, "failed to send http request")
+		return nil, errors.Wrap(resperr, "failed to send http request")
 	}
 	defer func(Body io.ReadCloser) {
 		err = Body.Close()
@@ -819,29 +925,33 @@ func unmarshalFromURL(ctx context.Context, from string, to interface{}) error {
 		}
 	}(resp.Body)
 	if resp.StatusCode != http.StatusOK {
-		return errors.Errorf("http request to %v failed with status code %d", from, resp.StatusCode)
+		return nil, errors.Errorf("http request to %v failed with status code %d", from, resp.StatusCode)
 	}
-	if decodeerr := json.NewDecoder(resp.Body).Decode(&to); decodeerr != nil {
-		return errors.Wrap(decodeerr, "failed to decode http response")
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read http response")
 	}
-	return nil
+	if decodeerr := json.Unmarshal(b, to); decodeerr != nil {
+		return nil, errors.Wrap(decodeerr, "failed to decode http response")
+	}
+	return b, nil
 }
 
-func unmarshalFromFile(ctx context.Context, from string, to interface{}) error {
+func unmarshalFromFile(ctx context.Context, from string, to interface{}) ([]byte, error) {
 	if ctx == nil {
-		return errors.New("node: nil context passed to unmarshalFromFile")
+		return nil, errors.New("node: nil context passed to unmarshalFromFile")
 	}
 	cleanpath := filepath.Clean(from)
 	b, err := os.ReadFile(cleanpath)
 	if err != nil {
-		return errors.Wrap(err, "failed to open file")
+		return nil, errors.Wrap(err, "failed to open file")
 	}
 
 	if err := yaml.Unmarshal(b, to); err != nil {
-		return errors.Wrap(err, "failed to unmarshal yaml file")
+		return nil, errors.Wrap(err, "failed to unmarshal yaml file")
 	}
 
-	return nil
+	return b, nil
 }
 
 func configureFastSSZHashingAlgorithm() {
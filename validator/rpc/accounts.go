@@ -9,15 +9,21 @@ import (
 
 	"github.com/prysmaticlabs/prysm/api/pagination"
 	"github.com/prysmaticlabs/prysm/cmd"
+	"github.com/prysmaticlabs/prysm/config/features"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/crypto/bls"
+	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	pb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/validator-client"
 	"github.com/prysmaticlabs/prysm/validator/accounts"
 	"github.com/prysmaticlabs/prysm/validator/accounts/petnames"
+	"github.com/prysmaticlabs/prysm/validator/db/kv"
 	"github.com/prysmaticlabs/prysm/validator/keymanager"
 	"github.com/prysmaticlabs/prysm/validator/keymanager/derived"
 	"github.com/prysmaticlabs/prysm/validator/keymanager/local"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 // ListAccounts allows retrieval of validating keys and their petnames
@@ -220,3 +226,116 @@ func (s *Server) VoluntaryExit(
 		ExitedKeys: rawExitedKeys,
 	}, nil
 }
+
+// GetDoppelgangerStatus returns the doppelganger protection status of the validating keys.
+func (s *Server) GetDoppelgangerStatus(_ context.Context, _ *emptypb.Empty) (*pb.DoppelgangerStatusResponse, error) {
+	if s.validatorService == nil {
+		return nil, status.Error(codes.FailedPrecondition, "Validator service not yet initialized")
+	}
+	if !features.Get().EnableDoppelGanger {
+		return &pb.DoppelgangerStatusResponse{}, nil
+	}
+	keyStatuses := s.validatorService.DoppelgangerStatuses()
+	statuses := make([]*pb.DoppelgangerStatusResponse_ValidatorStatus, len(keyStatuses))
+	for i, st := range keyStatuses {
+		pubKey := st.PublicKey
+		statuses[i] = &pb.DoppelgangerStatusResponse_ValidatorStatus{
+			PublicKey:       pubKey[:],
+			Status:          pb.DoppelgangerStatusResponse_Status(st.Status),
+			RemainingEpochs: st.RemainingEpochs,
+		}
+	}
+	return &pb.DoppelgangerStatusResponse{
+		Enabled:  true,
+		Statuses: statuses,
+	}, nil
+}
+
+// ListPerformanceRecords lists the performance records of validating keys over a range of epochs,
+// as recorded by the validator client when the performance ledger is enabled.
+func (s *Server) ListPerformanceRecords(
+	ctx context.Context, req *pb.ListPerformanceRecordsRequest,
+) (*pb.ListPerformanceRecordsResponse, error) {
+	if s.valDB == nil {
+		return nil, status.Error(codes.FailedPrecondition, "Validator database not yet initialized")
+	}
+	if int(req.PageSize) > cmd.Get().MaxRPCPageSize {
+		return nil, status.Errorf(codes.InvalidArgument, "Requested page size %d can not be greater than max size %d",
+			req.PageSize, cmd.Get().MaxRPCPageSize)
+	}
+	endEpoch := types.Epoch(req.EndEpoch)
+	if endEpoch == 0 {
+		endEpoch = types.Epoch(^uint64(0))
+	}
+	if endEpoch < types.Epoch(req.StartEpoch) {
+		return nil, status.Errorf(codes.InvalidArgument, "End epoch %d is lower than start epoch %d", endEpoch, req.StartEpoch)
+	}
+	var pubKeys [][fieldparams.BLSPubkeyLength]byte
+	if len(req.PublicKeys) == 0 {
+		var err error
+		pubKeys, err = s.valDB.PerformancePublicKeys(ctx)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not get public keys with performance records: %v", err)
+		}
+	} else {
+		pubKeys = make([][fieldparams.BLSPubkeyLength]byte, len(req.PublicKeys))
+		for i, pubKey := range req.PublicKeys {
+			if len(pubKey) != fieldparams.BLSPubkeyLength {
+				return nil, status.Errorf(codes.InvalidArgument, "%#x is not a valid public key", pubKey)
+			}
+			pubKeys[i] = bytesutil.ToBytes48(pubKey)
+		}
+	}
+	start, end, nextPageToken, err := pagination.StartAndEndPage(req.PageToken, int(req.PageSize), len(pubKeys))
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"Could not paginate results: %v",
+			err,
+		)
+	}
+	records := make([]*pb.ListPerformanceRecordsResponse_PerformanceRecord, 0)
+	for _, pubKey := range pubKeys[start:end] {
+		pubKeyRecords, err := s.valDB.PerformanceRecordsForPubKey(ctx, pubKey, types.Epoch(req.StartEpoch), endEpoch)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not get performance records of %#x: %v", pubKey, err)
+		}
+		for _, r := range pubKeyRecords {
+			records = append(records, performanceRecordToProto(pubKey, r))
+		}
+	}
+	return &pb.ListPerformanceRecordsResponse{
+		Records:       records,
+		TotalSize:     int32(len(pubKeys)),
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+func performanceRecordToProto(
+	pubKey [fieldparams.BLSPubkeyLength]byte, r *kv.PerformanceRecord,
+) *pb.ListPerformanceRecordsResponse_PerformanceRecord {
+	proposedSlots := make([]uint64, len(r.ProposedSlots))
+	for i, slot := range r.ProposedSlots {
+		proposedSlots[i] = uint64(slot)
+	}
+	failedProposalSlots := make([]uint64, len(r.FailedProposalSlots))
+	for i, slot := range r.FailedProposalSlots {
+		failedProposalSlots[i] = uint64(slot)
+	}
+	return &pb.ListPerformanceRecordsResponse_PerformanceRecord{
+		PublicKey:             pubKey[:],
+		Epoch:                 uint64(r.Epoch),
+		AttestationIncluded:   r.AttestationIncluded,
+		InclusionDistance:     uint64(r.InclusionDistance),
+		CorrectlyVotedSource:  r.CorrectlyVotedSource,
+		CorrectlyVotedTarget:  r.CorrectlyVotedTarget,
+		CorrectlyVotedHead:    r.CorrectlyVotedHead,
+		ProposedSlots:         proposedSlots,
+		FailedProposalSlots:   failedProposalSlots,
+		SyncMessagesSubmitted: r.SyncMessagesSubmitted,
+		SyncMessagesFailed:    r.SyncMessagesFailed,
+		BalanceBefore:         r.BalanceBefore,
+		BalanceAfter:          r.BalanceAfter,
+		EstimatedReward:       r.EstimatedReward,
+	}
+}
@@ -13,20 +13,26 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/prysmaticlabs/prysm/cmd/validator/flags"
+	"github.com/prysmaticlabs/prysm/config/features"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	pb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/validator-client"
 	"github.com/prysmaticlabs/prysm/testing/assert"
 	mock2 "github.com/prysmaticlabs/prysm/testing/mock"
 	"github.com/prysmaticlabs/prysm/testing/require"
+	validatormock "github.com/prysmaticlabs/prysm/testing/validator-mock"
 	"github.com/prysmaticlabs/prysm/validator/accounts"
 	"github.com/prysmaticlabs/prysm/validator/accounts/iface"
 	mock "github.com/prysmaticlabs/prysm/validator/accounts/testing"
 	"github.com/prysmaticlabs/prysm/validator/accounts/wallet"
 	"github.com/prysmaticlabs/prysm/validator/client"
+	"github.com/prysmaticlabs/prysm/validator/db/kv"
 	"github.com/prysmaticlabs/prysm/validator/keymanager"
 	"github.com/prysmaticlabs/prysm/validator/keymanager/derived"
 	constant "github.com/prysmaticlabs/prysm/validator/testing"
+	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -290,7 +296,7 @@ func TestServer_VoluntaryExit(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	ctx := context.Background()
-	mockValidatorClient := mock2.NewMockBeaconNodeValidatorClient(ctrl)
+	mockValidatorClient := validatormock.NewMockValidatorClient(ctrl)
 	mockNodeClient := mock2.NewMockNodeClient(ctrl)
 
 	mockValidatorClient.EXPECT().
@@ -368,3 +374,78 @@ func TestServer_VoluntaryExit(t *testing.T) {
 	require.NoError(t, err)
 	require.DeepEqual(t, rawPubKeys, res.ExitedKeys)
 }
+
+func TestServer_GetDoppelgangerStatus(t *testing.T) {
+	ctx := context.Background()
+	_, err := (&Server{}).GetDoppelgangerStatus(ctx, &emptypb.Empty{})
+	require.ErrorContains(t, "Validator service not yet initialized", err)
+
+	s := &Server{validatorService: &client.ValidatorService{}}
+	resp, err := s.GetDoppelgangerStatus(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, false, resp.Enabled)
+
+	resetCfg := features.InitWithReset(&features.Flags{EnableDoppelGanger: true})
+	defer resetCfg()
+	resp, err = s.GetDoppelgangerStatus(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, true, resp.Enabled)
+	assert.Equal(t, 0, len(resp.Statuses))
+}
+
+func TestServer_ListPerformanceRecords(t *testing.T) {
+	ctx := context.Background()
+	pubKeys := [][fieldparams.BLSPubkeyLength]byte{{1}, {2}, {3}}
+	validatorDB, err := kv.NewKVStore(ctx, t.TempDir(), &kv.Config{})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, validatorDB.Close())
+	})
+	for epoch := types.Epoch(1); epoch <= 3; epoch++ {
+		records := make(map[[fieldparams.BLSPubkeyLength]byte]*kv.PerformanceRecord)
+		for _, pubKey := range pubKeys {
+			records[pubKey] = &kv.PerformanceRecord{
+				Epoch:               epoch,
+				AttestationIncluded: true,
+				ProposedSlots:       []types.Slot{types.Slot(epoch)},
+				EstimatedReward:     -int64(epoch),
+			}
+		}
+		require.NoError(t, validatorDB.SavePerformanceRecords(ctx, records))
+	}
+	s := &Server{valDB: validatorDB}
+
+	resp, err := s.ListPerformanceRecords(ctx, &pb.ListPerformanceRecordsRequest{
+		StartEpoch: 2,
+		PageSize:   2,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), resp.TotalSize)
+	assert.Equal(t, "1", resp.NextPageToken)
+	require.Equal(t, 4, len(resp.Records))
+	assert.DeepEqual(t, &pb.ListPerformanceRecordsResponse_PerformanceRecord{
+		PublicKey:           pubKeys[0][:],
+		Epoch:               2,
+		AttestationIncluded: true,
+		ProposedSlots:       []uint64{2},
+		FailedProposalSlots: []uint64{},
+		EstimatedReward:     -2,
+	}, resp.Records[0])
+	assert.DeepEqual(t, pubKeys[1][:], resp.Records[3].PublicKey)
+	assert.Equal(t, uint64(3), resp.Records[3].Epoch)
+
+	resp, err = s.ListPerformanceRecords(ctx, &pb.ListPerformanceRecordsRequest{
+		PublicKeys: [][]byte{pubKeys[2][:]},
+		StartEpoch: 1,
+		EndEpoch:   1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(resp.Records))
+	assert.DeepEqual(t, pubKeys[2][:], resp.Records[0].PublicKey)
+	assert.Equal(t, uint64(1), resp.Records[0].Epoch)
+
+	_, err = s.ListPerformanceRecords(ctx, &pb.ListPerformanceRecordsRequest{StartEpoch: 3, EndEpoch: 2})
+	require.ErrorContains(t, "is lower than start epoch", err)
+	_, err = s.ListPerformanceRecords(ctx, &pb.ListPerformanceRecordsRequest{PublicKeys: [][]byte{{1}}})
+	require.ErrorContains(t, "is not a valid public key", err)
+}
@@ -0,0 +1,39 @@
+package apimiddleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/api/gateway/apimiddleware"
+)
+
+// The voluntary exit endpoint takes the epoch of the exit as an optional query parameter.
+// We make it more proto-friendly by moving it into the request body.
+func setVoluntaryExitEpoch(
+	endpoint *apimiddleware.Endpoint,
+	_ http.ResponseWriter,
+	req *http.Request,
+) (apimiddleware.RunDefault, apimiddleware.ErrorJson) {
+	if _, ok := endpoint.PostRequest.(*signVoluntaryExitRequestJson); !ok {
+		return true, nil
+	}
+	// The epoch is left out of the body when not requested, in which case the current epoch is used.
+	epoch := req.URL.Query().Get("epoch")
+	if _, err := strconv.ParseUint(epoch, 10, 64); epoch != "" && err != nil {
+		return false, &apimiddleware.DefaultErrorJson{
+			Message: errors.Wrapf(err, "invalid epoch %s", epoch).Error(),
+			Code:    http.StatusBadRequest,
+		}
+	}
+	j := &signVoluntaryExitRequestJson{Epoch: epoch}
+	b, err := json.Marshal(j)
+	if err != nil {
+		return false, apimiddleware.InternalServerErrorWithMessage(err, "could not marshal request body")
+	}
+	req.Body = io.NopCloser(bytes.NewReader(b))
+	return true, nil
+}
@@ -0,0 +1,57 @@
+package apimiddleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/api/gateway/apimiddleware"
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+)
+
+func TestSetVoluntaryExitEpoch(t *testing.T) {
+	endpoint := &apimiddleware.Endpoint{
+		PostRequest: &signVoluntaryExitRequestJson{},
+	}
+
+	t.Run("epoch in query", func(t *testing.T) {
+		request := httptest.NewRequest("POST", "http://foo.example/eth/v1/validator/0x01/voluntary_exit?epoch=10", &bytes.Buffer{})
+		runDefault, errJson := setVoluntaryExitEpoch(endpoint, nil, request)
+		require.Equal(t, true, errJson == nil)
+		assert.Equal(t, apimiddleware.RunDefault(true), runDefault)
+		body := &signVoluntaryExitRequestJson{}
+		require.NoError(t, json.NewDecoder(request.Body).Decode(body))
+		assert.Equal(t, "10", body.Epoch)
+	})
+	t.Run("no epoch in query", func(t *testing.T) {
+		request := httptest.NewRequest("POST", "http://foo.example/eth/v1/validator/0x01/voluntary_exit", &bytes.Buffer{})
+		runDefault, errJson := setVoluntaryExitEpoch(endpoint, nil, request)
+		require.Equal(t, true, errJson == nil)
+		assert.Equal(t, apimiddleware.RunDefault(true), runDefault)
+		body := make(map[string]interface{})
+		require.NoError(t, json.NewDecoder(request.Body).Decode(&body))
+		_, ok := body["epoch"]
+		assert.Equal(t, false, ok)
+	})
+	t.Run("zero epoch in query", func(t *testing.T) {
+		request := httptest.NewRequest("POST", "http://foo.example/eth/v1/validator/0x01/voluntary_exit?epoch=0", &bytes.Buffer{})
+		runDefault, errJson := setVoluntaryExitEpoch(endpoint, nil, request)
+		require.Equal(t, true, errJson == nil)
+		assert.Equal(t, apimiddleware.RunDefault(true), runDefault)
+		body := &signVoluntaryExitRequestJson{}
+		require.NoError(t, json.NewDecoder(request.Body).Decode(body))
+		assert.Equal(t, "0", body.Epoch)
+	})
+	t.Run("invalid epoch", func(t *testing.T) {
+		request := httptest.NewRequest("POST", "http://foo.example/eth/v1/validator/0x01/voluntary_exit?epoch=foo", &bytes.Buffer{})
+		runDefault, errJson := setVoluntaryExitEpoch(endpoint, nil, request)
+		require.NotNil(t, errJson)
+		assert.Equal(t, apimiddleware.RunDefault(false), runDefault)
+		assert.Equal(t, http.StatusBadRequest, errJson.StatusCode())
+		assert.Equal(t, true, strings.Contains(errJson.Msg(), "invalid epoch foo"))
+	})
+}
@@ -19,6 +19,9 @@ func (*ValidatorEndpointFactory) Paths() []string {
 		"/eth/v1/keystores",
 		"/eth/v1/remotekeys",
 		"/eth/v1/validator/{pubkey}/feerecipient",
+		"/eth/v1/validator/{pubkey}/gaslimit",
+		"/eth/v1/validator/{pubkey}/graffiti",
+		"/eth/v1/validator/{pubkey}/voluntary_exit",
 	}
 }
 
@@ -41,6 +44,18 @@ func (*ValidatorEndpointFactory) Create(path string) (*apimiddleware.Endpoint, e
 	case "/eth/v1/validator/{pubkey}/feerecipient":
 		endpoint.GetResponse = &getFeeRecipientByPubkeyResponseJson{}
 		endpoint.PostRequest = &setFeeRecipientByPubkeyRequestJson{}
+	case "/eth/v1/validator/{pubkey}/gaslimit":
+		endpoint.GetResponse = &getGasLimitResponseJson{}
+		endpoint.PostRequest = &setGasLimitRequestJson{}
+	case "/eth/v1/validator/{pubkey}/graffiti":
+		endpoint.GetResponse = &getGraffitiResponseJson{}
+		endpoint.PostRequest = &setGraffitiRequestJson{}
+	case "/eth/v1/validator/{pubkey}/voluntary_exit":
+		endpoint.PostRequest = &signVoluntaryExitRequestJson{}
+		endpoint.PostResponse = &signVoluntaryExitResponseJson{}
+		endpoint.Hooks = apimiddleware.HookCollection{
+			OnPreDeserializeRequestBodyIntoContainer: setVoluntaryExitEpoch,
+		}
 	default:
 		return nil, errors.New("invalid path")
 	}
@@ -79,3 +79,47 @@ type getFeeRecipientByPubkeyResponseJson struct {
 type setFeeRecipientByPubkeyRequestJson struct {
 	Ethaddress string `json:"ethaddress" hex:"true"`
 }
+
+type gasLimitJson struct {
+	Pubkey   string `json:"pubkey" hex:"true"`
+	GasLimit string `json:"gas_limit"`
+}
+
+type getGasLimitResponseJson struct {
+	Data *gasLimitJson `json:"data"`
+}
+
+type setGasLimitRequestJson struct {
+	GasLimit string `json:"gas_limit"`
+}
+
+type graffitiJson struct {
+	Pubkey   string `json:"pubkey" hex:"true"`
+	Graffiti string `json:"graffiti"`
+}
+
+type getGraffitiResponseJson struct {
+	Data *graffitiJson `json:"data"`
+}
+
+type setGraffitiRequestJson struct {
+	Graffiti string `json:"graffiti"`
+}
+
+type signVoluntaryExitRequestJson struct {
+	Epoch string `json:"epoch,omitempty"`
+}
+
+type signVoluntaryExitResponseJson struct {
+	Data *signedVoluntaryExitJson `json:"data"`
+}
+
+type signedVoluntaryExitJson struct {
+	Exit      *voluntaryExitJson `json:"message"`
+	Signature string             `json:"signature" hex:"true"`
+}
+
+type voluntaryExitJson struct {
+	Epoch          string `json:"epoch"`
+	ValidatorIndex string `json:"validator_index"`
+}
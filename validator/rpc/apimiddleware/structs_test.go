@@ -229,6 +229,54 @@ func TestDeleteRemoteKeys_JSONisEqual(t *testing.T) {
 }
 
 // note: this does not do a deep comparison of the structs
+func TestGetGasLimit_JSONisEqual(t *testing.T) {
+	middlewareResponse := &getGasLimitResponseJson{
+		Data: &gasLimitJson{
+			Pubkey:   "0x0",
+			GasLimit: "30000000",
+		},
+	}
+
+	protoResponse := &service.GetGasLimitResponse{
+		Data: &service.GetGasLimitResponse_GasLimit{
+			Pubkey:   make([]byte, fieldparams.BLSPubkeyLength),
+			GasLimit: 30000000,
+		},
+	}
+
+	getResp, err := areJsonPropertyNamesEqual(middlewareResponse, protoResponse)
+	require.NoError(t, err)
+	require.Equal(t, getResp, true)
+
+	resp, err := areJsonPropertyNamesEqual(middlewareResponse.Data, protoResponse.Data)
+	require.NoError(t, err)
+	require.Equal(t, resp, true)
+}
+
+func TestGetGraffiti_JSONisEqual(t *testing.T) {
+	middlewareResponse := &getGraffitiResponseJson{
+		Data: &graffitiJson{
+			Pubkey:   "0x0",
+			Graffiti: "prysm",
+		},
+	}
+
+	protoResponse := &service.GetGraffitiResponse{
+		Data: &service.GetGraffitiResponse_Graffiti{
+			Pubkey:   make([]byte, fieldparams.BLSPubkeyLength),
+			Graffiti: "prysm",
+		},
+	}
+
+	getResp, err := areJsonPropertyNamesEqual(middlewareResponse, protoResponse)
+	require.NoError(t, err)
+	require.Equal(t, getResp, true)
+
+	resp, err := areJsonPropertyNamesEqual(middlewareResponse.Data, protoResponse.Data)
+	require.NoError(t, err)
+	require.Equal(t, resp, true)
+}
+
 func areJsonPropertyNamesEqual(internal, proto interface{}) (bool, error) {
 	internalJSON, err := json.Marshal(internal)
 	if err != nil {
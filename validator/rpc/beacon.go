@@ -14,6 +14,7 @@ import (
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	validatorpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/validator-client"
 	"github.com/prysmaticlabs/prysm/validator/client"
+	grpc_api "github.com/prysmaticlabs/prysm/validator/client/grpc-api"
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
@@ -48,7 +49,7 @@ func (s *Server) registerBeaconClient() error {
 	s.beaconChainClient = ethpb.NewBeaconChainClient(conn)
 	s.beaconNodeClient = ethpb.NewNodeClient(conn)
 	s.beaconNodeHealthClient = ethpb.NewHealthClient(conn)
-	s.beaconNodeValidatorClient = ethpb.NewBeaconNodeValidatorClient(conn)
+	s.beaconNodeValidatorClient = grpc_api.NewGrpcValidatorClient(conn)
 	return nil
 }
 
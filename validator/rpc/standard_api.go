@@ -12,8 +12,12 @@ import (
 	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/config/params"
 	validatorServiceConfig "github.com/prysmaticlabs/prysm/config/validator/service"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	ethpbservice "github.com/prysmaticlabs/prysm/proto/eth/service"
+	"github.com/prysmaticlabs/prysm/proto/migration"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/validator/client"
 	"github.com/prysmaticlabs/prysm/validator/keymanager"
 	"github.com/prysmaticlabs/prysm/validator/keymanager/derived"
 	slashingprotection "github.com/prysmaticlabs/prysm/validator/slashing-protection-history"
@@ -399,7 +403,8 @@ func (s *Server) ListFeeRecipientByPubkey(_ context.Context, req *ethpbservice.P
 		return nil, status.Error(codes.FailedPrecondition, err.Error())
 	}
 	defaultFeeRecipient := params.BeaconConfig().DefaultFeeRecipient.Bytes()
-	if s.validatorService.ProposerSettings == nil {
+	settings := s.validatorService.ProposerSettings()
+	if settings == nil {
 		return &ethpbservice.GetFeeRecipientByPubkeyResponse{
 			Data: &ethpbservice.GetFeeRecipientByPubkeyResponse_FeeRecipient{
 				Pubkey:     validatorKey,
@@ -407,8 +412,8 @@ func (s *Server) ListFeeRecipientByPubkey(_ context.Context, req *ethpbservice.P
 			},
 		}, nil
 	}
-	if s.validatorService.ProposerSettings.ProposeConfig != nil {
-		proposerOption, found := s.validatorService.ProposerSettings.ProposeConfig[bytesutil.ToBytes48(validatorKey)]
+	if settings.ProposeConfig != nil {
+		proposerOption, found := settings.ProposeConfig[bytesutil.ToBytes48(validatorKey)]
 		if found {
 			return &ethpbservice.GetFeeRecipientByPubkeyResponse{
 				Data: &ethpbservice.GetFeeRecipientByPubkeyResponse_FeeRecipient{
@@ -418,8 +423,8 @@ func (s *Server) ListFeeRecipientByPubkey(_ context.Context, req *ethpbservice.P
 			}, nil
 		}
 	}
-	if s.validatorService.ProposerSettings.DefaultConfig != nil {
-		defaultFeeRecipient = s.validatorService.ProposerSettings.DefaultConfig.FeeRecipient.Bytes()
+	if settings.DefaultConfig != nil {
+		defaultFeeRecipient = settings.DefaultConfig.FeeRecipient.Bytes()
 	}
 	return &ethpbservice.GetFeeRecipientByPubkeyResponse{
 		Data: &ethpbservice.GetFeeRecipientByPubkeyResponse_FeeRecipient{
@@ -438,36 +443,96 @@ func (s *Server) SetFeeRecipientByPubkey(ctx context.Context, req *ethpbservice.
 	if err := validatePublicKey(validatorKey); err != nil {
 		return nil, status.Error(codes.FailedPrecondition, err.Error())
 	}
-	defaultOption := validatorServiceConfig.DefaultProposerOption()
 	encoded := hexutil.Encode(req.Ethaddress)
 	if !common.IsHexAddress(encoded) {
 		return nil, status.Error(
 			codes.InvalidArgument, "Fee recipient is not a valid Ethereum address")
 	}
-	pOption := validatorServiceConfig.DefaultProposerOption()
-	pOption.FeeRecipient = common.BytesToAddress(req.Ethaddress)
-	switch {
-	case s.validatorService.ProposerSettings == nil:
-		s.validatorService.ProposerSettings = &validatorServiceConfig.ProposerSettings{
-			ProposeConfig: map[[fieldparams.BLSPubkeyLength]byte]*validatorServiceConfig.ProposerOption{
-				bytesutil.ToBytes48(validatorKey): &pOption,
-			},
-			DefaultConfig: &defaultOption,
-		}
-	case s.validatorService.ProposerSettings.ProposeConfig == nil:
-		pOption.ValidatorRegistration = s.validatorService.ProposerSettings.DefaultConfig.ValidatorRegistration
-		s.validatorService.ProposerSettings.ProposeConfig = map[[fieldparams.BLSPubkeyLength]byte]*validatorServiceConfig.ProposerOption{
-			bytesutil.ToBytes48(validatorKey): &pOption,
+	pubkey := bytesutil.ToBytes48(validatorKey)
+	feeRecipient := common.BytesToAddress(req.Ethaddress)
+	s.validatorService.UpdateProposerSettings(pubkey, client.FeeRecipientSetting, func(settings *validatorServiceConfig.ProposerSettings) {
+		proposerOptionToUpdate(settings, pubkey).FeeRecipient = feeRecipient
+	})
+	// override the 200 success with 202 according to the specs
+	if err := grpc.SetHeader(ctx, metadata.Pairs("x-http-code", "202")); err != nil {
+		return &empty.Empty{}, status.Errorf(codes.Internal, "Could not set custom success code header: %v", err)
+	}
+	return &empty.Empty{}, nil
+}
+
+// DeleteFeeRecipientByPubkey updates the eth address mapped to the public key to the default fee recipient listed
+func (s *Server) DeleteFeeRecipientByPubkey(ctx context.Context, req *ethpbservice.PubkeyRequest) (*empty.Empty, error) {
+	if s.validatorService == nil {
+		return nil, status.Error(codes.FailedPrecondition, "Validator service not ready")
+	}
+	validatorKey := req.Pubkey
+	if err := validatePublicKey(validatorKey); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	pubkey := bytesutil.ToBytes48(validatorKey)
+	s.validatorService.UpdateProposerSettings(pubkey, client.FeeRecipientSetting, func(settings *validatorServiceConfig.ProposerSettings) {
+		if proposerOption, found := settings.ProposeConfig[pubkey]; found && proposerOption != nil {
+			proposerOption.FeeRecipient = settings.DefaultConfig.FeeRecipient
 		}
-	default:
-		proposerOption, found := s.validatorService.ProposerSettings.ProposeConfig[bytesutil.ToBytes48(validatorKey)]
-		if found {
-			proposerOption.FeeRecipient = common.BytesToAddress(req.Ethaddress)
-		} else {
-			pOption.ValidatorRegistration = s.validatorService.ProposerSettings.DefaultConfig.ValidatorRegistration
-			s.validatorService.ProposerSettings.ProposeConfig[bytesutil.ToBytes48(validatorKey)] = &pOption
+	})
+	// override the 200 success with 204 according to the specs
+	if err := grpc.SetHeader(ctx, metadata.Pairs("x-http-code", "204")); err != nil {
+		return &empty.Empty{}, status.Errorf(codes.Internal, "Could not set custom success code header: %v", err)
+	}
+	return &empty.Empty{}, nil
+}
+
+// GetGasLimit returns the gas limit used in the builder registrations of the public key.
+func (s *Server) GetGasLimit(_ context.Context, req *ethpbservice.PubkeyRequest) (*ethpbservice.GetGasLimitResponse, error) {
+	if s.validatorService == nil {
+		return nil, status.Error(codes.FailedPrecondition, "Validator service not ready")
+	}
+	validatorKey := req.Pubkey
+	if err := validatePublicKey(validatorKey); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	settings := s.validatorService.ProposerSettings()
+	gasLimit := defaultGasLimit(settings)
+	if settings != nil && settings.ProposeConfig != nil {
+		proposerOption, found := settings.ProposeConfig[bytesutil.ToBytes48(validatorKey)]
+		if found && proposerOption.ValidatorRegistration != nil {
+			gasLimit = proposerOption.ValidatorRegistration.GasLimit
 		}
 	}
+	return &ethpbservice.GetGasLimitResponse{
+		Data: &ethpbservice.GetGasLimitResponse_GasLimit{
+			Pubkey:   validatorKey,
+			GasLimit: gasLimit,
+		},
+	}, nil
+}
+
+// SetGasLimit updates the gas limit of the public key, and signs its builder registration again. The gas
+// limit is only used in builder registrations, so it is rejected for public keys registering with none.
+func (s *Server) SetGasLimit(ctx context.Context, req *ethpbservice.SetGasLimitRequest) (*empty.Empty, error) {
+	if s.validatorService == nil {
+		return nil, status.Error(codes.FailedPrecondition, "Validator service not ready")
+	}
+	validatorKey := req.Pubkey
+	if err := validatePublicKey(validatorKey); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err := client.ValidateGasLimit(req.GasLimit); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	pubkey := bytesutil.ToBytes48(validatorKey)
+	if !builderRegistrationEnabled(s.validatorService.ProposerSettings(), pubkey) {
+		return nil, status.Error(codes.FailedPrecondition,
+			"Builder registration is not enabled for the public key, a gas limit would have no effect")
+	}
+	gasLimit := req.GasLimit
+	s.validatorService.UpdateProposerSettings(pubkey, client.GasLimitSetting, func(settings *validatorServiceConfig.ProposerSettings) {
+		proposerOption := proposerOptionToUpdate(settings, pubkey)
+		if proposerOption.ValidatorRegistration == nil {
+			proposerOption.ValidatorRegistration = &validatorServiceConfig.ValidatorRegistration{}
+		}
+		proposerOption.ValidatorRegistration.GasLimit = gasLimit
+	})
 	// override the 200 success with 202 according to the specs
 	if err := grpc.SetHeader(ctx, metadata.Pairs("x-http-code", "202")); err != nil {
 		return &empty.Empty{}, status.Errorf(codes.Internal, "Could not set custom success code header: %v", err)
@@ -475,8 +540,8 @@ func (s *Server) SetFeeRecipientByPubkey(ctx context.Context, req *ethpbservice.
 	return &empty.Empty{}, nil
 }
 
-// DeleteFeeRecipientByPubkey updates the eth address mapped to the public key to the default fee recipient listed
-func (s *Server) DeleteFeeRecipientByPubkey(ctx context.Context, req *ethpbservice.PubkeyRequest) (*empty.Empty, error) {
+// DeleteGasLimit replaces the gas limit of the public key with the default gas limit.
+func (s *Server) DeleteGasLimit(ctx context.Context, req *ethpbservice.PubkeyRequest) (*empty.Empty, error) {
 	if s.validatorService == nil {
 		return nil, status.Error(codes.FailedPrecondition, "Validator service not ready")
 	}
@@ -484,16 +549,86 @@ func (s *Server) DeleteFeeRecipientByPubkey(ctx context.Context, req *ethpbservi
 	if err := validatePublicKey(validatorKey); err != nil {
 		return nil, status.Error(codes.FailedPrecondition, err.Error())
 	}
-	defaultFeeRecipient := params.BeaconConfig().DefaultFeeRecipient
-	if s.validatorService.ProposerSettings != nil && s.validatorService.ProposerSettings.DefaultConfig != nil {
-		defaultFeeRecipient = s.validatorService.ProposerSettings.DefaultConfig.FeeRecipient
+	pubkey := bytesutil.ToBytes48(validatorKey)
+	s.validatorService.UpdateProposerSettings(pubkey, client.GasLimitSetting, func(settings *validatorServiceConfig.ProposerSettings) {
+		if proposerOption, found := settings.ProposeConfig[pubkey]; found && proposerOption != nil && proposerOption.ValidatorRegistration != nil {
+			proposerOption.ValidatorRegistration.GasLimit = defaultGasLimit(settings)
+		}
+	})
+	// override the 200 success with 204 according to the specs
+	if err := grpc.SetHeader(ctx, metadata.Pairs("x-http-code", "204")); err != nil {
+		return &empty.Empty{}, status.Errorf(codes.Internal, "Could not set custom success code header: %v", err)
 	}
-	if s.validatorService.ProposerSettings != nil && s.validatorService.ProposerSettings.ProposeConfig != nil {
-		proposerOption, found := s.validatorService.ProposerSettings.ProposeConfig[bytesutil.ToBytes48(validatorKey)]
-		if found {
-			proposerOption.FeeRecipient = defaultFeeRecipient
+	return &empty.Empty{}, nil
+}
+
+// GetGraffiti returns the graffiti included in the blocks proposed by the public key.
+func (s *Server) GetGraffiti(_ context.Context, req *ethpbservice.PubkeyRequest) (*ethpbservice.GetGraffitiResponse, error) {
+	if s.validatorService == nil {
+		return nil, status.Error(codes.FailedPrecondition, "Validator service not ready")
+	}
+	validatorKey := req.Pubkey
+	if err := validatePublicKey(validatorKey); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	var graffiti string
+	settings := s.validatorService.ProposerSettings()
+	if settings != nil && settings.DefaultConfig != nil {
+		graffiti = settings.DefaultConfig.Graffiti
+	}
+	if settings != nil && settings.ProposeConfig != nil {
+		proposerOption, found := settings.ProposeConfig[bytesutil.ToBytes48(validatorKey)]
+		if found && proposerOption.Graffiti != "" {
+			graffiti = proposerOption.Graffiti
 		}
 	}
+	return &ethpbservice.GetGraffitiResponse{
+		Data: &ethpbservice.GetGraffitiResponse_Graffiti{
+			Pubkey:   validatorKey,
+			Graffiti: graffiti,
+		},
+	}, nil
+}
+
+// SetGraffiti updates the graffiti included in the blocks proposed by the public key.
+func (s *Server) SetGraffiti(ctx context.Context, req *ethpbservice.SetGraffitiRequest) (*empty.Empty, error) {
+	if s.validatorService == nil {
+		return nil, status.Error(codes.FailedPrecondition, "Validator service not ready")
+	}
+	validatorKey := req.Pubkey
+	if err := validatePublicKey(validatorKey); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if len(req.Graffiti) > fieldparams.RootLength {
+		return nil, status.Errorf(codes.InvalidArgument, "Graffiti is longer than %d bytes", fieldparams.RootLength)
+	}
+	pubkey := bytesutil.ToBytes48(validatorKey)
+	graffiti := req.Graffiti
+	s.validatorService.UpdateProposerSettings(pubkey, client.GraffitiSetting, func(settings *validatorServiceConfig.ProposerSettings) {
+		proposerOptionToUpdate(settings, pubkey).Graffiti = graffiti
+	})
+	// override the 200 success with 202 according to the specs
+	if err := grpc.SetHeader(ctx, metadata.Pairs("x-http-code", "202")); err != nil {
+		return &empty.Empty{}, status.Errorf(codes.Internal, "Could not set custom success code header: %v", err)
+	}
+	return &empty.Empty{}, nil
+}
+
+// DeleteGraffiti removes the graffiti of the public key, which falls back to the default graffiti.
+func (s *Server) DeleteGraffiti(ctx context.Context, req *ethpbservice.PubkeyRequest) (*empty.Empty, error) {
+	if s.validatorService == nil {
+		return nil, status.Error(codes.FailedPrecondition, "Validator service not ready")
+	}
+	validatorKey := req.Pubkey
+	if err := validatePublicKey(validatorKey); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	pubkey := bytesutil.ToBytes48(validatorKey)
+	s.validatorService.UpdateProposerSettings(pubkey, client.GraffitiSetting, func(settings *validatorServiceConfig.ProposerSettings) {
+		if proposerOption, found := settings.ProposeConfig[pubkey]; found && proposerOption != nil {
+			proposerOption.Graffiti = ""
+		}
+	})
 	// override the 200 success with 204 according to the specs
 	if err := grpc.SetHeader(ctx, metadata.Pairs("x-http-code", "204")); err != nil {
 		return &empty.Empty{}, status.Errorf(codes.Internal, "Could not set custom success code header: %v", err)
@@ -501,6 +636,90 @@ func (s *Server) DeleteFeeRecipientByPubkey(ctx context.Context, req *ethpbservi
 	return &empty.Empty{}, nil
 }
 
+// SignVoluntaryExit signs a voluntary exit for the public key with the keymanager in use. The signed exit
+// is returned to the caller instead of being submitted to the beacon node.
+func (s *Server) SignVoluntaryExit(ctx context.Context, req *ethpbservice.SignVoluntaryExitRequest) (*ethpbservice.SignVoluntaryExitResponse, error) {
+	if s.validatorService == nil {
+		return nil, status.Error(codes.FailedPrecondition, "Validator service not ready")
+	}
+	validatorKey := req.Pubkey
+	if err := validatePublicKey(validatorKey); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	km, err := s.validatorService.Keymanager()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get Prysm keymanager (possibly due to beacon node unavailable): %v", err)
+	}
+	pubKeys, err := km.FetchValidatingPublicKeys(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not retrieve public keys: %v", err)
+	}
+	found := false
+	for _, pubKey := range pubKeys {
+		if bytes.Equal(pubKey[:], validatorKey) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "Validator public key %#x is not managed by the keymanager", validatorKey)
+	}
+	indexResponse, err := s.beaconNodeValidatorClient.ValidatorIndex(ctx, &ethpb.ValidatorIndexRequest{PublicKey: validatorKey})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get validator index: %v", err)
+	}
+	var epoch types.Epoch
+	if req.Epoch != nil {
+		epoch = types.Epoch(*req.Epoch)
+	} else {
+		epoch, err = client.CurrentEpoch(ctx, s.beaconNodeClient)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not get current epoch: %v", err)
+		}
+	}
+	exit := &ethpb.VoluntaryExit{Epoch: epoch, ValidatorIndex: indexResponse.Index}
+	signedExit, err := client.CreateSignedVoluntaryExit(ctx, s.beaconNodeValidatorClient, km.Sign, validatorKey, exit)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not sign voluntary exit: %v", err)
+	}
+	return &ethpbservice.SignVoluntaryExitResponse{
+		Data: migration.V1Alpha1ExitToV1(signedExit),
+	}, nil
+}
+
+// proposerOptionToUpdate returns the proposer option of the public key, which starts as a copy of the default
+// option if the public key has none yet. The settings must have a default config and a propose config.
+func proposerOptionToUpdate(settings *validatorServiceConfig.ProposerSettings, pubkey [fieldparams.BLSPubkeyLength]byte) *validatorServiceConfig.ProposerOption {
+	proposerOption, found := settings.ProposeConfig[pubkey]
+	if !found || proposerOption == nil {
+		proposerOption = settings.DefaultConfig.Clone()
+		proposerOption.Graffiti = ""
+		settings.ProposeConfig[pubkey] = proposerOption
+	}
+	return proposerOption
+}
+
+// defaultGasLimit returns the gas limit of the validators without a gas limit of their own.
+func defaultGasLimit(settings *validatorServiceConfig.ProposerSettings) uint64 {
+	if settings != nil && settings.DefaultConfig != nil && settings.DefaultConfig.ValidatorRegistration != nil {
+		return settings.DefaultConfig.ValidatorRegistration.GasLimit
+	}
+	return params.BeaconConfig().DefaultBuilderGasLimit
+}
+
+// builderRegistrationEnabled reports whether the public key registers with the builder network, which is
+// the only use of its gas limit.
+func builderRegistrationEnabled(settings *validatorServiceConfig.ProposerSettings, pubkey [fieldparams.BLSPubkeyLength]byte) bool {
+	if settings == nil {
+		return false
+	}
+	option := settings.DefaultConfig
+	if proposerOption, found := settings.ProposeConfig[pubkey]; found && proposerOption != nil {
+		option = proposerOption
+	}
+	return option != nil && option.ValidatorRegistration != nil && option.ValidatorRegistration.Enable
+}
+
 func validatePublicKey(pubkey []byte) error {
 	if len(pubkey) != fieldparams.BLSPubkeyLength {
 		return status.Errorf(
@@ -6,21 +6,27 @@ import (
 	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/golang/mock/gomock"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/google/uuid"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/config/params"
 	validatorserviceconfig "github.com/prysmaticlabs/prysm/config/validator/service"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/crypto/bls"
 	"github.com/prysmaticlabs/prysm/encoding/bytesutil"
 	ethpbservice "github.com/prysmaticlabs/prysm/proto/eth/service"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	validatorpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/validator-client"
 	"github.com/prysmaticlabs/prysm/testing/assert"
+	mock2 "github.com/prysmaticlabs/prysm/testing/mock"
 	"github.com/prysmaticlabs/prysm/testing/require"
+	validatormock "github.com/prysmaticlabs/prysm/testing/validator-mock"
 	"github.com/prysmaticlabs/prysm/validator/accounts"
 	"github.com/prysmaticlabs/prysm/validator/accounts/iface"
 	mock "github.com/prysmaticlabs/prysm/validator/accounts/testing"
@@ -34,6 +40,7 @@ import (
 	mocks "github.com/prysmaticlabs/prysm/validator/testing"
 	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestServer_ListKeystores(t *testing.T) {
@@ -779,7 +786,7 @@ func TestServer_SetFeeRecipientByPubkey(t *testing.T) {
 			}
 			_, err = s.SetFeeRecipientByPubkey(ctx, &ethpbservice.SetFeeRecipientByPubkeyRequest{Pubkey: byteval, Ethaddress: common.HexToAddress(tt.args).Bytes()})
 			require.NoError(t, err)
-			assert.Equal(t, tt.want.EthAddress, s.validatorService.ProposerSettings.ProposeConfig[bytesutil.ToBytes48(byteval)].FeeRecipient.Hex())
+			assert.Equal(t, tt.want.EthAddress, s.validatorService.ProposerSettings().ProposeConfig[bytesutil.ToBytes48(byteval)].FeeRecipient.Hex())
 		})
 	}
 }
@@ -827,7 +834,299 @@ func TestServer_DeleteFeeRecipientByPubkey(t *testing.T) {
 			}
 			_, err = s.DeleteFeeRecipientByPubkey(ctx, &ethpbservice.PubkeyRequest{Pubkey: byteval})
 			require.NoError(t, err)
-			assert.Equal(t, tt.want.EthAddress, s.validatorService.ProposerSettings.ProposeConfig[bytesutil.ToBytes48(byteval)].FeeRecipient.Hex())
+			assert.Equal(t, tt.want.EthAddress, s.validatorService.ProposerSettings().ProposeConfig[bytesutil.ToBytes48(byteval)].FeeRecipient.Hex())
 		})
 	}
 }
+
+func TestServer_GetGasLimit(t *testing.T) {
+	ctx := context.Background()
+	byteval, err := hexutil.Decode("0xaf2e7ba294e03438ea819bd4033c6c1bf6b04320ee2075b77273c08d02f8a61bcc303c2c06bd3713cb442072ae591493")
+	require.NoError(t, err)
+	tests := []struct {
+		name             string
+		proposerSettings *validatorserviceconfig.ProposerSettings
+		want             uint64
+	}{
+		{
+			name:             "No proposer settings",
+			proposerSettings: nil,
+			want:             params.BeaconConfig().DefaultBuilderGasLimit,
+		},
+		{
+			name: "Default gas limit",
+			proposerSettings: &validatorserviceconfig.ProposerSettings{
+				DefaultConfig: &validatorserviceconfig.ProposerOption{
+					ValidatorRegistration: &validatorserviceconfig.ValidatorRegistration{
+						Enable:   true,
+						GasLimit: 40000000,
+					},
+				},
+			},
+			want: 40000000,
+		},
+		{
+			name: "Gas limit of the public key",
+			proposerSettings: &validatorserviceconfig.ProposerSettings{
+				ProposeConfig: map[[48]byte]*validatorserviceconfig.ProposerOption{
+					bytesutil.ToBytes48(byteval): {
+						ValidatorRegistration: &validatorserviceconfig.ValidatorRegistration{
+							Enable:   true,
+							GasLimit: 35000000,
+						},
+					},
+				},
+				DefaultConfig: &validatorserviceconfig.ProposerOption{
+					ValidatorRegistration: &validatorserviceconfig.ValidatorRegistration{
+						Enable:   true,
+						GasLimit: 40000000,
+					},
+				},
+			},
+			want: 35000000,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vs, err := client.NewValidatorService(ctx, &client.Config{
+				Validator:        &mock.MockValidator{},
+				ProposerSettings: tt.proposerSettings,
+			})
+			require.NoError(t, err)
+			s := &Server{
+				validatorService: vs,
+			}
+			got, err := s.GetGasLimit(ctx, &ethpbservice.PubkeyRequest{Pubkey: byteval})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.Data.GasLimit)
+		})
+	}
+}
+
+func TestServer_SetGasLimit(t *testing.T) {
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), &runtime.ServerTransportStream{})
+	byteval, err := hexutil.Decode("0xaf2e7ba294e03438ea819bd4033c6c1bf6b04320ee2075b77273c08d02f8a61bcc303c2c06bd3713cb442072ae591493")
+	require.NoError(t, err)
+	defaultFeeRecipient := common.HexToAddress("0x046Fb65722E7b2455012BFEBf6177F1D2e9738D9")
+	previous := &validatorserviceconfig.ProposerSettings{
+		DefaultConfig: &validatorserviceconfig.ProposerOption{
+			FeeRecipient: defaultFeeRecipient,
+			ValidatorRegistration: &validatorserviceconfig.ValidatorRegistration{
+				Enable:   true,
+				GasLimit: 30000000,
+			},
+		},
+	}
+	vs, err := client.NewValidatorService(ctx, &client.Config{
+		Validator:        &mock.MockValidator{},
+		ProposerSettings: previous,
+	})
+	require.NoError(t, err)
+	s := &Server{
+		validatorService: vs,
+	}
+
+	_, err = s.SetGasLimit(ctx, &ethpbservice.SetGasLimitRequest{Pubkey: byteval, GasLimit: 0})
+	require.ErrorContains(t, "below the minimum gas limit", err)
+	_, err = s.SetGasLimit(ctx, &ethpbservice.SetGasLimitRequest{Pubkey: byteval, GasLimit: 4999})
+	require.ErrorContains(t, "below the minimum gas limit", err)
+
+	_, err = s.SetGasLimit(ctx, &ethpbservice.SetGasLimitRequest{Pubkey: byteval, GasLimit: 35000000})
+	require.NoError(t, err)
+	option := s.validatorService.ProposerSettings().ProposeConfig[bytesutil.ToBytes48(byteval)]
+	require.NotNil(t, option)
+	assert.Equal(t, uint64(35000000), option.ValidatorRegistration.GasLimit)
+	assert.Equal(t, true, option.ValidatorRegistration.Enable)
+	assert.Equal(t, defaultFeeRecipient, option.FeeRecipient)
+	// The settings in use are replaced rather than modified.
+	assert.Equal(t, 0, len(previous.ProposeConfig))
+	assert.Equal(t, uint64(30000000), previous.DefaultConfig.ValidatorRegistration.GasLimit)
+
+	// Reloading the proposer settings keeps the gas limit set through the API.
+	require.NoError(t, vs.SetProposerSettings(ctx, previous))
+	option = s.validatorService.ProposerSettings().ProposeConfig[bytesutil.ToBytes48(byteval)]
+	require.NotNil(t, option)
+	assert.Equal(t, uint64(35000000), option.ValidatorRegistration.GasLimit)
+
+	_, err = s.DeleteGasLimit(ctx, &ethpbservice.PubkeyRequest{Pubkey: byteval})
+	require.NoError(t, err)
+	option = s.validatorService.ProposerSettings().ProposeConfig[bytesutil.ToBytes48(byteval)]
+	assert.Equal(t, uint64(30000000), option.ValidatorRegistration.GasLimit)
+}
+
+func TestServer_SetGasLimit_RegistrationDisabled(t *testing.T) {
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), &runtime.ServerTransportStream{})
+	byteval, err := hexutil.Decode("0xaf2e7ba294e03438ea819bd4033c6c1bf6b04320ee2075b77273c08d02f8a61bcc303c2c06bd3713cb442072ae591493")
+	require.NoError(t, err)
+	tests := []struct {
+		name             string
+		proposerSettings *validatorserviceconfig.ProposerSettings
+	}{
+		{
+			name: "no proposer settings",
+		},
+		{
+			name: "no builder registration",
+			proposerSettings: &validatorserviceconfig.ProposerSettings{
+				DefaultConfig: &validatorserviceconfig.ProposerOption{},
+			},
+		},
+		{
+			name: "builder registration disabled for the key",
+			proposerSettings: &validatorserviceconfig.ProposerSettings{
+				ProposeConfig: map[[fieldparams.BLSPubkeyLength]byte]*validatorserviceconfig.ProposerOption{
+					bytesutil.ToBytes48(byteval): {
+						ValidatorRegistration: &validatorserviceconfig.ValidatorRegistration{Enable: false, GasLimit: 30000000},
+					},
+				},
+				DefaultConfig: &validatorserviceconfig.ProposerOption{
+					ValidatorRegistration: &validatorserviceconfig.ValidatorRegistration{Enable: true, GasLimit: 30000000},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vs, err := client.NewValidatorService(ctx, &client.Config{
+				Validator:        &mock.MockValidator{},
+				ProposerSettings: tt.proposerSettings,
+			})
+			require.NoError(t, err)
+			s := &Server{
+				validatorService: vs,
+			}
+			_, err = s.SetGasLimit(ctx, &ethpbservice.SetGasLimitRequest{Pubkey: byteval, GasLimit: 35000000})
+			require.ErrorContains(t, "Builder registration is not enabled", err)
+		})
+	}
+}
+
+func TestServer_SetGraffiti(t *testing.T) {
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), &runtime.ServerTransportStream{})
+	byteval, err := hexutil.Decode("0xaf2e7ba294e03438ea819bd4033c6c1bf6b04320ee2075b77273c08d02f8a61bcc303c2c06bd3713cb442072ae591493")
+	require.NoError(t, err)
+	vs, err := client.NewValidatorService(ctx, &client.Config{
+		Validator: &mock.MockValidator{},
+		ProposerSettings: &validatorserviceconfig.ProposerSettings{
+			DefaultConfig: &validatorserviceconfig.ProposerOption{
+				Graffiti: "default",
+			},
+		},
+	})
+	require.NoError(t, err)
+	s := &Server{
+		validatorService: vs,
+	}
+
+	got, err := s.GetGraffiti(ctx, &ethpbservice.PubkeyRequest{Pubkey: byteval})
+	require.NoError(t, err)
+	assert.Equal(t, "default", got.Data.Graffiti)
+
+	_, err = s.SetGraffiti(ctx, &ethpbservice.SetGraffitiRequest{Pubkey: byteval, Graffiti: string(make([]byte, 33))})
+	require.ErrorContains(t, "Graffiti is longer than 32 bytes", err)
+
+	_, err = s.SetGraffiti(ctx, &ethpbservice.SetGraffitiRequest{Pubkey: byteval, Graffiti: "prysm"})
+	require.NoError(t, err)
+	got, err = s.GetGraffiti(ctx, &ethpbservice.PubkeyRequest{Pubkey: byteval})
+	require.NoError(t, err)
+	assert.Equal(t, "prysm", got.Data.Graffiti)
+
+	_, err = s.DeleteGraffiti(ctx, &ethpbservice.PubkeyRequest{Pubkey: byteval})
+	require.NoError(t, err)
+	got, err = s.GetGraffiti(ctx, &ethpbservice.PubkeyRequest{Pubkey: byteval})
+	require.NoError(t, err)
+	assert.Equal(t, "default", got.Data.Graffiti)
+}
+
+func TestServer_SignVoluntaryExit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	mockValidatorClient := validatormock.NewMockValidatorClient(ctrl)
+	mockNodeClient := mock2.NewMockNodeClient(ctrl)
+
+	localWalletDir := setupWalletDir(t)
+	defaultWalletPath = localWalletDir
+	w, err := accounts.CreateWalletWithKeymanager(ctx, &accounts.CreateWalletConfig{
+		WalletCfg: &wallet.Config{
+			WalletDir:      defaultWalletPath,
+			KeymanagerKind: keymanager.Derived,
+			WalletPassword: strongPass,
+		},
+		SkipMnemonicConfirm: true,
+	})
+	require.NoError(t, err)
+	km, err := w.InitializeKeymanager(ctx, iface.InitKeymanagerConfig{ListenForChanges: false})
+	require.NoError(t, err)
+	dr, ok := km.(*derived.Keymanager)
+	require.Equal(t, true, ok)
+	require.NoError(t, dr.RecoverAccountsFromMnemonic(ctx, mocks.TestMnemonic, "", 1))
+	pubKeys, err := dr.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	vs, err := client.NewValidatorService(ctx, &client.Config{
+		Wallet: w,
+		Validator: &mock.MockValidator{
+			Km: km,
+		},
+	})
+	require.NoError(t, err)
+	s := &Server{
+		walletInitialized:         true,
+		wallet:                    w,
+		beaconNodeClient:          mockNodeClient,
+		beaconNodeValidatorClient: mockValidatorClient,
+		validatorService:          vs,
+	}
+
+	t.Run("requested epoch", func(t *testing.T) {
+		mockValidatorClient.EXPECT().
+			ValidatorIndex(gomock.Any(), &ethpb.ValidatorIndexRequest{PublicKey: pubKeys[0][:]}).
+			Return(&ethpb.ValidatorIndexResponse{Index: 2}, nil)
+		mockValidatorClient.EXPECT().
+			DomainData(gomock.Any(), &ethpb.DomainRequest{Epoch: 5, Domain: params.BeaconConfig().DomainVoluntaryExit[:]}).
+			Return(&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}, nil)
+
+		epoch := uint64(5)
+		resp, err := s.SignVoluntaryExit(ctx, &ethpbservice.SignVoluntaryExitRequest{Pubkey: pubKeys[0][:], Epoch: &epoch})
+		require.NoError(t, err)
+		assert.Equal(t, types.Epoch(5), resp.Data.Message.Epoch)
+		assert.Equal(t, types.ValidatorIndex(2), resp.Data.Message.ValidatorIndex)
+		assert.Equal(t, fieldparams.BLSSignatureLength, len(resp.Data.Signature))
+	})
+	t.Run("requested genesis epoch", func(t *testing.T) {
+		mockValidatorClient.EXPECT().
+			ValidatorIndex(gomock.Any(), &ethpb.ValidatorIndexRequest{PublicKey: pubKeys[0][:]}).
+			Return(&ethpb.ValidatorIndexResponse{Index: 2}, nil)
+		mockValidatorClient.EXPECT().
+			DomainData(gomock.Any(), &ethpb.DomainRequest{Epoch: 0, Domain: params.BeaconConfig().DomainVoluntaryExit[:]}).
+			Return(&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}, nil)
+
+		epoch := uint64(0)
+		resp, err := s.SignVoluntaryExit(ctx, &ethpbservice.SignVoluntaryExitRequest{Pubkey: pubKeys[0][:], Epoch: &epoch})
+		require.NoError(t, err)
+		assert.Equal(t, types.Epoch(0), resp.Data.Message.Epoch)
+	})
+	t.Run("current epoch", func(t *testing.T) {
+		// Any time in the past will suffice
+		genesisTime := &timestamppb.Timestamp{
+			Seconds: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC).Unix(),
+		}
+		mockValidatorClient.EXPECT().
+			ValidatorIndex(gomock.Any(), gomock.Any()).
+			Return(&ethpb.ValidatorIndexResponse{Index: 2}, nil)
+		mockNodeClient.EXPECT().
+			GetGenesis(gomock.Any(), gomock.Any()).
+			Return(&ethpb.Genesis{GenesisTime: genesisTime}, nil)
+		mockValidatorClient.EXPECT().
+			DomainData(gomock.Any(), gomock.Any()).
+			Return(&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}, nil)
+
+		resp, err := s.SignVoluntaryExit(ctx, &ethpbservice.SignVoluntaryExitRequest{Pubkey: pubKeys[0][:]})
+		require.NoError(t, err)
+		assert.NotEqual(t, types.Epoch(0), resp.Data.Message.Epoch)
+	})
+	t.Run("unknown public key", func(t *testing.T) {
+		_, err := s.SignVoluntaryExit(ctx, &ethpbservice.SignVoluntaryExitRequest{Pubkey: make([]byte, fieldparams.BLSPubkeyLength)})
+		require.ErrorContains(t, "is not managed by the keymanager", err)
+	})
+}
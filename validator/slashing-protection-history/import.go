@@ -23,14 +23,29 @@ import (
 // protection in the validator client's database. For more information, see the EIP document here:
 // https://eips.ethereum.org/EIPS/eip-3076.
 func ImportStandardProtectionJSON(ctx context.Context, validatorDB db.Database, r io.Reader) error {
+	interchangeJSON, err := ParseStandardProtectionJSON(r)
+	if err != nil {
+		return err
+	}
+	return ImportStandardProtection(ctx, validatorDB, interchangeJSON)
+}
+
+// ParseStandardProtectionJSON reads an EIP-3076 compliant JSON file.
+func ParseStandardProtectionJSON(r io.Reader) (*format.EIPSlashingProtectionFormat, error) {
 	encodedJSON, err := io.ReadAll(r)
 	if err != nil {
-		return errors.Wrap(err, "could not read slashing protection JSON file")
+		return nil, errors.Wrap(err, "could not read slashing protection JSON file")
 	}
 	interchangeJSON := &format.EIPSlashingProtectionFormat{}
 	if err := json.Unmarshal(encodedJSON, interchangeJSON); err != nil {
-		return errors.Wrap(err, "could not unmarshal slashing protection JSON file")
+		return nil, errors.Wrap(err, "could not unmarshal slashing protection JSON file")
 	}
+	return interchangeJSON, nil
+}
+
+// ImportStandardProtection imports EIP-3076 compliant slashing protection data into the
+// validator client's database, see ImportStandardProtectionJSON.
+func ImportStandardProtection(ctx context.Context, validatorDB db.Database, interchangeJSON *format.EIPSlashingProtectionFormat) error {
 	if interchangeJSON.Data == nil {
 		log.Warn("No slashing protection data to import")
 		return nil
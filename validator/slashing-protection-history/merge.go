@@ -0,0 +1,191 @@
+package history
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/validator/db/kv"
+	"github.com/prysmaticlabs/prysm/validator/slashing-protection-history/format"
+)
+
+// MergeStandardProtectionJSONs merges several EIP-3076 compliant slashing protection files of a same
+// chain, such as the databases of validator clients which ran the same keys, into one which can be
+// imported without losing any protection. Records found in several files are kept once. When the
+// records of a public key conflict, the most conservative data is kept: a slot signed with different
+// block roots is kept without signing root, and slashable attestations are replaced by the minimal
+// form of the history of the key.
+func MergeStandardProtectionJSONs(
+	ctx context.Context, interchangeJSONs ...*format.EIPSlashingProtectionFormat,
+) (*format.EIPSlashingProtectionFormat, error) {
+	if len(interchangeJSONs) == 0 {
+		return nil, errors.New("no slashing protection data to merge")
+	}
+	mergedJSON := &format.EIPSlashingProtectionFormat{Metadata: interchangeJSONs[0].Metadata}
+	var data []*format.ProtectionData
+	for i, interchangeJSON := range interchangeJSONs {
+		if interchangeJSON.Metadata.InterchangeFormatVersion != format.InterchangeFormatVersion {
+			return nil, fmt.Errorf(
+				"slashing protection JSON version '%s' of file %d is not supported, wanted '%s'",
+				interchangeJSON.Metadata.InterchangeFormatVersion,
+				i,
+				format.InterchangeFormatVersion,
+			)
+		}
+		if !strings.EqualFold(interchangeJSON.Metadata.GenesisValidatorsRoot, mergedJSON.Metadata.GenesisValidatorsRoot) {
+			return nil, fmt.Errorf(
+				"genesis validators root %s of file %d does not match %s, files must come from the same chain",
+				interchangeJSON.Metadata.GenesisValidatorsRoot,
+				i,
+				mergedJSON.Metadata.GenesisValidatorsRoot,
+			)
+		}
+		data = append(data, interchangeJSON.Data...)
+	}
+
+	signedBlocksByPubKey, err := parseBlocksForUniquePublicKeys(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse unique entries for blocks by public key")
+	}
+	signedAttsByPubKey, err := parseAttestationsForUniquePublicKeys(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse unique entries for attestations by public key")
+	}
+	mergedJSON.Data = make([]*format.ProtectionData, 0)
+	for _, pubKey := range sortedPubKeys(signedBlocksByPubKey, signedAttsByPubKey) {
+		merged, err := mergeProtectionData(ctx, pubKey, signedBlocksByPubKey[pubKey], signedAttsByPubKey[pubKey])
+		if err != nil {
+			return nil, err
+		}
+		mergedJSON.Data = append(mergedJSON.Data, merged)
+	}
+	return mergedJSON, nil
+}
+
+func mergeProtectionData(
+	ctx context.Context,
+	pubKey [fieldparams.BLSPubkeyLength]byte,
+	signedBlocks []*format.SignedBlock,
+	signedAtts []*format.SignedAttestation,
+) (*format.ProtectionData, error) {
+	pubKeyHex, err := pubKeyToHexString(pubKey[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "could not convert public key to hex string")
+	}
+	proposalHistory, err := transformSignedBlocks(ctx, signedBlocks)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not parse signed blocks for key %s", pubKeyHex)
+	}
+	records, err := transformSignedAttestations(pubKey, signedAtts)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not parse signed attestations for key %s", pubKeyHex)
+	}
+
+	mergedBlocks, err := mergeSignedBlocks(proposalHistory.Proposals)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not merge signed blocks for key %s", pubKeyHex)
+	}
+	records = uniqueAttestationRecords(records)
+	var mergedAtts []*format.SignedAttestation
+	if len(slashableAttestations(records)) > 0 {
+		log.WithField("pubKey", pubKeyHex).Warn(
+			"Slashing protection files hold slashable attestations for public key, keeping its minimal history",
+		)
+		mergedAtts = minimalSignedAttestations(records)
+	} else {
+		mergedAtts, err = signedAttestationsFromRecords(records)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not merge signed attestations for key %s", pubKeyHex)
+		}
+	}
+	return &format.ProtectionData{
+		Pubkey:             pubKeyHex,
+		SignedBlocks:       mergedBlocks,
+		SignedAttestations: mergedAtts,
+	}, nil
+}
+
+// mergeSignedBlocks keeps a single block per slot, without signing root when the slot
+// was signed with different ones.
+func mergeSignedBlocks(proposals []kv.Proposal) ([]*format.SignedBlock, error) {
+	rootBySlot := make(map[types.Slot][]byte)
+	slots := make([]types.Slot, 0)
+	for _, p := range proposals {
+		root, ok := rootBySlot[p.Slot]
+		if !ok {
+			rootBySlot[p.Slot] = p.SigningRoot
+			slots = append(slots, p.Slot)
+			continue
+		}
+		if !bytes.Equal(root, p.SigningRoot) {
+			rootBySlot[p.Slot] = nil
+		}
+	}
+	sort.Slice(slots, func(i, j int) bool {
+		return slots[i] < slots[j]
+	})
+	signedBlocks := make([]*format.SignedBlock, len(slots))
+	for i, slot := range slots {
+		var root string
+		if r := rootBySlot[slot]; r != nil && !bytes.Equal(r, make([]byte, fieldparams.RootLength)) {
+			var err error
+			root, err = rootToHexString(r)
+			if err != nil {
+				return nil, errors.Wrap(err, "could not convert signing root to hex string")
+			}
+		}
+		signedBlocks[i] = &format.SignedBlock{
+			Slot:        fmt.Sprintf("%d", slot),
+			SigningRoot: root,
+		}
+	}
+	return signedBlocks, nil
+}
+
+// uniqueAttestationRecords drops the records which were found several times, and orders
+// them by target epoch.
+func uniqueAttestationRecords(records []*kv.AttestationRecord) []*kv.AttestationRecord {
+	type key struct {
+		source, target types.Epoch
+		root           [32]byte
+	}
+	seen := make(map[key]bool, len(records))
+	unique := make([]*kv.AttestationRecord, 0, len(records))
+	for _, r := range records {
+		k := key{source: r.Source, target: r.Target, root: r.SigningRoot}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		unique = append(unique, r)
+	}
+	sort.SliceStable(unique, func(i, j int) bool {
+		return unique[i].Target < unique[j].Target
+	})
+	return unique
+}
+
+func signedAttestationsFromRecords(records []*kv.AttestationRecord) ([]*format.SignedAttestation, error) {
+	signedAtts := make([]*format.SignedAttestation, len(records))
+	for i, r := range records {
+		var root string
+		if r.SigningRoot != [32]byte{} {
+			var err error
+			root, err = rootToHexString(r.SigningRoot[:])
+			if err != nil {
+				return nil, errors.Wrap(err, "could not convert signing root to hex string")
+			}
+		}
+		signedAtts[i] = &format.SignedAttestation{
+			SourceEpoch: fmt.Sprintf("%d", r.Source),
+			TargetEpoch: fmt.Sprintf("%d", r.Target),
+			SigningRoot: root,
+		}
+	}
+	return signedAtts, nil
+}
@@ -0,0 +1,104 @@
+package history
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/validator/slashing-protection-history/format"
+)
+
+func TestMergeStandardProtectionJSONs(t *testing.T) {
+	first := testInterchange(
+		&format.ProtectionData{
+			Pubkey: testPubKeyHex(1),
+			SignedBlocks: []*format.SignedBlock{
+				{Slot: "5", SigningRoot: testRootHex(1)},
+				{Slot: "8", SigningRoot: testRootHex(2)},
+			},
+			SignedAttestations: []*format.SignedAttestation{
+				{SourceEpoch: "1", TargetEpoch: "2", SigningRoot: testRootHex(1)},
+				{SourceEpoch: "2", TargetEpoch: "3", SigningRoot: testRootHex(2)},
+			},
+		},
+		&format.ProtectionData{
+			Pubkey: testPubKeyHex(3),
+			SignedAttestations: []*format.SignedAttestation{
+				{SourceEpoch: "1", TargetEpoch: "10", SigningRoot: testRootHex(1)},
+			},
+		},
+	)
+	second := testInterchange(
+		&format.ProtectionData{
+			Pubkey: testPubKeyHex(1),
+			SignedBlocks: []*format.SignedBlock{
+				{Slot: "5", SigningRoot: testRootHex(1)},
+				{Slot: "8", SigningRoot: testRootHex(3)},
+			},
+			SignedAttestations: []*format.SignedAttestation{
+				{SourceEpoch: "2", TargetEpoch: "3", SigningRoot: testRootHex(2)},
+				{SourceEpoch: "3", TargetEpoch: "4", SigningRoot: testRootHex(3)},
+			},
+		},
+		&format.ProtectionData{
+			Pubkey:       testPubKeyHex(2),
+			SignedBlocks: []*format.SignedBlock{{Slot: "3"}},
+		},
+		&format.ProtectionData{
+			Pubkey: testPubKeyHex(3),
+			SignedAttestations: []*format.SignedAttestation{
+				{SourceEpoch: "4", TargetEpoch: "5", SigningRoot: testRootHex(2)},
+			},
+		},
+	)
+
+	mergedJSON, err := MergeStandardProtectionJSONs(context.Background(), first, second)
+	require.NoError(t, err)
+	assert.DeepEqual(t, first.Metadata, mergedJSON.Metadata)
+	assert.DeepEqual(t, []*format.ProtectionData{
+		{
+			Pubkey: testPubKeyHex(1),
+			SignedBlocks: []*format.SignedBlock{
+				{Slot: "5", SigningRoot: testRootHex(1)},
+				// Conflicting signing roots are dropped.
+				{Slot: "8"},
+			},
+			SignedAttestations: []*format.SignedAttestation{
+				{SourceEpoch: "1", TargetEpoch: "2", SigningRoot: testRootHex(1)},
+				{SourceEpoch: "2", TargetEpoch: "3", SigningRoot: testRootHex(2)},
+				{SourceEpoch: "3", TargetEpoch: "4", SigningRoot: testRootHex(3)},
+			},
+		},
+		{
+			Pubkey:             testPubKeyHex(2),
+			SignedBlocks:       []*format.SignedBlock{{Slot: "3"}},
+			SignedAttestations: []*format.SignedAttestation{},
+		},
+		{
+			Pubkey:       testPubKeyHex(3),
+			SignedBlocks: []*format.SignedBlock{},
+			// The surround vote across files is replaced by the minimal history of the key.
+			SignedAttestations: []*format.SignedAttestation{{SourceEpoch: "4", TargetEpoch: "10"}},
+		},
+	}, mergedJSON.Data)
+
+	pairs, err := FindSlashablePairs(context.Background(), mergedJSON)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(pairs))
+}
+
+func TestMergeStandardProtectionJSONs_Metadata(t *testing.T) {
+	_, err := MergeStandardProtectionJSONs(context.Background())
+	require.ErrorContains(t, "no slashing protection data to merge", err)
+
+	otherChain := testInterchange()
+	otherChain.Metadata.GenesisValidatorsRoot = testRootHex(1)
+	_, err = MergeStandardProtectionJSONs(context.Background(), testInterchange(), otherChain)
+	require.ErrorContains(t, "files must come from the same chain", err)
+
+	otherVersion := testInterchange()
+	otherVersion.Metadata.InterchangeFormatVersion = "4"
+	_, err = MergeStandardProtectionJSONs(context.Background(), testInterchange(), otherVersion)
+	require.ErrorContains(t, "is not supported", err)
+}
@@ -0,0 +1,98 @@
+package history
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/validator/db/kv"
+	"github.com/prysmaticlabs/prysm/validator/slashing-protection-history/format"
+)
+
+// MinimalStandardProtectionJSON converts EIP-3076 compliant slashing protection data into its
+// minimal form, which only keeps for each public key its highest signed block slot, and an
+// attestation with its highest source and target epochs, without signing roots. Importing it
+// refuses to sign any block or attestation at or below these, which is as safe as the complete
+// data while being much smaller for validators with a long history.
+func MinimalStandardProtectionJSON(
+	ctx context.Context, interchangeJSON *format.EIPSlashingProtectionFormat,
+) (*format.EIPSlashingProtectionFormat, error) {
+	signedBlocksByPubKey, err := parseBlocksForUniquePublicKeys(interchangeJSON.Data)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse unique entries for blocks by public key")
+	}
+	signedAttsByPubKey, err := parseAttestationsForUniquePublicKeys(interchangeJSON.Data)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse unique entries for attestations by public key")
+	}
+
+	minimalJSON := &format.EIPSlashingProtectionFormat{Metadata: interchangeJSON.Metadata}
+	minimalJSON.Data = make([]*format.ProtectionData, 0)
+	for _, pubKey := range sortedPubKeys(signedBlocksByPubKey, signedAttsByPubKey) {
+		data, err := minimalProtectionData(ctx, pubKey, signedBlocksByPubKey[pubKey], signedAttsByPubKey[pubKey])
+		if err != nil {
+			return nil, err
+		}
+		minimalJSON.Data = append(minimalJSON.Data, data)
+	}
+	return minimalJSON, nil
+}
+
+func minimalProtectionData(
+	ctx context.Context,
+	pubKey [fieldparams.BLSPubkeyLength]byte,
+	signedBlocks []*format.SignedBlock,
+	signedAtts []*format.SignedAttestation,
+) (*format.ProtectionData, error) {
+	pubKeyHex, err := pubKeyToHexString(pubKey[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "could not convert public key to hex string")
+	}
+	proposalHistory, err := transformSignedBlocks(ctx, signedBlocks)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not parse signed blocks for key %s", pubKeyHex)
+	}
+	records, err := transformSignedAttestations(pubKey, signedAtts)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not parse signed attestations for key %s", pubKeyHex)
+	}
+	return &format.ProtectionData{
+		Pubkey:             pubKeyHex,
+		SignedBlocks:       minimalSignedBlocks(proposalHistory.Proposals),
+		SignedAttestations: minimalSignedAttestations(records),
+	}, nil
+}
+
+func minimalSignedBlocks(proposals []kv.Proposal) []*format.SignedBlock {
+	if len(proposals) == 0 {
+		return make([]*format.SignedBlock, 0)
+	}
+	var highestSlot types.Slot
+	for _, p := range proposals {
+		if p.Slot > highestSlot {
+			highestSlot = p.Slot
+		}
+	}
+	return []*format.SignedBlock{{Slot: fmt.Sprintf("%d", highestSlot)}}
+}
+
+func minimalSignedAttestations(records []*kv.AttestationRecord) []*format.SignedAttestation {
+	if len(records) == 0 {
+		return make([]*format.SignedAttestation, 0)
+	}
+	var highestSource, highestTarget types.Epoch
+	for _, r := range records {
+		if r.Source > highestSource {
+			highestSource = r.Source
+		}
+		if r.Target > highestTarget {
+			highestTarget = r.Target
+		}
+	}
+	return []*format.SignedAttestation{{
+		SourceEpoch: fmt.Sprintf("%d", highestSource),
+		TargetEpoch: fmt.Sprintf("%d", highestTarget),
+	}}
+}
@@ -0,0 +1,52 @@
+package history
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/validator/slashing-protection-history/format"
+)
+
+func TestMinimalStandardProtectionJSON(t *testing.T) {
+	interchangeJSON := testInterchange(
+		&format.ProtectionData{
+			Pubkey: testPubKeyHex(2),
+			SignedBlocks: []*format.SignedBlock{
+				{Slot: "12", SigningRoot: testRootHex(1)},
+				{Slot: "30", SigningRoot: testRootHex(2)},
+				{Slot: "20", SigningRoot: testRootHex(3)},
+			},
+			SignedAttestations: []*format.SignedAttestation{
+				{SourceEpoch: "1", TargetEpoch: "2", SigningRoot: testRootHex(1)},
+				{SourceEpoch: "5", TargetEpoch: "6", SigningRoot: testRootHex(2)},
+				{SourceEpoch: "2", TargetEpoch: "7", SigningRoot: testRootHex(3)},
+			},
+		},
+		&format.ProtectionData{
+			Pubkey:             testPubKeyHex(1),
+			SignedAttestations: []*format.SignedAttestation{{SourceEpoch: "3", TargetEpoch: "4"}},
+		},
+	)
+
+	minimalJSON, err := MinimalStandardProtectionJSON(context.Background(), interchangeJSON)
+	require.NoError(t, err)
+	assert.DeepEqual(t, interchangeJSON.Metadata, minimalJSON.Metadata)
+	assert.DeepEqual(t, []*format.ProtectionData{
+		{
+			Pubkey:             testPubKeyHex(1),
+			SignedBlocks:       []*format.SignedBlock{},
+			SignedAttestations: []*format.SignedAttestation{{SourceEpoch: "3", TargetEpoch: "4"}},
+		},
+		{
+			Pubkey:             testPubKeyHex(2),
+			SignedBlocks:       []*format.SignedBlock{{Slot: "30"}},
+			SignedAttestations: []*format.SignedAttestation{{SourceEpoch: "5", TargetEpoch: "7"}},
+		},
+	}, minimalJSON.Data)
+
+	pairs, err := FindSlashablePairs(context.Background(), minimalJSON)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(pairs))
+}
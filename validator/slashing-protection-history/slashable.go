@@ -0,0 +1,158 @@
+package history
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/config/fieldparams"
+	types "github.com/prysmaticlabs/prysm/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/slashings"
+	"github.com/prysmaticlabs/prysm/validator/db/kv"
+	"github.com/prysmaticlabs/prysm/validator/slashing-protection-history/format"
+)
+
+// SlashablePair is a pair of messages signed by a validator public key which are slashable
+// together, as found in slashing protection data.
+type SlashablePair struct {
+	PubKey string
+	Reason string
+	First  string
+	Second string
+}
+
+// FindSlashablePairs looks for messages signed by a same public key which are slashable together
+// in EIP-3076 compliant slashing protection data. These are the public keys an import would refuse
+// the data of and blacklist.
+func FindSlashablePairs(ctx context.Context, interchangeJSON *format.EIPSlashingProtectionFormat) ([]*SlashablePair, error) {
+	signedBlocksByPubKey, err := parseBlocksForUniquePublicKeys(interchangeJSON.Data)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse unique entries for blocks by public key")
+	}
+	signedAttsByPubKey, err := parseAttestationsForUniquePublicKeys(interchangeJSON.Data)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse unique entries for attestations by public key")
+	}
+
+	pairs := make([]*SlashablePair, 0)
+	for _, pubKey := range sortedPubKeys(signedBlocksByPubKey, signedAttsByPubKey) {
+		pubKeyHex, err := pubKeyToHexString(pubKey[:])
+		if err != nil {
+			return nil, errors.Wrap(err, "could not convert public key to hex string")
+		}
+		if signedBlocks, ok := signedBlocksByPubKey[pubKey]; ok {
+			proposalHistory, err := transformSignedBlocks(ctx, signedBlocks)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not parse signed blocks for key %s", pubKeyHex)
+			}
+			for _, p := range slashableProposals(proposalHistory.Proposals) {
+				pairs = append(pairs, &SlashablePair{
+					PubKey: pubKeyHex,
+					Reason: "double proposal",
+					First:  fmt.Sprintf("slot %d with signing root %#x", p[0].Slot, p[0].SigningRoot),
+					Second: fmt.Sprintf("slot %d with signing root %#x", p[1].Slot, p[1].SigningRoot),
+				})
+			}
+		}
+		if signedAtts, ok := signedAttsByPubKey[pubKey]; ok {
+			records, err := transformSignedAttestations(pubKey, signedAtts)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not parse signed attestations for key %s", pubKeyHex)
+			}
+			for _, p := range slashableAttestations(records) {
+				reason := "surround vote"
+				if p[0].Target == p[1].Target {
+					reason = "double vote"
+				}
+				pairs = append(pairs, &SlashablePair{
+					PubKey: pubKeyHex,
+					Reason: reason,
+					First:  fmt.Sprintf("source %d, target %d with signing root %#x", p[0].Source, p[0].Target, p[0].SigningRoot),
+					Second: fmt.Sprintf("source %d, target %d with signing root %#x", p[1].Source, p[1].Target, p[1].SigningRoot),
+				})
+			}
+		}
+	}
+	return pairs, nil
+}
+
+// slashableProposals returns the pairs of proposals at a same slot with different signing roots,
+// following the rules of filterSlashablePubKeysFromBlocks.
+func slashableProposals(proposals []kv.Proposal) [][2]kv.Proposal {
+	pairs := make([][2]kv.Proposal, 0)
+	firstBySlot := make(map[types.Slot]kv.Proposal)
+	for _, p := range proposals {
+		first, ok := firstBySlot[p.Slot]
+		if !ok {
+			firstBySlot[p.Slot] = p
+			continue
+		}
+		if !bytes.Equal(first.SigningRoot, p.SigningRoot) {
+			pairs = append(pairs, [2]kv.Proposal{first, p})
+		}
+	}
+	return pairs
+}
+
+// slashableAttestations returns the pairs of double votes and surround votes among attestation
+// records, following the rules of filterSlashablePubKeysFromAttestations. Surround votes are found
+// by sweeping the records by source epoch, so that large histories are checked quickly.
+func slashableAttestations(records []*kv.AttestationRecord) [][2]*kv.AttestationRecord {
+	pairs := make([][2]*kv.AttestationRecord, 0)
+	firstByTarget := make(map[types.Epoch]*kv.AttestationRecord)
+	for _, r := range records {
+		first, ok := firstByTarget[r.Target]
+		if !ok {
+			firstByTarget[r.Target] = r
+			continue
+		}
+		if slashings.SigningRootsDiffer(first.SigningRoot, r.SigningRoot) {
+			pairs = append(pairs, [2]*kv.AttestationRecord{first, r})
+		}
+	}
+
+	sorted := make([]*kv.AttestationRecord, len(records))
+	copy(sorted, records)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Source < sorted[j].Source
+	})
+	// The record with the highest target among those with a lower source surrounds any
+	// record with a lower target.
+	var highest *kv.AttestationRecord
+	for i := 0; i < len(sorted); {
+		j := i
+		for j < len(sorted) && sorted[j].Source == sorted[i].Source {
+			if highest != nil && highest.Target > sorted[j].Target {
+				pairs = append(pairs, [2]*kv.AttestationRecord{highest, sorted[j]})
+			}
+			j++
+		}
+		for ; i < j; i++ {
+			if highest == nil || sorted[i].Target > highest.Target {
+				highest = sorted[i]
+			}
+		}
+	}
+	return pairs
+}
+
+func sortedPubKeys(
+	signedBlocksByPubKey map[[fieldparams.BLSPubkeyLength]byte][]*format.SignedBlock,
+	signedAttsByPubKey map[[fieldparams.BLSPubkeyLength]byte][]*format.SignedAttestation,
+) [][fieldparams.BLSPubkeyLength]byte {
+	pubKeys := make([][fieldparams.BLSPubkeyLength]byte, 0, len(signedAttsByPubKey))
+	for pubKey := range signedAttsByPubKey {
+		pubKeys = append(pubKeys, pubKey)
+	}
+	for pubKey := range signedBlocksByPubKey {
+		if _, ok := signedAttsByPubKey[pubKey]; !ok {
+			pubKeys = append(pubKeys, pubKey)
+		}
+	}
+	sort.Slice(pubKeys, func(i, j int) bool {
+		return bytes.Compare(pubKeys[i][:], pubKeys[j][:]) < 0
+	})
+	return pubKeys
+}
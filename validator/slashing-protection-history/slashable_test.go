@@ -0,0 +1,112 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/testing/assert"
+	"github.com/prysmaticlabs/prysm/testing/require"
+	"github.com/prysmaticlabs/prysm/validator/slashing-protection-history/format"
+)
+
+func testPubKeyHex(i int) string {
+	return fmt.Sprintf("0x%02x%s", i, strings.Repeat("00", 47))
+}
+
+func testRootHex(i int) string {
+	return fmt.Sprintf("0x%02x%s", i, strings.Repeat("00", 31))
+}
+
+func testInterchange(data ...*format.ProtectionData) *format.EIPSlashingProtectionFormat {
+	interchangeJSON := &format.EIPSlashingProtectionFormat{Data: data}
+	interchangeJSON.Metadata.InterchangeFormatVersion = format.InterchangeFormatVersion
+	interchangeJSON.Metadata.GenesisValidatorsRoot = testRootHex(0xff)
+	return interchangeJSON
+}
+
+func TestFindSlashablePairs(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []*format.ProtectionData
+		reasons []string
+	}{
+		{
+			name: "not slashable",
+			data: []*format.ProtectionData{{
+				Pubkey: testPubKeyHex(1),
+				SignedBlocks: []*format.SignedBlock{
+					{Slot: "5", SigningRoot: testRootHex(1)},
+					{Slot: "5", SigningRoot: testRootHex(1)},
+					{Slot: "6"},
+				},
+				SignedAttestations: []*format.SignedAttestation{
+					{SourceEpoch: "1", TargetEpoch: "2", SigningRoot: testRootHex(1)},
+					{SourceEpoch: "1", TargetEpoch: "2", SigningRoot: testRootHex(1)},
+					{SourceEpoch: "2", TargetEpoch: "3"},
+					{SourceEpoch: "2", TargetEpoch: "4"},
+				},
+			}},
+		},
+		{
+			name: "double proposal",
+			data: []*format.ProtectionData{
+				{Pubkey: testPubKeyHex(1), SignedBlocks: []*format.SignedBlock{{Slot: "5", SigningRoot: testRootHex(1)}}},
+				{Pubkey: testPubKeyHex(1), SignedBlocks: []*format.SignedBlock{{Slot: "5", SigningRoot: testRootHex(2)}}},
+			},
+			reasons: []string{"double proposal"},
+		},
+		{
+			name: "double vote",
+			data: []*format.ProtectionData{{
+				Pubkey: testPubKeyHex(1),
+				SignedAttestations: []*format.SignedAttestation{
+					{SourceEpoch: "1", TargetEpoch: "2", SigningRoot: testRootHex(1)},
+					{SourceEpoch: "0", TargetEpoch: "2", SigningRoot: testRootHex(2)},
+				},
+			}},
+			reasons: []string{"double vote"},
+		},
+		{
+			name: "double vote without signing root",
+			data: []*format.ProtectionData{{
+				Pubkey: testPubKeyHex(1),
+				SignedAttestations: []*format.SignedAttestation{
+					{SourceEpoch: "1", TargetEpoch: "2"},
+					{SourceEpoch: "1", TargetEpoch: "2"},
+				},
+			}},
+			reasons: []string{"double vote"},
+		},
+		{
+			name: "surround votes",
+			data: []*format.ProtectionData{{
+				Pubkey: testPubKeyHex(1),
+				SignedAttestations: []*format.SignedAttestation{
+					{SourceEpoch: "3", TargetEpoch: "4"},
+					{SourceEpoch: "1", TargetEpoch: "10"},
+					{SourceEpoch: "5", TargetEpoch: "6"},
+					{SourceEpoch: "11", TargetEpoch: "12"},
+				},
+			}},
+			reasons: []string{"surround vote", "surround vote"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pairs, err := FindSlashablePairs(context.Background(), testInterchange(tt.data...))
+			require.NoError(t, err)
+			require.Equal(t, len(tt.reasons), len(pairs))
+			for i, p := range pairs {
+				assert.Equal(t, testPubKeyHex(1), p.PubKey)
+				assert.Equal(t, tt.reasons[i], p.Reason)
+			}
+		})
+	}
+}
+
+func TestFindSlashablePairs_InvalidData(t *testing.T) {
+	_, err := FindSlashablePairs(context.Background(), testInterchange(&format.ProtectionData{Pubkey: "0x1234"}))
+	require.ErrorContains(t, "is not a valid public key", err)
+}